@@ -0,0 +1,32 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/pkg/errors"
+)
+
+// RunLocalTask runs a single task, followed by the teardown of its task
+// group, instead of polling the communicator for tasks. It is meant for
+// running a task outside of Evergreen with a client.Local communicator,
+// which records the task's final status.
+func (a *Agent) RunLocalTask(ctx context.Context, td client.TaskData, taskGroup string) error {
+	tc := &taskContext{
+		task:          td,
+		taskGroup:     taskGroup,
+		runGroupSetup: true,
+	}
+	if err := a.resetLogging(ctx, tc); err != nil {
+		return errors.WithStack(err)
+	}
+
+	tskCtx, tskCancel := context.WithCancel(ctx)
+	defer tskCancel()
+	if err := a.runTask(tskCtx, tskCancel, tc); err != nil {
+		return errors.WithStack(err)
+	}
+
+	a.runPostGroupCommands(ctx, tc)
+	return nil
+}
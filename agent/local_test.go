@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLocalTask(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "local-task")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	config := `
+pre:
+  - command: shell.exec
+    params:
+      script: echo "pre ran"
+post:
+  - command: shell.exec
+    params:
+      script: echo "post ran"
+functions:
+  greet:
+    command: shell.exec
+    params:
+      script: echo "${greeting} from ${task_name}"
+tasks:
+  - name: hello
+    commands:
+      - func: greet
+  - name: generate
+    commands:
+      - command: shell.exec
+        params:
+          script: |
+            echo '{"tasks": [{"name": "generated"}]}' > generated.json
+      - command: generate.tasks
+        params:
+          files:
+            - generated.json
+buildvariants:
+  - name: local
+    tasks:
+      - name: hello
+      - name: generate
+`
+	run := func(taskName string) (*client.Local, string) {
+		outputDir := filepath.Join(dir, taskName)
+		comm, err := client.NewLocal(client.LocalOptions{
+			Task: &task.Task{
+				Id:           taskName,
+				DisplayName:  taskName,
+				BuildVariant: "local",
+				Project:      "local",
+				Version:      "local",
+			},
+			Version:    &version.Version{Id: "local", Identifier: "local", Config: config, CreateTime: time.Now()},
+			ProjectRef: &model.ProjectRef{Identifier: "local"},
+			Distro:     &distro.Distro{Id: "local", WorkDir: dir},
+			Expansions: map[string]string{"greeting": "hello"},
+			OutputDir:  outputDir,
+		})
+		require.NoError(err)
+
+		agt := New(Options{HostID: "local", WorkingDirectory: dir, Cleanup: true}, comm)
+		require.NoError(agt.RunLocalTask(context.Background(), client.TaskData{ID: taskName, Secret: "local"}, ""))
+		require.NotNil(comm.EndTaskDetail())
+
+		log, err := ioutil.ReadFile(filepath.Join(outputDir, "task.log"))
+		require.NoError(err)
+		return comm, string(log)
+	}
+
+	comm, log := run("hello")
+	assert.Equal(evergreen.TaskSucceeded, comm.EndTaskDetail().Status)
+	assert.Contains(log, "pre ran")
+	assert.Contains(log, "hello from hello")
+	assert.Contains(log, "post ran")
+
+	comm, log = run("generate")
+	assert.Equal(evergreen.TaskFailed, comm.EndTaskDetail().Status)
+	assert.Contains(log, "generate.tasks requires an Evergreen API server")
+	assert.Contains(log, "post ran")
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-//LoadUserManager is used to check the configuration for authentication and create a UserManager depending on what type of authentication (Crowd or Naive) is used.
+//LoadUserManager is used to check the configuration for authentication and create a UserManager depending on what type of authentication (Crowd, Naive, GitHub, LDAP or OIDC) is used.
 func LoadUserManager(authConfig evergreen.AuthConfig) (gimlet.UserManager, error) {
 	var manager gimlet.UserManager
 	var err error
@@ -38,6 +38,24 @@ func LoadUserManager(authConfig evergreen.AuthConfig) (gimlet.UserManager, error
 			return nil, err
 		}
 	}
+	if authConfig.LDAP != nil {
+		if manager != nil {
+			return nil, errors.New("Cannot have multiple forms of authentication in configuration")
+		}
+		manager, err = NewLDAPUserManager(authConfig.LDAP)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if authConfig.OIDC != nil {
+		if manager != nil {
+			return nil, errors.New("Cannot have multiple forms of authentication in configuration")
+		}
+		manager, err = NewOIDCUserManager(authConfig.OIDC)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if manager != nil {
 		return manager, nil
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+)
+
+const ldapTimeout = 10 * time.Second
+
+// LDAPUserManager authenticates users by binding to an LDAP directory as
+// them. The system roles of a user are set from the LDAP groups they are a
+// member of each time they log in. Logins are cached in the database, and
+// expire after the configured duration. Connections to the directory are
+// always encrypted with TLS.
+type LDAPUserManager struct {
+	conf        evergreen.LDAPConfig
+	expireAfter time.Duration
+	// tlsConfig verifies the directory's certificate; if nil, it is
+	// verified against the system's roots.
+	tlsConfig *tls.Config
+}
+
+// NewLDAPUserManager creates a manager for the given directory. The
+// configuration must have been validated.
+func NewLDAPUserManager(conf *evergreen.LDAPConfig) (gimlet.UserManager, error) {
+	if conf.URL == "" {
+		return nil, errors.New("no URL for LDAP config")
+	}
+	if conf.UserBaseDN == "" {
+		return nil, errors.New("no user base DN for LDAP config")
+	}
+	if conf.UserAttribute == "" {
+		return nil, errors.New("no user attribute for LDAP config")
+	}
+
+	return &LDAPUserManager{
+		conf:        *conf,
+		expireAfter: time.Duration(conf.ExpireAfterMinutes) * time.Minute,
+	}, nil
+}
+
+// GetUserByToken returns the user who logged in with the token, if their
+// login has not expired.
+func (m *LDAPUserManager) GetUserByToken(_ context.Context, token string) (gimlet.User, error) {
+	u, err := model.GetLoginCache(token, m.expireAfter)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem getting user by token")
+	}
+	return u, nil
+}
+
+// CreateUserToken authenticates the user against the directory, updates their
+// roles from their groups, and returns their login token.
+func (m *LDAPUserManager) CreateUserToken(username, password string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ldapTimeout)
+	defer cancel()
+
+	u, err := m.authenticate(ctx, username, password)
+	if err != nil {
+		return "", errors.Wrapf(err, "problem authenticating user '%s'", username)
+	}
+
+	dbUser, err := model.GetOrCreateUser(u.UserId, u.Name, u.EmailAddress)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if err = model.SetUserRoles(dbUser.Id, u.SiteRoles); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return model.PutLoginCache(dbUser.Id)
+}
+
+// authenticate binds as the user and returns them, with the roles of the
+// groups they are a member of.
+func (m *LDAPUserManager) authenticate(ctx context.Context, username, password string) (*simpleUser, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password are required")
+	}
+
+	conn, err := dialLDAP(ctx, m.conf.URL, m.tlsConfig, ldapTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	userAttributes := []string{"cn", "displayName", "mail"}
+	var entry *ldap.Entry
+	if m.conf.BindDN != "" {
+		if err = bindLDAP(conn, m.conf.BindDN, m.conf.BindPassword); err != nil {
+			return nil, errors.Wrap(err, "problem binding with the service account")
+		}
+		if entry, err = findLDAPUser(conn, m.conf.UserBaseDN, m.conf.UserAttribute, username, userAttributes); err != nil {
+			return nil, err
+		}
+		if err = bindLDAP(conn, entry.DN, password); err != nil {
+			return nil, err
+		}
+		// look up groups as the service account, which users may not be
+		// allowed to do
+		if err = bindLDAP(conn, m.conf.BindDN, m.conf.BindPassword); err != nil {
+			return nil, errors.Wrap(err, "problem binding with the service account")
+		}
+	} else {
+		dn := fmt.Sprintf("%s=%s,%s", m.conf.UserAttribute, escapeLDAPDNValue(username), m.conf.UserBaseDN)
+		if err = bindLDAP(conn, dn, password); err != nil {
+			return nil, err
+		}
+		if entry, err = findLDAPUser(conn, m.conf.UserBaseDN, m.conf.UserAttribute, username, userAttributes); err != nil {
+			return nil, err
+		}
+	}
+
+	roles, err := m.roles(conn, entry.DN)
+	if err != nil {
+		return nil, err
+	}
+
+	name := firstLDAPAttribute(entry, "displayName", "cn")
+	if name == "" {
+		name = username
+	}
+
+	return &simpleUser{
+		UserId:       username,
+		Name:         name,
+		EmailAddress: firstLDAPAttribute(entry, "mail"),
+		SiteRoles:    roles,
+	}, nil
+}
+
+// roles returns the roles mapped to the groups that the user is a member of.
+func (m *LDAPUserManager) roles(conn *ldap.Conn, userDN string) ([]string, error) {
+	roles := []string{}
+	if m.conf.GroupBaseDN == "" || len(m.conf.GroupRoles) == 0 {
+		return roles, nil
+	}
+
+	groups, err := searchLDAP(conn, m.conf.GroupBaseDN, m.conf.GroupMemberAttribute, userDN, []string{"cn"})
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding groups")
+	}
+	for _, group := range groups {
+		for _, name := range group.GetEqualFoldAttributeValues("cn") {
+			for _, groupRole := range m.conf.GroupRoles {
+				if strings.EqualFold(groupRole.Group, name) && !util.StringSliceContains(roles, groupRole.Role) {
+					roles = append(roles, groupRole.Role)
+				}
+			}
+		}
+	}
+
+	return roles, nil
+}
+
+func (*LDAPUserManager) GetLoginHandler(string) http.HandlerFunc    { return nil }
+func (*LDAPUserManager) GetLoginCallbackHandler() http.HandlerFunc  { return nil }
+func (*LDAPUserManager) IsRedirect() bool                           { return false }
+func (*LDAPUserManager) GetUserByID(id string) (gimlet.User, error) { return getUserByID(id) }
+func (*LDAPUserManager) GetOrCreateUser(u gimlet.User) (gimlet.User, error) {
+	return getOrCreateUser(u)
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+)
+
+// dialLDAP connects to the directory at the URL. Connections are always
+// encrypted: ldaps URLs are dialed with TLS, and ldap URLs are upgraded with
+// StartTLS before they are used, failing if the server does not support it.
+// The connection is closed once the context's deadline or the timeout passes.
+func dialLDAP(ctx context.Context, rawURL string, tlsConf *tls.Config, timeout time.Duration) (*ldap.Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid LDAP URL '%s'", rawURL)
+	}
+
+	var port string
+	switch u.Scheme {
+	case "ldap":
+		port = "389"
+	case "ldaps":
+		port = "636"
+	default:
+		return nil, errors.Errorf("unsupported LDAP URL scheme '%s'", u.Scheme)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	if tlsConf == nil {
+		tlsConf = &tls.Config{}
+	}
+	tlsConf = tlsConf.Clone()
+	if tlsConf.ServerName == "" {
+		tlsConf.ServerName = u.Hostname()
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	rawConn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem connecting to '%s'", rawURL)
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err = rawConn.SetDeadline(deadline); err != nil {
+		rawConn.Close()
+		return nil, errors.WithStack(err)
+	}
+
+	var conn *ldap.Conn
+	if u.Scheme == "ldaps" {
+		tlsConn := tls.Client(rawConn, tlsConf)
+		if err = tlsConn.Handshake(); err != nil {
+			rawConn.Close()
+			return nil, errors.Wrapf(err, "problem establishing TLS with '%s'", rawURL)
+		}
+		conn = ldap.NewConn(tlsConn, true)
+		conn.Start()
+	} else {
+		conn = ldap.NewConn(rawConn, false)
+		conn.Start()
+		if err = conn.StartTLS(tlsConf); err != nil {
+			conn.Close()
+			return nil, errors.Wrapf(err, "problem starting TLS with '%s'", rawURL)
+		}
+	}
+	conn.SetTimeout(timeout)
+
+	return conn, nil
+}
+
+// bindLDAP authenticates the connection as the given DN. It refuses to send
+// the password over a connection that is not encrypted.
+func bindLDAP(conn *ldap.Conn, dn, password string) error {
+	if _, ok := conn.TLSConnectionState(); !ok {
+		return errors.New("refusing to bind to LDAP over a connection without TLS")
+	}
+	if password == "" {
+		// an empty password is an unauthenticated bind, which servers
+		// accept for any DN
+		return errors.New("password is required to bind to LDAP")
+	}
+	return errors.Wrapf(conn.Bind(dn, password), "problem binding as '%s'", dn)
+}
+
+// findLDAPUser returns the single entry under the base DN whose attribute
+// has the given value.
+func findLDAPUser(conn *ldap.Conn, base, attribute, value string, attributes []string) (*ldap.Entry, error) {
+	entries, err := searchLDAP(conn, base, attribute, value, attributes)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding user")
+	}
+	if len(entries) != 1 {
+		return nil, errors.Errorf("found %d users, expected 1", len(entries))
+	}
+	return entries[0], nil
+}
+
+// searchLDAP returns the entries under the base DN whose attribute has the
+// given value.
+func searchLDAP(conn *ldap.Conn, base, attribute, value string, attributes []string) ([]*ldap.Entry, error) {
+	filter := fmt.Sprintf("(%s=%s)", ldap.EscapeFilter(attribute), ldap.EscapeFilter(value))
+	req := ldap.NewSearchRequest(base, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
+	res, err := conn.Search(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return res.Entries, nil
+}
+
+// firstLDAPAttribute returns the first value of the first of the attributes
+// that the entry has.
+func firstLDAPAttribute(entry *ldap.Entry, attributes ...string) string {
+	for _, attribute := range attributes {
+		if value := entry.GetEqualFoldAttributeValue(attribute); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// escapeLDAPDNValue escapes the special characters of an attribute value in
+// a distinguished name (RFC 4514).
+func escapeLDAPDNValue(value string) string {
+	buf := &bytes.Buffer{}
+	for i, r := range value {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, r),
+			i == 0 && (r == ' ' || r == '#'),
+			i == len(value)-1 && r == ' ':
+			buf.WriteRune('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
@@ -0,0 +1,435 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ldapTestEntry is an entry in the test directory.
+type ldapTestEntry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// ldapTestServer is an in-process LDAP directory that supports simple binds,
+// equality searches and StartTLS, enough to stand in for a real directory.
+type ldapTestServer struct {
+	listener  net.Listener
+	scheme    string
+	tlsConfig *tls.Config
+	startTLS  bool
+	passwords map[string]string
+	entries   []ldapTestEntry
+
+	mu    sync.Mutex
+	binds []string
+}
+
+// ldapTestServerOptions configure how clients connect to the test server:
+// with TLS from the start (ldaps), or in plain text, upgraded with StartTLS
+// if it is supported.
+type ldapTestServerOptions struct {
+	ldaps    bool
+	startTLS bool
+}
+
+func newLDAPTestServer(t *testing.T, opts ldapTestServerOptions, passwords map[string]string, entries []ldapTestEntry) *ldapTestServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &ldapTestServer{
+		listener:  listener,
+		scheme:    "ldap",
+		tlsConfig: ldapTestServerTLSConfig(t),
+		startTLS:  opts.startTLS,
+		passwords: passwords,
+		entries:   entries,
+	}
+	if opts.ldaps {
+		s.scheme = "ldaps"
+		s.listener = tls.NewListener(listener, s.tlsConfig)
+	}
+	go s.serve()
+	return s
+}
+
+// ldapTestServerTLSConfig returns a configuration with a self-signed
+// certificate for 127.0.0.1.
+func ldapTestServerTLSConfig(t *testing.T) *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key, Leaf: cert}}}
+}
+
+// clientTLSConfig returns a configuration that trusts the server's
+// certificate.
+func (s *ldapTestServer) clientTLSConfig() *tls.Config {
+	roots := x509.NewCertPool()
+	roots.AddCert(s.tlsConfig.Certificates[0].Leaf)
+	return &tls.Config{RootCAs: roots}
+}
+
+func (s *ldapTestServer) url() string { return s.scheme + "://" + s.listener.Addr().String() }
+
+func (s *ldapTestServer) close() { _ = s.listener.Close() }
+
+func (s *ldapTestServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *ldapTestServer) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	reader := bufio.NewReader(conn)
+	bound := ""
+
+	for {
+		msg, err := ber.ReadPacket(reader)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id, ok := msg.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+		op := msg.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationExtendedRequest:
+			if !s.startTLS || op.Children[0].Data.String() != "1.3.6.1.4.1.1466.20037" {
+				s.respond(conn, id, ldapTestResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError, "unsupported extended operation"))
+				continue
+			}
+			s.respond(conn, id, ldapTestResult(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess, ""))
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err = tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Data.String()
+			password := op.Children[2].Data.String()
+			s.mu.Lock()
+			s.binds = append(s.binds, dn)
+			s.mu.Unlock()
+			if expected, ok := s.passwords[dn]; ok && expected == password {
+				bound = dn
+				s.respond(conn, id, ldapTestResult(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess, ""))
+			} else {
+				bound = ""
+				s.respond(conn, id, ldapTestResult(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials, "invalid credentials"))
+			}
+		case ldap.ApplicationSearchRequest:
+			if bound == "" {
+				s.respond(conn, id, ldapTestResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights, "insufficient access rights"))
+				continue
+			}
+			base := strings.ToLower(op.Children[0].Data.String())
+			filter := op.Children[6]
+			attribute := strings.ToLower(filter.Children[0].Data.String())
+			value := filter.Children[1].Data.String()
+			for _, entry := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(entry.DN), base) {
+					continue
+				}
+				for _, v := range entry.Attributes[attribute] {
+					if strings.EqualFold(v, value) {
+						s.respond(conn, id, ldapTestSearchEntry(entry))
+						break
+					}
+				}
+			}
+			s.respond(conn, id, ldapTestResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess, ""))
+		default:
+			return
+		}
+	}
+}
+
+func (s *ldapTestServer) respond(conn net.Conn, id int64, op *ber.Packet) {
+	msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	msg.AppendChild(op)
+	_, _ = conn.Write(msg.Bytes())
+}
+
+func (s *ldapTestServer) boundAs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.binds...)
+}
+
+func ldapTestResult(tag ber.Tag, code uint16, message string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, ""))
+	return op
+}
+
+func ldapTestSearchEntry(entry ldapTestEntry) *ber.Packet {
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, values := range entry.Attributes {
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		attribute.AppendChild(set)
+		attributes.AppendChild(attribute)
+	}
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, ""))
+	op.AppendChild(attributes)
+	return op
+}
+
+func ldapTestDirectory() (map[string]string, []ldapTestEntry) {
+	passwords := map[string]string{
+		"cn=evergreen,ou=services,dc=example,dc=com": "service-password",
+		"uid=annie,ou=people,dc=example,dc=com":      "annie-password",
+		"uid=bob,ou=people,dc=example,dc=com":        "bob-password",
+	}
+	entries := []ldapTestEntry{
+		{
+			DN: "uid=annie,ou=people,dc=example,dc=com",
+			Attributes: map[string][]string{
+				"uid":         {"annie"},
+				"cn":          {"Annie"},
+				"displayname": {"Annie Admin"},
+				"mail":        {"annie@example.com"},
+			},
+		},
+		{
+			DN: "uid=bob,ou=people,dc=example,dc=com",
+			Attributes: map[string][]string{
+				"uid":  {"bob"},
+				"cn":   {"Bob"},
+				"mail": {"bob@example.com"},
+			},
+		},
+		{
+			DN: "cn=admins,ou=groups,dc=example,dc=com",
+			Attributes: map[string][]string{
+				"cn":     {"admins"},
+				"member": {"uid=annie,ou=people,dc=example,dc=com"},
+			},
+		},
+		{
+			DN: "cn=developers,ou=groups,dc=example,dc=com",
+			Attributes: map[string][]string{
+				"cn": {"developers"},
+				"member": {
+					"uid=annie,ou=people,dc=example,dc=com",
+					"uid=bob,ou=people,dc=example,dc=com",
+				},
+			},
+		},
+	}
+	return passwords, entries
+}
+
+func TestLDAPUserManager(t *testing.T) {
+	passwords, entries := ldapTestDirectory()
+
+	newManager := func(t *testing.T, server *ldapTestServer, bindDN string) *LDAPUserManager {
+		conf := &evergreen.LDAPConfig{
+			URL:         server.url(),
+			UserBaseDN:  "ou=people,dc=example,dc=com",
+			GroupBaseDN: "ou=groups,dc=example,dc=com",
+			GroupRoles: []evergreen.LDAPGroupRole{
+				{Group: "Admins", Role: "admin"},
+				{Group: "developers", Role: "developer"},
+			},
+		}
+		if bindDN != "" {
+			conf.BindDN = bindDN
+			conf.BindPassword = passwords[bindDN]
+		}
+		require.NoError(t, validateLDAPConfig(conf))
+
+		um, err := LoadUserManager(evergreen.AuthConfig{LDAP: conf})
+		require.NoError(t, err)
+		require.IsType(t, &LDAPUserManager{}, um)
+		m := um.(*LDAPUserManager)
+		m.tlsConfig = server.clientTLSConfig()
+		return m
+	}
+
+	for serverName, opts := range map[string]ldapTestServerOptions{
+		"LDAPS":    {ldaps: true},
+		"StartTLS": {startTLS: true},
+	} {
+		t.Run(serverName, func(t *testing.T) {
+			server := newLDAPTestServer(t, opts, passwords, entries)
+			defer server.close()
+
+			for name, bindDN := range map[string]string{
+				"ServiceAccount": "cn=evergreen,ou=services,dc=example,dc=com",
+				"UserBind":       "",
+			} {
+				t.Run(name, func(t *testing.T) {
+					m := newManager(t, server, bindDN)
+					ctx := context.Background()
+
+					t.Run("MapsGroupsToRoles", func(t *testing.T) {
+						u, err := m.authenticate(ctx, "annie", "annie-password")
+						require.NoError(t, err)
+						assert.Equal(t, "annie", u.Username())
+						assert.Equal(t, "Annie Admin", u.DisplayName())
+						assert.Equal(t, "annie@example.com", u.Email())
+						assert.Equal(t, []string{"admin", "developer"}, u.Roles())
+
+						u, err = m.authenticate(ctx, "bob", "bob-password")
+						require.NoError(t, err)
+						assert.Equal(t, "Bob", u.DisplayName())
+						assert.Equal(t, []string{"developer"}, u.Roles())
+					})
+					t.Run("WrongPassword", func(t *testing.T) {
+						_, err := m.authenticate(ctx, "annie", "bob-password")
+						assert.Error(t, err)
+					})
+					t.Run("UnknownUser", func(t *testing.T) {
+						_, err := m.authenticate(ctx, "carol", "carol-password")
+						assert.Error(t, err)
+					})
+					t.Run("EmptyPassword", func(t *testing.T) {
+						_, err := m.authenticate(ctx, "annie", "")
+						assert.Error(t, err)
+					})
+				})
+			}
+
+			t.Run("ServiceAccountLooksUpGroups", func(t *testing.T) {
+				m := newManager(t, server, "cn=evergreen,ou=services,dc=example,dc=com")
+				before := len(server.boundAs())
+				_, err := m.authenticate(context.Background(), "bob", "bob-password")
+				require.NoError(t, err)
+				assert.Equal(t, []string{
+					"cn=evergreen,ou=services,dc=example,dc=com",
+					"uid=bob,ou=people,dc=example,dc=com",
+					"cn=evergreen,ou=services,dc=example,dc=com",
+				}, server.boundAs()[before:])
+			})
+			t.Run("WrongServicePassword", func(t *testing.T) {
+				m := newManager(t, server, "cn=evergreen,ou=services,dc=example,dc=com")
+				m.conf.BindPassword = "wrong"
+				_, err := m.authenticate(context.Background(), "annie", "annie-password")
+				assert.Error(t, err)
+			})
+			t.Run("UntrustedCertificate", func(t *testing.T) {
+				m := newManager(t, server, "")
+				m.tlsConfig = nil
+				before := len(server.boundAs())
+				_, err := m.authenticate(context.Background(), "annie", "annie-password")
+				assert.Error(t, err)
+				assert.Len(t, server.boundAs(), before)
+			})
+		})
+	}
+
+	t.Run("RefusesToBindWithoutTLS", func(t *testing.T) {
+		server := newLDAPTestServer(t, ldapTestServerOptions{}, passwords, entries)
+		defer server.close()
+
+		for name, bindDN := range map[string]string{
+			"ServiceAccount": "cn=evergreen,ou=services,dc=example,dc=com",
+			"UserBind":       "",
+		} {
+			t.Run(name, func(t *testing.T) {
+				m := newManager(t, server, bindDN)
+				_, err := m.authenticate(context.Background(), "annie", "annie-password")
+				assert.Error(t, err)
+				assert.Empty(t, server.boundAs())
+			})
+		}
+	})
+	t.Run("Unreachable", func(t *testing.T) {
+		server := newLDAPTestServer(t, ldapTestServerOptions{ldaps: true}, passwords, entries)
+		server.close()
+		m := newManager(t, server, "")
+		m.conf.URL = "ldaps://127.0.0.1:1"
+		_, err := m.authenticate(context.Background(), "annie", "annie-password")
+		assert.Error(t, err)
+	})
+}
+
+func TestEscapeLDAPDNValue(t *testing.T) {
+	assert.Equal(t, "annie", escapeLDAPDNValue("annie"))
+	assert.Equal(t, `smith\, john`, escapeLDAPDNValue("smith, john"))
+	assert.Equal(t, `a\=b\+c`, escapeLDAPDNValue("a=b+c"))
+	assert.Equal(t, `\#hash`, escapeLDAPDNValue("#hash"))
+	assert.Equal(t, `\ padded\ `, escapeLDAPDNValue(" padded "))
+}
+
+func TestLDAPConfigValidation(t *testing.T) {
+	conf := &evergreen.LDAPConfig{UserBaseDN: "ou=people,dc=example,dc=com"}
+	assert.Error(t, validateLDAPConfig(conf))
+
+	conf.URL = "http://ldap.example.com"
+	assert.Error(t, validateLDAPConfig(conf))
+
+	conf.URL = "ldap://ldap.example.com"
+	assert.NoError(t, validateLDAPConfig(conf))
+
+	conf.URL = "ldaps://ldap.example.com"
+	require.NoError(t, validateLDAPConfig(conf))
+	assert.Equal(t, "uid", conf.UserAttribute)
+	assert.Equal(t, "member", conf.GroupMemberAttribute)
+	assert.NotZero(t, conf.ExpireAfterMinutes)
+
+	conf.BindDN = "cn=evergreen,ou=services,dc=example,dc=com"
+	assert.Error(t, validateLDAPConfig(conf))
+	conf.BindPassword = "password"
+	assert.NoError(t, validateLDAPConfig(conf))
+
+	conf.GroupRoles = []evergreen.LDAPGroupRole{{Group: "admins", Role: "admin"}}
+	assert.Error(t, validateLDAPConfig(conf))
+	conf.GroupBaseDN = "ou=groups,dc=example,dc=com"
+	assert.NoError(t, validateLDAPConfig(conf))
+}
+
+func validateLDAPConfig(conf *evergreen.LDAPConfig) error {
+	authConfig := evergreen.AuthConfig{LDAP: conf}
+	return authConfig.ValidateAndDefault()
+}
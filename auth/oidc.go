@@ -0,0 +1,493 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcTimeout     = 10 * time.Second
+	oidcStateCookie = "evergreen-oidc-state"
+	// oidcStateMaxAge is how long a user has to log in with the provider.
+	oidcStateMaxAge = 10 * time.Minute
+	// oidcClockSkew is how far the clocks of Evergreen and the provider may
+	// differ when checking the expiration of ID tokens.
+	oidcClockSkew = time.Minute
+)
+
+// OIDCUserManager authenticates users with an OpenID Connect provider using
+// the authorization code flow. The login handler redirects the user to the
+// provider, and the callback handler exchanges the code it receives for an
+// ID token, which is verified with the provider's JSON web keys. Logins are
+// cached in the database, and expire after the configured duration.
+type OIDCUserManager struct {
+	conf        evergreen.OIDCConfig
+	expireAfter time.Duration
+
+	mu       sync.Mutex
+	provider *oidcProvider
+	keys     map[string]*rsa.PublicKey
+}
+
+// oidcProvider is the part of the provider's discovery document that the
+// authorization code flow needs.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcLoginState is stored in a cookie between the login redirect and the
+// callback.
+type oidcLoginState struct {
+	State       string `json:"state"`
+	Nonce       string `json:"nonce"`
+	CallbackURI string `json:"callback_uri"`
+	Redirect    string `json:"redirect"`
+}
+
+// NewOIDCUserManager creates a manager for the given provider. The
+// configuration must have been validated.
+func NewOIDCUserManager(conf *evergreen.OIDCConfig) (gimlet.UserManager, error) {
+	if conf.Issuer == "" {
+		return nil, errors.New("no issuer for OIDC config")
+	}
+	if conf.ClientID == "" {
+		return nil, errors.New("no client id for OIDC config")
+	}
+	if conf.ClientSecret == "" {
+		return nil, errors.New("no client secret for OIDC config")
+	}
+	if conf.UsernameClaim == "" {
+		return nil, errors.New("no username claim for OIDC config")
+	}
+
+	return &OIDCUserManager{
+		conf:        *conf,
+		expireAfter: time.Duration(conf.ExpireAfterMinutes) * time.Minute,
+		keys:        map[string]*rsa.PublicKey{},
+	}, nil
+}
+
+// GetUserByToken returns the user who logged in with the token, if their
+// login has not expired.
+func (m *OIDCUserManager) GetUserByToken(_ context.Context, token string) (gimlet.User, error) {
+	u, err := model.GetLoginCache(token, m.expireAfter)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem getting user by token")
+	}
+	return u, nil
+}
+
+// CreateUserToken is not implemented in OIDCUserManager
+func (*OIDCUserManager) CreateUserToken(string, string) (string, error) {
+	return "", errors.New("OIDCUserManager does not create tokens via username/password")
+}
+
+// GetLoginHandler returns the function that starts the authorization code
+// flow by redirecting the user to the provider.
+func (m *OIDCUserManager) GetLoginHandler(callbackUri string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), oidcTimeout)
+		defer cancel()
+
+		provider, err := m.getProvider(ctx)
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "problem getting OIDC provider configuration",
+				"issuer":  m.conf.Issuer,
+			}))
+			http.Error(w, "authentication provider is unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		state := oidcLoginState{
+			State:       util.RandomString(),
+			Nonce:       util.RandomString(),
+			CallbackURI: callbackUri + "/login/redirect/callback",
+			Redirect:    r.FormValue("redirect"),
+		}
+		cookieValue, err := json.Marshal(state)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcStateCookie,
+			Value:    base64.RawURLEncoding.EncodeToString(cookieValue),
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+			MaxAge:   int(oidcStateMaxAge / time.Second),
+		})
+
+		config := m.oauthConfig(provider, state.CallbackURI)
+		http.Redirect(w, r, config.AuthCodeURL(state.State, oauth2.SetAuthURLParam("nonce", state.Nonce)), http.StatusFound)
+	}
+}
+
+// GetLoginCallbackHandler returns the function that is called when the
+// provider redirects the user back to Evergreen.
+func (m *OIDCUserManager) GetLoginCallbackHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state, err := readOIDCLoginState(r)
+		// the state is only good for one login
+		http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: "/", HttpOnly: true, Secure: true, MaxAge: -1})
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "problem reading OIDC login state",
+			}))
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.FormValue("state")), []byte(state.State)) != 1 {
+			grip.Error(message.Fields{
+				"message": "OIDC login state does not match",
+			})
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		if errCode := r.FormValue("error"); errCode != "" {
+			grip.Error(message.Fields{
+				"message":     "OIDC provider returned an error",
+				"error":       errCode,
+				"description": r.FormValue("error_description"),
+			})
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		code := r.FormValue("code")
+		if code == "" {
+			grip.Error("Error getting code from OIDC provider for authentication")
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), oidcTimeout)
+		defer cancel()
+
+		u, err := m.authenticate(ctx, code, state)
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "problem authenticating with OIDC provider",
+				"issuer":  m.conf.Issuer,
+			}))
+			http.Error(w, "could not authenticate user", http.StatusUnauthorized)
+			return
+		}
+
+		dbUser, err := model.GetOrCreateUser(u.UserId, u.Name, u.EmailAddress)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		token, err := model.PutLoginCache(dbUser.Id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		setLoginToken(token, w)
+
+		// only redirect to pages of Evergreen
+		redirect := state.Redirect
+		if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") {
+			redirect = "/"
+		}
+		http.Redirect(w, r, redirect, http.StatusFound)
+	}
+}
+
+func readOIDCLoginState(r *http.Request) (*oidcLoginState, error) {
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		return nil, errors.Wrap(err, "no login state")
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed login state")
+	}
+	state := &oidcLoginState{}
+	if err = json.Unmarshal(value, state); err != nil {
+		return nil, errors.Wrap(err, "malformed login state")
+	}
+	if state.State == "" || state.Nonce == "" {
+		return nil, errors.New("incomplete login state")
+	}
+	return state, nil
+}
+
+// authenticate exchanges an authorization code for an ID token and returns
+// the user it identifies.
+func (m *OIDCUserManager) authenticate(ctx context.Context, code string, state *oidcLoginState) (*simpleUser, error) {
+	provider, err := m.getProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	token, err := m.oauthConfig(provider, state.CallbackURI).Exchange(context.WithValue(ctx, oauth2.HTTPClient, client), code)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem exchanging authorization code")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response has no ID token")
+	}
+
+	claims, err := m.verifyIDToken(ctx, rawIDToken, state.Nonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ID token")
+	}
+
+	return m.userFromClaims(claims)
+}
+
+func (m *OIDCUserManager) oauthConfig(provider *oidcProvider, callbackURI string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     m.conf.ClientID,
+		ClientSecret: m.conf.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  provider.AuthorizationEndpoint,
+			TokenURL: provider.TokenEndpoint,
+		},
+		RedirectURL: callbackURI,
+		Scopes:      m.conf.Scopes,
+	}
+}
+
+func (m *OIDCUserManager) userFromClaims(claims map[string]interface{}) (*simpleUser, error) {
+	claimString := func(name string) string {
+		s, _ := claims[name].(string)
+		return s
+	}
+
+	username := claimString(m.conf.UsernameClaim)
+	if username == "" {
+		return nil, errors.Errorf("ID token has no '%s' claim", m.conf.UsernameClaim)
+	}
+	name := claimString("name")
+	if name == "" {
+		name = username
+	}
+
+	return &simpleUser{
+		UserId:       username,
+		Name:         name,
+		EmailAddress: claimString("email"),
+	}, nil
+}
+
+// getProvider returns the provider's discovery document, fetching it the
+// first time it is needed.
+func (m *OIDCUserManager) getProvider(ctx context.Context) (*oidcProvider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.provider != nil {
+		return m.provider, nil
+	}
+
+	issuer := strings.TrimSuffix(m.conf.Issuer, "/")
+	provider := &oidcProvider{}
+	if err := getOIDCDocument(ctx, issuer+"/.well-known/openid-configuration", provider); err != nil {
+		return nil, errors.Wrap(err, "problem getting OIDC discovery document")
+	}
+	if strings.TrimSuffix(provider.Issuer, "/") != issuer {
+		return nil, errors.Errorf("provider issuer '%s' does not match '%s'", provider.Issuer, m.conf.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing endpoints")
+	}
+
+	m.provider = provider
+	return m.provider, nil
+}
+
+func getOIDCDocument(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "problem getting '%s'", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("getting '%s' returned status %d", url, resp.StatusCode)
+	}
+
+	return errors.Wrapf(json.NewDecoder(resp.Body).Decode(out), "problem decoding '%s'", url)
+}
+
+// getKey returns the provider's RSA key with the given ID, refetching the
+// provider's keys if it is not known, since providers rotate their keys.
+func (m *OIDCUserManager) getKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	provider, err := m.getProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if key, ok := m.keys[kid]; ok {
+		return key, nil
+	}
+
+	jwks := struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}{}
+	if err = getOIDCDocument(ctx, provider.JWKSURI, &jwks); err != nil {
+		return nil, errors.Wrap(err, "problem getting OIDC keys")
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		exponent := 0
+		for _, b := range e {
+			exponent = exponent<<8 | int(b)
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}
+	}
+	m.keys = keys
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return nil, errors.Errorf("provider has no key '%s'", kid)
+	}
+	return key, nil
+}
+
+// verifyIDToken checks the signature and claims of an RS256 ID token,
+// returning its claims.
+func (m *OIDCUserManager) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (map[string]interface{}, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT")
+	}
+
+	header := struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, errors.Wrap(err, "malformed JWT header")
+	}
+	if header.Alg != "RS256" {
+		return nil, errors.Errorf("unsupported signing algorithm '%s'", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed JWT signature")
+	}
+	key, err := m.getKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errors.Wrap(err, "invalid JWT signature")
+	}
+
+	claims := map[string]interface{}{}
+	if err = decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, errors.Wrap(err, "malformed JWT claims")
+	}
+
+	provider, err := m.getProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if iss, _ := claims["iss"].(string); iss != provider.Issuer {
+		return nil, errors.Errorf("token was issued by '%s'", iss)
+	}
+	if !jwtAudienceContains(claims["aud"], m.conf.ClientID) {
+		return nil, errors.New("token was not issued for this client")
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("token has no expiration")
+	}
+	if time.Now().Add(-oidcClockSkew).After(time.Unix(int64(exp), 0)) {
+		return nil, errors.New("token has expired")
+	}
+	if tokenNonce, _ := claims["nonce"].(string); subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return nil, errors.New("token nonce does not match")
+	}
+
+	return claims, nil
+}
+
+func decodeJWTPart(part string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(json.Unmarshal(data, out))
+}
+
+func jwtAudienceContains(aud interface{}, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []interface{}:
+		for _, a := range v {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (*OIDCUserManager) IsRedirect() bool                           { return true }
+func (*OIDCUserManager) GetUserByID(id string) (gimlet.User, error) { return getUserByID(id) }
+func (*OIDCUserManager) GetOrCreateUser(u gimlet.User) (gimlet.User, error) {
+	return getOrCreateUser(u)
+}
@@ -0,0 +1,308 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// oidcTestProvider is an in-process OpenID Connect provider that serves a
+// discovery document, its keys, and a token endpoint that issues ID tokens
+// for a single authorization code.
+type oidcTestProvider struct {
+	server       *httptest.Server
+	key          *rsa.PrivateKey
+	kid          string
+	clientID     string
+	clientSecret string
+
+	mu         sync.Mutex
+	code       string
+	claims     map[string]interface{}
+	jwksserved int
+}
+
+func newOIDCTestProvider(t *testing.T) *oidcTestProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &oidcTestProvider{
+		key:          key,
+		kid:          "key-1",
+		clientID:     "evergreen",
+		clientSecret: "client-secret",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeOIDCTestJSON(w, map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.jwksserved++
+		writeOIDCTestJSON(w, map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"use": "sig",
+					"kid": p.kid,
+					"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok {
+			id, secret = r.FormValue("client_id"), r.FormValue("client_secret")
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if id != p.clientID || secret != p.clientSecret || r.FormValue("code") != p.code || p.code == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		p.code = ""
+		writeOIDCTestJSON(w, map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     p.sign(p.kid, p.claims),
+		})
+	})
+	p.server = httptest.NewServer(mux)
+
+	return p
+}
+
+// issue sets the claims of the ID token that the provider will exchange for
+// the returned code.
+func (p *oidcTestProvider) issue(claims map[string]interface{}) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.code = "code-" + claims["nonce"].(string)
+	p.claims = claims
+	return p.code
+}
+
+func (p *oidcTestProvider) validClaims(nonce string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":                p.server.URL,
+		"aud":                p.clientID,
+		"sub":                "1234",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              nonce,
+		"preferred_username": "annie",
+		"name":               "Annie Admin",
+		"email":              "annie@example.com",
+	}
+}
+
+func (p *oidcTestProvider) sign(kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeOIDCTestJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestOIDCUserManager(t *testing.T) {
+	p := newOIDCTestProvider(t)
+	defer p.server.Close()
+
+	newManager := func(t *testing.T) *OIDCUserManager {
+		conf := &evergreen.OIDCConfig{
+			Issuer:       p.server.URL,
+			ClientID:     p.clientID,
+			ClientSecret: p.clientSecret,
+		}
+		authConfig := evergreen.AuthConfig{OIDC: conf}
+		require.NoError(t, authConfig.ValidateAndDefault())
+
+		um, err := LoadUserManager(authConfig)
+		require.NoError(t, err)
+		require.IsType(t, &OIDCUserManager{}, um)
+		assert.True(t, um.IsRedirect())
+		return um.(*OIDCUserManager)
+	}
+	ctx := context.Background()
+
+	t.Run("LoginRedirectsToProvider", func(t *testing.T) {
+		m := newManager(t)
+		rw := httptest.NewRecorder()
+		m.GetLoginHandler("http://evergreen.example.com")(rw, httptest.NewRequest(http.MethodGet, "/login/redirect?redirect=/waterfall", nil))
+		require.Equal(t, http.StatusFound, rw.Code)
+
+		location, err := url.Parse(rw.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, p.server.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+		query := location.Query()
+		assert.Equal(t, "code", query.Get("response_type"))
+		assert.Equal(t, p.clientID, query.Get("client_id"))
+		assert.Equal(t, "http://evergreen.example.com/login/redirect/callback", query.Get("redirect_uri"))
+		assert.Equal(t, "openid profile email", query.Get("scope"))
+
+		cookies := rw.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.True(t, cookies[0].Secure)
+		assert.True(t, cookies[0].HttpOnly)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(cookies[0])
+		state, err := readOIDCLoginState(req)
+		require.NoError(t, err)
+		assert.Equal(t, state.State, query.Get("state"))
+		assert.Equal(t, state.Nonce, query.Get("nonce"))
+		assert.Equal(t, "/waterfall", state.Redirect)
+	})
+	t.Run("CallbackRejectsMismatchedState", func(t *testing.T) {
+		m := newManager(t)
+		rw := httptest.NewRecorder()
+		m.GetLoginHandler("http://evergreen.example.com")(rw, httptest.NewRequest(http.MethodGet, "/login/redirect", nil))
+		cookies := rw.Result().Cookies()
+		require.Len(t, cookies, 1)
+
+		req := httptest.NewRequest(http.MethodGet, "/login/redirect/callback?state=forged&code=code", nil)
+		req.AddCookie(cookies[0])
+		rw = httptest.NewRecorder()
+		m.GetLoginCallbackHandler()(rw, req)
+		assert.Equal(t, http.StatusFound, rw.Code)
+		assert.Equal(t, "/login", rw.Header().Get("Location"))
+	})
+	t.Run("CallbackRequiresLoginState", func(t *testing.T) {
+		m := newManager(t)
+		rw := httptest.NewRecorder()
+		m.GetLoginCallbackHandler()(rw, httptest.NewRequest(http.MethodGet, "/login/redirect/callback?state=state&code=code", nil))
+		assert.Equal(t, http.StatusFound, rw.Code)
+		assert.Equal(t, "/login", rw.Header().Get("Location"))
+	})
+	t.Run("AuthenticateExchangesCode", func(t *testing.T) {
+		m := newManager(t)
+		state := &oidcLoginState{State: "state", Nonce: "nonce-1", CallbackURI: "http://evergreen.example.com/login/redirect/callback"}
+		code := p.issue(p.validClaims(state.Nonce))
+
+		u, err := m.authenticate(ctx, code, state)
+		require.NoError(t, err)
+		assert.Equal(t, "annie", u.Username())
+		assert.Equal(t, "Annie Admin", u.DisplayName())
+		assert.Equal(t, "annie@example.com", u.Email())
+
+		// the code is only good once
+		_, err = m.authenticate(ctx, code, state)
+		assert.Error(t, err)
+	})
+	t.Run("AuthenticateRequiresUsernameClaim", func(t *testing.T) {
+		m := newManager(t)
+		state := &oidcLoginState{State: "state", Nonce: "nonce-2"}
+		claims := p.validClaims(state.Nonce)
+		delete(claims, "preferred_username")
+
+		_, err := m.authenticate(ctx, p.issue(claims), state)
+		assert.Error(t, err)
+	})
+	t.Run("VerifyIDToken", func(t *testing.T) {
+		m := newManager(t)
+		const nonce = "nonce"
+
+		claims, err := m.verifyIDToken(ctx, p.sign(p.kid, p.validClaims(nonce)), nonce)
+		require.NoError(t, err)
+		assert.Equal(t, "annie", claims["preferred_username"])
+
+		for name, modify := range map[string]func(map[string]interface{}){
+			"WrongIssuer":   func(c map[string]interface{}) { c["iss"] = "https://attacker.example.com" },
+			"WrongAudience": func(c map[string]interface{}) { c["aud"] = "someone-else" },
+			"Expired":       func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+			"NoExpiration":  func(c map[string]interface{}) { delete(c, "exp") },
+			"WrongNonce":    func(c map[string]interface{}) { c["nonce"] = "replayed" },
+		} {
+			t.Run(name, func(t *testing.T) {
+				claims := p.validClaims(nonce)
+				modify(claims)
+				_, err := m.verifyIDToken(ctx, p.sign(p.kid, claims), nonce)
+				assert.Error(t, err)
+			})
+		}
+		t.Run("AudienceList", func(t *testing.T) {
+			claims := p.validClaims(nonce)
+			claims["aud"] = []string{"someone-else", p.clientID}
+			_, err := m.verifyIDToken(ctx, p.sign(p.kid, claims), nonce)
+			assert.NoError(t, err)
+		})
+		t.Run("BadSignature", func(t *testing.T) {
+			token := p.sign(p.kid, p.validClaims(nonce))
+			other := p.sign(p.kid, p.validClaims("other"))
+			forged := token[:strings.LastIndex(token, ".")] + other[strings.LastIndex(other, "."):]
+			_, err := m.verifyIDToken(ctx, forged, nonce)
+			assert.Error(t, err)
+		})
+		t.Run("UnsignedToken", func(t *testing.T) {
+			header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+			payload, err := json.Marshal(p.validClaims(nonce))
+			require.NoError(t, err)
+			_, err = m.verifyIDToken(ctx, header+"."+base64.RawURLEncoding.EncodeToString(payload)+".", nonce)
+			assert.Error(t, err)
+		})
+		t.Run("Malformed", func(t *testing.T) {
+			_, err := m.verifyIDToken(ctx, "not-a-jwt", nonce)
+			assert.Error(t, err)
+		})
+		t.Run("UnknownKeyRefetchesKeys", func(t *testing.T) {
+			p.mu.Lock()
+			before := p.jwksserved
+			p.mu.Unlock()
+
+			_, err := m.verifyIDToken(ctx, p.sign("rotated", p.validClaims(nonce)), nonce)
+			assert.Error(t, err)
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			assert.Equal(t, before+1, p.jwksserved)
+		})
+	})
+}
+
+func TestOIDCConfigValidation(t *testing.T) {
+	conf := &evergreen.OIDCConfig{ClientID: "id", ClientSecret: "secret"}
+	authConfig := evergreen.AuthConfig{OIDC: conf}
+	assert.Error(t, authConfig.ValidateAndDefault())
+
+	conf.Issuer = "https://accounts.example.com"
+	require.NoError(t, authConfig.ValidateAndDefault())
+	assert.Equal(t, []string{"openid", "profile", "email"}, conf.Scopes)
+	assert.Equal(t, "preferred_username", conf.UsernameClaim)
+	assert.NotZero(t, conf.ExpireAfterMinutes)
+
+	conf.Scopes = []string{"profile"}
+	assert.Error(t, authConfig.ValidateAndDefault())
+}
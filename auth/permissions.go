@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+// ProjectRoles returns the names of the roles that a user has on a project:
+// the project's default role, admin if they are one of the project's admins,
+// and the roles in assignments that are assigned to them or to one of their
+// groups.
+func ProjectRoles(u gimlet.User, projectRef *model.ProjectRef, assignments []role.Assignment) []string {
+	if u == nil || projectRef == nil {
+		return []string{}
+	}
+
+	roles := []string{projectRef.GetDefaultRole()}
+	if util.StringSliceContains(projectRef.Admins, u.Username()) {
+		roles = append(roles, role.Admin)
+	}
+	groups := u.Roles()
+	for _, assignment := range assignments {
+		if assignment.ProjectID != projectRef.Identifier {
+			continue
+		}
+		if assignment.User == u.Username() || (assignment.Group != "" && util.StringSliceContains(groups, assignment.Group)) {
+			roles = append(roles, assignment.Role)
+		}
+	}
+
+	return util.UniqueStrings(roles)
+}
+
+// HasProjectPermission returns true if the user is a superuser, or has a role
+// on the project that allows the permission.
+func HasProjectPermission(superUsers []string, u gimlet.User, projectRef *model.ProjectRef, p role.Permission) (bool, error) {
+	if u == nil || projectRef == nil {
+		return false, nil
+	}
+	if IsSuperUser(superUsers, u) {
+		return true, nil
+	}
+
+	assignments, err := role.FindForUser(projectRef.Identifier, u.Username(), u.Roles())
+	if err != nil {
+		return false, errors.Wrapf(err, "problem finding roles of user '%s'", u.Username())
+	}
+
+	return role.AnyHas(ProjectRoles(u, projectRef, assignments), p), nil
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectRoles(t *testing.T) {
+	assert := assert.New(t)
+
+	projectRef := &model.ProjectRef{
+		Identifier: "mci",
+		Admins:     []string{"admin"},
+	}
+	assignments := []role.Assignment{
+		{ProjectID: "mci", Role: role.Patcher, User: "me"},
+		{ProjectID: "mci", Role: role.Admin, Group: "leads"},
+		{ProjectID: "other", Role: role.Admin, User: "me"},
+	}
+
+	me := &user.DBUser{Id: "me"}
+	assert.Equal([]string{role.Scheduler, role.Patcher}, ProjectRoles(me, projectRef, assignments))
+	assert.Equal([]string{role.Scheduler, role.Admin}, ProjectRoles(&user.DBUser{Id: "admin"}, projectRef, nil))
+	assert.Equal([]string{role.Scheduler, role.Admin}, ProjectRoles(&user.DBUser{Id: "lead", SystemRoles: []string{"leads"}}, projectRef, assignments))
+	assert.Empty(ProjectRoles(nil, projectRef, assignments))
+	assert.Empty(ProjectRoles(me, nil, assignments))
+
+	projectRef.DefaultRole = role.Viewer
+	roles := ProjectRoles(me, projectRef, assignments)
+	assert.Equal([]string{role.Viewer, role.Patcher}, roles)
+	assert.True(role.AnyHas(roles, role.SubmitPatches))
+	assert.False(role.AnyHas(roles, role.TaskControl))
+	assert.False(role.AnyHas(ProjectRoles(&user.DBUser{Id: "you"}, projectRef, assignments), role.SubmitPatches))
+}
+
+func TestHasProjectPermissionSuperUser(t *testing.T) {
+	assert := assert.New(t)
+
+	projectRef := &model.ProjectRef{Identifier: "mci", DefaultRole: role.Viewer}
+
+	ok, err := HasProjectPermission([]string{"root"}, &user.DBUser{Id: "root"}, projectRef, role.EditProject)
+	assert.NoError(err)
+	assert.True(ok)
+
+	ok, err = HasProjectPermission([]string{"root"}, nil, projectRef, role.EditProject)
+	assert.NoError(err)
+	assert.False(ok)
+
+	ok, err = HasProjectPermission([]string{"root"}, &user.DBUser{Id: "root"}, nil, role.EditProject)
+	assert.NoError(err)
+	assert.False(ok)
+}
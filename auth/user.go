@@ -13,4 +13,4 @@ func (u *simpleUser) Email() string       { return u.EmailAddress }
 func (u *simpleUser) Username() string    { return u.UserId }
 func (u *simpleUser) IsNil() bool         { return u == nil }
 func (u *simpleUser) GetAPIKey() string   { return u.APIKey }
-func (u *simpleUser) Roles() []string     { out := []string{}; copy(out, u.SiteRoles); return out }
+func (u *simpleUser) Roles() []string     { return append([]string{}, u.SiteRoles...) }
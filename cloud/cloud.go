@@ -103,6 +103,8 @@ func GetManager(ctx context.Context, providerName string, settings *evergreen.Se
 		provider = &gceManager{}
 	case evergreen.ProviderNameVsphere:
 		provider = &vsphereManager{}
+	case evergreen.ProviderNameKubernetes:
+		provider = &kubernetesManager{}
 	default:
 		return nil, errors.Errorf("No known provider for '%s'", providerName)
 	}
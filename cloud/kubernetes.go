@@ -0,0 +1,275 @@
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// kubernetesManager implements the Manager interface for Kubernetes, running
+// each host as a single short-lived pod.
+type kubernetesManager struct {
+	client kubernetesClient
+}
+
+// KubernetesSettings specifies the settings used to configure a pod.
+type KubernetesSettings struct {
+	Namespace string   `mapstructure:"namespace" json:"namespace" bson:"namespace"`
+	Image     string   `mapstructure:"image" json:"image" bson:"image"`
+	Command   []string `mapstructure:"command" json:"command,omitempty" bson:"command,omitempty"`
+	Args      []string `mapstructure:"args" json:"args,omitempty" bson:"args,omitempty"`
+
+	// Resource quantities use Kubernetes notation, i.e. "500m" or "2Gi".
+	CPURequest    string `mapstructure:"cpu_request" json:"cpu_request,omitempty" bson:"cpu_request,omitempty"`
+	MemoryRequest string `mapstructure:"memory_request" json:"memory_request,omitempty" bson:"memory_request,omitempty"`
+	CPULimit      string `mapstructure:"cpu_limit" json:"cpu_limit,omitempty" bson:"cpu_limit,omitempty"`
+	MemoryLimit   string `mapstructure:"memory_limit" json:"memory_limit,omitempty" bson:"memory_limit,omitempty"`
+
+	NodeSelector   map[string]string `mapstructure:"node_selector" json:"node_selector,omitempty" bson:"node_selector,omitempty"`
+	ServiceAccount string            `mapstructure:"service_account" json:"service_account,omitempty" bson:"service_account,omitempty"`
+}
+
+// Validate checks that the settings from the distro are sane.
+func (s *KubernetesSettings) Validate() error {
+	if s.Namespace == "" {
+		return errors.New("Namespace must not be blank")
+	}
+	if s.Image == "" {
+		return errors.New("Image must not be blank")
+	}
+	return nil
+}
+
+// GetSettings returns an empty KubernetesSettings struct.
+func (m *kubernetesManager) GetSettings() ProviderSettings {
+	return &KubernetesSettings{}
+}
+
+// Configure loads the API server credentials from the global config object.
+func (m *kubernetesManager) Configure(ctx context.Context, s *evergreen.Settings) error {
+	if m.client == nil {
+		m.client = &kubernetesClientImpl{}
+	}
+
+	if err := m.client.Init(s.Providers.Kubernetes); err != nil {
+		return errors.Wrap(err, "Failed to initialize client connection")
+	}
+
+	return nil
+}
+
+// SpawnHost creates a pod for the host.
+//
+// ProviderSettings in the distro should have the following settings:
+//   - Namespace:      namespace in which to create the pod
+//   - Image:          container image that runs the host
+//   - Command/Args:   (optional) override the image's entrypoint
+//   - CPURequest, MemoryRequest, CPULimit, MemoryLimit: (optional) resources
+//   - NodeSelector:   (optional) labels a node must have to run the pod
+//   - ServiceAccount: (optional) service account to run the pod as
+func (m *kubernetesManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
+	if h.Distro.Provider != evergreen.ProviderNameKubernetes {
+		return nil, errors.Errorf("Can't spawn instance of %s for distro %s: provider is %s",
+			evergreen.ProviderNameKubernetes, h.Distro.Id, h.Distro.Provider)
+	}
+
+	s, err := getKubernetesSettings(h)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := m.client.CreatePod(ctx, h, s); err != nil {
+		if rmErr := h.Remove(); rmErr != nil {
+			grip.Errorf("Could not remove intent host '%s': %+v", h.Id, rmErr)
+		}
+		grip.Error(err)
+		return nil, errors.Wrapf(err, "Could not start new pod for distro '%s'", h.Distro.Id)
+	}
+
+	event.LogHostStarted(h.Id)
+	grip.Debug(message.Fields{
+		"message":   "new kubernetes host",
+		"host":      h.Id,
+		"namespace": s.Namespace,
+	})
+
+	return h, nil
+}
+
+// GetInstanceStatus returns a universal status code representing the phase
+// of the host's pod.
+func (m *kubernetesManager) GetInstanceStatus(ctx context.Context, h *host.Host) (CloudStatus, error) {
+	s, err := getKubernetesSettings(h)
+	if err != nil {
+		return StatusUnknown, err
+	}
+
+	pod, err := m.client.GetPod(ctx, h, s.Namespace)
+	if err != nil {
+		if errors.Cause(err) == errKubernetesPodNotFound {
+			return StatusTerminated, nil
+		}
+		return StatusUnknown, err
+	}
+
+	return kubernetesToEvgStatus(pod.Status.Phase), nil
+}
+
+// GetInstanceStatuses returns the statuses of the pods backing the hosts,
+// making a single list call per namespace for the pods that Evergreen
+// manages. Hosts whose pods no longer exist are reported as terminated.
+func (m *kubernetesManager) GetInstanceStatuses(ctx context.Context, hosts []host.Host) ([]CloudStatus, error) {
+	namespaces := map[string][]int{}
+	for i := range hosts {
+		s, err := getKubernetesSettings(&hosts[i])
+		if err != nil {
+			return nil, err
+		}
+		namespaces[s.Namespace] = append(namespaces[s.Namespace], i)
+	}
+
+	statuses := make([]CloudStatus, len(hosts))
+	for namespace, indexes := range namespaces {
+		pods, err := m.client.ListPods(ctx, namespace, map[string]string{kubernetesManagedByLabel: kubernetesManagedByValue})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error listing pods in namespace '%s'", namespace)
+		}
+
+		phases := make(map[string]string, len(pods))
+		for _, pod := range pods {
+			phases[pod.Metadata.Name] = pod.Status.Phase
+		}
+
+		for _, i := range indexes {
+			phase, ok := phases[hosts[i].Id]
+			if !ok {
+				statuses[i] = StatusTerminated
+				continue
+			}
+			statuses[i] = kubernetesToEvgStatus(phase)
+		}
+	}
+
+	return statuses, nil
+}
+
+// TerminateInstance deletes the host's pod.
+func (m *kubernetesManager) TerminateInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status == evergreen.HostTerminated {
+		err := errors.Errorf("Can not terminate %s - already marked as terminated!", h.Id)
+		grip.Error(err)
+		return err
+	}
+
+	s, err := getKubernetesSettings(h)
+	if err != nil {
+		return err
+	}
+
+	if err := m.client.DeletePod(ctx, h, s.Namespace); err != nil {
+		return errors.Wrap(err, "API call to delete pod failed")
+	}
+
+	grip.Info(message.Fields{
+		"message":   "terminated kubernetes pod",
+		"host":      h.Id,
+		"namespace": s.Namespace,
+	})
+
+	// Set the host status as terminated and update its termination time
+	return h.Terminate(user)
+}
+
+// IsUp checks whether the host's pod is running.
+func (m *kubernetesManager) IsUp(ctx context.Context, h *host.Host) (bool, error) {
+	status, err := m.GetInstanceStatus(ctx, h)
+	if err != nil {
+		return false, err
+	}
+
+	return status == StatusRunning, nil
+}
+
+// OnUp does nothing since labels are attached when the pod is created.
+func (m *kubernetesManager) OnUp(context.Context, *host.Host) error {
+	return nil
+}
+
+// GetDNSName returns the IP address of the host's pod.
+func (m *kubernetesManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
+	s, err := getKubernetesSettings(h)
+	if err != nil {
+		return "", err
+	}
+
+	pod, err := m.client.GetPod(ctx, h, s.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	if pod.Status.PodIP == "" {
+		return "", errors.Errorf("pod for host '%s' has not been assigned an IP", h.Id)
+	}
+
+	return pod.Status.PodIP, nil
+}
+
+// GetSSHOptions generates the command line args to be passed to SSH to allow
+// connection to the pod.
+func (m *kubernetesManager) GetSSHOptions(h *host.Host, keyPath string) ([]string, error) {
+	if keyPath == "" {
+		return []string{}, errors.New("No key specified for Kubernetes host")
+	}
+
+	opts := []string{"-i", keyPath}
+	for _, opt := range h.Distro.SSHOptions {
+		opts = append(opts, "-o", opt)
+	}
+	return opts, nil
+}
+
+// TimeTilNextPayment returns the amount of time until the next payment is due
+// for the host. For Kubernetes this is not relevant.
+func (m *kubernetesManager) TimeTilNextPayment(_ *host.Host) time.Duration {
+	return time.Duration(0)
+}
+
+// getKubernetesSettings decodes and validates the provider settings of the
+// host's distro.
+func getKubernetesSettings(h *host.Host) (*KubernetesSettings, error) {
+	s := &KubernetesSettings{}
+	if h.Distro.ProviderSettings != nil {
+		if err := mapstructure.Decode(h.Distro.ProviderSettings, s); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding params for distro '%s'", h.Distro.Id)
+		}
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "Invalid Kubernetes settings in distro '%s'", h.Distro.Id)
+	}
+
+	return s, nil
+}
+
+// kubernetesToEvgStatus converts a pod phase to an Evergreen cloud status.
+func kubernetesToEvgStatus(phase string) CloudStatus {
+	switch phase {
+	case "Pending":
+		return StatusInitializing
+	case "Running":
+		return StatusRunning
+	case "Succeeded":
+		return StatusTerminated
+	case "Failed":
+		return StatusFailed
+	default:
+		return StatusUnknown
+	}
+}
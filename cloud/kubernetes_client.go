@@ -0,0 +1,313 @@
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// kubernetesHostLabel is the pod label holding the Evergreen host ID.
+	kubernetesHostLabel = "evergreen-host-id"
+	// kubernetesDistroLabel is the pod label holding the Evergreen distro ID.
+	kubernetesDistroLabel = "evergreen-distro-id"
+	// kubernetesManagedByLabel marks every pod that Evergreen creates, so
+	// that listing pods doesn't return pods that other tools manage in the
+	// same namespace.
+	kubernetesManagedByLabel = "app.kubernetes.io/managed-by"
+	// kubernetesManagedByValue is the value of kubernetesManagedByLabel.
+	kubernetesManagedByValue = "evergreen"
+	// kubernetesContainerName is the name of the single container in each pod.
+	kubernetesContainerName = "evergreen-task-host"
+)
+
+// errKubernetesPodNotFound is returned by a kubernetesClient when the API
+// server reports that a pod does not exist.
+var errKubernetesPodNotFound = errors.New("pod not found")
+
+// kubernetesAPIError is returned when the API server responds to a request
+// with an unsuccessful status code.
+type kubernetesAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *kubernetesAPIError) Error() string {
+	return fmt.Sprintf("Kubernetes API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// isKubernetesNotFound returns whether the API server reported that the
+// requested resource does not exist.
+func isKubernetesNotFound(err error) bool {
+	apiErr, ok := errors.Cause(err).(*kubernetesAPIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// The kubernetesClient interface wraps interaction with the Kubernetes API
+// server.
+type kubernetesClient interface {
+	Init(evergreen.KubernetesConfig) error
+	CreatePod(context.Context, *host.Host, *KubernetesSettings) (*kubernetesPod, error)
+	GetPod(context.Context, *host.Host, string) (*kubernetesPod, error)
+	ListPods(context.Context, string, map[string]string) ([]kubernetesPod, error)
+	DeletePod(context.Context, *host.Host, string) error
+}
+
+// kubernetesPod contains the subset of the Kubernetes v1 Pod resource that
+// Evergreen reads and writes.
+type kubernetesPod struct {
+	APIVersion string                `json:"apiVersion,omitempty"`
+	Kind       string                `json:"kind,omitempty"`
+	Metadata   kubernetesPodMetadata `json:"metadata"`
+	Spec       kubernetesPodSpec     `json:"spec"`
+	Status     kubernetesPodStatus   `json:"status,omitempty"`
+}
+
+type kubernetesPodMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type kubernetesPodSpec struct {
+	Containers         []kubernetesContainer `json:"containers"`
+	NodeSelector       map[string]string     `json:"nodeSelector,omitempty"`
+	ServiceAccountName string                `json:"serviceAccountName,omitempty"`
+	RestartPolicy      string                `json:"restartPolicy,omitempty"`
+}
+
+type kubernetesContainer struct {
+	Name      string                         `json:"name"`
+	Image     string                         `json:"image"`
+	Command   []string                       `json:"command,omitempty"`
+	Args      []string                       `json:"args,omitempty"`
+	Resources kubernetesResourceRequirements `json:"resources,omitempty"`
+}
+
+type kubernetesResourceRequirements struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
+
+type kubernetesPodStatus struct {
+	Phase  string `json:"phase,omitempty"`
+	PodIP  string `json:"podIP,omitempty"`
+	HostIP string `json:"hostIP,omitempty"`
+}
+
+type kubernetesPodList struct {
+	Items []kubernetesPod `json:"items"`
+}
+
+// makePod builds the pod definition used to run the given host.
+func makePod(h *host.Host, s *KubernetesSettings) *kubernetesPod {
+	requests := map[string]string{}
+	if s.CPURequest != "" {
+		requests["cpu"] = s.CPURequest
+	}
+	if s.MemoryRequest != "" {
+		requests["memory"] = s.MemoryRequest
+	}
+	limits := map[string]string{}
+	if s.CPULimit != "" {
+		limits["cpu"] = s.CPULimit
+	}
+	if s.MemoryLimit != "" {
+		limits["memory"] = s.MemoryLimit
+	}
+
+	return &kubernetesPod{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Metadata: kubernetesPodMetadata{
+			Name:      h.Id,
+			Namespace: s.Namespace,
+			Labels: map[string]string{
+				kubernetesHostLabel:      h.Id,
+				kubernetesDistroLabel:    h.Distro.Id,
+				kubernetesManagedByLabel: kubernetesManagedByValue,
+			},
+		},
+		Spec: kubernetesPodSpec{
+			Containers: []kubernetesContainer{
+				{
+					Name:    kubernetesContainerName,
+					Image:   s.Image,
+					Command: s.Command,
+					Args:    s.Args,
+					Resources: kubernetesResourceRequirements{
+						Requests: requests,
+						Limits:   limits,
+					},
+				},
+			},
+			NodeSelector:       s.NodeSelector,
+			ServiceAccountName: s.ServiceAccount,
+			RestartPolicy:      "Never",
+		},
+	}
+}
+
+type kubernetesClientImpl struct {
+	apiServer  string
+	token      string
+	httpClient *http.Client
+}
+
+// Init configures the client to talk to the API server described by the
+// admin settings.
+func (c *kubernetesClientImpl) Init(config evergreen.KubernetesConfig) error {
+	if config.APIServer == "" {
+		return errors.New("Kubernetes API server must not be blank")
+	}
+	c.apiServer = strings.TrimSuffix(config.APIServer, "/")
+	c.token = config.Token
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}
+	if config.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(config.CACert)) {
+			return errors.New("could not parse Kubernetes CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	c.httpClient = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	return nil
+}
+
+// CreatePod creates a pod for the host in the namespace given by the settings.
+func (c *kubernetesClientImpl) CreatePod(ctx context.Context, h *host.Host, s *KubernetesSettings) (*kubernetesPod, error) {
+	pod := makePod(h, s)
+	grip.Debug(message.Fields{
+		"message":   "creating pod",
+		"host":      h.Id,
+		"namespace": s.Namespace,
+		"image":     s.Image,
+	})
+
+	out := &kubernetesPod{}
+	if err := c.do(ctx, http.MethodPost, c.podsPath(s.Namespace), pod, out); err != nil {
+		return nil, errors.Wrapf(err, "error creating pod for host '%s'", h.Id)
+	}
+
+	return out, nil
+}
+
+// GetPod returns the pod backing the host.
+func (c *kubernetesClientImpl) GetPod(ctx context.Context, h *host.Host, namespace string) (*kubernetesPod, error) {
+	out := &kubernetesPod{}
+	if err := c.do(ctx, http.MethodGet, c.podPath(namespace, h.Id), nil, out); err != nil {
+		if isKubernetesNotFound(err) {
+			return nil, errKubernetesPodNotFound
+		}
+		return nil, errors.Wrapf(err, "error getting pod for host '%s'", h.Id)
+	}
+
+	return out, nil
+}
+
+// ListPods returns the pods in a namespace that match all of the labels.
+func (c *kubernetesClientImpl) ListPods(ctx context.Context, namespace string, labels map[string]string) ([]kubernetesPod, error) {
+	selector := []string{}
+	for k, v := range labels {
+		selector = append(selector, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	path := c.podsPath(namespace)
+	if len(selector) > 0 {
+		path += "?labelSelector=" + url.QueryEscape(strings.Join(selector, ","))
+	}
+
+	out := &kubernetesPodList{}
+	if err := c.do(ctx, http.MethodGet, path, nil, out); err != nil {
+		return nil, errors.Wrapf(err, "error listing pods in namespace '%s'", namespace)
+	}
+
+	return out.Items, nil
+}
+
+// DeletePod deletes the pod backing the host. Deleting a pod that does not
+// exist is not an error.
+func (c *kubernetesClientImpl) DeletePod(ctx context.Context, h *host.Host, namespace string) error {
+	err := c.do(ctx, http.MethodDelete, c.podPath(namespace, h.Id), nil, nil)
+	if err != nil && !isKubernetesNotFound(err) {
+		return errors.Wrapf(err, "error deleting pod for host '%s'", h.Id)
+	}
+
+	return nil
+}
+
+func (c *kubernetesClientImpl) podsPath(namespace string) string {
+	return fmt.Sprintf("/api/v1/namespaces/%s/pods", url.PathEscape(namespace))
+}
+
+func (c *kubernetesClientImpl) podPath(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", c.podsPath(namespace), url.PathEscape(name))
+}
+
+// do sends a request to the API server, encoding in as the JSON body if it is
+// not nil and decoding the response into out if it is not nil.
+func (c *kubernetesClientImpl) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body *bytes.Buffer
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, "error marshalling request body")
+		}
+		body = bytes.NewBuffer(payload)
+	} else {
+		body = &bytes.Buffer{}
+	}
+
+	req, err := http.NewRequest(method, c.apiServer+path, body)
+	if err != nil {
+		return errors.Wrap(err, "error creating request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error sending %s request to %s", method, path)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "error reading response body")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &kubernetesAPIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return errors.Wrap(json.Unmarshal(respBody, out), "error decoding response body")
+}
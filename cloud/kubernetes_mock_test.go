@@ -0,0 +1,89 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+)
+
+type kubernetesClientMock struct {
+	// API call options
+	failInit   bool
+	failCreate bool
+	failGet    bool
+	failList   bool
+	failDelete bool
+
+	// pods holds the pods that exist, keyed by name
+	pods map[string]*kubernetesPod
+	// phase is the phase assigned to newly created pods
+	phase string
+}
+
+func (c *kubernetesClientMock) Init(evergreen.KubernetesConfig) error {
+	if c.failInit {
+		return errors.New("failed to initialize client")
+	}
+	if c.pods == nil {
+		c.pods = map[string]*kubernetesPod{}
+	}
+	return nil
+}
+
+func (c *kubernetesClientMock) CreatePod(_ context.Context, h *host.Host, s *KubernetesSettings) (*kubernetesPod, error) {
+	if c.failCreate {
+		return nil, errors.New("failed to create pod")
+	}
+
+	pod := makePod(h, s)
+	pod.Status = kubernetesPodStatus{Phase: c.phase, PodIP: "10.0.0.1"}
+	c.pods[h.Id] = pod
+
+	return pod, nil
+}
+
+func (c *kubernetesClientMock) GetPod(_ context.Context, h *host.Host, namespace string) (*kubernetesPod, error) {
+	if c.failGet {
+		return nil, errors.New("failed to get pod")
+	}
+
+	pod, ok := c.pods[h.Id]
+	if !ok || pod.Metadata.Namespace != namespace {
+		return nil, errKubernetesPodNotFound
+	}
+
+	return pod, nil
+}
+
+func (c *kubernetesClientMock) ListPods(_ context.Context, namespace string, labels map[string]string) ([]kubernetesPod, error) {
+	if c.failList {
+		return nil, errors.New("failed to list pods")
+	}
+
+	pods := []kubernetesPod{}
+outer:
+	for _, pod := range c.pods {
+		if pod.Metadata.Namespace != namespace {
+			continue
+		}
+		for k, v := range labels {
+			if pod.Metadata.Labels[k] != v {
+				continue outer
+			}
+		}
+		pods = append(pods, *pod)
+	}
+
+	return pods, nil
+}
+
+func (c *kubernetesClientMock) DeletePod(_ context.Context, h *host.Host, _ string) error {
+	if c.failDelete {
+		return errors.New("failed to delete pod")
+	}
+
+	delete(c.pods, h.Id)
+	return nil
+}
@@ -0,0 +1,298 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type KubernetesSuite struct {
+	client  *kubernetesClientMock
+	manager *kubernetesManager
+	distro  distro.Distro
+	suite.Suite
+}
+
+func TestKubernetesSuite(t *testing.T) {
+	suite.Run(t, new(KubernetesSuite))
+}
+
+func (s *KubernetesSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *KubernetesSuite) SetupTest() {
+	s.client = &kubernetesClientMock{phase: "Running"}
+	s.manager = &kubernetesManager{client: s.client}
+	s.NoError(s.manager.Configure(context.Background(), &evergreen.Settings{}))
+	s.distro = distro.Distro{
+		Id:       "distro",
+		Provider: evergreen.ProviderNameKubernetes,
+		ProviderSettings: &map[string]interface{}{
+			"namespace":      "evergreen",
+			"image":          "evergreen/task-host:latest",
+			"cpu_request":    "500m",
+			"memory_request": "1Gi",
+			"node_selector":  map[string]string{"pool": "tasks"},
+		},
+	}
+	s.NoError(db.Clear(host.Collection))
+}
+
+func (s *KubernetesSuite) TestValidateSettings() {
+	settings := &KubernetesSettings{Namespace: "evergreen", Image: "image"}
+	s.NoError(settings.Validate())
+
+	settings = &KubernetesSettings{Image: "image"}
+	s.Error(settings.Validate())
+
+	settings = &KubernetesSettings{Namespace: "evergreen"}
+	s.Error(settings.Validate())
+}
+
+func (s *KubernetesSuite) TestConfigureFailsWithClientError() {
+	manager := &kubernetesManager{client: &kubernetesClientMock{failInit: true}}
+	s.Error(manager.Configure(context.Background(), &evergreen.Settings{}))
+}
+
+func (s *KubernetesSuite) TestSpawnHost() {
+	ctx := context.Background()
+	h := NewIntent(s.distro, s.distro.GenerateName(), evergreen.ProviderNameKubernetes, HostOptions{})
+
+	spawned, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.Equal(h.Id, spawned.Id)
+
+	pod, ok := s.client.pods[h.Id]
+	s.Require().True(ok)
+	s.Equal("evergreen", pod.Metadata.Namespace)
+	s.Equal(h.Id, pod.Metadata.Labels[kubernetesHostLabel])
+	s.Equal("distro", pod.Metadata.Labels[kubernetesDistroLabel])
+	s.Require().Len(pod.Spec.Containers, 1)
+	s.Equal("evergreen/task-host:latest", pod.Spec.Containers[0].Image)
+	s.Equal("500m", pod.Spec.Containers[0].Resources.Requests["cpu"])
+	s.Equal("1Gi", pod.Spec.Containers[0].Resources.Requests["memory"])
+	s.Equal("tasks", pod.Spec.NodeSelector["pool"])
+}
+
+func (s *KubernetesSuite) TestSpawnHostInvalidSettings() {
+	ctx := context.Background()
+
+	s.distro.Provider = evergreen.ProviderNameDocker
+	h := NewIntent(s.distro, "pod", evergreen.ProviderNameKubernetes, HostOptions{})
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+
+	s.distro.Provider = evergreen.ProviderNameKubernetes
+	s.distro.ProviderSettings = &map[string]interface{}{"namespace": "evergreen"}
+	h = NewIntent(s.distro, "pod", evergreen.ProviderNameKubernetes, HostOptions{})
+	_, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+}
+
+func (s *KubernetesSuite) TestSpawnHostAPICallFails() {
+	ctx := context.Background()
+	s.client.failCreate = true
+	h := NewIntent(s.distro, "pod", evergreen.ProviderNameKubernetes, HostOptions{})
+	s.NoError(h.Insert())
+
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+
+	dbHost, err := host.FindOneId(h.Id)
+	s.NoError(err)
+	s.Nil(dbHost)
+}
+
+func (s *KubernetesSuite) TestGetInstanceStatus() {
+	ctx := context.Background()
+	h := NewIntent(s.distro, "pod", evergreen.ProviderNameKubernetes, HostOptions{})
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+
+	status, err := s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+
+	s.client.pods[h.Id].Status.Phase = "Pending"
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusInitializing, status)
+
+	s.client.failGet = true
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.Error(err)
+	s.Equal(StatusUnknown, status)
+
+	s.client.failGet = false
+	delete(s.client.pods, h.Id)
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusTerminated, status)
+}
+
+func (s *KubernetesSuite) TestGetInstanceStatuses() {
+	ctx := context.Background()
+	s.Implements((*BatchManager)(nil), s.manager)
+
+	hosts := []host.Host{}
+	for _, id := range []string{"pod-a", "pod-b", "pod-c"} {
+		h := NewIntent(s.distro, id, evergreen.ProviderNameKubernetes, HostOptions{})
+		hosts = append(hosts, *h)
+	}
+	_, err := s.manager.SpawnHost(ctx, &hosts[0])
+	s.Require().NoError(err)
+	_, err = s.manager.SpawnHost(ctx, &hosts[1])
+	s.Require().NoError(err)
+	s.client.pods["pod-b"].Status.Phase = "Failed"
+
+	statuses, err := s.manager.GetInstanceStatuses(ctx, hosts)
+	s.NoError(err)
+	s.Equal([]CloudStatus{StatusRunning, StatusFailed, StatusTerminated}, statuses)
+
+	s.client.failList = true
+	_, err = s.manager.GetInstanceStatuses(ctx, hosts)
+	s.Error(err)
+}
+
+func (s *KubernetesSuite) TestIsUp() {
+	ctx := context.Background()
+	h := NewIntent(s.distro, "pod", evergreen.ProviderNameKubernetes, HostOptions{})
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+
+	up, err := s.manager.IsUp(ctx, h)
+	s.NoError(err)
+	s.True(up)
+
+	s.client.pods[h.Id].Status.Phase = "Pending"
+	up, err = s.manager.IsUp(ctx, h)
+	s.NoError(err)
+	s.False(up)
+}
+
+func (s *KubernetesSuite) TestGetDNSName() {
+	ctx := context.Background()
+	h := NewIntent(s.distro, "pod", evergreen.ProviderNameKubernetes, HostOptions{})
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+
+	dns, err := s.manager.GetDNSName(ctx, h)
+	s.NoError(err)
+	s.Equal("10.0.0.1", dns)
+
+	s.client.pods[h.Id].Status.PodIP = ""
+	_, err = s.manager.GetDNSName(ctx, h)
+	s.Error(err)
+}
+
+func (s *KubernetesSuite) TestTerminateInstance() {
+	ctx := context.Background()
+	h := NewIntent(s.distro, "pod", evergreen.ProviderNameKubernetes, HostOptions{})
+	s.NoError(h.Insert())
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+
+	s.client.failDelete = true
+	s.Error(s.manager.TerminateInstance(ctx, h, evergreen.User))
+
+	s.client.failDelete = false
+	s.NoError(s.manager.TerminateInstance(ctx, h, evergreen.User))
+	s.NotContains(s.client.pods, h.Id)
+
+	dbHost, err := host.FindOneId(h.Id)
+	s.NoError(err)
+	s.Require().NotNil(dbHost)
+	s.Equal(evergreen.HostTerminated, dbHost.Status)
+
+	s.Error(s.manager.TerminateInstance(ctx, dbHost, evergreen.User))
+}
+
+func TestKubernetesClientImpl(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	pods := map[string]kubernetesPod{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/namespaces/evergreen/pods":
+			pod := kubernetesPod{}
+			assert.NoError(json.NewDecoder(r.Body).Decode(&pod))
+			pod.Status.Phase = "Pending"
+			pods[pod.Metadata.Name] = pod
+			w.WriteHeader(http.StatusCreated)
+			assert.NoError(json.NewEncoder(w).Encode(pod))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/namespaces/evergreen/pods":
+			assert.Equal(kubernetesManagedByLabel+"="+kubernetesManagedByValue, r.URL.Query().Get("labelSelector"))
+			list := kubernetesPodList{}
+			for _, pod := range pods {
+				list.Items = append(list.Items, pod)
+			}
+			assert.NoError(json.NewEncoder(w).Encode(list))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/namespaces/missing/pods":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			pod, ok := pods["pod"]
+			if !ok || r.URL.Path != "/api/v1/namespaces/evergreen/pods/pod" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			assert.NoError(json.NewEncoder(w).Encode(pod))
+		case r.Method == http.MethodDelete:
+			if _, ok := pods["pod"]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(pods, "pod")
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &kubernetesClientImpl{}
+	assert.Error(client.Init(evergreen.KubernetesConfig{}))
+	require.NoError(client.Init(evergreen.KubernetesConfig{APIServer: server.URL, Token: "token"}))
+
+	h := &host.Host{Id: "pod", Distro: distro.Distro{Id: "distro"}}
+	settings := &KubernetesSettings{Namespace: "evergreen", Image: "image", MemoryRequest: "1Gi"}
+
+	created, err := client.CreatePod(ctx, h, settings)
+	require.NoError(err)
+	assert.Equal("pod", created.Metadata.Name)
+	assert.Equal("1Gi", pods["pod"].Spec.Containers[0].Resources.Requests["memory"])
+
+	pod, err := client.GetPod(ctx, h, "evergreen")
+	require.NoError(err)
+	assert.Equal("Pending", pod.Status.Phase)
+
+	list, err := client.ListPods(ctx, "evergreen", map[string]string{kubernetesManagedByLabel: kubernetesManagedByValue})
+	require.NoError(err)
+	assert.Len(list, 1)
+	assert.Equal(kubernetesManagedByValue, list[0].Metadata.Labels[kubernetesManagedByLabel])
+
+	// a missing namespace is not a missing pod
+	_, err = client.ListPods(ctx, "missing", nil)
+	assert.Error(err)
+	assert.NotEqual(errKubernetesPodNotFound, errors.Cause(err))
+
+	assert.NoError(client.DeletePod(ctx, h, "evergreen"))
+	assert.NoError(client.DeletePod(ctx, h, "evergreen"))
+
+	_, err = client.GetPod(ctx, h, "evergreen")
+	assert.Equal(errKubernetesPodNotFound, errors.Cause(err))
+}
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// cacheRestore extracts a cache entry saved by cache.save, looking for it in
+// the host's local cache first and then in s3. A missing entry is not an
+// error, so that the task can build the cached content itself.
+type cacheRestore struct {
+	cacheParams `mapstructure:",squash" plugin:"expand"`
+
+	// ExtractTo is the directory the entry is extracted into.
+	ExtractTo string `mapstructure:"extract_to" plugin:"expand"`
+
+	base
+}
+
+func cacheRestoreFactory() Command   { return &cacheRestore{} }
+func (c *cacheRestore) Name() string { return "cache.restore" }
+
+// ParseParams parses and validates the command's parameters.
+func (c *cacheRestore) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrapf(err, "error decoding %s params", c.Name())
+	}
+
+	return errors.Wrapf(c.validate(), "error validating %s params", c.Name())
+}
+
+func (c *cacheRestore) validate() error {
+	if c.ExtractTo == "" {
+		return errors.New("extract_to cannot be blank")
+	}
+
+	return c.cacheParams.validate()
+}
+
+// Execute restores the cache entry, if it exists.
+func (c *cacheRestore) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "error expanding params")
+	}
+	if err := c.validate(); err != nil {
+		return errors.Wrap(err, "expanded params are not valid")
+	}
+
+	if !filepath.IsAbs(c.ExtractTo) {
+		c.ExtractTo = filepath.Join(conf.WorkDir, c.ExtractTo)
+	}
+
+	cache := c.localCache(conf)
+	entry, ok := cache.get(conf.Task.Project, c.Key)
+	if ok {
+		logger.Task().Infof("found cache entry '%s' on the host", c.Key)
+	} else if c.Bucket != "" {
+		var err error
+		entry, ok, err = c.download(ctx, logger, conf, cache)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if !ok {
+		logger.Task().Infof("cache entry '%s' not found", c.Key)
+		return nil
+	}
+
+	f, err := os.Open(entry)
+	if err != nil {
+		return errors.Wrapf(err, "problem opening cache entry '%s'", c.Key)
+	}
+	defer f.Close()
+
+	if err = util.ExtractTarball(ctx, f, c.ExtractTo, []string{}); err != nil {
+		// don't restore a corrupt entry again
+		logger.Execution().Warning(os.Remove(entry))
+		return errors.Wrapf(err, "problem extracting cache entry '%s'", c.Key)
+	}
+
+	logger.Task().Infof("restored cache entry '%s' to '%s'", c.Key, c.ExtractTo)
+	return nil
+}
+
+// download fetches the cache entry from s3 into the local cache, returning
+// false if it does not exist.
+func (c *cacheRestore) download(ctx context.Context, logger client.LoggerProducer, conf *model.TaskConfig, cache *localCache) (string, bool, error) {
+	remotePath := c.remotePath(conf)
+
+	tmp, err := cache.tempFile()
+	if err != nil {
+		return "", false, errors.WithStack(err)
+	}
+	tmpPath := tmp.Name()
+	logger.Execution().Warning(tmp.Close())
+	defer func() {
+		if _, err = os.Stat(tmpPath); err == nil {
+			logger.Execution().Warning(os.Remove(tmpPath))
+		}
+	}()
+
+	httpClient := util.GetHTTPClient()
+	defer util.PutHTTPClient(httpClient)
+	bucket := c.bucket(httpClient)
+
+	logger.Task().Infof("fetching cache entry '%s' from s3 bucket %s", c.Key, c.Bucket)
+	err = withS3Retry(ctx, logger, fmt.Sprintf("fetching cache entry '%s'", c.Key), func() error {
+		reader, err := bucket.GetReader(remotePath)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		return copyToFile(tmpPath, reader)
+	})
+	if isS3NotFound(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, errors.WithStack(err)
+	}
+
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		return "", false, errors.WithStack(err)
+	}
+	entry, err := cache.put(conf.Task.Project, c.Key, tmpPath)
+	if err != nil {
+		if entry == "" {
+			return "", false, errors.WithStack(err)
+		}
+		logger.Execution().Warning(errors.Wrap(err, "problem evicting old cache entries"))
+	}
+	logger.Task().Infof("fetched cache entry %s", cacheEntryDescription(c.Key, info.Size()))
+
+	return entry, true, nil
+}
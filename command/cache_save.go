@@ -0,0 +1,195 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/goamz/goamz/s3"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// cacheSave archives files as a cache entry, for cache.restore to extract
+// in later tasks. The entry is kept in the host's local cache and, if a
+// bucket is given, uploaded to s3 so that other hosts can restore it. Entries
+// are never overwritten, since the key identifies their content.
+type cacheSave struct {
+	cacheParams `mapstructure:",squash" plugin:"expand"`
+
+	// SourceDir is the directory to archive, and Include and ExcludeFiles
+	// are filename blobs selecting the files in it, as in archive.targz_pack.
+	SourceDir    string   `mapstructure:"source_dir" plugin:"expand"`
+	Include      []string `mapstructure:"include" plugin:"expand"`
+	ExcludeFiles []string `mapstructure:"exclude_files" plugin:"expand"`
+
+	// Permissions is the ACL of the entry in s3, private by default.
+	Permissions string `mapstructure:"permissions" plugin:"expand"`
+
+	base
+}
+
+func cacheSaveFactory() Command   { return &cacheSave{} }
+func (c *cacheSave) Name() string { return "cache.save" }
+
+// ParseParams parses and validates the command's parameters.
+func (c *cacheSave) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrapf(err, "error decoding %s params", c.Name())
+	}
+
+	return errors.Wrapf(c.validate(), "error validating %s params", c.Name())
+}
+
+func (c *cacheSave) validate() error {
+	catcher := grip.NewBasicCatcher()
+	if c.SourceDir == "" {
+		catcher.Add(errors.New("source_dir cannot be blank"))
+	}
+	if len(c.Include) == 0 {
+		catcher.Add(errors.New("include cannot be empty"))
+	}
+	if c.Permissions == "" {
+		c.Permissions = string(s3.Private)
+	}
+	if !util.IsExpandable(c.Permissions) && !validS3Permissions(c.Permissions) {
+		catcher.Add(errors.Errorf("permissions '%s' are not valid", c.Permissions))
+	}
+	catcher.Add(c.cacheParams.validate())
+
+	return catcher.Resolve()
+}
+
+// Execute saves the cache entry, unless it already exists.
+func (c *cacheSave) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "error expanding params")
+	}
+	if err := c.validate(); err != nil {
+		return errors.Wrap(err, "expanded params are not valid")
+	}
+
+	if !filepath.IsAbs(c.SourceDir) {
+		c.SourceDir = filepath.Join(conf.WorkDir, c.SourceDir)
+	}
+
+	cache := c.localCache(conf)
+	entry, cachedLocally := cache.get(conf.Task.Project, c.Key)
+
+	httpClient := util.GetHTTPClient()
+	defer util.PutHTTPClient(httpClient)
+	bucket := c.bucket(httpClient)
+	remotePath := c.remotePath(conf)
+
+	cachedRemotely := c.Bucket == ""
+	if !cachedRemotely {
+		err := withS3Retry(ctx, logger, fmt.Sprintf("checking for cache entry '%s'", c.Key), func() error {
+			var err error
+			cachedRemotely, err = bucket.Exists(remotePath)
+			return err
+		})
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if cachedLocally && cachedRemotely {
+		logger.Task().Infof("cache entry '%s' already exists", c.Key)
+		return nil
+	}
+
+	if !cachedLocally {
+		var (
+			filesArchived int
+			err           error
+		)
+		entry, filesArchived, err = c.makeEntry(ctx, logger, conf, cache)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if filesArchived == 0 {
+			logger.Task().Warningf("no files to cache in '%s', not saving cache entry '%s'", c.SourceDir, c.Key)
+			return nil
+		}
+	}
+
+	if !cachedRemotely {
+		info, err := os.Stat(entry)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		logger.Task().Infof("uploading cache entry %s to s3 bucket %s",
+			cacheEntryDescription(c.Key, info.Size()), c.Bucket)
+		err = withS3Retry(ctx, logger, fmt.Sprintf("uploading cache entry '%s'", c.Key), func() error {
+			f, err := os.Open(entry)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			return bucket.PutReader(remotePath, f, info.Size(), "application/x-gzip", s3.ACL(c.Permissions), s3.Options{})
+		})
+		if err != nil {
+			return errors.Wrapf(err, "problem uploading cache entry '%s'", c.Key)
+		}
+	}
+
+	logger.Task().Infof("saved cache entry '%s'", c.Key)
+	return nil
+}
+
+// makeEntry archives the files into the local cache, returning the path of
+// the new entry and the number of files archived.
+func (c *cacheSave) makeEntry(ctx context.Context, logger client.LoggerProducer, conf *model.TaskConfig, cache *localCache) (string, int, error) {
+	tmp, err := cache.tempFile()
+	if err != nil {
+		return "", -1, errors.WithStack(err)
+	}
+	tmpPath := tmp.Name()
+	logger.Execution().Warning(tmp.Close())
+	defer func() {
+		if _, err = os.Stat(tmpPath); err == nil {
+			logger.Execution().Warning(os.Remove(tmpPath))
+		}
+	}()
+
+	filesArchived, err := c.makeArchive(ctx, logger, tmpPath)
+	if err != nil || filesArchived == 0 {
+		return "", filesArchived, errors.Wrapf(err, "problem archiving cache entry '%s'", c.Key)
+	}
+
+	entry, err := cache.put(conf.Task.Project, c.Key, tmpPath)
+	if err != nil {
+		if entry == "" {
+			return "", -1, errors.WithStack(err)
+		}
+		logger.Execution().Warning(errors.Wrap(err, "problem evicting old cache entries"))
+	}
+
+	return entry, filesArchived, nil
+}
+
+func (c *cacheSave) makeArchive(ctx context.Context, logger client.LoggerProducer, target string) (int, error) {
+	f, gz, tarWriter, err := util.TarGzWriter(target)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error opening target archive file %s", target)
+	}
+
+	filesArchived, err := util.BuildArchive(ctx, tarWriter, c.SourceDir, c.Include,
+		c.ExcludeFiles, logger.Execution())
+
+	catcher := grip.NewBasicCatcher()
+	catcher.Add(err)
+	catcher.Add(tarWriter.Close())
+	catcher.Add(gz.Close())
+	catcher.Add(f.Close())
+
+	return filesArchived, catcher.Resolve()
+}
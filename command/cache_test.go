@@ -0,0 +1,150 @@
+package command
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheParseParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("cache.restore", cacheRestoreFactory().Name())
+	assert.Error(cacheRestoreFactory().ParseParams(map[string]interface{}{"key": "deps"}))
+	assert.Error(cacheRestoreFactory().ParseParams(map[string]interface{}{"extract_to": "deps"}))
+	assert.NoError(cacheRestoreFactory().ParseParams(map[string]interface{}{"key": "deps-${hash}", "extract_to": "deps"}))
+	assert.Error(cacheRestoreFactory().ParseParams(map[string]interface{}{"key": "deps", "extract_to": "deps", "bucket": "bucket"}))
+	restore := cacheRestoreFactory()
+	assert.NoError(restore.ParseParams(map[string]interface{}{
+		"key": "deps", "extract_to": "deps", "bucket": "bucket", "aws_key": "key", "aws_secret": "secret",
+	}))
+	assert.Equal("secret", restore.(*cacheRestore).AwsSecret)
+
+	assert.Equal("cache.save", cacheSaveFactory().Name())
+	assert.Error(cacheSaveFactory().ParseParams(map[string]interface{}{"key": "deps", "source_dir": "deps"}))
+	assert.Error(cacheSaveFactory().ParseParams(map[string]interface{}{"key": "deps", "include": []string{"*"}}))
+	assert.Error(cacheSaveFactory().ParseParams(map[string]interface{}{
+		"key": "deps", "source_dir": "deps", "include": []string{"*"}, "permissions": "everyone",
+	}))
+	assert.Error(cacheSaveFactory().ParseParams(map[string]interface{}{
+		"key": "deps", "source_dir": "deps", "include": []string{"*"}, "max_local_size_mb": -1,
+	}))
+	save := cacheSaveFactory()
+	assert.NoError(save.ParseParams(map[string]interface{}{"key": "deps", "source_dir": "deps", "include": []string{"*"}}))
+	assert.Equal("private", save.(*cacheSave).Permissions)
+}
+
+func TestLocalCacheEviction(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "cache-test")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	cache := &localCache{dir: dir, maxSize: 25}
+	addEntry := func(key string, age time.Duration) {
+		tmp, err := cache.tempFile()
+		require.NoError(err)
+		_, err = tmp.Write(make([]byte, 10))
+		require.NoError(err)
+		require.NoError(tmp.Close())
+		entry, err := cache.put("project", key, tmp.Name())
+		require.NoError(err)
+		mtime := time.Now().Add(-age)
+		require.NoError(os.Chtimes(entry, mtime, mtime))
+	}
+
+	cached := func(key string) bool {
+		_, err := os.Stat(cache.entryPath("project", key))
+		return err == nil
+	}
+
+	addEntry("a", 3*time.Hour)
+	addEntry("b", 2*time.Hour)
+	_, ok := cache.get("other", "a")
+	assert.False(ok)
+	// using a makes it more recent than b
+	_, ok = cache.get("project", "a")
+	assert.True(ok)
+
+	addEntry("c", time.Hour)
+	assert.True(cached("a"))
+	assert.False(cached("b"))
+	assert.True(cached("c"))
+
+	// the new entry is kept, and the least recently used is evicted
+	addEntry("d", 4*time.Hour)
+	assert.True(cached("a"))
+	assert.False(cached("c"))
+	assert.True(cached("d"))
+}
+
+func TestCacheSaveAndRestoreLocally(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "cache-test")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	taskDir := filepath.Join(dir, "task")
+	require.NoError(os.MkdirAll(filepath.Join(taskDir, "deps", "lib"), 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(taskDir, "deps", "lib", "dep.txt"), []byte("dependency"), 0644))
+
+	conf := &model.TaskConfig{
+		Task:       &task.Task{Id: "task", Secret: "secret", Project: "project"},
+		Distro:     &distro.Distro{WorkDir: dir},
+		Expansions: util.NewExpansions(map[string]string{"hash": "abc"}),
+		WorkDir:    taskDir,
+	}
+	comm := client.NewMock("http://localhost.com")
+	logger := comm.GetLoggerProducer(ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret})
+
+	restore := cacheRestoreFactory()
+	require.NoError(restore.ParseParams(map[string]interface{}{"key": "deps-${hash}", "extract_to": "restored"}))
+	require.NoError(restore.Execute(ctx, comm, logger, conf))
+	_, err = os.Stat(filepath.Join(taskDir, "restored"))
+	assert.True(os.IsNotExist(err))
+
+	save := cacheSaveFactory()
+	require.NoError(save.ParseParams(map[string]interface{}{
+		"key": "deps-${hash}", "source_dir": "deps", "include": []string{"**"},
+	}))
+	require.NoError(save.Execute(ctx, comm, logger, conf))
+	entries, err := ioutil.ReadDir(filepath.Join(dir, cacheDirName))
+	require.NoError(err)
+	assert.Len(entries, 1)
+
+	restore = cacheRestoreFactory()
+	require.NoError(restore.ParseParams(map[string]interface{}{"key": "deps-${hash}", "extract_to": "restored"}))
+	require.NoError(restore.Execute(ctx, comm, logger, conf))
+	data, err := ioutil.ReadFile(filepath.Join(taskDir, "restored", "lib", "dep.txt"))
+	require.NoError(err)
+	assert.Equal("dependency", string(data))
+
+	// saving an existing entry does nothing
+	require.NoError(ioutil.WriteFile(filepath.Join(taskDir, "deps", "lib", "dep.txt"), []byte("changed"), 0644))
+	save = cacheSaveFactory()
+	require.NoError(save.ParseParams(map[string]interface{}{
+		"key": "deps-${hash}", "source_dir": "deps", "include": []string{"**"},
+	}))
+	require.NoError(save.Execute(ctx, comm, logger, conf))
+	require.NoError(os.RemoveAll(filepath.Join(taskDir, "restored")))
+	require.NoError(restore.Execute(ctx, comm, logger, conf))
+	data, err = ioutil.ReadFile(filepath.Join(taskDir, "restored", "lib", "dep.txt"))
+	require.NoError(err)
+	assert.Equal("dependency", string(data))
+}
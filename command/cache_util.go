@@ -0,0 +1,257 @@
+package command
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/goamz/goamz/aws"
+	"github.com/goamz/goamz/s3"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	// cacheDirName is the directory under the agent's working directory
+	// holding the local cache. It starts with a period so that the agent
+	// does not remove it when cleaning up its working directory on startup.
+	cacheDirName = ".evergreen-cache"
+
+	cacheArchiveExtension  = ".tgz"
+	defaultCacheMaxSizeMB  = 10 * 1024
+	cacheArchiveTempPrefix = "tmp-"
+)
+
+// cacheParams are the parameters shared by cache.restore and cache.save.
+type cacheParams struct {
+	// Key identifies the cache entry, and is typically built from an
+	// expansion holding a hash of the files the cached content depends
+	// on, e.g. "node_modules-${lockfile_hash}".
+	Key string `mapstructure:"key" plugin:"expand"`
+
+	// Bucket is the s3 bucket the cache entries are shared through. If it
+	// is blank, entries are only kept on the host.
+	Bucket string `mapstructure:"bucket" plugin:"expand"`
+
+	// Prefix is prepended to the remote name of cache entries within the
+	// bucket.
+	Prefix string `mapstructure:"prefix" plugin:"expand"`
+
+	// AwsKey and AwsSecret are the user's credentials for authenticating
+	// interactions with s3.
+	AwsKey    string `mapstructure:"aws_key" plugin:"expand"`
+	AwsSecret string `mapstructure:"aws_secret" plugin:"expand"`
+
+	// MaxLocalSizeMB is the size the local cache directory is trimmed to,
+	// by evicting the least recently used entries.
+	MaxLocalSizeMB int `mapstructure:"max_local_size_mb"`
+}
+
+func (p *cacheParams) validate() error {
+	catcher := grip.NewBasicCatcher()
+	if p.Key == "" {
+		catcher.Add(errors.New("key cannot be blank"))
+	}
+	if p.MaxLocalSizeMB < 0 {
+		catcher.Add(errors.New("max_local_size_mb cannot be negative"))
+	}
+	if p.Bucket != "" {
+		if err := validateS3BucketName(p.Bucket); err != nil {
+			catcher.Add(errors.Wrapf(err, "%s is an invalid bucket name", p.Bucket))
+		}
+		if p.AwsKey == "" {
+			catcher.Add(errors.New("aws_key cannot be blank"))
+		}
+		if p.AwsSecret == "" {
+			catcher.Add(errors.New("aws_secret cannot be blank"))
+		}
+	}
+
+	return catcher.Resolve()
+}
+
+// remotePath returns the name of the cache entry within its bucket. Entries
+// are scoped to the project, so that projects sharing a bucket cannot
+// restore each other's entries.
+func (p *cacheParams) remotePath(conf *model.TaskConfig) string {
+	return path.Join(p.Prefix, conf.Task.Project, p.Key+cacheArchiveExtension)
+}
+
+func (p *cacheParams) localCache(conf *model.TaskConfig) *localCache {
+	maxSizeMB := p.MaxLocalSizeMB
+	if maxSizeMB == 0 {
+		maxSizeMB = defaultCacheMaxSizeMB
+	}
+
+	baseDir := conf.WorkDir
+	if conf.Distro != nil && conf.Distro.WorkDir != "" {
+		baseDir = conf.Distro.WorkDir
+	}
+
+	return &localCache{
+		dir:     filepath.Join(baseDir, cacheDirName),
+		maxSize: int64(maxSizeMB) * 1024 * 1024,
+	}
+}
+
+func (p *cacheParams) bucket(httpClient *http.Client) *s3.Bucket {
+	auth := &aws.Auth{
+		AccessKey: p.AwsKey,
+		SecretKey: p.AwsSecret,
+	}
+	session := thirdparty.NewS3Session(auth, aws.USEast, httpClient)
+	return session.Bucket(p.Bucket)
+}
+
+// localCache is a directory of cache entries, one archive per key, that is
+// kept under a maximum size by evicting the least recently used entries. An
+// entry's modification time is its last use.
+type localCache struct {
+	dir     string
+	maxSize int64
+}
+
+func (c *localCache) entryPath(project, key string) string {
+	hash := sha256.Sum256([]byte(project + "/" + key))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+cacheArchiveExtension)
+}
+
+// get returns the path of the entry for the key, if it is in the cache, and
+// marks the entry as used.
+func (c *localCache) get(project, key string) (string, bool) {
+	entry := c.entryPath(project, key)
+	if _, err := os.Stat(entry); err != nil {
+		return "", false
+	}
+
+	now := time.Now()
+	grip.Warning(os.Chtimes(entry, now, now))
+
+	return entry, true
+}
+
+// tempFile creates a file in the cache directory to build an entry in,
+// so that it can be added to the cache with a rename.
+func (c *localCache) tempFile() (*os.File, error) {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "problem creating cache directory '%s'", c.dir)
+	}
+
+	f, err := ioutil.TempFile(c.dir, cacheArchiveTempPrefix)
+	return f, errors.Wrap(err, "problem creating temporary cache file")
+}
+
+// put moves the file at tempPath into the cache as the entry for the key,
+// then evicts entries until the cache fits in its maximum size. The new
+// entry is never evicted.
+func (c *localCache) put(project, key, tempPath string) (string, error) {
+	entry := c.entryPath(project, key)
+	if err := os.Rename(tempPath, entry); err != nil {
+		return "", errors.Wrapf(err, "problem adding '%s' to the cache", key)
+	}
+
+	return entry, errors.WithStack(c.evict(entry))
+}
+
+func (c *localCache) evict(keep string) error {
+	infos, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return errors.Wrapf(err, "problem reading cache directory '%s'", c.dir)
+	}
+
+	entries := []os.FileInfo{}
+	var size int64
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), cacheArchiveExtension) {
+			continue
+		}
+		entries = append(entries, info)
+		size += info.Size()
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+
+	catcher := grip.NewBasicCatcher()
+	for _, info := range entries {
+		if size <= c.maxSize {
+			break
+		}
+		entry := filepath.Join(c.dir, info.Name())
+		if entry == keep {
+			continue
+		}
+		if err = os.Remove(entry); err != nil {
+			catcher.Add(err)
+			continue
+		}
+		size -= info.Size()
+	}
+
+	return catcher.Resolve()
+}
+
+// isS3NotFound returns true if an s3 operation failed because the object does
+// not exist.
+func isS3NotFound(err error) bool {
+	s3Err, ok := errors.Cause(err).(*s3.Error)
+	return ok && s3Err.StatusCode == http.StatusNotFound
+}
+
+// withS3Retry runs an s3 operation until it succeeds, fails because the
+// object does not exist, or runs out of attempts.
+func withS3Retry(ctx context.Context, logger client.LoggerProducer, op string, fn func() error) error {
+	backoffCounter := getS3OpBackoff()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for i := 1; i <= maxS3OpAttempts; i++ {
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("%s canceled", op)
+		case <-timer.C:
+			err := fn()
+			if err == nil || isS3NotFound(err) {
+				return err
+			}
+
+			logger.Execution().Errorf("problem with %s (attempt %d of %d), retrying: %s",
+				op, i, maxS3OpAttempts, err.Error())
+			timer.Reset(backoffCounter.Duration())
+		}
+	}
+
+	return errors.Errorf("%s failed after %d attempts", op, maxS3OpAttempts)
+}
+
+// copyToFile writes the contents of a reader to a file, replacing it.
+func copyToFile(fn string, reader io.Reader) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return errors.Wrapf(err, "problem opening '%s'", fn)
+	}
+
+	_, err = io.Copy(f, reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return errors.Wrapf(err, "problem writing '%s'", fn)
+}
+
+func cacheEntryDescription(key string, size int64) string {
+	return fmt.Sprintf("'%s' (%.1f MB)", key, float64(size)/(1024*1024))
+}
@@ -9,13 +9,15 @@ import (
 
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 )
 
 type generateTask struct {
-	// Files are a list of JSON documents.
+	// Files are a list of JSON documents, or YAML documents if they end in
+	// .yml or .yaml, in the same shape as a project configuration file.
 	Files []string `mapstructure:"files"`
 
 	base
@@ -77,6 +79,13 @@ func generateTaskForFile(fn string, conf *model.TaskConfig) ([]byte, error) {
 		return nil, errors.Wrapf(err, "Problem reading from file '%s'", fn)
 	}
 
+	if util.IsYAMLFile(fn) {
+		data, err = util.YAMLToJSON(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem converting YAML file '%s' to JSON", fn)
+		}
+	}
+
 	return data, nil
 }
 
@@ -106,6 +106,29 @@ func (s *generateSuite) TestExecuteSuccess() {
 	s.NoError(c.Execute(s.ctx, s.comm, s.logger, s.conf))
 }
 
+func (s *generateSuite) TestGenerateTaskForYAMLFile() {
+	yml := `
+tasks:
+  - name: test
+    commands:
+      - command: git.get_project
+        params:
+          directory: src
+      - func: echo-hi
+`
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.tmpDirName, "generated.yml"), []byte(yml), 0644))
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.tmpDirName, "generated.json"), []byte(s.json), 0644))
+
+	fromYAML, err := generateTaskForFile("generated.yml", s.conf)
+	s.Require().NoError(err)
+	fromJSON, err := generateTaskForFile("generated.json", s.conf)
+	s.Require().NoError(err)
+	s.JSONEq(string(fromJSON), string(fromYAML))
+
+	c := &generateTask{Files: []string{"generated.yml"}}
+	s.NoError(c.Execute(s.ctx, s.comm, s.logger, s.conf))
+}
+
 func (s *generateSuite) TestMakeJsonOfAllFiles() {
 	thingOne := []byte(`
 {
@@ -392,13 +392,17 @@ func getApplyCommand(patchFile string) (string, error) {
 
 // getPatchCommands, given a module patch of a patch, will return the appropriate list of commands that
 // need to be executed, except for apply. If the patch is empty it will not apply the patch.
-func getPatchCommands(modulePatch patch.ModulePatch, dir, patchPath string) []string {
+// The directory is only reset to the patch's base revision if reset is true,
+// so that several patches for the same directory can be stacked.
+func getPatchCommands(modulePatch patch.ModulePatch, dir, patchPath string, reset bool) []string {
 	patchCommands := []string{
 		fmt.Sprintf("set -o xtrace"),
 		fmt.Sprintf("set -o errexit"),
 		fmt.Sprintf("ls"),
 		fmt.Sprintf("cd '%s'", dir),
-		fmt.Sprintf("git reset --hard '%s'", modulePatch.Githash),
+	}
+	if reset {
+		patchCommands = append(patchCommands, fmt.Sprintf("git reset --hard '%s'", modulePatch.Githash))
 	}
 	if modulePatch.PatchSet.Patch == "" {
 		return patchCommands
@@ -420,7 +424,10 @@ func (c *gitFetchProject) applyPatch(ctx context.Context, logger client.LoggerPr
 
 	output := subprocess.OutputOptions{Output: stdOut, Error: stdErr}
 
-	// patch sets and contain multiple patches, some of them for modules
+	// patch sets and contain multiple patches, some of them for modules.
+	// Commit queue patches contain several patches for the same directory,
+	// which are applied on top of each other.
+	resetDirs := map[string]bool{}
 	for _, patchPart := range p.Patches {
 		if ctx.Err() != nil {
 			return errors.New("apply patch operation canceled")
@@ -477,7 +484,8 @@ func (c *gitFetchProject) applyPatch(ctx context.Context, logger client.LoggerPr
 		tempAbsPath := tempFile.Name()
 
 		// this applies the patch using the patch files in the temp directory
-		patchCommandStrings := getPatchCommands(patchPart, dir, tempAbsPath)
+		patchCommandStrings := getPatchCommands(patchPart, dir, tempAbsPath, !resetDirs[dir])
+		resetDirs[dir] = true
 		applyCommand, err := getApplyCommand(tempAbsPath)
 		if err != nil {
 			logger.Execution().Error("Could not to determine patch type")
@@ -145,14 +145,19 @@ func TestGetPatchCommands(t *testing.T) {
 		},
 	}
 
-	cmds := getPatchCommands(modulePatch, "/teapot", "/tmp/bestest.patch")
+	cmds := getPatchCommands(modulePatch, "/teapot", "/tmp/bestest.patch", true)
 
 	assert.Len(cmds, 5)
 	assert.Equal("cd '/teapot'", cmds[3])
 	assert.Equal("git reset --hard 'a4aa03d0472d8503380479b76aef96c044182822'", cmds[4])
 
 	modulePatch.PatchSet.Patch = "bestest code"
-	cmds = getPatchCommands(modulePatch, "/teapot", "/tmp/bestest.patch")
+	cmds = getPatchCommands(modulePatch, "/teapot", "/tmp/bestest.patch", true)
 	assert.Len(cmds, 6)
 	assert.Equal("git apply --stat '/tmp/bestest.patch' || true", cmds[5])
+
+	cmds = getPatchCommands(modulePatch, "/teapot", "/tmp/bestest.patch", false)
+	assert.Len(cmds, 5)
+	assert.Equal("cd '/teapot'", cmds[3])
+	assert.Equal("git apply --stat '/tmp/bestest.patch' || true", cmds[4])
 }
@@ -25,7 +25,11 @@ func init() {
 		"archive.auto_extract":          autoExtractFactory,
 		"attach.results":                attachResultsFactory,
 		"attach.xunit_results":          xunitResultsFactory,
+		"attach.tap_results":            tapResultsFactory,
+		"attach.cucumber_results":       cucumberResultsFactory,
 		"attach.artifacts":              attachArtifactsFactory,
+		"cache.restore":                 cacheRestoreFactory,
+		"cache.save":                    cacheSaveFactory,
 		evergreen.CreateHostCommandName: createHostFactory,
 		"host.list":                     listHostFactory,
 		"expansions.fetch_vars":         fetchVarsFactory,
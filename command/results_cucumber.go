@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"os"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// cucumberResultsCommand reads in Cucumber JSON reports and converts their
+// scenarios to a format Evergreen can use.
+type cucumberResultsCommand struct {
+	// File describes the relative path of the file to be sent. Supports globbing.
+	// Note that this can also be described via expansions.
+	File  string   `mapstructure:"file" plugin:"expand"`
+	Files []string `mapstructure:"files" plugin:"expand"`
+	base
+}
+
+func cucumberResultsFactory() Command          { return &cucumberResultsCommand{} }
+func (c *cucumberResultsCommand) Name() string { return "attach.cucumber_results" }
+
+// ParseParams reads and validates the command parameters. This is required
+// to satisfy the 'Command' interface
+func (c *cucumberResultsCommand) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrapf(err, "error decoding '%s' params", c.Name())
+	}
+
+	if c.File == "" && len(c.Files) == 0 {
+		return errors.New("must specify at least one file")
+	}
+
+	return nil
+}
+
+// Execute parses the Cucumber reports and sends their results and the logs of
+// unsuccessful scenarios to the API server.
+func (c *cucumberResultsCommand) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "problem expanding params")
+	}
+	if c.File != "" {
+		c.Files = append(c.Files, c.File)
+	}
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- c.parseAndUploadResults(ctx, conf, logger, comm)
+	}()
+
+	select {
+	case err := <-errChan:
+		return errors.WithStack(err)
+	case <-ctx.Done():
+		logger.Execution().Info("Received signal to terminate execution of attach Cucumber results command")
+		return nil
+	}
+}
+
+func (c *cucumberResultsCommand) parseAndUploadResults(ctx context.Context, conf *model.TaskConfig,
+	logger client.LoggerProducer, comm client.Communicator) error {
+
+	tests := []task.TestResult{}
+	logs := []*model.TestLog{}
+	logIdxToTestIdx := []int{}
+
+	reportFilePaths, err := getFilePaths(conf.WorkDir, c.Files)
+	if err != nil {
+		return err
+	}
+
+	var features []cucumberFeature
+	for _, reportFileLoc := range reportFilePaths {
+		if ctx.Err() != nil {
+			return errors.New("operation canceled")
+		}
+
+		features, err = readCucumberFile(reportFileLoc)
+		if err != nil {
+			return err
+		}
+
+		for _, scenario := range cucumberScenarios(features) {
+			test, log := scenario.toModelTestResultAndLog(conf.Task)
+			if log != nil {
+				logs = append(logs, log)
+				logIdxToTestIdx = append(logIdxToTestIdx, len(tests))
+			}
+			tests = append(tests, test)
+		}
+	}
+
+	return sendTestLogsAndResults(ctx, conf, logger, comm, tests, logs, logIdxToTestIdx)
+}
+
+// readCucumberFile parses the features in the Cucumber report at path.
+func readCucumberFile(path string) ([]cucumberFeature, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't open Cucumber report")
+	}
+	defer file.Close()
+
+	features, err := parseCucumberResults(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing Cucumber report '%s'", path)
+	}
+
+	return features, nil
+}
@@ -0,0 +1,187 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// cucumber step and hook result statuses
+const (
+	cucumberPassed    = "passed"
+	cucumberFailed    = "failed"
+	cucumberAmbiguous = "ambiguous"
+)
+
+// cucumberFeature is a feature in a Cucumber JSON report.
+type cucumberFeature struct {
+	URI      string            `json:"uri"`
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Elements []cucumberElement `json:"elements"`
+}
+
+// cucumberElement is a scenario or background of a feature.
+type cucumberElement struct {
+	ID      string         `json:"id"`
+	Name    string         `json:"name"`
+	Keyword string         `json:"keyword"`
+	Type    string         `json:"type"`
+	Line    int            `json:"line"`
+	Before  []cucumberStep `json:"before"`
+	Steps   []cucumberStep `json:"steps"`
+	After   []cucumberStep `json:"after"`
+}
+
+// cucumberStep is a step or hook, along with its result.
+type cucumberStep struct {
+	Keyword string         `json:"keyword"`
+	Name    string         `json:"name"`
+	Line    int            `json:"line"`
+	Result  cucumberResult `json:"result"`
+}
+
+type cucumberResult struct {
+	Status string `json:"status"`
+	// Duration is in nanoseconds
+	Duration     int64  `json:"duration"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// cucumberScenario is a scenario with the steps of the backgrounds that ran
+// before it.
+type cucumberScenario struct {
+	Feature string
+	Name    string
+	Line    int
+	Steps   []cucumberStep
+}
+
+func parseCucumberResults(reader io.Reader) ([]cucumberFeature, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read results file")
+	}
+
+	features := []cucumberFeature{}
+	if err = json.Unmarshal(data, &features); err != nil {
+		return nil, errors.Wrap(err, "results are not a Cucumber JSON report")
+	}
+
+	return features, nil
+}
+
+// cucumberScenarios flattens the features of a report into scenarios. Since
+// the report lists a feature's background as an element before each of its
+// scenarios, background steps are folded into the scenario that follows.
+func cucumberScenarios(features []cucumberFeature) []cucumberScenario {
+	scenarios := []cucumberScenario{}
+	for _, feature := range features {
+		featureName := feature.Name
+		if featureName == "" {
+			featureName = feature.URI
+		}
+
+		var background []cucumberStep
+		for _, element := range feature.Elements {
+			if element.Type == "background" {
+				background = append(background, element.Steps...)
+				continue
+			}
+
+			steps := append([]cucumberStep{}, element.Before...)
+			steps = append(steps, background...)
+			steps = append(steps, element.Steps...)
+			steps = append(steps, element.After...)
+			background = nil
+
+			scenarios = append(scenarios, cucumberScenario{
+				Feature: featureName,
+				Name:    element.Name,
+				Line:    element.Line,
+				Steps:   steps,
+			})
+		}
+	}
+
+	return scenarios
+}
+
+// status returns the Evergreen test status of the scenario: failed if any
+// step or hook failed, passed if all of them passed, and skipped otherwise,
+// e.g. for scenarios with pending or undefined steps.
+func (s cucumberScenario) status() string {
+	passed := true
+	for _, step := range s.Steps {
+		switch step.Result.Status {
+		case cucumberFailed, cucumberAmbiguous:
+			return evergreen.TestFailedStatus
+		case cucumberPassed:
+		default:
+			passed = false
+		}
+	}
+	if !passed || len(s.Steps) == 0 {
+		return evergreen.TestSkippedStatus
+	}
+	return evergreen.TestSucceededStatus
+}
+
+func (s cucumberScenario) duration() time.Duration {
+	var total time.Duration
+	for _, step := range s.Steps {
+		total += time.Duration(step.Result.Duration)
+	}
+	return total
+}
+
+// toModelTestResultAndLog converts a Cucumber scenario into a task.TestResult
+// and, for scenarios that did not pass, a model.TestLog listing its steps.
+func (s cucumberScenario) toModelTestResultAndLog(t *task.Task) (task.TestResult, *model.TestLog) {
+	res := task.TestResult{}
+
+	name := s.Name
+	if name == "" {
+		name = fmt.Sprintf("line %d", s.Line)
+	}
+	res.TestFile = util.CleanForPath(fmt.Sprintf("%s.%s", s.Feature, name))
+
+	res.StartTime = float64(time.Now().Unix())
+	res.EndTime = res.StartTime + s.duration().Seconds()
+	res.Status = s.status()
+
+	if res.Status == evergreen.TestSucceededStatus {
+		return res, nil
+	}
+
+	log := &model.TestLog{
+		Name:          res.TestFile,
+		Task:          t.Id,
+		TaskExecution: t.Execution,
+		Lines:         []string{fmt.Sprintf("Scenario: %s (%s)", s.Name, strings.ToUpper(res.Status))},
+	}
+	for _, step := range s.Steps {
+		description := strings.TrimSpace(step.Keyword + step.Name)
+		if description == "" {
+			description = "hook"
+		}
+		log.Lines = append(log.Lines, fmt.Sprintf("  %s (%s)", description, step.Result.Status))
+		if step.Result.ErrorMessage != "" {
+			for _, line := range strings.Split(strings.TrimSpace(step.Result.ErrorMessage), "\n") {
+				log.Lines = append(log.Lines, "    "+line)
+			}
+		}
+	}
+	res.URL = log.URL()
+
+	return res, log
+}
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCucumberFixture(t *testing.T, name string) []cucumberFeature {
+	file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "cucumber", name))
+	require.NoError(t, err)
+	defer file.Close()
+
+	features, err := parseCucumberResults(file)
+	require.NoError(t, err)
+	return features
+}
+
+func TestParseCucumberResults(t *testing.T) {
+	assert := assert.New(t)
+
+	features := parseCucumberFixture(t, "ruby.json")
+	require.Len(t, features, 2)
+	assert.Equal("Checkout", features[0].Name)
+	assert.Len(features[0].Elements, 4)
+
+	scenarios := cucumberScenarios(features)
+	require.Len(t, scenarios, 3)
+
+	// background steps are folded into the following scenario
+	card := scenarios[0]
+	assert.Equal("Checkout", card.Feature)
+	assert.Equal("Pay by card", card.Name)
+	require.Len(t, card.Steps, 3)
+	assert.Equal("a signed in customer", card.Steps[0].Name)
+	assert.Equal(evergreen.TestSucceededStatus, card.status())
+	assert.Equal(time.Second, card.duration())
+
+	voucher := scenarios[1]
+	assert.Len(voucher.Steps, 3)
+	assert.Equal(evergreen.TestFailedStatus, voucher.status())
+
+	refund := scenarios[2]
+	assert.Equal("Refunds", refund.Feature)
+	assert.Len(refund.Steps, 2)
+	assert.Equal(evergreen.TestSkippedStatus, refund.status())
+
+	_, err := parseCucumberResults(strings.NewReader(`<testsuite></testsuite>`))
+	assert.Error(err)
+}
+
+func TestCucumberScenarioToModelTestResultAndLog(t *testing.T) {
+	assert := assert.New(t)
+	tsk := &task.Task{Id: "task", Execution: 2}
+
+	scenarios := cucumberScenarios(parseCucumberFixture(t, "ruby.json"))
+	require.Len(t, scenarios, 3)
+
+	res, log := scenarios[0].toModelTestResultAndLog(tsk)
+	assert.Equal("Checkout.Pay_by_card", res.TestFile)
+	assert.Equal(evergreen.TestSucceededStatus, res.Status)
+	assert.InDelta(1.0, res.EndTime-res.StartTime, 0.001)
+	assert.Nil(log)
+
+	res, log = scenarios[1].toModelTestResultAndLog(tsk)
+	assert.Equal("Checkout.Pay_by_voucher", res.TestFile)
+	assert.Equal(evergreen.TestFailedStatus, res.Status)
+	require.NotNil(t, log)
+	assert.Equal(res.TestFile, log.Name)
+	assert.Equal(tsk.Id, log.Task)
+	assert.Equal(tsk.Execution, log.TaskExecution)
+	assert.Equal(log.URL(), res.URL)
+	assert.Equal([]string{
+		"Scenario: Pay by voucher (FAIL)",
+		"  Given a signed in customer (passed)",
+		"  When the customer pays with an expired voucher (failed)",
+		"    expected voucher to be rejected",
+		"    ./features/step_definitions/checkout_steps.rb:12",
+		"  Then the order is rejected (skipped)",
+	}, log.Lines)
+
+	res, log = scenarios[2].toModelTestResultAndLog(tsk)
+	assert.Equal(evergreen.TestSkippedStatus, res.Status)
+	require.NotNil(t, log)
+	assert.Contains(log.Lines, "  hook (passed)")
+	assert.Contains(log.Lines, "  Given a paid order (undefined)")
+
+	scenarios = cucumberScenarios(parseCucumberFixture(t, "js.json"))
+	require.Len(t, scenarios, 1)
+	res, log = scenarios[0].toModelTestResultAndLog(tsk)
+	assert.Equal("Search.Find_a_product", res.TestFile)
+	assert.Equal(evergreen.TestFailedStatus, res.Status)
+	require.NotNil(t, log)
+	assert.Contains(log.Lines, "    AssertionError: expected 2 to equal 3")
+}
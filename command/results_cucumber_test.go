@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCucumberResultsParseParams(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := cucumberResultsFactory()
+	assert.Equal("attach.cucumber_results", cmd.Name())
+	assert.Error(cmd.ParseParams(map[string]interface{}{}))
+	assert.NoError(cmd.ParseParams(map[string]interface{}{"file": "cucumber.json"}))
+}
+
+func TestCucumberResultsExecute(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := &model.TaskConfig{
+		Task:       &task.Task{Id: "task", Secret: "secret"},
+		Expansions: util.NewExpansions(map[string]string{}),
+		WorkDir:    workingDirectory,
+	}
+	comm := client.NewMock("http://localhost.com")
+	logger := comm.GetLoggerProducer(ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret})
+
+	cmd := cucumberResultsFactory()
+	require.NoError(cmd.ParseParams(map[string]interface{}{
+		"file":  "testdata/cucumber/ruby.json",
+		"files": []string{"testdata/cucumber/js.json"},
+	}))
+	require.NoError(cmd.Execute(ctx, comm, logger, conf))
+
+	require.NotNil(comm.LocalTestResults)
+	results := comm.LocalTestResults.Results
+	require.Len(results, 4)
+	assert.Equal("Search.Find_a_product", results[0].TestFile)
+	assert.Equal(evergreen.TestFailedStatus, results[0].Status)
+	assert.Equal("Checkout.Pay_by_card", results[1].TestFile)
+	assert.Equal(evergreen.TestSucceededStatus, results[1].Status)
+	assert.Empty(results[1].LogId)
+	assert.Len(comm.TestLogs, 3)
+}
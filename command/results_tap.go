@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// tapResultsCommand reads in files of TAP (Test Anything Protocol) output, as
+// produced by e.g. Perl's prove or node-tap, and converts them to a format
+// Evergreen can use.
+type tapResultsCommand struct {
+	// File describes the relative path of the file to be sent. Supports globbing.
+	// Note that this can also be described via expansions.
+	File  string   `mapstructure:"file" plugin:"expand"`
+	Files []string `mapstructure:"files" plugin:"expand"`
+	base
+}
+
+func tapResultsFactory() Command          { return &tapResultsCommand{} }
+func (c *tapResultsCommand) Name() string { return "attach.tap_results" }
+
+// ParseParams reads and validates the command parameters. This is required
+// to satisfy the 'Command' interface
+func (c *tapResultsCommand) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrapf(err, "error decoding '%s' params", c.Name())
+	}
+
+	if c.File == "" && len(c.Files) == 0 {
+		return errors.New("must specify at least one file")
+	}
+
+	return nil
+}
+
+// Execute parses the TAP files and sends their results and the logs of
+// unsuccessful tests to the API server.
+func (c *tapResultsCommand) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *model.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "problem expanding params")
+	}
+	if c.File != "" {
+		c.Files = append(c.Files, c.File)
+	}
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- c.parseAndUploadResults(ctx, conf, logger, comm)
+	}()
+
+	select {
+	case err := <-errChan:
+		return errors.WithStack(err)
+	case <-ctx.Done():
+		logger.Execution().Info("Received signal to terminate execution of attach TAP results command")
+		return nil
+	}
+}
+
+func (c *tapResultsCommand) parseAndUploadResults(ctx context.Context, conf *model.TaskConfig,
+	logger client.LoggerProducer, comm client.Communicator) error {
+
+	tests := []task.TestResult{}
+	logs := []*model.TestLog{}
+	logIdxToTestIdx := []int{}
+
+	reportFilePaths, err := getFilePaths(conf.WorkDir, c.Files)
+	if err != nil {
+		return err
+	}
+
+	var results *tapResults
+	for _, reportFileLoc := range reportFilePaths {
+		if ctx.Err() != nil {
+			return errors.New("operation canceled")
+		}
+
+		results, err = readTAPFile(reportFileLoc)
+		if err != nil {
+			return err
+		}
+
+		suite := strings.TrimSuffix(filepath.Base(reportFileLoc), filepath.Ext(reportFileLoc))
+		for _, tt := range results.Tests {
+			test, log := tt.toModelTestResultAndLog(conf.Task, suite)
+			if log != nil {
+				logs = append(logs, log)
+				logIdxToTestIdx = append(logIdxToTestIdx, len(tests))
+			}
+			tests = append(tests, test)
+		}
+
+		if test, log := results.incompleteTestResultAndLog(conf.Task, suite); test != nil {
+			logger.Task().Warningf("TAP file '%s' is incomplete", reportFileLoc)
+			logs = append(logs, log)
+			logIdxToTestIdx = append(logIdxToTestIdx, len(tests))
+			tests = append(tests, *test)
+		}
+	}
+
+	return sendTestLogsAndResults(ctx, conf, logger, comm, tests, logs, logIdxToTestIdx)
+}
+
+// readTAPFile parses the TAP results in the file at path.
+func readTAPFile(path string) (*tapResults, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't open TAP file")
+	}
+	defer file.Close()
+
+	results, err := parseTAPResults(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing TAP file '%s'", path)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,244 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	// tapTestLineRegex matches test points, e.g. "not ok 3 - description # TODO reason".
+	// The description ends at the first unescaped '#', and anything after it
+	// that is not a SKIP or TODO directive is a comment.
+	tapTestLineRegex = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?((?:[^#\\]|\\.)*?)\s*(?:#\s*(?:(?i:(skip|todo))\S*\s*(.*)|.*))?$`)
+	tapPlanRegex     = regexp.MustCompile(`^1\.\.(\d+)`)
+	tapBailOutRegex  = regexp.MustCompile(`^Bail out!\s*(.*)$`)
+)
+
+// tapResults is the content of a TAP (Test Anything Protocol) stream.
+type tapResults struct {
+	Plan          int
+	Tests         []tapTest
+	BailedOut     bool
+	BailOutReason string
+}
+
+// tapTest is a single TAP test point, along with any diagnostics and YAML
+// block that follow it.
+type tapTest struct {
+	Number      int
+	Description string
+	OK          bool
+	Directive   string
+	Reason      string
+	Duration    time.Duration
+	Diagnostics []string
+}
+
+// parseTAPResults reads a TAP stream. Only top level test points are
+// reported; indented subtest output and YAML blocks are kept as the
+// diagnostics of the test point they belong to.
+func parseTAPResults(reader io.Reader) (*tapResults, error) {
+	results := &tapResults{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	// output preceding a test point, e.g. subtests, belongs to that test
+	pending := []string{}
+	var yamlBlock []string
+	inYAML := false
+	// a YAML block must directly follow its test point
+	afterTest := false
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		wasAfterTest := afterTest
+		afterTest = false
+
+		if inYAML {
+			if trimmed == "..." {
+				inYAML = false
+				results.addYAMLBlock(yamlBlock)
+				continue
+			}
+			yamlBlock = append(yamlBlock, line)
+			continue
+		}
+		if trimmed == "---" && wasAfterTest && line != trimmed {
+			inYAML = true
+			yamlBlock = []string{}
+			continue
+		}
+
+		if line != trimmed {
+			pending = append(pending, line)
+			continue
+		}
+
+		switch {
+		case tapTestLineRegex.MatchString(line):
+			results.addTest(tapTestLineRegex.FindStringSubmatch(line), pending)
+			pending = []string{}
+			afterTest = true
+		case tapPlanRegex.MatchString(line):
+			plan, err := strconv.Atoi(tapPlanRegex.FindStringSubmatch(line)[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid plan '%s'", line)
+			}
+			results.Plan = plan
+		case tapBailOutRegex.MatchString(line):
+			results.BailedOut = true
+			results.BailOutReason = tapBailOutRegex.FindStringSubmatch(line)[1]
+		case strings.HasPrefix(line, "#"):
+			// diagnostics describe the most recent test point, unless they
+			// introduce the subtests of the next one
+			if len(results.Tests) > 0 && len(pending) == 0 && !strings.HasPrefix(line, "# Subtest") {
+				last := &results.Tests[len(results.Tests)-1]
+				last.Diagnostics = append(last.Diagnostics, line)
+			} else {
+				pending = append(pending, line)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "problem reading TAP results")
+	}
+	if inYAML {
+		results.addYAMLBlock(yamlBlock)
+	}
+
+	return results, nil
+}
+
+func (r *tapResults) addTest(match []string, pending []string) {
+	t := tapTest{
+		OK:          match[1] == "ok",
+		Description: strings.Replace(strings.TrimSpace(match[3]), `\#`, "#", -1),
+		Directive:   strings.ToUpper(match[4]),
+		Reason:      strings.TrimSpace(match[5]),
+		Diagnostics: pending,
+	}
+	if match[2] != "" {
+		t.Number, _ = strconv.Atoi(match[2])
+	} else {
+		t.Number = len(r.Tests) + 1
+	}
+
+	r.Tests = append(r.Tests, t)
+}
+
+// addYAMLBlock attaches a YAML diagnostic block to the most recent test
+// point, reading its duration if the producer reported one.
+func (r *tapResults) addYAMLBlock(lines []string) {
+	if len(r.Tests) == 0 {
+		return
+	}
+	last := &r.Tests[len(r.Tests)-1]
+	last.Diagnostics = append(last.Diagnostics, lines...)
+
+	block := struct {
+		DurationMS float64 `yaml:"duration_ms"`
+	}{}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &block); err == nil && block.DurationMS > 0 {
+		last.Duration = time.Duration(block.DurationMS * float64(time.Millisecond))
+	}
+}
+
+// missingTests returns the number of tests that were planned but did not
+// report a result.
+func (r *tapResults) missingTests() int {
+	if r.Plan <= len(r.Tests) {
+		return 0
+	}
+	return r.Plan - len(r.Tests)
+}
+
+// incompleteTestResultAndLog returns a failed test result describing why the
+// stream ended early, if it bailed out or ran fewer tests than planned.
+func (r *tapResults) incompleteTestResultAndLog(t *task.Task, suite string) (*task.TestResult, *model.TestLog) {
+	var lines []string
+	if r.BailedOut {
+		lines = append(lines, fmt.Sprintf("Bail out! %s", r.BailOutReason))
+	}
+	if missing := r.missingTests(); missing > 0 {
+		lines = append(lines, fmt.Sprintf("planned %d tests but only ran %d", r.Plan, len(r.Tests)))
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	res := &task.TestResult{
+		TestFile: util.CleanForPath(suite),
+		Status:   evergreen.TestFailedStatus,
+	}
+	res.StartTime = float64(time.Now().Unix())
+	res.EndTime = res.StartTime
+	log := &model.TestLog{
+		Name:          res.TestFile,
+		Task:          t.Id,
+		TaskExecution: t.Execution,
+		Lines:         lines,
+	}
+	res.URL = log.URL()
+
+	return res, log
+}
+
+// toModelTestResultAndLog converts a TAP test point into a task.TestResult
+// and, for tests that did not pass, a model.TestLog of its diagnostics. Tests
+// are named after their suite, which is usually the TAP file name.
+func (tt tapTest) toModelTestResultAndLog(t *task.Task, suite string) (task.TestResult, *model.TestLog) {
+	res := task.TestResult{}
+
+	name := tt.Description
+	if name == "" {
+		name = fmt.Sprintf("test %d", tt.Number)
+	}
+	res.TestFile = util.CleanForPath(fmt.Sprintf("%s.%s", suite, name))
+
+	res.StartTime = float64(time.Now().Unix())
+	res.EndTime = res.StartTime + tt.Duration.Seconds()
+
+	// a failing TODO test is expected to fail, so it is skipped instead
+	var log *model.TestLog
+	switch {
+	case tt.Directive == "SKIP":
+		res.Status = evergreen.TestSkippedStatus
+	case tt.Directive == "TODO" && !tt.OK:
+		res.Status = evergreen.TestSkippedStatus
+	case tt.OK:
+		res.Status = evergreen.TestSucceededStatus
+	default:
+		res.Status = evergreen.TestFailedStatus
+	}
+
+	if res.Status != evergreen.TestSucceededStatus {
+		header := fmt.Sprintf("ok %d - %s", tt.Number, tt.Description)
+		if !tt.OK {
+			header = "not " + header
+		}
+		if tt.Directive != "" {
+			header = fmt.Sprintf("%s # %s %s", header, tt.Directive, tt.Reason)
+		}
+		log = &model.TestLog{
+			Name:          res.TestFile,
+			Task:          t.Id,
+			TaskExecution: t.Execution,
+			Lines:         append([]string{strings.TrimSpace(header)}, tt.Diagnostics...),
+		}
+		res.URL = log.URL()
+	}
+
+	return res, log
+}
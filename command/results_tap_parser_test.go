@@ -0,0 +1,137 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTAPFixture(t *testing.T, name string) *tapResults {
+	file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "tap", name))
+	require.NoError(t, err)
+	defer file.Close()
+
+	results, err := parseTAPResults(file)
+	require.NoError(t, err)
+	return results
+}
+
+func TestParseTAPResults(t *testing.T) {
+	assert := assert.New(t)
+
+	results := parseTAPFixture(t, "perl.tap")
+	assert.Equal(6, results.Plan)
+	assert.False(results.BailedOut)
+	assert.Zero(results.missingTests())
+	require.Len(t, results.Tests, 6)
+
+	assert.True(results.Tests[0].OK)
+	assert.Equal(1, results.Tests[0].Number)
+	assert.Equal("use Widget", results.Tests[0].Description)
+
+	failed := results.Tests[2]
+	assert.False(failed.OK)
+	assert.Equal("widget spins clockwise", failed.Description)
+	require.Len(t, failed.Diagnostics, 4)
+	assert.Contains(failed.Diagnostics[0], "Failed test")
+
+	assert.True(results.Tests[3].OK)
+	assert.Equal("", results.Tests[3].Description)
+	assert.Equal("SKIP", results.Tests[3].Directive)
+	assert.Equal("no network connection", results.Tests[3].Reason)
+
+	assert.False(results.Tests[4].OK)
+	assert.Equal("widget survives a reboot", results.Tests[4].Description)
+	assert.Equal("TODO", results.Tests[4].Directive)
+
+	assert.Equal("issue #42 stays fixed", results.Tests[5].Description)
+	assert.Empty(results.Tests[5].Directive)
+}
+
+func TestParseTAPResultsWithSubtests(t *testing.T) {
+	assert := assert.New(t)
+
+	results := parseTAPFixture(t, "node.tap")
+	assert.Equal(2, results.Plan)
+	require.Len(t, results.Tests, 2)
+
+	parser := results.Tests[0]
+	assert.True(parser.OK)
+	assert.Equal("parser", parser.Description)
+	assert.Equal(12500*time.Microsecond, parser.Duration)
+	assert.Contains(parser.Diagnostics, "    ok 2 - parses nested input")
+
+	server := results.Tests[1]
+	assert.False(server.OK)
+	assert.Equal("server", server.Description)
+	assert.Equal(1500*time.Millisecond, server.Duration)
+	assert.Contains(server.Diagnostics, "    not ok 1 - responds to requests")
+	assert.Contains(server.Diagnostics, "    file: test/server.js")
+}
+
+func TestParseTAPResultsBailOut(t *testing.T) {
+	assert := assert.New(t)
+
+	results := parseTAPFixture(t, "bailout.tap")
+	assert.True(results.BailedOut)
+	assert.Equal("could not create schema", results.BailOutReason)
+	assert.Len(results.Tests, 2)
+	assert.Equal(2, results.missingTests())
+
+	tsk := &task.Task{Id: "task", Execution: 1}
+	res, log := results.incompleteTestResultAndLog(tsk, "bailout")
+	require.NotNil(t, res)
+	require.NotNil(t, log)
+	assert.Equal("bailout", res.TestFile)
+	assert.Equal(evergreen.TestFailedStatus, res.Status)
+	assert.Equal([]string{"Bail out! could not create schema", "planned 4 tests but only ran 2"}, log.Lines)
+
+	res, log = parseTAPFixture(t, "perl.tap").incompleteTestResultAndLog(tsk, "perl")
+	assert.Nil(res)
+	assert.Nil(log)
+}
+
+func TestTAPTestToModelTestResultAndLog(t *testing.T) {
+	assert := assert.New(t)
+
+	results := parseTAPFixture(t, "perl.tap")
+	tsk := &task.Task{Id: "task", Execution: 1}
+
+	expected := []struct {
+		name   string
+		status string
+		hasLog bool
+	}{
+		{"widget.use_Widget", evergreen.TestSucceededStatus, false},
+		{"widget.widget_has_a_name", evergreen.TestSucceededStatus, false},
+		{"widget.widget_spins_clockwise", evergreen.TestFailedStatus, true},
+		{"widget.test_4", evergreen.TestSkippedStatus, true},
+		{"widget.widget_survives_a_reboot", evergreen.TestSkippedStatus, true},
+		{"widget.issue__42_stays_fixed", evergreen.TestSucceededStatus, false},
+	}
+	for i, tt := range results.Tests {
+		res, log := tt.toModelTestResultAndLog(tsk, "widget")
+		assert.Equal(expected[i].name, res.TestFile)
+		assert.Equal(expected[i].status, res.Status, res.TestFile)
+		if !expected[i].hasLog {
+			assert.Nil(log)
+			continue
+		}
+		require.NotNil(t, log)
+		assert.Equal(res.TestFile, log.Name)
+		assert.Equal(tsk.Id, log.Task)
+		assert.Equal(tsk.Execution, log.TaskExecution)
+		assert.Equal(log.URL(), res.URL)
+	}
+
+	_, log := results.Tests[2].toModelTestResultAndLog(tsk, "widget")
+	assert.Equal("not ok 3 - widget spins clockwise", log.Lines[0])
+	assert.Contains(log.Lines[4], "expected: 'clockwise'")
+}
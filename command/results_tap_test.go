@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTAPResultsParseParams(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := tapResultsFactory()
+	assert.Equal("attach.tap_results", cmd.Name())
+	assert.Error(cmd.ParseParams(map[string]interface{}{}))
+	assert.NoError(cmd.ParseParams(map[string]interface{}{"file": "results.tap"}))
+	assert.NoError(cmd.ParseParams(map[string]interface{}{"files": []string{"*.tap"}}))
+}
+
+func TestTAPResultsExecute(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := &model.TaskConfig{
+		Task:       &task.Task{Id: "task", Secret: "secret"},
+		Expansions: util.NewExpansions(map[string]string{"dir": "tap"}),
+		WorkDir:    workingDirectory,
+	}
+	comm := client.NewMock("http://localhost.com")
+	logger := comm.GetLoggerProducer(ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret})
+
+	cmd := tapResultsFactory()
+	require.NoError(cmd.ParseParams(map[string]interface{}{
+		"files": []string{"testdata/${dir}/*.tap"},
+	}))
+	require.NoError(cmd.Execute(ctx, comm, logger, conf))
+
+	require.NotNil(comm.LocalTestResults)
+	results := map[string]task.TestResult{}
+	for _, res := range comm.LocalTestResults.Results {
+		results[res.TestFile] = res
+	}
+	// 2 results from bailout.tap plus its incomplete result, 2 from node.tap
+	// and 6 from perl.tap
+	assert.Len(results, 11)
+	assert.Equal(evergreen.TestFailedStatus, results["bailout"].Status)
+	assert.Equal(evergreen.TestSucceededStatus, results["node.parser"].Status)
+	assert.Equal(evergreen.TestFailedStatus, results["node.server"].Status)
+	assert.Equal(evergreen.TestSkippedStatus, results["perl.test_4"].Status)
+
+	// logs are only sent for unsuccessful tests
+	assert.Len(comm.TestLogs, 6)
+	for _, res := range comm.LocalTestResults.Results {
+		if res.Status == evergreen.TestSucceededStatus {
+			assert.Empty(res.LogId)
+		} else {
+			assert.NotEmpty(res.LogId, res.TestFile)
+		}
+	}
+}
@@ -71,3 +71,30 @@ func sendJSONLogs(ctx context.Context, logger client.LoggerProducer,
 	logger.Task().Info("Attach test logs succeeded")
 	return logID, nil
 }
+
+// sendTestLogsAndResults sends the logs of parsed test results, links each
+// test result at logIdxToTestIdx[i] to the log at logs[i], and then sends the
+// results. A log that fails to send is skipped rather than failing the
+// command.
+func sendTestLogsAndResults(ctx context.Context, conf *model.TaskConfig,
+	logger client.LoggerProducer, comm client.Communicator,
+	tests []task.TestResult, logs []*model.TestLog, logIdxToTestIdx []int) error {
+
+	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
+
+	for i, log := range logs {
+		if ctx.Err() != nil {
+			return errors.New("operation canceled")
+		}
+
+		logId, err := sendJSONLogs(ctx, logger, comm, td, log)
+		if err != nil {
+			logger.Task().Warningf("problem uploading logs for %s", log.Name)
+			continue
+		}
+		tests[logIdxToTestIdx[i]].LogId = logId
+		tests[logIdxToTestIdx[i]].LineNum = 1
+	}
+
+	return sendJSONResults(ctx, conf, logger, comm, &task.LocalTestResults{Results: tests})
+}
@@ -167,21 +167,5 @@ func (c *xunitResults) parseAndUploadResults(ctx context.Context, conf *model.Ta
 		}
 	}
 
-	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
-
-	for i, log := range logs {
-		if ctx.Err() != nil {
-			return errors.New("operation canceled")
-		}
-
-		logId, err := sendJSONLogs(ctx, logger, comm, td, log)
-		if err != nil {
-			logger.Task().Warningf("problem uploading logs for %s", log.Name)
-			continue
-		}
-		tests[logIdxToTestIdx[i]].LogId = logId
-		tests[logIdxToTestIdx[i]].LineNum = 1
-	}
-
-	return sendJSONResults(ctx, conf, logger, comm, &task.LocalTestResults{tests})
+	return sendTestLogsAndResults(ctx, conf, logger, comm, tests, logs, logIdxToTestIdx)
 }
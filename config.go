@@ -74,6 +74,7 @@ type Settings struct {
 	Providers          CloudProviders            `yaml:"providers" bson:"providers" json:"providers" id:"providers"`
 	RepoTracker        RepoTrackerConfig         `yaml:"repotracker" bson:"repotracker" json:"repotracker" id:"repotracker"`
 	Scheduler          SchedulerConfig           `yaml:"scheduler" bson:"scheduler" json:"scheduler" id:"scheduler"`
+	Secrets            SecretsConfig             `yaml:"secrets" bson:"secrets" json:"secrets" id:"secrets"`
 	ServiceFlags       ServiceFlags              `bson:"service_flags" json:"service_flags" id:"service_flags"`
 	Slack              SlackConfig               `yaml:"slack" bson:"slack" json:"slack" id:"slack"`
 	Splunk             send.SplunkConnectionInfo `yaml:"splunk" bson:"splunk" json:"splunk"`
@@ -1,6 +1,8 @@
 package evergreen
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
@@ -8,9 +10,10 @@ import (
 
 // NotifyConfig hold logging and email settings for the notify package.
 type NotifyConfig struct {
-	BufferTargetPerInterval int        `bson:"buffer_target_per_interval" json:"buffer_target_per_interval" yaml:"buffer_target_per_interval"`
-	BufferIntervalSeconds   int        `bson:"buffer_interval_seconds" json:"buffer_interval_seconds" yaml:"buffer_interval_seconds"`
-	SMTP                    SMTPConfig `bson:"smtp" json:"smtp" yaml:"smtp"`
+	BufferTargetPerInterval int           `bson:"buffer_target_per_interval" json:"buffer_target_per_interval" yaml:"buffer_target_per_interval"`
+	BufferIntervalSeconds   int           `bson:"buffer_interval_seconds" json:"buffer_interval_seconds" yaml:"buffer_interval_seconds"`
+	SMTP                    SMTPConfig    `bson:"smtp" json:"smtp" yaml:"smtp"`
+	Webhook                 WebhookConfig `bson:"webhook" json:"webhook" yaml:"webhook"`
 }
 
 func (c *NotifyConfig) SectionId() string { return "notify" }
@@ -44,9 +47,88 @@ func (c *NotifyConfig) ValidateAndDefault() error {
 
 	}
 
+	return errors.Wrap(c.Webhook.ValidateAndDefault(), "invalid webhook settings")
+}
+
+// WebhookConfig holds the retry schedule and circuit breaker settings of the
+// evergreen-webhook sender. Zero values are replaced by defaults.
+type WebhookConfig struct {
+	// MaxAttempts is the number of times a webhook notification is sent
+	// before it is moved to the dead-letter queue
+	MaxAttempts int `bson:"max_attempts" json:"max_attempts" yaml:"max_attempts"`
+	// MinRetryDelaySeconds is the delay before the first retry, which
+	// doubles after every failed attempt, up to MaxRetryDelaySeconds
+	MinRetryDelaySeconds int `bson:"min_retry_delay_secs" json:"min_retry_delay_secs" yaml:"min_retry_delay_secs"`
+	MaxRetryDelaySeconds int `bson:"max_retry_delay_secs" json:"max_retry_delay_secs" yaml:"max_retry_delay_secs"`
+	// CircuitBreakerThreshold is the number of consecutive failures after
+	// which an endpoint isn't sent to for CircuitBreakerCooldownSeconds. A
+	// negative threshold disables circuit breaking.
+	CircuitBreakerThreshold       int `bson:"circuit_breaker_threshold" json:"circuit_breaker_threshold" yaml:"circuit_breaker_threshold"`
+	CircuitBreakerCooldownSeconds int `bson:"circuit_breaker_cooldown_secs" json:"circuit_breaker_cooldown_secs" yaml:"circuit_breaker_cooldown_secs"`
+}
+
+func (c *WebhookConfig) ValidateAndDefault() error {
+	if c.MaxAttempts < 0 || c.MinRetryDelaySeconds < 0 || c.MaxRetryDelaySeconds < 0 ||
+		c.CircuitBreakerCooldownSeconds < 0 {
+		return errors.New("webhook settings cannot be negative")
+	}
+	*c = c.Defaulted()
+	if c.MinRetryDelaySeconds > c.MaxRetryDelaySeconds {
+		return errors.New("minimum webhook retry delay cannot be greater than the maximum")
+	}
+
 	return nil
 }
 
+// Defaulted returns a copy of the settings with defaults in place of unset
+// values.
+func (c WebhookConfig) Defaulted() WebhookConfig {
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 5
+	}
+	if c.MinRetryDelaySeconds == 0 {
+		c.MinRetryDelaySeconds = 30
+	}
+	if c.MaxRetryDelaySeconds == 0 {
+		c.MaxRetryDelaySeconds = 60 * 60
+	}
+	if c.CircuitBreakerThreshold == 0 {
+		c.CircuitBreakerThreshold = 5
+	}
+	if c.CircuitBreakerCooldownSeconds == 0 {
+		c.CircuitBreakerCooldownSeconds = 5 * 60
+	}
+	return c
+}
+
+// CircuitBreakerFailures returns the number of consecutive failures after
+// which the circuit breaker opens, or zero if circuit breaking is disabled.
+func (c WebhookConfig) CircuitBreakerFailures() int {
+	c = c.Defaulted()
+	if c.CircuitBreakerThreshold < 0 {
+		return 0
+	}
+	return c.CircuitBreakerThreshold
+}
+
+// RetryDelay returns how long to wait before sending a webhook notification
+// again, after it has failed the given number of times.
+func (c WebhookConfig) RetryDelay(failures int) time.Duration {
+	c = c.Defaulted()
+	maxDelay := time.Duration(c.MaxRetryDelaySeconds) * time.Second
+	delay := time.Duration(c.MinRetryDelaySeconds) * time.Second
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
 type AlertsConfig struct {
 	SMTP SMTPConfig `bson:"smtp" json:"smtp" yaml:"smtp"`
 }
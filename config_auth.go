@@ -2,8 +2,10 @@ package evergreen
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
@@ -39,11 +41,49 @@ type GithubAuthConfig struct {
 	Organization string   `bson:"organization" json:"organization" yaml:"organization"`
 }
 
-// AuthConfig has a pointer to either a CrowConfig or a NaiveAuthConfig.
+// LDAPConfig holds settings for authenticating users against an LDAP
+// directory. Users are authenticated by binding as them. If BindDN is set,
+// Evergreen binds as that account to find users and their groups; otherwise,
+// a user's DN is UserAttribute=<username>,UserBaseDN. Connections to the
+// directory are always encrypted: ldaps URLs use TLS from the start, and ldap
+// URLs are upgraded with StartTLS before binding.
+type LDAPConfig struct {
+	URL                  string          `bson:"url" json:"url" yaml:"url"`
+	BindDN               string          `bson:"bind_dn" json:"bind_dn" yaml:"bind_dn"`
+	BindPassword         string          `bson:"bind_password" json:"bind_password" yaml:"bind_password"`
+	UserBaseDN           string          `bson:"user_base_dn" json:"user_base_dn" yaml:"user_base_dn"`
+	UserAttribute        string          `bson:"user_attribute" json:"user_attribute" yaml:"user_attribute"`
+	GroupBaseDN          string          `bson:"group_base_dn" json:"group_base_dn" yaml:"group_base_dn"`
+	GroupMemberAttribute string          `bson:"group_member_attribute" json:"group_member_attribute" yaml:"group_member_attribute"`
+	GroupRoles           []LDAPGroupRole `bson:"group_roles" json:"group_roles" yaml:"group_roles"`
+	ExpireAfterMinutes   int             `bson:"expire_after_minutes" json:"expire_after_minutes" yaml:"expire_after_minutes"`
+}
+
+// LDAPGroupRole gives the members of an LDAP group, identified by its common
+// name, a system role.
+type LDAPGroupRole struct {
+	Group string `bson:"group" json:"group" yaml:"group"`
+	Role  string `bson:"role" json:"role" yaml:"role"`
+}
+
+// OIDCConfig holds settings for authenticating users with an OpenID Connect
+// provider using the authorization code flow.
+type OIDCConfig struct {
+	Issuer             string   `bson:"issuer" json:"issuer" yaml:"issuer"`
+	ClientID           string   `bson:"client_id" json:"client_id" yaml:"client_id"`
+	ClientSecret       string   `bson:"client_secret" json:"client_secret" yaml:"client_secret"`
+	Scopes             []string `bson:"scopes" json:"scopes" yaml:"scopes"`
+	UsernameClaim      string   `bson:"username_claim" json:"username_claim" yaml:"username_claim"`
+	ExpireAfterMinutes int      `bson:"expire_after_minutes" json:"expire_after_minutes" yaml:"expire_after_minutes"`
+}
+
+// AuthConfig has a pointer to exactly one form of authentication.
 type AuthConfig struct {
 	Crowd  *CrowdConfig      `bson:"crowd" json:"crowd" yaml:"crowd"`
 	Naive  *NaiveAuthConfig  `bson:"naive" json:"naive" yaml:"naive"`
 	Github *GithubAuthConfig `bson:"github" json:"github" yaml:"github"`
+	LDAP   *LDAPConfig       `bson:"ldap" json:"ldap" yaml:"ldap"`
+	OIDC   *OIDCConfig       `bson:"oidc" json:"oidc" yaml:"oidc"`
 }
 
 func (c *AuthConfig) SectionId() string { return "auth" }
@@ -63,6 +103,8 @@ func (c *AuthConfig) Set() error {
 			"crowd":  c.Crowd,
 			"naive":  c.Naive,
 			"github": c.Github,
+			"ldap":   c.LDAP,
+			"oidc":   c.OIDC,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
@@ -70,7 +112,7 @@ func (c *AuthConfig) Set() error {
 
 func (c *AuthConfig) ValidateAndDefault() error {
 	catcher := grip.NewSimpleCatcher()
-	if c.Crowd == nil && c.Naive == nil && c.Github == nil {
+	if c.Crowd == nil && c.Naive == nil && c.Github == nil && c.LDAP == nil && c.OIDC == nil {
 		catcher.Add(errors.New("You must specify one form of authentication"))
 	}
 	if c.Naive != nil {
@@ -87,5 +129,81 @@ func (c *AuthConfig) ValidateAndDefault() error {
 			catcher.Add(errors.New("Must specify either a set of users or an organization for Github Authentication"))
 		}
 	}
+	if c.LDAP != nil {
+		catcher.Add(c.LDAP.validateAndDefault())
+	}
+	if c.OIDC != nil {
+		catcher.Add(c.OIDC.validateAndDefault())
+	}
+	return catcher.Resolve()
+}
+
+const defaultLoginExpireAfterMinutes = 7 * 24 * 60
+
+func (c *LDAPConfig) validateAndDefault() error {
+	catcher := grip.NewSimpleCatcher()
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		catcher.Add(errors.Errorf("LDAP URL '%s' must be of the form ldaps://host[:port], or ldap://host[:port] for a server that supports StartTLS", c.URL))
+	}
+	if c.UserBaseDN == "" {
+		catcher.Add(errors.New("Must specify a base DN for LDAP users"))
+	}
+	if c.BindDN != "" && c.BindPassword == "" {
+		catcher.Add(errors.New("Must specify a password for the LDAP bind DN"))
+	}
+	for _, groupRole := range c.GroupRoles {
+		if groupRole.Group == "" || groupRole.Role == "" {
+			catcher.Add(errors.New("LDAP group roles must specify both a group and a role"))
+		}
+	}
+	if len(c.GroupRoles) != 0 && c.GroupBaseDN == "" {
+		catcher.Add(errors.New("Must specify a base DN for LDAP groups to map them to roles"))
+	}
+	if c.ExpireAfterMinutes < 0 {
+		catcher.Add(errors.New("LDAP login expiration cannot be negative"))
+	}
+
+	if c.UserAttribute == "" {
+		c.UserAttribute = "uid"
+	}
+	if c.GroupMemberAttribute == "" {
+		c.GroupMemberAttribute = "member"
+	}
+	if c.ExpireAfterMinutes == 0 {
+		c.ExpireAfterMinutes = defaultLoginExpireAfterMinutes
+	}
+
+	return catcher.Resolve()
+}
+
+func (c *OIDCConfig) validateAndDefault() error {
+	catcher := grip.NewSimpleCatcher()
+	u, err := url.Parse(c.Issuer)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		catcher.Add(errors.Errorf("OIDC issuer '%s' must be a URL", c.Issuer))
+	}
+	if c.ClientID == "" {
+		catcher.Add(errors.New("Must specify a client ID for OIDC"))
+	}
+	if c.ClientSecret == "" {
+		catcher.Add(errors.New("Must specify a client secret for OIDC"))
+	}
+	if c.ExpireAfterMinutes < 0 {
+		catcher.Add(errors.New("OIDC login expiration cannot be negative"))
+	}
+
+	if len(c.Scopes) == 0 {
+		c.Scopes = []string{"openid", "profile", "email"}
+	} else if !util.StringSliceContains(c.Scopes, "openid") {
+		catcher.Add(errors.New("OIDC scopes must include 'openid'"))
+	}
+	if c.UsernameClaim == "" {
+		c.UsernameClaim = "preferred_username"
+	}
+	if c.ExpireAfterMinutes == 0 {
+		c.ExpireAfterMinutes = defaultLoginExpireAfterMinutes
+	}
+
 	return catcher.Resolve()
 }
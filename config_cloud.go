@@ -8,11 +8,12 @@ import (
 
 // CloudProviders stores configuration settings for the supported cloud host providers.
 type CloudProviders struct {
-	AWS       AWSConfig       `bson:"aws" json:"aws" yaml:"aws"`
-	Docker    DockerConfig    `bson:"docker" json:"docker" yaml:"docker"`
-	GCE       GCEConfig       `bson:"gce" json:"gce" yaml:"gce"`
-	OpenStack OpenStackConfig `bson:"openstack" json:"openstack" yaml:"openstack"`
-	VSphere   VSphereConfig   `bson:"vsphere" json:"vsphere" yaml:"vsphere"`
+	AWS        AWSConfig        `bson:"aws" json:"aws" yaml:"aws"`
+	Docker     DockerConfig     `bson:"docker" json:"docker" yaml:"docker"`
+	GCE        GCEConfig        `bson:"gce" json:"gce" yaml:"gce"`
+	Kubernetes KubernetesConfig `bson:"kubernetes" json:"kubernetes" yaml:"kubernetes"`
+	OpenStack  OpenStackConfig  `bson:"openstack" json:"openstack" yaml:"openstack"`
+	VSphere    VSphereConfig    `bson:"vsphere" json:"vsphere" yaml:"vsphere"`
 }
 
 func (c *CloudProviders) SectionId() string { return "providers" }
@@ -29,11 +30,12 @@ func (c *CloudProviders) Get() error {
 func (c *CloudProviders) Set() error {
 	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
 		"$set": bson.M{
-			"aws":       c.AWS,
-			"docker":    c.Docker,
-			"gce":       c.GCE,
-			"openstack": c.OpenStack,
-			"vsphere":   c.VSphere,
+			"aws":        c.AWS,
+			"docker":     c.Docker,
+			"gce":        c.GCE,
+			"kubernetes": c.Kubernetes,
+			"openstack":  c.OpenStack,
+			"vsphere":    c.VSphere,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
@@ -77,6 +79,16 @@ type GCEConfig struct {
 	TokenURI     string `bson:"token_uri" json:"token_uri" yaml:"token_uri"`
 }
 
+// KubernetesConfig stores auth info for a Kubernetes cluster's API server.
+// The token should belong to a service account that can create, get, list and
+// delete pods in the namespaces that distros use.
+type KubernetesConfig struct {
+	APIServer          string `bson:"api_server" json:"api_server" yaml:"api_server"`
+	Token              string `bson:"token" json:"token" yaml:"token"`
+	CACert             string `bson:"ca_cert" json:"ca_cert" yaml:"ca_cert"`
+	InsecureSkipVerify bool   `bson:"insecure_skip_verify" json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+}
+
 // VSphereConfig stores auth info for VMware vSphere. The config fields refer
 // to your vCenter server, a centralized management tool for the vSphere suite.
 type VSphereConfig struct {
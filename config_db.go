@@ -49,24 +49,28 @@ var (
 	containerPoolsKey     = bsonutil.MustHaveTag(Settings{}, "ContainerPools")
 
 	// degraded mode flags
-	taskDispatchKey                 = bsonutil.MustHaveTag(ServiceFlags{}, "TaskDispatchDisabled")
-	hostinitKey                     = bsonutil.MustHaveTag(ServiceFlags{}, "HostinitDisabled")
-	monitorKey                      = bsonutil.MustHaveTag(ServiceFlags{}, "MonitorDisabled")
-	alertsKey                       = bsonutil.MustHaveTag(ServiceFlags{}, "AlertsDisabled")
-	taskrunnerKey                   = bsonutil.MustHaveTag(ServiceFlags{}, "TaskrunnerDisabled")
-	repotrackerKey                  = bsonutil.MustHaveTag(ServiceFlags{}, "RepotrackerDisabled")
-	schedulerKey                    = bsonutil.MustHaveTag(ServiceFlags{}, "SchedulerDisabled")
-	githubPRTestingDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "GithubPRTestingDisabled")
-	repotrackerPushEventDisabledKey = bsonutil.MustHaveTag(ServiceFlags{}, "RepotrackerPushEventDisabled")
-	cliUpdatesDisabledKey           = bsonutil.MustHaveTag(ServiceFlags{}, "CLIUpdatesDisabled")
-	backgroundStatsDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "BackgroundStatsDisabled")
-	eventProcessingDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "EventProcessingDisabled")
-	jiraNotificationsDisabledKey    = bsonutil.MustHaveTag(ServiceFlags{}, "JIRANotificationsDisabled")
-	slackNotificationsDisabledKey   = bsonutil.MustHaveTag(ServiceFlags{}, "SlackNotificationsDisabled")
-	emailNotificationsDisabledKey   = bsonutil.MustHaveTag(ServiceFlags{}, "EmailNotificationsDisabled")
-	webhookNotificationsDisabledKey = bsonutil.MustHaveTag(ServiceFlags{}, "WebhookNotificationsDisabled")
-	githubStatusAPIDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "GithubStatusAPIDisabled")
-	taskLoggingDisabledKey          = bsonutil.MustHaveTag(ServiceFlags{}, "TaskLoggingDisabled")
+	taskDispatchKey                    = bsonutil.MustHaveTag(ServiceFlags{}, "TaskDispatchDisabled")
+	hostinitKey                        = bsonutil.MustHaveTag(ServiceFlags{}, "HostinitDisabled")
+	monitorKey                         = bsonutil.MustHaveTag(ServiceFlags{}, "MonitorDisabled")
+	alertsKey                          = bsonutil.MustHaveTag(ServiceFlags{}, "AlertsDisabled")
+	taskrunnerKey                      = bsonutil.MustHaveTag(ServiceFlags{}, "TaskrunnerDisabled")
+	repotrackerKey                     = bsonutil.MustHaveTag(ServiceFlags{}, "RepotrackerDisabled")
+	schedulerKey                       = bsonutil.MustHaveTag(ServiceFlags{}, "SchedulerDisabled")
+	githubPRTestingDisabledKey         = bsonutil.MustHaveTag(ServiceFlags{}, "GithubPRTestingDisabled")
+	repotrackerPushEventDisabledKey    = bsonutil.MustHaveTag(ServiceFlags{}, "RepotrackerPushEventDisabled")
+	cliUpdatesDisabledKey              = bsonutil.MustHaveTag(ServiceFlags{}, "CLIUpdatesDisabled")
+	backgroundStatsDisabledKey         = bsonutil.MustHaveTag(ServiceFlags{}, "BackgroundStatsDisabled")
+	eventProcessingDisabledKey         = bsonutil.MustHaveTag(ServiceFlags{}, "EventProcessingDisabled")
+	jiraNotificationsDisabledKey       = bsonutil.MustHaveTag(ServiceFlags{}, "JIRANotificationsDisabled")
+	slackNotificationsDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "SlackNotificationsDisabled")
+	teamsNotificationsDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "TeamsNotificationsDisabled")
+	mattermostNotificationsDisabledKey = bsonutil.MustHaveTag(ServiceFlags{}, "MattermostNotificationsDisabled")
+	emailNotificationsDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "EmailNotificationsDisabled")
+	webhookNotificationsDisabledKey    = bsonutil.MustHaveTag(ServiceFlags{}, "WebhookNotificationsDisabled")
+	githubStatusAPIDisabledKey         = bsonutil.MustHaveTag(ServiceFlags{}, "GithubStatusAPIDisabled")
+	taskLoggingDisabledKey             = bsonutil.MustHaveTag(ServiceFlags{}, "TaskLoggingDisabled")
+	flakyTestDetectionDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "FlakyTestDetectionDisabled")
+	commitQueueDisabledKey             = bsonutil.MustHaveTag(ServiceFlags{}, "CommitQueueDisabled")
 
 	// ContainerPoolsConfig keys
 	poolsKey = bsonutil.MustHaveTag(ContainerPoolsConfig{}, "Pools")
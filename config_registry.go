@@ -43,6 +43,7 @@ func resetRegistry() error {
 		&NotifyConfig{},
 		&RepoTrackerConfig{},
 		&SchedulerConfig{},
+		&SecretsConfig{},
 		&ServiceFlags{},
 		&SlackConfig{},
 		&UIConfig{},
@@ -7,6 +7,10 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
+// HostAllocatorCostBudget is the name of the host allocator that caps new
+// hosts by distro and project hourly budgets.
+const HostAllocatorCostBudget = "cost_budget"
+
 // SchedulerConfig holds relevant settings for the scheduler process.
 type SchedulerConfig struct {
 	TaskFinder       string  `bson:"task_finder" json:"task_finder" yaml:"task_finder"`
@@ -50,7 +54,7 @@ func (c *SchedulerConfig) ValidateAndDefault() error {
 			finders, c.TaskFinder)
 	}
 
-	allocators := []string{"duration", "deficit", "utilization"}
+	allocators := []string{"duration", "deficit", "utilization", HostAllocatorCostBudget}
 	if c.HostAllocator == "" {
 		c.HostAllocator = allocators[0]
 		return nil
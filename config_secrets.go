@@ -0,0 +1,95 @@
+package evergreen
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HTTPKVSecretsConfig holds settings for reading secrets from a Vault-style
+// key/value HTTP API.
+type HTTPKVSecretsConfig struct {
+	URL   string `bson:"url" json:"url" yaml:"url"`
+	Token string `bson:"token" json:"token" yaml:"token"`
+	// TokenHeader is the header used to send the token. Defaults to
+	// X-Vault-Token.
+	TokenHeader string `bson:"token_header" json:"token_header" yaml:"token_header"`
+	// PathPrefix is the secret path that holds each project's secrets, as
+	// "<prefix>/<project>/...". Defaults to DefaultHTTPKVPathPrefix.
+	PathPrefix string `bson:"path_prefix" json:"path_prefix" yaml:"path_prefix"`
+}
+
+// DefaultHTTPKVPathPrefix is the default secret path that holds each
+// project's secrets in an HTTP KV store.
+const DefaultHTTPKVPathPrefix = "secret/data/evergreen/projects"
+
+// KeyringSecretsConfig holds settings for reading secrets from a local file
+// of AES-GCM encrypted values.
+type KeyringSecretsConfig struct {
+	Path string `bson:"path" json:"path" yaml:"path"`
+	// Key is the hex-encoded AES-128, AES-192 or AES-256 key.
+	Key string `bson:"key" json:"key" yaml:"key"`
+}
+
+// SecretsConfig has pointers to the external secret stores that project
+// variables may reference instead of holding their values directly.
+type SecretsConfig struct {
+	HTTPKV  *HTTPKVSecretsConfig  `bson:"http_kv" json:"http_kv" yaml:"http_kv"`
+	Keyring *KeyringSecretsConfig `bson:"keyring" json:"keyring" yaml:"keyring"`
+}
+
+func (c *SecretsConfig) SectionId() string { return "secrets" }
+
+func (c *SecretsConfig) Get() error {
+	err := db.FindOneQ(ConfigCollection, db.Query(byId(c.SectionId())), c)
+	if err != nil && err.Error() == errNotFound {
+		*c = SecretsConfig{}
+		return nil
+	}
+	return errors.Wrapf(err, "error retrieving section %s", c.SectionId())
+}
+
+func (c *SecretsConfig) Set() error {
+	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			"http_kv": c.HTTPKV,
+			"keyring": c.Keyring,
+		},
+	})
+	return errors.Wrapf(err, "error updating section %s", c.SectionId())
+}
+
+func (c *SecretsConfig) ValidateAndDefault() error {
+	catcher := grip.NewSimpleCatcher()
+	if c.HTTPKV != nil {
+		if c.HTTPKV.URL == "" {
+			catcher.Add(errors.New("HTTP KV secrets store must have a URL"))
+		}
+		if c.HTTPKV.TokenHeader == "" {
+			c.HTTPKV.TokenHeader = "X-Vault-Token"
+		}
+		c.HTTPKV.PathPrefix = strings.Trim(c.HTTPKV.PathPrefix, "/")
+		if c.HTTPKV.PathPrefix == "" {
+			c.HTTPKV.PathPrefix = DefaultHTTPKVPathPrefix
+		}
+		if strings.Contains(c.HTTPKV.PathPrefix, "..") {
+			catcher.Add(errors.New("HTTP KV secrets path prefix must not contain '..'"))
+		}
+	}
+	if c.Keyring != nil {
+		if c.Keyring.Path == "" {
+			catcher.Add(errors.New("keyring secrets store must have a path"))
+		}
+		key, err := hex.DecodeString(c.Keyring.Key)
+		if err != nil {
+			catcher.Add(errors.Wrap(err, "keyring key must be hex-encoded"))
+		} else if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+			catcher.Add(errors.Errorf("keyring key must be 16, 24 or 32 bytes, not %d", len(key)))
+		}
+	}
+	return catcher.Resolve()
+}
@@ -20,14 +20,18 @@ type ServiceFlags struct {
 	CLIUpdatesDisabled           bool `bson:"cli_updates_disabled" json:"cli_updates_disabled"`
 	BackgroundStatsDisabled      bool `bson:"background_stats_disabled" json:"background_stats_disabled"`
 	TaskLoggingDisabled          bool `bson:"task_logging_disabled" json:"task_logging_disabled"`
+	FlakyTestDetectionDisabled   bool `bson:"flaky_test_detection_disabled" json:"flaky_test_detection_disabled"`
+	CommitQueueDisabled          bool `bson:"commit_queue_disabled" json:"commit_queue_disabled"`
 
 	// Notification Flags
-	EventProcessingDisabled      bool `bson:"event_processing_disabled" json:"event_processing_disabled"`
-	JIRANotificationsDisabled    bool `bson:"jira_notifications_disabled" json:"jira_notifications_disabled"`
-	SlackNotificationsDisabled   bool `bson:"slack_notifications_disabled" json:"slack_notifications_disabled"`
-	EmailNotificationsDisabled   bool `bson:"email_notifications_disabled" json:"email_notifications_disabled"`
-	WebhookNotificationsDisabled bool `bson:"webhook_notifications_disabled" json:"webhook_notifications_disabled"`
-	GithubStatusAPIDisabled      bool `bson:"github_status_api_disabled" json:"github_status_api_disabled"`
+	EventProcessingDisabled         bool `bson:"event_processing_disabled" json:"event_processing_disabled"`
+	JIRANotificationsDisabled       bool `bson:"jira_notifications_disabled" json:"jira_notifications_disabled"`
+	SlackNotificationsDisabled      bool `bson:"slack_notifications_disabled" json:"slack_notifications_disabled"`
+	TeamsNotificationsDisabled      bool `bson:"teams_notifications_disabled" json:"teams_notifications_disabled"`
+	MattermostNotificationsDisabled bool `bson:"mattermost_notifications_disabled" json:"mattermost_notifications_disabled"`
+	EmailNotificationsDisabled      bool `bson:"email_notifications_disabled" json:"email_notifications_disabled"`
+	WebhookNotificationsDisabled    bool `bson:"webhook_notifications_disabled" json:"webhook_notifications_disabled"`
+	GithubStatusAPIDisabled         bool `bson:"github_status_api_disabled" json:"github_status_api_disabled"`
 }
 
 func (c *ServiceFlags) SectionId() string { return "service_flags" }
@@ -44,24 +48,28 @@ func (c *ServiceFlags) Get() error {
 func (c *ServiceFlags) Set() error {
 	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
 		"$set": bson.M{
-			taskDispatchKey:                 c.TaskDispatchDisabled,
-			hostinitKey:                     c.HostinitDisabled,
-			monitorKey:                      c.MonitorDisabled,
-			alertsKey:                       c.AlertsDisabled,
-			taskrunnerKey:                   c.TaskrunnerDisabled,
-			repotrackerKey:                  c.RepotrackerDisabled,
-			schedulerKey:                    c.SchedulerDisabled,
-			githubPRTestingDisabledKey:      c.GithubPRTestingDisabled,
-			repotrackerPushEventDisabledKey: c.RepotrackerPushEventDisabled,
-			cliUpdatesDisabledKey:           c.CLIUpdatesDisabled,
-			backgroundStatsDisabledKey:      c.BackgroundStatsDisabled,
-			eventProcessingDisabledKey:      c.EventProcessingDisabled,
-			jiraNotificationsDisabledKey:    c.JIRANotificationsDisabled,
-			slackNotificationsDisabledKey:   c.SlackNotificationsDisabled,
-			emailNotificationsDisabledKey:   c.EmailNotificationsDisabled,
-			webhookNotificationsDisabledKey: c.WebhookNotificationsDisabled,
-			githubStatusAPIDisabledKey:      c.GithubStatusAPIDisabled,
-			taskLoggingDisabledKey:          c.TaskLoggingDisabled,
+			taskDispatchKey:                    c.TaskDispatchDisabled,
+			hostinitKey:                        c.HostinitDisabled,
+			monitorKey:                         c.MonitorDisabled,
+			alertsKey:                          c.AlertsDisabled,
+			taskrunnerKey:                      c.TaskrunnerDisabled,
+			repotrackerKey:                     c.RepotrackerDisabled,
+			schedulerKey:                       c.SchedulerDisabled,
+			githubPRTestingDisabledKey:         c.GithubPRTestingDisabled,
+			repotrackerPushEventDisabledKey:    c.RepotrackerPushEventDisabled,
+			cliUpdatesDisabledKey:              c.CLIUpdatesDisabled,
+			backgroundStatsDisabledKey:         c.BackgroundStatsDisabled,
+			eventProcessingDisabledKey:         c.EventProcessingDisabled,
+			jiraNotificationsDisabledKey:       c.JIRANotificationsDisabled,
+			slackNotificationsDisabledKey:      c.SlackNotificationsDisabled,
+			teamsNotificationsDisabledKey:      c.TeamsNotificationsDisabled,
+			mattermostNotificationsDisabledKey: c.MattermostNotificationsDisabled,
+			emailNotificationsDisabledKey:      c.EmailNotificationsDisabled,
+			webhookNotificationsDisabledKey:    c.WebhookNotificationsDisabled,
+			githubStatusAPIDisabledKey:         c.GithubStatusAPIDisabled,
+			taskLoggingDisabledKey:             c.TaskLoggingDisabled,
+			flakyTestDetectionDisabledKey:      c.FlakyTestDetectionDisabled,
+			commitQueueDisabledKey:             c.CommitQueueDisabled,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
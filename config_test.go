@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/util"
@@ -85,6 +86,38 @@ func TestGetGithubSettings(t *testing.T) {
 	})
 }
 
+func TestWebhookConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := WebhookConfig{}
+	assert.NoError(conf.ValidateAndDefault())
+	assert.Equal(5, conf.MaxAttempts)
+	assert.Equal(30, conf.MinRetryDelaySeconds)
+	assert.Equal(60*60, conf.MaxRetryDelaySeconds)
+
+	conf = WebhookConfig{MinRetryDelaySeconds: 10, MaxRetryDelaySeconds: 50}
+	assert.Equal(10*time.Second, conf.RetryDelay(1))
+	assert.Equal(20*time.Second, conf.RetryDelay(2))
+	assert.Equal(40*time.Second, conf.RetryDelay(3))
+	assert.Equal(50*time.Second, conf.RetryDelay(4))
+	assert.Equal(50*time.Second, conf.RetryDelay(100))
+
+	conf = WebhookConfig{MinRetryDelaySeconds: 100, MaxRetryDelaySeconds: 50}
+	assert.Error(conf.ValidateAndDefault())
+	conf = WebhookConfig{MaxAttempts: -1}
+	assert.Error(conf.ValidateAndDefault())
+
+	// circuit breaking is on by default, and disabled by a negative
+	// threshold
+	conf = WebhookConfig{}
+	assert.NoError(conf.ValidateAndDefault())
+	assert.Equal(5, conf.CircuitBreakerFailures())
+	conf = WebhookConfig{CircuitBreakerThreshold: -1}
+	assert.NoError(conf.ValidateAndDefault())
+	assert.Equal(-1, conf.CircuitBreakerThreshold)
+	assert.Equal(0, conf.CircuitBreakerFailures())
+}
+
 type AdminSuite struct {
 	suite.Suite
 }
@@ -435,12 +435,28 @@ func (e *envState) initSenders() error {
 		e.senders[SenderSlack] = sender
 	}
 
-	sender, err = util.NewEvergreenWebhookLogger()
+	webhookConf := e.settings.Notify.Webhook.Defaulted()
+	sender, err = util.NewEvergreenWebhookLoggerWithOptions(util.EvergreenWebhookOptions{
+		CircuitBreakerThreshold: webhookConf.CircuitBreakerFailures(),
+		CircuitBreakerCooldown:  time.Duration(webhookConf.CircuitBreakerCooldownSeconds) * time.Second,
+	})
 	if err != nil {
 		return errors.Wrap(err, "Failed to setup evergreen webhook logger")
 	}
 	e.senders[SenderEvergreenWebhook] = sender
 
+	sender, err = util.NewChatWebhookLogger("teams")
+	if err != nil {
+		return errors.Wrap(err, "Failed to setup teams logger")
+	}
+	e.senders[SenderTeams] = sender
+
+	sender, err = util.NewChatWebhookLogger("mattermost")
+	if err != nil {
+		return errors.Wrap(err, "Failed to setup mattermost logger")
+	}
+	e.senders[SenderMattermost] = sender
+
 	catcher := grip.NewBasicCatcher()
 	for _, s := range e.senders {
 		catcher.Add(s.SetLevel(levelInfo))
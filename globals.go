@@ -142,6 +142,7 @@ const (
 	ProviderNameStatic      = "static"
 	ProviderNameOpenstack   = "openstack"
 	ProviderNameVsphere     = "vsphere"
+	ProviderNameKubernetes  = "kubernetes"
 	ProviderNameMock        = "mock"
 
 	// TODO: This can be removed when no more hosts with provider ec2 are running.
@@ -159,6 +160,7 @@ var (
 		ProviderNameGce,
 		ProviderNameOpenstack,
 		ProviderNameVsphere,
+		ProviderNameKubernetes,
 	}
 )
 
@@ -175,6 +177,8 @@ const (
 	PatchVersionRequester       = "patch_request"
 	GithubPRRequester           = "github_pull_request"
 	RepotrackerVersionRequester = "gitter_request"
+	PeriodicBuildRequester      = "periodic_build_request"
+	TriggerRequester            = "trigger_request"
 )
 
 const (
@@ -191,6 +195,8 @@ const (
 	SenderJIRAIssue
 	SenderJIRAComment
 	SenderEmail
+	SenderTeams
+	SenderMattermost
 )
 
 const (
@@ -212,6 +218,14 @@ var (
 		GithubPRRequester,
 	}
 
+	// SystemVersionRequesters are the requesters of versions that
+	// Evergreen creates on a project's behalf rather than for a user.
+	SystemVersionRequesters = []string{
+		RepotrackerVersionRequester,
+		PeriodicBuildRequester,
+		TriggerRequester,
+	}
+
 	// UphostStatus is a list of all host statuses that are considered "up."
 	// This is used for query building.
 	UphostStatus = []string{
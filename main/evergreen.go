@@ -52,10 +52,14 @@ func buildApp() *cli.App {
 		operations.Fetch(),
 		operations.Evaluate(),
 		operations.Validate(),
+		operations.RunLocal(),
+		operations.Generate(),
 		operations.List(),
 		operations.TestHistory(),
 		operations.LastGreen(),
 		operations.Subscriptions(),
+		operations.CommitQueue(),
+		operations.Task(),
 
 		// Patch creation and management commands (top-level)
 		operations.Patch(),
@@ -76,6 +76,15 @@ func ByBuildId(id string) db.Q {
 	return db.Query(bson.D{{BuildIdKey, id}}).Sort([]string{TaskNameKey})
 }
 
+// ByBuildIds returns all entries with any of the given Build Ids
+func ByBuildIds(buildIds []string) db.Q {
+	return db.Query(bson.M{
+		BuildIdKey: bson.M{
+			"$in": buildIds,
+		},
+	})
+}
+
 // === DB Logic ===
 
 // Upsert updates the files entry in the db if an entry already exists,
@@ -0,0 +1,261 @@
+package commitqueue
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Collection is the name of the commit queue collection in the database.
+const Collection = "commit_queue"
+
+// CommitQueueItem is a GitHub pull request waiting to be merged.
+type CommitQueueItem struct {
+	PRNumber int `bson:"pr_number" json:"pr_number"`
+	// HeadHash is the head of the pull request that was tested, and is the
+	// only commit the pull request may be merged at.
+	HeadHash string `bson:"head_hash,omitempty" json:"head_hash"`
+	// Author is the Evergreen user who enqueued the pull request, and
+	// GithubAuthor the GitHub user who opened it.
+	Author       string `bson:"author" json:"author"`
+	GithubAuthor string `bson:"github_author,omitempty" json:"github_author"`
+	// Version is the id of the patch testing the pull request on top of
+	// the items ahead of it, if one has been created.
+	Version     string    `bson:"version,omitempty" json:"version"`
+	EnqueueTime time.Time `bson:"enqueue_time" json:"enqueue_time"`
+}
+
+// CommitQueue is the ordered list of pull requests waiting to be merged into
+// the branch of a project.
+type CommitQueue struct {
+	ProjectID string            `bson:"_id" json:"project_id"`
+	Queue     []CommitQueueItem `bson:"queue" json:"queue"`
+	// LockedBy is the job processing the queue, if any, and LockedAt the
+	// time it started, so that only one job changes the queue at a time.
+	LockedBy string    `bson:"locked_by,omitempty" json:"-"`
+	LockedAt time.Time `bson:"locked_at,omitempty" json:"-"`
+}
+
+var (
+	IdKey       = bsonutil.MustHaveTag(CommitQueue{}, "ProjectID")
+	QueueKey    = bsonutil.MustHaveTag(CommitQueue{}, "Queue")
+	LockedByKey = bsonutil.MustHaveTag(CommitQueue{}, "LockedBy")
+	LockedAtKey = bsonutil.MustHaveTag(CommitQueue{}, "LockedAt")
+
+	PRNumberKey     = bsonutil.MustHaveTag(CommitQueueItem{}, "PRNumber")
+	HeadHashKey     = bsonutil.MustHaveTag(CommitQueueItem{}, "HeadHash")
+	GithubAuthorKey = bsonutil.MustHaveTag(CommitQueueItem{}, "GithubAuthor")
+	VersionKey      = bsonutil.MustHaveTag(CommitQueueItem{}, "Version")
+)
+
+// FindOneId returns the commit queue of a project, or nil if the project has
+// no queue.
+func FindOneId(projectID string) (*CommitQueue, error) {
+	cq := &CommitQueue{}
+	err := db.FindOneQ(Collection, db.Query(bson.M{IdKey: projectID}), cq)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding commit queue for project '%s'", projectID)
+	}
+	return cq, nil
+}
+
+// FindAll returns all commit queues that have items waiting.
+func FindAll() ([]CommitQueue, error) {
+	queues := []CommitQueue{}
+	err := db.FindAllQ(Collection, db.Query(bson.M{QueueKey + ".0": bson.M{"$exists": true}}), &queues)
+	return queues, errors.Wrap(err, "problem finding commit queues")
+}
+
+// Enqueue adds a pull request to the end of the commit queue of a project,
+// creating the queue if needed, and returns its position in the queue. A pull
+// request can only be in a queue once.
+func Enqueue(projectID string, item CommitQueueItem) (int, error) {
+	if item.PRNumber <= 0 {
+		return -1, errors.New("pull request number must be positive")
+	}
+	if item.EnqueueTime.IsZero() {
+		item.EnqueueTime = time.Now()
+	}
+
+	_, err := db.Upsert(Collection,
+		bson.M{IdKey: projectID},
+		bson.M{"$setOnInsert": bson.M{QueueKey: []CommitQueueItem{}}},
+	)
+	if err != nil {
+		return -1, errors.Wrapf(err, "problem creating commit queue for project '%s'", projectID)
+	}
+
+	err = db.Update(Collection,
+		bson.M{
+			IdKey: projectID,
+			bsonutil.GetDottedKeyName(QueueKey, PRNumberKey): bson.M{"$ne": item.PRNumber},
+		},
+		bson.M{"$push": bson.M{QueueKey: item}},
+	)
+	if err == mgo.ErrNotFound {
+		return -1, errors.Errorf("pull request #%d is already in the commit queue", item.PRNumber)
+	}
+	if err != nil {
+		return -1, errors.Wrapf(err, "problem adding pull request #%d to the commit queue", item.PRNumber)
+	}
+
+	cq, err := FindOneId(projectID)
+	if err != nil {
+		return -1, err
+	}
+	if cq == nil {
+		return -1, errors.Errorf("commit queue for project '%s' not found", projectID)
+	}
+	return cq.FindItem(item.PRNumber), nil
+}
+
+// Lock marks the commit queue of a project as being processed by owner,
+// unless another owner locked it less than timeout ago. It returns false if
+// the queue is locked by another owner or does not exist.
+func Lock(projectID, owner string, timeout time.Duration) (bool, error) {
+	now := time.Now()
+	err := db.Update(Collection,
+		bson.M{
+			IdKey: projectID,
+			"$or": []bson.M{
+				{LockedByKey: bson.M{"$exists": false}},
+				{LockedByKey: owner},
+				{LockedAtKey: bson.M{"$lt": now.Add(-timeout)}},
+			},
+		},
+		bson.M{"$set": bson.M{
+			LockedByKey: owner,
+			LockedAtKey: now,
+		}},
+	)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "problem locking commit queue for project '%s'", projectID)
+	}
+	return true, nil
+}
+
+// Unlock releases the lock that owner holds on the commit queue of a
+// project. It does nothing if owner does not hold the lock.
+func Unlock(projectID, owner string) error {
+	err := db.Update(Collection,
+		bson.M{
+			IdKey:       projectID,
+			LockedByKey: owner,
+		},
+		bson.M{"$unset": bson.M{
+			LockedByKey: 1,
+			LockedAtKey: 1,
+		}},
+	)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return errors.Wrapf(err, "problem unlocking commit queue for project '%s'", projectID)
+}
+
+// FindItem returns the position of a pull request in the queue, or -1 if it
+// is not queued.
+func (q *CommitQueue) FindItem(prNumber int) int {
+	for i, item := range q.Queue {
+		if item.PRNumber == prNumber {
+			return i
+		}
+	}
+	return -1
+}
+
+// Next returns the item at the head of the queue.
+func (q *CommitQueue) Next() (CommitQueueItem, bool) {
+	if len(q.Queue) == 0 {
+		return CommitQueueItem{}, false
+	}
+	return q.Queue[0], true
+}
+
+// Remove removes a pull request from the queue, returning false if it was not
+// queued.
+func (q *CommitQueue) Remove(prNumber int) (bool, error) {
+	idx := q.FindItem(prNumber)
+	if idx < 0 {
+		return false, nil
+	}
+
+	err := db.Update(Collection,
+		bson.M{IdKey: q.ProjectID},
+		bson.M{"$pull": bson.M{QueueKey: bson.M{PRNumberKey: prNumber}}},
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "problem removing pull request #%d from the commit queue", prNumber)
+	}
+	q.Queue = append(q.Queue[:idx], q.Queue[idx+1:]...)
+
+	return true, nil
+}
+
+// SetVersion records the patch testing a queued pull request, along with the
+// head of the pull request and its GitHub author.
+func (q *CommitQueue) SetVersion(prNumber int, version, headHash, githubAuthor string) error {
+	idx := q.FindItem(prNumber)
+	if idx < 0 {
+		return errors.Errorf("pull request #%d is not in the commit queue", prNumber)
+	}
+
+	err := db.Update(Collection,
+		bson.M{
+			IdKey: q.ProjectID,
+			bsonutil.GetDottedKeyName(QueueKey, PRNumberKey): prNumber,
+		},
+		bson.M{"$set": bson.M{
+			bsonutil.GetDottedKeyName(QueueKey, "$", VersionKey):      version,
+			bsonutil.GetDottedKeyName(QueueKey, "$", HeadHashKey):     headHash,
+			bsonutil.GetDottedKeyName(QueueKey, "$", GithubAuthorKey): githubAuthor,
+		}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "problem setting version of pull request #%d", prNumber)
+	}
+	q.Queue[idx].Version = version
+	q.Queue[idx].HeadHash = headHash
+	q.Queue[idx].GithubAuthor = githubAuthor
+
+	return nil
+}
+
+// ClearVersions forgets the patches of the items in the queue from the given
+// position on, so that they are retested against the new state of the queue.
+// It returns the ids of the patches that were cleared.
+func (q *CommitQueue) ClearVersions(from int) ([]string, error) {
+	cleared := []string{}
+	if from < 0 {
+		from = 0
+	}
+	for i := from; i < len(q.Queue); i++ {
+		if q.Queue[i].Version == "" {
+			continue
+		}
+		err := db.Update(Collection,
+			bson.M{
+				IdKey: q.ProjectID,
+				bsonutil.GetDottedKeyName(QueueKey, PRNumberKey): q.Queue[i].PRNumber,
+			},
+			bson.M{"$unset": bson.M{bsonutil.GetDottedKeyName(QueueKey, "$", VersionKey): 1}},
+		)
+		if err != nil {
+			return cleared, errors.Wrapf(err, "problem clearing version of pull request #%d", q.Queue[i].PRNumber)
+		}
+		cleared = append(cleared, q.Queue[i].Version)
+		q.Queue[i].Version = ""
+	}
+
+	return cleared, nil
+}
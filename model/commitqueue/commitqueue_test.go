@@ -0,0 +1,151 @@
+package commitqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommitQueueSuite struct {
+	suite.Suite
+}
+
+func TestCommitQueueSuite(t *testing.T) {
+	suite.Run(t, new(CommitQueueSuite))
+}
+
+func (s *CommitQueueSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *CommitQueueSuite) SetupTest() {
+	s.Require().NoError(db.Clear(Collection))
+}
+
+func (s *CommitQueueSuite) TestEnqueue() {
+	pos, err := Enqueue("mci", CommitQueueItem{PRNumber: 1, Author: "me"})
+	s.NoError(err)
+	s.Equal(0, pos)
+	pos, err = Enqueue("mci", CommitQueueItem{PRNumber: 2, Author: "you"})
+	s.NoError(err)
+	s.Equal(1, pos)
+
+	_, err = Enqueue("mci", CommitQueueItem{PRNumber: 1, Author: "you"})
+	s.Error(err)
+	_, err = Enqueue("mci", CommitQueueItem{Author: "you"})
+	s.Error(err)
+
+	cq, err := FindOneId("mci")
+	s.NoError(err)
+	s.Require().NotNil(cq)
+	s.Require().Len(cq.Queue, 2)
+	s.Equal("me", cq.Queue[0].Author)
+	s.False(cq.Queue[0].EnqueueTime.IsZero())
+
+	cq, err = FindOneId("nope")
+	s.NoError(err)
+	s.Nil(cq)
+}
+
+func (s *CommitQueueSuite) TestRemove() {
+	for _, pr := range []int{1, 2, 3} {
+		_, err := Enqueue("mci", CommitQueueItem{PRNumber: pr})
+		s.Require().NoError(err)
+	}
+	cq, err := FindOneId("mci")
+	s.Require().NoError(err)
+
+	removed, err := cq.Remove(2)
+	s.NoError(err)
+	s.True(removed)
+	removed, err = cq.Remove(4)
+	s.NoError(err)
+	s.False(removed)
+	s.Equal(-1, cq.FindItem(2))
+
+	cq, err = FindOneId("mci")
+	s.Require().NoError(err)
+	s.Require().Len(cq.Queue, 2)
+	s.Equal(1, cq.Queue[0].PRNumber)
+	s.Equal(3, cq.Queue[1].PRNumber)
+
+	queues, err := FindAll()
+	s.NoError(err)
+	s.Len(queues, 1)
+	_, err = cq.Remove(1)
+	s.NoError(err)
+	_, err = cq.Remove(3)
+	s.NoError(err)
+	queues, err = FindAll()
+	s.NoError(err)
+	s.Empty(queues)
+}
+
+func (s *CommitQueueSuite) TestVersions() {
+	for _, pr := range []int{1, 2} {
+		_, err := Enqueue("mci", CommitQueueItem{PRNumber: pr})
+		s.Require().NoError(err)
+	}
+	cq, err := FindOneId("mci")
+	s.Require().NoError(err)
+
+	s.NoError(cq.SetVersion(1, "v1", "abc", "octocat"))
+	s.NoError(cq.SetVersion(2, "v2", "def", "octodog"))
+	s.Error(cq.SetVersion(3, "v3", "ghi", "octocow"))
+
+	cq, err = FindOneId("mci")
+	s.Require().NoError(err)
+	s.Equal("v1", cq.Queue[0].Version)
+	s.Equal("abc", cq.Queue[0].HeadHash)
+	s.Equal("octodog", cq.Queue[1].GithubAuthor)
+
+	cleared, err := cq.ClearVersions(1)
+	s.NoError(err)
+	s.Equal([]string{"v2"}, cleared)
+	s.Equal("v1", cq.Queue[0].Version)
+
+	cleared, err = cq.ClearVersions(0)
+	s.NoError(err)
+	s.Equal([]string{"v1"}, cleared)
+
+	cq, err = FindOneId("mci")
+	s.Require().NoError(err)
+	s.Empty(cq.Queue[0].Version)
+	s.Empty(cq.Queue[1].Version)
+	s.Equal("def", cq.Queue[1].HeadHash)
+}
+
+func (s *CommitQueueSuite) TestLock() {
+	locked, err := Lock("mci", "job-1", time.Minute)
+	s.NoError(err)
+	s.False(locked, "a queue that doesn't exist can't be locked")
+
+	_, err = Enqueue("mci", CommitQueueItem{PRNumber: 1})
+	s.Require().NoError(err)
+
+	locked, err = Lock("mci", "job-1", time.Minute)
+	s.NoError(err)
+	s.True(locked)
+	locked, err = Lock("mci", "job-2", time.Minute)
+	s.NoError(err)
+	s.False(locked)
+
+	// only the owner can release the lock
+	s.NoError(Unlock("mci", "job-2"))
+	locked, err = Lock("mci", "job-2", time.Minute)
+	s.NoError(err)
+	s.False(locked)
+
+	s.NoError(Unlock("mci", "job-1"))
+	locked, err = Lock("mci", "job-2", time.Minute)
+	s.NoError(err)
+	s.True(locked)
+
+	// a lock held past the timeout is taken over
+	locked, err = Lock("mci", "job-3", -time.Second)
+	s.NoError(err)
+	s.True(locked)
+}
@@ -23,6 +23,10 @@ var (
 	ExpansionsKey       = bsonutil.MustHaveTag(Distro{}, "Expansions")
 	DisabledKey         = bsonutil.MustHaveTag(Distro{}, "Disabled")
 	ContainerPoolKey    = bsonutil.MustHaveTag(Distro{}, "ContainerPool")
+	HourlyBudgetKey     = bsonutil.MustHaveTag(Distro{}, "HourlyBudget")
+	MinHostsKey         = bsonutil.MustHaveTag(Distro{}, "MinHosts")
+	WarmStandbyKey      = bsonutil.MustHaveTag(Distro{}, "WarmStandby")
+	HostSchedulesKey    = bsonutil.MustHaveTag(Distro{}, "HostSchedules")
 )
 
 const Collection = "distro"
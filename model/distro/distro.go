@@ -34,6 +34,43 @@ type Distro struct {
 	Disabled     bool        `bson:"disabled,omitempty" json:"disabled,omitempty" mapstructure:"disabled,omitempty"`
 
 	ContainerPool string `bson:"container_pool,omitempty" json:"container_pool,omitempty" mapstructure:"container_pool,omitempty"`
+
+	// HourlyBudget is the maximum amount per hour that the cost_budget host
+	// allocator will spend on running hosts of this distro. Zero means no cap.
+	HourlyBudget float64 `bson:"hourly_budget,omitempty" json:"hourly_budget,omitempty" mapstructure:"hourly_budget,omitempty"`
+
+	// MinHosts is the number of hosts the scheduler keeps running, and idle
+	// host termination leaves running, even when there are no tasks.
+	MinHosts int `bson:"min_hosts,omitempty" json:"min_hosts,omitempty" mapstructure:"min_hosts,omitempty"`
+	// WarmStandby is the number of idle hosts the scheduler keeps running
+	// beyond those needed for the task queue, so that new tasks start
+	// without waiting for a host to be provisioned.
+	WarmStandby int `bson:"warm_standby,omitempty" json:"warm_standby,omitempty" mapstructure:"warm_standby,omitempty"`
+	// HostSchedules raise MinHosts and WarmStandby at certain times.
+	HostSchedules []HostSchedule `bson:"host_schedules,omitempty" json:"host_schedules,omitempty" mapstructure:"host_schedules,omitempty"`
+}
+
+// HostSchedule raises the host pool targets of a distro during a daily
+// window, e.g. "keep 20 hosts 9-18 UTC on weekdays".
+type HostSchedule struct {
+	// Days are the days of the week the schedule applies on, as lowercase
+	// names ("monday") or "weekdays" or "weekends". If empty, the schedule
+	// applies every day.
+	Days []string `bson:"days,omitempty" json:"days,omitempty" mapstructure:"days,omitempty"`
+	// StartHour and EndHour are the hours, in UTC, that the window starts
+	// at and ends before.
+	StartHour int `bson:"start_hour" json:"start_hour" mapstructure:"start_hour"`
+	EndHour   int `bson:"end_hour" json:"end_hour" mapstructure:"end_hour"`
+
+	MinHosts    int `bson:"min_hosts,omitempty" json:"min_hosts,omitempty" mapstructure:"min_hosts,omitempty"`
+	WarmStandby int `bson:"warm_standby,omitempty" json:"warm_standby,omitempty" mapstructure:"warm_standby,omitempty"`
+}
+
+// HostPoolTarget is the number of hosts of a distro to keep running
+// regardless of the task queue.
+type HostPoolTarget struct {
+	MinHosts    int
+	WarmStandby int
 }
 
 type DistroGroup []Distro
@@ -54,6 +91,9 @@ func init() {
 func (d *Distro) GenerateName() string {
 	// gceMaxNameLength is the maximum length of an instance name permitted by GCE.
 	const gceMaxNameLength = 63
+	// kubernetesMaxNameLength is the maximum length of a DNS label, which
+	// keeps pod names usable as hostnames.
+	const kubernetesMaxNameLength = 63
 
 	switch d.Provider {
 	case evergreen.ProviderNameStatic:
@@ -75,6 +115,17 @@ func (d *Distro) GenerateName() string {
 		}
 	}
 
+	if d.Provider == evergreen.ProviderNameKubernetes {
+		// Pod names must be lowercase alphanumeric characters or '-'
+		r, _ := regexp.Compile("[^a-z0-9-]+")
+		name = string(r.ReplaceAll([]byte(strings.ToLower(name)), []byte("-")))
+
+		if len(name) > kubernetesMaxNameLength {
+			name = name[:kubernetesMaxNameLength]
+		}
+		name = strings.TrimRight(name, "-")
+	}
+
 	return name
 }
 
@@ -135,6 +186,76 @@ func ValidateContainerPoolDistros(s *evergreen.Settings) error {
 	return errors.WithStack(catcher.Resolve())
 }
 
+var hostScheduleDays = map[string][]time.Weekday{
+	"sunday":    {time.Sunday},
+	"monday":    {time.Monday},
+	"tuesday":   {time.Tuesday},
+	"wednesday": {time.Wednesday},
+	"thursday":  {time.Thursday},
+	"friday":    {time.Friday},
+	"saturday":  {time.Saturday},
+	"weekdays":  {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends":  {time.Saturday, time.Sunday},
+}
+
+// IsValidHostScheduleDay returns true if the day can be used in the days of
+// a host schedule.
+func IsValidHostScheduleDay(day string) bool {
+	_, ok := hostScheduleDays[day]
+	return ok
+}
+
+// IsActive returns true if the schedule applies at the given time.
+func (s *HostSchedule) IsActive(t time.Time) bool {
+	t = t.UTC()
+	if t.Hour() < s.StartHour || t.Hour() >= s.EndHour {
+		return false
+	}
+	if len(s.Days) == 0 {
+		return true
+	}
+	for _, day := range s.Days {
+		for _, weekday := range hostScheduleDays[day] {
+			if t.Weekday() == weekday {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HostPoolTarget returns the number of hosts to keep running at the given
+// time: the largest of the distro's own targets and those of its active
+// schedules, limited to the pool size.
+func (d *Distro) HostPoolTarget(t time.Time) HostPoolTarget {
+	target := HostPoolTarget{
+		MinHosts:    d.MinHosts,
+		WarmStandby: d.WarmStandby,
+	}
+	for _, schedule := range d.HostSchedules {
+		if !schedule.IsActive(t) {
+			continue
+		}
+		if schedule.MinHosts > target.MinHosts {
+			target.MinHosts = schedule.MinHosts
+		}
+		if schedule.WarmStandby > target.WarmStandby {
+			target.WarmStandby = schedule.WarmStandby
+		}
+	}
+
+	if d.PoolSize > 0 {
+		if target.MinHosts > d.PoolSize {
+			target.MinHosts = d.PoolSize
+		}
+		if target.WarmStandby > d.PoolSize {
+			target.WarmStandby = d.PoolSize
+		}
+	}
+
+	return target
+}
+
 // GetDistroIds returns a slice of distro IDs for the given group of distros
 func (distros DistroGroup) GetDistroIds() []string {
 	var ids []string
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
@@ -53,6 +54,26 @@ func TestGenerateGceName(t *testing.T) {
 	assert.True(r.Match([]byte(tooManyChars)))
 }
 
+func TestGenerateKubernetesName(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := regexp.Compile("^[a-z0-9](?:[-a-z0-9]{0,61}[a-z0-9])?$")
+	assert.NoError(err)
+	d := Distro{Id: "name", Provider: evergreen.ProviderNameKubernetes}
+
+	nameA := d.GenerateName()
+	nameB := d.GenerateName()
+	assert.True(r.MatchString(nameA))
+	assert.True(r.MatchString(nameB))
+	assert.NotEqual(nameA, nameB)
+
+	d.Id = "Invalid_Distro.Name"
+	assert.True(r.MatchString(d.GenerateName()))
+
+	d.Id = strings.Repeat("abc-", 20)
+	assert.True(r.MatchString(d.GenerateName()))
+}
+
 func TestIsParent(t *testing.T) {
 	assert := assert.New(t)
 	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
@@ -153,3 +174,49 @@ func TestGetDistroIds(t *testing.T) {
 	ids := hosts.GetDistroIds()
 	assert.Equal([]string{"d1", "d2", "d3"}, ids)
 }
+
+func TestHostPoolTarget(t *testing.T) {
+	assert := assert.New(t)
+
+	// Monday
+	morning := time.Date(2018, time.July, 2, 8, 30, 0, 0, time.UTC)
+	workday := time.Date(2018, time.July, 2, 9, 0, 0, 0, time.UTC)
+	evening := time.Date(2018, time.July, 2, 18, 0, 0, 0, time.UTC)
+	saturday := time.Date(2018, time.July, 7, 12, 0, 0, 0, time.UTC)
+
+	d := Distro{
+		PoolSize:    30,
+		MinHosts:    2,
+		WarmStandby: 1,
+		HostSchedules: []HostSchedule{
+			{Days: []string{"weekdays"}, StartHour: 9, EndHour: 18, MinHosts: 20},
+			{Days: []string{"saturday", "sunday"}, StartHour: 0, EndHour: 24, WarmStandby: 3},
+		},
+	}
+	assert.Equal(HostPoolTarget{MinHosts: 2, WarmStandby: 1}, d.HostPoolTarget(morning))
+	assert.Equal(HostPoolTarget{MinHosts: 20, WarmStandby: 1}, d.HostPoolTarget(workday))
+	assert.Equal(HostPoolTarget{MinHosts: 2, WarmStandby: 1}, d.HostPoolTarget(evening))
+	assert.Equal(HostPoolTarget{MinHosts: 2, WarmStandby: 3}, d.HostPoolTarget(saturday))
+
+	// schedules use UTC
+	est := time.FixedZone("EST", -5*60*60)
+	assert.Equal(HostPoolTarget{MinHosts: 20, WarmStandby: 1}, d.HostPoolTarget(time.Date(2018, time.July, 2, 4, 0, 0, 0, est)))
+
+	// targets are limited to the pool size
+	d.PoolSize = 10
+	assert.Equal(HostPoolTarget{MinHosts: 10, WarmStandby: 1}, d.HostPoolTarget(workday))
+
+	// schedules without days apply every day
+	d.HostSchedules = []HostSchedule{{StartHour: 12, EndHour: 13, MinHosts: 5}}
+	assert.Equal(HostPoolTarget{MinHosts: 5, WarmStandby: 1}, d.HostPoolTarget(saturday))
+}
+
+func TestIsValidHostScheduleDay(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsValidHostScheduleDay("monday"))
+	assert.True(IsValidHostScheduleDay("weekdays"))
+	assert.True(IsValidHostScheduleDay("weekends"))
+	assert.False(IsValidHostScheduleDay("Monday"))
+	assert.False(IsValidHostScheduleDay("mon"))
+}
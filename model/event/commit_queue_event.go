@@ -0,0 +1,59 @@
+package event
+
+import (
+	"time"
+
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+func init() {
+	registry.AddType(ResourceTypeCommitQueue, commitQueueEventDataFactory)
+	registry.AllowSubscription(ResourceTypeCommitQueue, CommitQueueStateChange)
+}
+
+func commitQueueEventDataFactory() interface{} {
+	return &CommitQueueEventData{}
+}
+
+const (
+	ResourceTypeCommitQueue = "COMMIT_QUEUE"
+
+	CommitQueueStateChange = "STATE_CHANGE"
+
+	CommitQueueMerged  = "merged"
+	CommitQueueEvicted = "evicted"
+)
+
+// CommitQueueEventData records a pull request leaving the commit queue of a
+// project, either because it was merged or because it was evicted.
+type CommitQueueEventData struct {
+	Status   string `bson:"status,omitempty" json:"status,omitempty"`
+	PRNumber int    `bson:"pr_number" json:"pr_number"`
+	Owner    string `bson:"owner,omitempty" json:"owner,omitempty"`
+	Repo     string `bson:"repo,omitempty" json:"repo,omitempty"`
+	Author   string `bson:"author,omitempty" json:"author,omitempty"`
+	PatchID  string `bson:"patch_id,omitempty" json:"patch_id,omitempty"`
+	Message  string `bson:"message,omitempty" json:"message,omitempty"`
+}
+
+// LogCommitQueueStateChangeEvent logs a pull request leaving the commit queue
+// of the project with the given id.
+func LogCommitQueueStateChangeEvent(projectID string, data CommitQueueEventData) {
+	event := EventLogEntry{
+		Timestamp:    time.Now().Truncate(0).Round(time.Millisecond),
+		ResourceId:   projectID,
+		ResourceType: ResourceTypeCommitQueue,
+		EventType:    CommitQueueStateChange,
+		Data:         &data,
+	}
+
+	logger := NewDBEventLogger(AllLogCollection)
+	if err := logger.LogEvent(&event); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"resource_type": ResourceTypeCommitQueue,
+			"message":       "error logging event",
+			"source":        "event-log-fail",
+		}))
+	}
+}
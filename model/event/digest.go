@@ -0,0 +1,79 @@
+package event
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// Digest frequencies. Subscriptions with a digest collect the notifications
+// that match them, and send a single summary of them hourly, daily, or after
+// a number of events.
+const (
+	DigestHourly = "hourly"
+	DigestDaily  = "daily"
+	DigestCount  = "count"
+)
+
+// ValidDigestFrequencies are the frequencies that a digest may have.
+var ValidDigestFrequencies = []string{DigestHourly, DigestDaily, DigestCount}
+
+// digestResourceTypes are the resource types whose events can be collected
+// into digests, since they can be grouped by version and variant.
+var digestResourceTypes = []string{ResourceTypeTask, ResourceTypeBuild, ResourceTypeVersion}
+
+// digestSubscriberTypes are the subscribers that can receive digests.
+var digestSubscriberTypes = []string{
+	EmailSubscriberType,
+	SlackSubscriberType,
+	TeamsSubscriberType,
+	MattermostSubscriberType,
+}
+
+// DigestOptions configure a subscription to deliver digests instead of one
+// notification per event.
+type DigestOptions struct {
+	Frequency string `bson:"frequency" json:"frequency"`
+	// Count is the number of events that a digest of the count frequency
+	// collects before it is sent.
+	Count int `bson:"count,omitempty" json:"count,omitempty"`
+}
+
+func (d *DigestOptions) Validate() error {
+	if !util.StringSliceContains(ValidDigestFrequencies, d.Frequency) {
+		return errors.Errorf("'%s' is not a valid digest frequency, must be one of %v", d.Frequency, ValidDigestFrequencies)
+	}
+	if d.Frequency == DigestCount && d.Count <= 0 {
+		return errors.New("digests sent after a number of events must have a positive count")
+	}
+	if d.Frequency != DigestCount && d.Count != 0 {
+		return errors.Errorf("only digests sent after a number of events can have a count, not '%s' digests", d.Frequency)
+	}
+	return nil
+}
+
+// Interval returns how long a digest collects notifications before it is
+// sent, or zero if it is sent after a number of events.
+func (d *DigestOptions) Interval() time.Duration {
+	switch d.Frequency {
+	case DigestHourly:
+		return time.Hour
+	case DigestDaily:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// IsDue returns true if a digest of pending notifications, the oldest of
+// which was created at the given time, should be sent now.
+func (d *DigestOptions) IsDue(pending int, oldest, now time.Time) bool {
+	if pending == 0 {
+		return false
+	}
+	if d.Frequency == DigestCount {
+		return pending >= d.Count
+	}
+	return !now.Before(oldest.Add(d.Interval()))
+}
@@ -0,0 +1,75 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestOptionsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&DigestOptions{Frequency: DigestHourly}).Validate())
+	assert.NoError((&DigestOptions{Frequency: DigestDaily}).Validate())
+	assert.NoError((&DigestOptions{Frequency: DigestCount, Count: 5}).Validate())
+
+	assert.Error((&DigestOptions{}).Validate())
+	assert.Error((&DigestOptions{Frequency: "weekly"}).Validate())
+	assert.Error((&DigestOptions{Frequency: DigestCount}).Validate())
+	assert.Error((&DigestOptions{Frequency: DigestCount, Count: -1}).Validate())
+	assert.Error((&DigestOptions{Frequency: DigestHourly, Count: 5}).Validate())
+}
+
+func TestDigestOptionsIsDue(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	hourly := &DigestOptions{Frequency: DigestHourly}
+	assert.False(hourly.IsDue(0, now.Add(-2*time.Hour), now))
+	assert.False(hourly.IsDue(10, now.Add(-59*time.Minute), now))
+	assert.True(hourly.IsDue(1, now.Add(-time.Hour), now))
+
+	daily := &DigestOptions{Frequency: DigestDaily}
+	assert.False(daily.IsDue(10, now.Add(-23*time.Hour), now))
+	assert.True(daily.IsDue(1, now.Add(-25*time.Hour), now))
+
+	count := &DigestOptions{Frequency: DigestCount, Count: 3}
+	assert.False(count.IsDue(2, now.Add(-48*time.Hour), now))
+	assert.True(count.IsDue(3, now, now))
+	assert.True(count.IsDue(4, now, now))
+}
+
+func TestSubscriptionValidateDigest(t *testing.T) {
+	assert := assert.New(t)
+	target := "me@example.com"
+	sub := Subscription{
+		Type:    ResourceTypeTask,
+		Trigger: "build-break",
+		Selectors: []Selector{
+			{Type: "project", Data: "mci"},
+		},
+		Subscriber: Subscriber{
+			Type:   EmailSubscriberType,
+			Target: &target,
+		},
+		OwnerType: OwnerTypeProject,
+		Owner:     "mci",
+		Digest:    &DigestOptions{Frequency: DigestHourly},
+	}
+	assert.NoError(sub.Validate())
+
+	sub.Digest = &DigestOptions{Frequency: "weekly"}
+	assert.Error(sub.Validate())
+
+	sub.Digest = &DigestOptions{Frequency: DigestDaily}
+	sub.Type = ResourceTypeHost
+	assert.Error(sub.Validate())
+
+	sub.Type = ResourceTypeTask
+	sub.Subscriber = Subscriber{
+		Type:   JIRACommentSubscriberType,
+		Target: &target,
+	}
+	assert.Error(sub.Validate())
+}
@@ -0,0 +1,285 @@
+package event
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// MessageTemplate replaces the text of the notifications of a subscription.
+// Subject and Body are Go text/templates, rendered with a
+// MessageTemplateData. The subject is used for emails and JIRA issues, and
+// the body for every subscriber.
+type MessageTemplate struct {
+	Subject string `bson:"subject,omitempty" json:"subject,omitempty"`
+	Body    string `bson:"body" json:"body"`
+}
+
+// messageTemplateSubscriberTypes are the subscribers whose messages can be
+// replaced by a template. Webhooks and GitHub statuses have a fixed format.
+var messageTemplateSubscriberTypes = []string{
+	EmailSubscriberType,
+	SlackSubscriberType,
+	TeamsSubscriberType,
+	MattermostSubscriberType,
+	JIRACommentSubscriberType,
+	JIRAIssueSubscriberType,
+}
+
+// messageTemplateFuncs are the functions that message templates can use,
+// in addition to the text/template builtins.
+var messageTemplateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// MessageTemplateData is the data model that message templates are rendered
+// with. The fields common to every event are always set, and exactly one of
+// Task, Build, Version, Patch and Host is set, depending on the resource
+// type of the subscription.
+type MessageTemplateData struct {
+	// Object is the kind of resource, e.g. "task" or "build"
+	Object string
+	// ID, DisplayName and URL identify the resource and link to it in the
+	// Evergreen UI
+	ID          string
+	DisplayName string
+	URL         string
+	// Project is the identifier of the resource's project
+	Project string
+	// Status is the status that the event is about, e.g. "failed" or
+	// "success"
+	Status string
+	// Trigger is the trigger of the subscription
+	Trigger string
+
+	Task    *MessageTemplateTask
+	Build   *MessageTemplateBuild
+	Version *MessageTemplateVersion
+	Patch   *MessageTemplatePatch
+	Host    *MessageTemplateHost
+}
+
+// MessageTemplateTask describes a task for message templates.
+type MessageTemplateTask struct {
+	ID           string
+	DisplayName  string
+	BuildVariant string
+	Status       string
+	Execution    int
+	Version      string
+	Revision     string
+	Requester    string
+	// Details is the description of the command that failed, if any
+	Details     string
+	TimedOut    bool
+	TimeTaken   time.Duration
+	FailedTests []string
+}
+
+// MessageTemplateBuild describes a build for message templates.
+type MessageTemplateBuild struct {
+	ID           string
+	DisplayName  string
+	BuildVariant string
+	Status       string
+	Version      string
+	Revision     string
+	Requester    string
+	TimeTaken    time.Duration
+	FailedTasks  []string
+}
+
+// MessageTemplateVersion describes a version for message templates.
+type MessageTemplateVersion struct {
+	ID        string
+	Revision  string
+	Author    string
+	Message   string
+	Status    string
+	Requester string
+}
+
+// MessageTemplatePatch describes a patch for message templates.
+type MessageTemplatePatch struct {
+	ID          string
+	Number      int
+	Author      string
+	Description string
+	Status      string
+	Version     string
+	// PullRequestURL links to the patch's GitHub pull request, if any
+	PullRequestURL string
+}
+
+// MessageTemplateHost describes a host for message templates.
+type MessageTemplateHost struct {
+	ID             string
+	Distro         string
+	Provider       string
+	Status         string
+	Owner          string
+	Hostname       string
+	ExpirationTime time.Time
+}
+
+// SampleMessageTemplateData returns the data of an example event of the
+// resource type, to validate and preview message templates with.
+func SampleMessageTemplateData(resourceType, trigger string) (*MessageTemplateData, error) {
+	const url = "https://evergreen.example.com"
+
+	data := &MessageTemplateData{
+		Project: "sample-project",
+		Status:  "failed",
+		Trigger: trigger,
+	}
+	switch resourceType {
+	case ResourceTypeTask:
+		data.Object = "task"
+		data.ID = "sample_project_ubuntu_compile_abcdef0_19_01_01_00_00_00"
+		data.DisplayName = "compile"
+		data.URL = url + "/task/" + data.ID
+		data.Task = &MessageTemplateTask{
+			ID:           data.ID,
+			DisplayName:  data.DisplayName,
+			BuildVariant: "ubuntu",
+			Status:       "failed",
+			Version:      "sample_project_abcdef0",
+			Revision:     "abcdef0123456789abcdef0123456789abcdef01",
+			Requester:    "gitter_request",
+			Details:      "'shell.exec' in 'compile'",
+			TimeTaken:    12 * time.Minute,
+			FailedTests:  []string{"TestOne", "TestTwo"},
+		}
+
+	case ResourceTypeBuild:
+		data.Object = "build"
+		data.ID = "sample_project_ubuntu_abcdef0_19_01_01_00_00_00"
+		data.DisplayName = "Ubuntu"
+		data.URL = url + "/build/" + data.ID
+		data.Build = &MessageTemplateBuild{
+			ID:           data.ID,
+			DisplayName:  data.DisplayName,
+			BuildVariant: "ubuntu",
+			Status:       "failed",
+			Version:      "sample_project_abcdef0",
+			Revision:     "abcdef0123456789abcdef0123456789abcdef01",
+			Requester:    "gitter_request",
+			TimeTaken:    40 * time.Minute,
+			FailedTasks:  []string{"compile", "test"},
+		}
+
+	case ResourceTypeVersion:
+		data.Object = "version"
+		data.ID = "sample_project_abcdef0"
+		data.DisplayName = data.ID
+		data.URL = url + "/version/" + data.ID
+		data.Version = &MessageTemplateVersion{
+			ID:        data.ID,
+			Revision:  "abcdef0123456789abcdef0123456789abcdef01",
+			Author:    "octocat",
+			Message:   "Fix the flaky test",
+			Status:    "failed",
+			Requester: "gitter_request",
+		}
+
+	case ResourceTypePatch:
+		data.Object = "patch"
+		data.ID = "5c2b5fb8e3c3310b0f6b6e4a"
+		data.DisplayName = data.ID
+		data.URL = url + "/version/" + data.ID
+		data.Patch = &MessageTemplatePatch{
+			ID:             data.ID,
+			Number:         42,
+			Author:         "octocat",
+			Description:    "Try out the fix for the flaky test",
+			Status:         "failed",
+			Version:        data.ID,
+			PullRequestURL: "https://github.com/octocat/hello-world/pull/1",
+		}
+
+	case ResourceTypeHost:
+		data.Object = "host"
+		data.ID = "evg-ubuntu-1234"
+		data.DisplayName = data.ID
+		data.URL = url + "/spawn"
+		data.Status = "spawned"
+		data.Host = &MessageTemplateHost{
+			ID:             data.ID,
+			Distro:         "ubuntu",
+			Provider:       "ec2",
+			Status:         "running",
+			Owner:          "octocat",
+			Hostname:       "ec2-1-2-3-4.compute-1.amazonaws.com",
+			ExpirationTime: time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC),
+		}
+
+	default:
+		return nil, errors.Errorf("message templates are not supported for '%s' subscriptions", resourceType)
+	}
+
+	return data, nil
+}
+
+func (t *MessageTemplate) parse() (*template.Template, *template.Template, error) {
+	subject, err := template.New("subject").Funcs(messageTemplateFuncs).Parse(t.Subject)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid subject template")
+	}
+	body, err := template.New("body").Funcs(messageTemplateFuncs).Parse(t.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid body template")
+	}
+	return subject, body, nil
+}
+
+// Render executes the subject and body templates with the data.
+func (t *MessageTemplate) Render(data *MessageTemplateData) (string, string, error) {
+	subjectTmpl, bodyTmpl, err := t.parse()
+	if err != nil {
+		return "", "", err
+	}
+
+	buf := &bytes.Buffer{}
+	if err = subjectTmpl.Execute(buf, data); err != nil {
+		return "", "", errors.Wrap(err, "failed to execute subject template")
+	}
+	subject := strings.TrimSpace(buf.String())
+
+	buf = &bytes.Buffer{}
+	if err = bodyTmpl.Execute(buf, data); err != nil {
+		return "", "", errors.Wrap(err, "failed to execute body template")
+	}
+
+	return subject, buf.String(), nil
+}
+
+// Validate checks that the templates can be rendered for the resource type
+// and subscriber type of a subscription, using sample data.
+func (t *MessageTemplate) Validate(resourceType, trigger, subscriberType string) error {
+	if strings.TrimSpace(t.Body) == "" {
+		return errors.New("message template must have a body")
+	}
+	if !util.StringSliceContains(messageTemplateSubscriberTypes, subscriberType) {
+		return errors.Errorf("message templates are not supported for '%s' subscribers", subscriberType)
+	}
+
+	data, err := SampleMessageTemplateData(resourceType, trigger)
+	if err != nil {
+		return err
+	}
+	_, body, err := t.Render(data)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(body) == "" {
+		return errors.New("message template body renders an empty message")
+	}
+
+	return nil
+}
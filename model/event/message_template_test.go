@@ -0,0 +1,91 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageTemplateRender(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	data, err := SampleMessageTemplateData(ResourceTypeTask, "outcome")
+	require.NoError(err)
+
+	tmpl := &MessageTemplate{
+		Subject: "  {{ .Project }}: {{ .DisplayName | upper }} {{ .Status }}  ",
+		Body:    "{{ .Trigger }} on {{ .Task.BuildVariant }}: {{ join .Task.FailedTests \", \" }}",
+	}
+	subject, body, err := tmpl.Render(data)
+	assert.NoError(err)
+	assert.Equal("sample-project: COMPILE failed", subject)
+	assert.Equal("outcome on ubuntu: TestOne, TestTwo", body)
+
+	tmpl = &MessageTemplate{Body: "{{ .Task.ID "}
+	_, _, err = tmpl.Render(data)
+	assert.Error(err)
+
+	tmpl = &MessageTemplate{Body: "{{ .Task.NoSuchField }}"}
+	_, _, err = tmpl.Render(data)
+	assert.Error(err)
+}
+
+func TestSampleMessageTemplateData(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, resourceType := range []string{ResourceTypeTask, ResourceTypeBuild, ResourceTypeVersion, ResourceTypePatch, ResourceTypeHost} {
+		data, err := SampleMessageTemplateData(resourceType, "outcome")
+		assert.NoError(err)
+		assert.NotEmpty(data.Object)
+		assert.NotEmpty(data.ID)
+		assert.NotEmpty(data.URL)
+	}
+
+	data, err := SampleMessageTemplateData(ResourceTypeAdmin, "outcome")
+	assert.Error(err)
+	assert.Nil(data)
+}
+
+func TestMessageTemplateValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpl := &MessageTemplate{Subject: "{{ .Object }} {{ .Status }}", Body: "{{ .Build.DisplayName }} {{ .URL }}"}
+	assert.NoError(tmpl.Validate(ResourceTypeBuild, "outcome", SlackSubscriberType))
+	assert.NoError(tmpl.Validate(ResourceTypeBuild, "outcome", EmailSubscriberType))
+
+	// fields of a different resource type are nil in the sample data
+	assert.Error(tmpl.Validate(ResourceTypeTask, "outcome", SlackSubscriberType))
+	assert.Error(tmpl.Validate(ResourceTypeAdmin, "outcome", SlackSubscriberType))
+	assert.Error(tmpl.Validate(ResourceTypeBuild, "outcome", EvergreenWebhookSubscriberType))
+	assert.Error(tmpl.Validate(ResourceTypeBuild, "outcome", GithubPullRequestSubscriberType))
+
+	assert.Error((&MessageTemplate{Subject: "subject"}).Validate(ResourceTypeBuild, "outcome", SlackSubscriberType))
+	assert.Error((&MessageTemplate{Body: "{{ if .Task }}{{ .Task.ID }}{{ end }}"}).Validate(ResourceTypeBuild, "outcome", SlackSubscriberType))
+}
+
+func TestSubscriptionValidateMessageTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	sub := NewSubscriptionByOwner("me", Subscriber{
+		Type:   SlackSubscriberType,
+		Target: "#channel",
+	}, ResourceTypeVersion, "outcome")
+	sub.OwnerType = OwnerTypePerson
+	sub.Template = &MessageTemplate{Body: "{{ .Version.Author }} broke {{ .Project }}"}
+	assert.NoError(sub.Validate())
+
+	sub.Template = &MessageTemplate{Body: "{{ .Version.Author"}
+	assert.Error(sub.Validate())
+
+	sub.Template = &MessageTemplate{Body: "{{ .Version.Author }}"}
+	sub.Subscriber = Subscriber{
+		Type: EvergreenWebhookSubscriberType,
+		Target: &WebhookSubscriber{
+			URL:    "https://example.com",
+			Secret: []byte("secret"),
+		},
+	}
+	assert.Error(sub.Validate())
+}
@@ -0,0 +1,97 @@
+package event
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func init() {
+	registry.AddType(ResourceTypeProject, projectEventDataFactory)
+}
+
+const (
+	ResourceTypeProject = "PROJECT"
+
+	EventTypeProjectAdded    = "PROJECT_ADDED"
+	EventTypeProjectModified = "PROJECT_MODIFIED"
+)
+
+// projectChangeEvent is the data of a logged change to the settings of a
+// project. The settings snapshots are defined by the model package.
+type projectChangeEvent struct {
+	GUID   string      `bson:"guid"`
+	User   string      `bson:"user"`
+	Before interface{} `bson:"before"`
+	After  interface{} `bson:"after"`
+}
+
+// ProjectChangeEventData holds a logged change to the settings of a project.
+// Before and After are left as raw BSON, for the model package to decode
+// into project settings.
+type ProjectChangeEventData struct {
+	GUID   string   `bson:"guid" json:"guid"`
+	User   string   `bson:"user" json:"user"`
+	Before bson.Raw `bson:"before" json:"-"`
+	After  bson.Raw `bson:"after" json:"-"`
+}
+
+func projectEventDataFactory() interface{} {
+	return &ProjectChangeEventData{}
+}
+
+// LogProjectEvent records a change to the settings of a project by a user.
+func LogProjectEvent(eventType, projectID, user string, before, after interface{}) error {
+	event := EventLogEntry{
+		Timestamp:    time.Now(),
+		ResourceType: ResourceTypeProject,
+		ResourceId:   projectID,
+		EventType:    eventType,
+		Data: projectChangeEvent{
+			GUID:   util.RandomString(),
+			User:   user,
+			Before: before,
+			After:  after,
+		},
+	}
+
+	if err := NewDBEventLogger(AllLogCollection).LogEvent(&event); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"resource_type": ResourceTypeProject,
+			"message":       "error logging event",
+			"source":        "event-log-fail",
+			"project":       projectID,
+		}))
+		return errors.Wrap(err, "error logging project event")
+	}
+
+	return nil
+}
+
+// ProjectEventsBefore builds a query for the newest n events of a project
+// logged before the given time.
+func ProjectEventsBefore(projectID string, before time.Time, n int) db.Q {
+	filter := resourceTypeKeyIs(ResourceTypeProject)
+	filter[ResourceIdKey] = projectID
+	filter[TimestampKey] = bson.M{
+		"$lt": before,
+	}
+
+	return db.Query(filter).Sort([]string{"-" + TimestampKey}).Limit(n)
+}
+
+// ProjectEventByGUID builds a query for the event of a project with the
+// given GUID.
+func ProjectEventByGUID(projectID, guid string) db.Q {
+	filter := resourceTypeKeyIs(ResourceTypeProject)
+	filter[ResourceIdKey] = projectID
+	filter[bsonutil.GetDottedKeyName(DataKey, "guid")] = guid
+
+	return db.Query(filter)
+}
@@ -16,7 +16,9 @@ const (
 	ResourceTypeScheduler = "SCHEDULER"
 
 	// event types
-	EventSchedulerRun = "SCHEDULER_RUN"
+	EventSchedulerRun               = "SCHEDULER_RUN"
+	EventSchedulerBudgetExceeded    = "SCHEDULER_BUDGET_EXCEEDED"
+	EventSchedulerBudgetCostUnknown = "SCHEDULER_BUDGET_COST_UNKNOWN"
 )
 
 type TaskQueueInfo struct {
@@ -25,19 +27,56 @@ type TaskQueueInfo struct {
 	ExpectedDuration time.Duration `bson:"ex_d" json:"expected_duration,"`
 }
 
+// BudgetInfo describes a scheduler run in which an hourly budget limited the
+// number of hosts that could be started. Project is empty when the distro's
+// own budget was the limit. HostHourlyCost is zero if the cost of the
+// distro's hosts was unknown.
+type BudgetInfo struct {
+	Project        string  `bson:"proj,omitempty" json:"project,omitempty"`
+	HourlyBudget   float64 `bson:"budget" json:"hourly_budget"`
+	HostHourlyCost float64 `bson:"host_cost" json:"host_hourly_cost"`
+	HostsRequested int     `bson:"n_req" json:"hosts_requested"`
+	HostsAllowed   int     `bson:"n_allowed" json:"hosts_allowed"`
+	TasksWaiting   int     `bson:"n_waiting" json:"tasks_waiting"`
+}
+
 // implements EventData
 type SchedulerEventData struct {
 	TaskQueueInfo TaskQueueInfo `bson:"tq_info" json:"task_queue_info"`
 	DistroId      string        `bson:"d_id" json:"distro_id"`
+	Budget        *BudgetInfo   `bson:"budget,omitempty" json:"budget,omitempty"`
 }
 
 // LogSchedulerEvent takes care of logging the statistics about the scheduler at a given time.
 // The ResourceId is the time that the scheduler runs.
 func LogSchedulerEvent(eventData SchedulerEventData) {
+	logSchedulerEvent(EventSchedulerRun, eventData)
+}
+
+// LogSchedulerBudgetExceededEvent records that an hourly budget kept the
+// scheduler from starting as many hosts as the distro's queue needed.
+func LogSchedulerBudgetExceededEvent(distroID string, info BudgetInfo) {
+	logSchedulerEvent(EventSchedulerBudgetExceeded, SchedulerEventData{
+		DistroId: distroID,
+		Budget:   &info,
+	})
+}
+
+// LogSchedulerBudgetCostUnknownEvent records that the scheduler could not
+// estimate the cost of a distro's hosts, so it limited the hosts it started
+// without being able to check the hourly budgets.
+func LogSchedulerBudgetCostUnknownEvent(distroID string, info BudgetInfo) {
+	logSchedulerEvent(EventSchedulerBudgetCostUnknown, SchedulerEventData{
+		DistroId: distroID,
+		Budget:   &info,
+	})
+}
+
+func logSchedulerEvent(eventType string, eventData SchedulerEventData) {
 	event := EventLogEntry{
 		Timestamp:    time.Now(),
 		ResourceId:   eventData.DistroId,
-		EventType:    EventSchedulerRun,
+		EventType:    eventType,
 		Data:         eventData,
 		ResourceType: ResourceTypeScheduler,
 	}
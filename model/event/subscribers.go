@@ -2,6 +2,7 @@ package event
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
@@ -17,6 +18,8 @@ const (
 	EvergreenWebhookSubscriberType  = "evergreen-webhook"
 	EmailSubscriberType             = "email"
 	SlackSubscriberType             = "slack"
+	TeamsSubscriberType             = "teams"
+	MattermostSubscriberType        = "mattermost"
 )
 
 var SubscriberTypes = []string{
@@ -26,6 +29,8 @@ var SubscriberTypes = []string{
 	EvergreenWebhookSubscriberType,
 	EmailSubscriberType,
 	SlackSubscriberType,
+	TeamsSubscriberType,
+	MattermostSubscriberType,
 }
 
 //nolint: deadcode, megacheck
@@ -65,7 +70,8 @@ func (s *Subscriber) SetBSON(raw bson.Raw) error {
 	case JIRAIssueSubscriberType:
 		s.Target = &JIRAIssueSubscriber{}
 
-	case JIRACommentSubscriberType, EmailSubscriberType, SlackSubscriberType:
+	case JIRACommentSubscriberType, EmailSubscriberType, SlackSubscriberType,
+		TeamsSubscriberType, MattermostSubscriberType:
 		str := ""
 		s.Target = &str
 
@@ -116,9 +122,34 @@ func (s *Subscriber) Validate() error {
 	if s.Target == nil {
 		catcher.Add(errors.New("type is required for subscriber"))
 	}
+	if s.Type == TeamsSubscriberType || s.Type == MattermostSubscriberType {
+		catcher.Add(validateChatWebhookURL(s.Target))
+	}
 	return catcher.Resolve()
 }
 
+// validateChatWebhookURL checks that the target of a Teams or Mattermost
+// subscriber is the URL of an incoming webhook.
+func validateChatWebhookURL(target interface{}) error {
+	var webhookURL string
+	switch v := target.(type) {
+	case string:
+		webhookURL = v
+	case *string:
+		if v != nil {
+			webhookURL = *v
+		}
+	default:
+		return errors.New("incoming webhook URL must be a string")
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("'%s' is not a valid incoming webhook URL", webhookURL)
+	}
+	return nil
+}
+
 type WebhookSubscriber struct {
 	URL    string `bson:"url"`
 	Secret []byte `bson:"secret"`
@@ -171,3 +202,17 @@ func NewSlackSubscriber(t string) Subscriber {
 		Target: t,
 	}
 }
+
+func NewTeamsSubscriber(webhookURL string) Subscriber {
+	return Subscriber{
+		Type:   TeamsSubscriberType,
+		Target: webhookURL,
+	}
+}
+
+func NewMattermostSubscriber(webhookURL string) Subscriber {
+	return Subscriber{
+		Type:   MattermostSubscriberType,
+		Target: webhookURL,
+	}
+}
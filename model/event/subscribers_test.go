@@ -106,3 +106,25 @@ func TestSubscribersStringerWithMissingAttributes(t *testing.T) {
 
 	assert.True(strings.HasSuffix(webhookSub.String(), "NIL_URL"))
 }
+
+func TestChatWebhookSubscriberValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	target := "https://mattermost.example.com/hooks/abc"
+	for _, sub := range []Subscriber{
+		NewTeamsSubscriber("https://outlook.office.com/webhook/abc"),
+		NewMattermostSubscriber("http://mattermost.example.com/hooks/abc"),
+		{Type: MattermostSubscriberType, Target: &target},
+	} {
+		assert.NoError(sub.Validate(), sub.String())
+	}
+
+	for _, sub := range []Subscriber{
+		NewTeamsSubscriber(""),
+		NewTeamsSubscriber("#channel"),
+		NewMattermostSubscriber("ftp://example.com/hooks/abc"),
+		{Type: TeamsSubscriberType, Target: 5},
+	} {
+		assert.Error(sub.Validate(), sub.String())
+	}
+}
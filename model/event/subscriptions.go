@@ -31,6 +31,8 @@ var (
 	subscriptionOwnerKey          = bsonutil.MustHaveTag(Subscription{}, "Owner")
 	subscriptionOwnerTypeKey      = bsonutil.MustHaveTag(Subscription{}, "OwnerType")
 	subscriptionTriggerDataKey    = bsonutil.MustHaveTag(Subscription{}, "TriggerData")
+	subscriptionDigestKey         = bsonutil.MustHaveTag(Subscription{}, "Digest")
+	subscriptionTemplateKey       = bsonutil.MustHaveTag(Subscription{}, "Template")
 )
 
 type OwnerType string
@@ -48,6 +50,7 @@ const (
 	ImplicitSubscriptionBuildBreak                    = "build-break"
 	ImplicitSubscriptionSpawnhostExpiration           = "spawnhost-expiration"
 	ImplicitSubscriptionSpawnHostOutcome              = "spawnhost-outcome"
+	ImplicitSubscriptionCommitQueue                   = "commit-queue"
 )
 
 type Subscription struct {
@@ -60,6 +63,8 @@ type Subscription struct {
 	OwnerType      OwnerType         `bson:"owner_type"`
 	Owner          string            `bson:"owner"`
 	TriggerData    map[string]string `bson:"trigger_data,omitempty"`
+	Digest         *DigestOptions    `bson:"digest,omitempty"`
+	Template       *MessageTemplate  `bson:"template,omitempty"`
 }
 
 type unmarshalSubscription struct {
@@ -72,6 +77,8 @@ type unmarshalSubscription struct {
 	OwnerType      OwnerType         `bson:"owner_type"`
 	Owner          string            `bson:"owner"`
 	TriggerData    map[string]string `bson:"trigger_data,omitempty"`
+	Digest         *DigestOptions    `bson:"digest,omitempty"`
+	Template       *MessageTemplate  `bson:"template,omitempty"`
 }
 
 func (s *Subscription) SetBSON(raw bson.Raw) error {
@@ -90,6 +97,8 @@ func (s *Subscription) SetBSON(raw bson.Raw) error {
 	s.Owner = temp.Owner
 	s.OwnerType = temp.OwnerType
 	s.TriggerData = temp.TriggerData
+	s.Digest = temp.Digest
+	s.Template = temp.Template
 
 	return nil
 }
@@ -187,6 +196,8 @@ func (s *Subscription) Upsert() error {
 		subscriptionOwnerKey:          s.Owner,
 		subscriptionOwnerTypeKey:      s.OwnerType,
 		subscriptionTriggerDataKey:    s.TriggerData,
+		subscriptionDigestKey:         s.Digest,
+		subscriptionTemplateKey:       s.Template,
 	}
 
 	// note: this prevents changing the owner of an existing subscription, which is desired
@@ -260,6 +271,18 @@ func (s *Subscription) Validate() error {
 	}
 	catcher.Add(s.runCustomValidation())
 	catcher.Add(s.Subscriber.Validate())
+	if s.Digest != nil {
+		catcher.Add(s.Digest.Validate())
+		if !util.StringSliceContains(digestResourceTypes, s.Type) {
+			catcher.Add(errors.Errorf("digests are not supported for '%s' subscriptions", s.Type))
+		}
+		if !util.StringSliceContains(digestSubscriberTypes, s.Subscriber.Type) {
+			catcher.Add(errors.Errorf("digests are not supported for '%s' subscribers", s.Subscriber.Type))
+		}
+	}
+	if s.Template != nil {
+		catcher.Add(errors.Wrap(s.Template.Validate(s.Type, s.Trigger, s.Subscriber.Type), "invalid message template"))
+	}
 	return catcher.Resolve()
 }
 
@@ -393,6 +416,8 @@ func CreateOrUpdateImplicitSubscription(subscriptionType string, id string,
 				temp = NewSpawnhostExpirationSubscription(user, subscriber)
 			case ImplicitSubscriptionSpawnHostOutcome:
 				temp = NewSpawnHostOutcomeByOwner(user, subscriber)
+			case ImplicitSubscriptionCommitQueue:
+				temp = NewCommitQueueSubscriptionByOwner(user, subscriber)
 			default:
 				return nil, errors.Errorf("unknown subscription type: %s", subscriptionType)
 			}
@@ -436,6 +461,10 @@ func NewPatchOutcomeSubscriptionByOwner(owner string, sub Subscriber) Subscripti
 	return NewSubscriptionByOwner(owner, sub, ResourceTypePatch, triggerOutcome)
 }
 
+func NewCommitQueueSubscriptionByOwner(owner string, sub Subscriber) Subscription {
+	return NewSubscriptionByOwner(owner, sub, ResourceTypeCommitQueue, triggerOutcome)
+}
+
 func NewBuildBreakSubscriptionByOwner(owner string, sub Subscriber) Subscription {
 	return Subscription{
 		ID:      bson.NewObjectId().Hex(),
@@ -30,6 +30,7 @@ const (
 	TaskPriorityChanged         = "TASK_PRIORITY_CHANGED"
 	TaskJiraAlertCreated        = "TASK_JIRA_ALERT_CREATED"
 	TaskDepdendenciesOverridden = "TASK_DEPENDENCIES_OVERRIDDEN"
+	TaskAutoRetried             = "TASK_AUTO_RETRIED"
 )
 
 // implements Data
@@ -40,6 +41,9 @@ type TaskEventData struct {
 	Status    string `bson:"s,omitempty" json:"status,omitempty"`
 	JiraIssue string `bson:"jira,omitempty" json:"jira,omitempty"`
 
+	// RetryReason is the kind of failure that caused an automatic retry
+	RetryReason string `bson:"rr,omitempty" json:"retry_reason,omitempty"`
+
 	Timestamp time.Time `bson:"ts,omitempty" json:"timestamp,omitempty"`
 	Priority  int64     `bson:"pri,omitempty" json:"priority,omitempty"`
 }
@@ -154,3 +158,15 @@ func LogTaskDependenciesOverridden(taskId string, execution int, userID string)
 	logTaskEvent(taskId, TaskDepdendenciesOverridden,
 		TaskEventData{Execution: execution, UserId: userID})
 }
+
+// LogTaskAutoRetried records that a task execution failed with a failure
+// covered by its retry policy and that a new execution was created. The
+// timestamp is the earliest time the new execution may be scheduled. It is
+// logged instead of the task finishing, since the task is not finished until
+// its retries are exhausted; the host still records that it finished the
+// execution.
+func LogTaskAutoRetried(taskId string, execution int, hostId, status, reason string, retryAfter time.Time) {
+	logTaskEvent(taskId, TaskAutoRetried,
+		TaskEventData{Execution: execution, Status: status, RetryReason: reason, Timestamp: retryAfter})
+	LogHostEvent(hostId, EventTaskFinished, HostEventData{TaskExecution: execution, TaskStatus: status, TaskId: taskId})
+}
@@ -0,0 +1,272 @@
+package flakytest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// Collection is the name of the flaky tests collection in the database.
+	Collection = "flaky_tests"
+
+	// DetectionWindow is how far back flaky test detection looks for
+	// finished tasks.
+	DetectionWindow = 14 * 24 * time.Hour
+
+	taskBatchSize = 500
+)
+
+// FlakyTest is the flakiness of a test in a task of a build variant, computed
+// over the mainline executions of the task that finished in the detection
+// window.
+type FlakyTest struct {
+	ID           string `bson:"_id" json:"id"`
+	Project      string `bson:"project" json:"project"`
+	BuildVariant string `bson:"build_variant" json:"build_variant"`
+	TaskName     string `bson:"task_name" json:"task_name"`
+	TestFile     string `bson:"test_file" json:"test_file"`
+
+	// Score is between 0 and 1, and is higher the more often the outcome
+	// of the test flips, both between consecutive runs and between the
+	// executions of the same revision.
+	Score float64 `bson:"score" json:"score"`
+
+	// Runs and Failures count the executions of the test that passed or
+	// failed; skipped tests are not counted.
+	Runs     int `bson:"runs" json:"runs"`
+	Failures int `bson:"failures" json:"failures"`
+	// Transitions is the number of times the outcome changed between
+	// consecutive runs.
+	Transitions int `bson:"transitions" json:"transitions"`
+	// Restarts is the number of runs that were reruns of a task.
+	Restarts int `bson:"restarts" json:"restarts"`
+	// Revisions is the number of revisions the test ran on, and
+	// FlippedRevisions the number of those on which it both passed and
+	// failed.
+	Revisions        int `bson:"revisions" json:"revisions"`
+	FlippedRevisions int `bson:"flipped_revisions" json:"flipped_revisions"`
+
+	LastFailingTask      string    `bson:"last_failing_task" json:"last_failing_task"`
+	LastFailingExecution int       `bson:"last_failing_execution" json:"last_failing_execution"`
+	LastFailingRevision  string    `bson:"last_failing_revision" json:"last_failing_revision"`
+	WindowStart          time.Time `bson:"window_start" json:"window_start"`
+	UpdatedAt            time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+var (
+	IDKey           = bsonutil.MustHaveTag(FlakyTest{}, "ID")
+	ProjectKey      = bsonutil.MustHaveTag(FlakyTest{}, "Project")
+	BuildVariantKey = bsonutil.MustHaveTag(FlakyTest{}, "BuildVariant")
+	TaskNameKey     = bsonutil.MustHaveTag(FlakyTest{}, "TaskName")
+	TestFileKey     = bsonutil.MustHaveTag(FlakyTest{}, "TestFile")
+	ScoreKey        = bsonutil.MustHaveTag(FlakyTest{}, "Score")
+)
+
+// Run is a single execution of a test.
+type Run struct {
+	TaskID    string
+	Execution int
+	Revision  string
+	Order     int
+	Status    string
+}
+
+func (r Run) failed() bool {
+	return r.Status == evergreen.TestFailedStatus || r.Status == evergreen.TestSilentlyFailedStatus
+}
+
+func (r Run) counts() bool {
+	return r.failed() || r.Status == evergreen.TestSucceededStatus
+}
+
+// NewFlakyTest scores the runs of a test. The score is the mean of the
+// fraction of consecutive runs whose outcome differs and the fraction of
+// rerun revisions on which the test both passed and failed, so a test that
+// was never rerun scores at most 0.5.
+func NewFlakyTest(project, variant, taskName, testFile string, runs []Run) FlakyTest {
+	ft := FlakyTest{
+		ID:           fmt.Sprintf("%s|%s|%s|%s", project, variant, taskName, testFile),
+		Project:      project,
+		BuildVariant: variant,
+		TaskName:     taskName,
+		TestFile:     testFile,
+	}
+
+	counted := make([]Run, 0, len(runs))
+	for _, r := range runs {
+		if r.counts() {
+			counted = append(counted, r)
+		}
+	}
+	sort.SliceStable(counted, func(i, j int) bool {
+		if counted[i].Order != counted[j].Order {
+			return counted[i].Order < counted[j].Order
+		}
+		return counted[i].Execution < counted[j].Execution
+	})
+
+	type revisionRuns struct {
+		executions int
+		passed     bool
+		failed     bool
+	}
+	revisions := map[string]*revisionRuns{}
+	for i, r := range counted {
+		ft.Runs++
+		if r.Execution > 0 {
+			ft.Restarts++
+		}
+		if r.failed() {
+			ft.Failures++
+			ft.LastFailingTask = r.TaskID
+			ft.LastFailingExecution = r.Execution
+			ft.LastFailingRevision = r.Revision
+		}
+		if i > 0 && r.failed() != counted[i-1].failed() {
+			ft.Transitions++
+		}
+
+		rev, ok := revisions[r.Revision]
+		if !ok {
+			rev = &revisionRuns{}
+			revisions[r.Revision] = rev
+		}
+		rev.executions++
+		rev.passed = rev.passed || !r.failed()
+		rev.failed = rev.failed || r.failed()
+	}
+
+	rerunRevisions := 0
+	for _, rev := range revisions {
+		if rev.executions > 1 {
+			rerunRevisions++
+		}
+		if rev.passed && rev.failed {
+			ft.FlippedRevisions++
+		}
+	}
+	ft.Revisions = len(revisions)
+
+	if ft.Runs > 1 {
+		ft.Score += float64(ft.Transitions) / float64(ft.Runs-1) / 2
+	}
+	if rerunRevisions > 0 {
+		ft.Score += float64(ft.FlippedRevisions) / float64(rerunRevisions) / 2
+	}
+
+	return ft
+}
+
+// IsFlaky returns true if the test failed at least once and did not fail
+// consistently.
+func (ft *FlakyTest) IsFlaky() bool {
+	return ft.Failures > 0 && ft.Score > 0
+}
+
+// Detect computes the flakiness of the tests of the mainline tasks of a
+// project that finished since the given time, returning only flaky tests.
+func Detect(project string, since time.Time) ([]FlakyTest, error) {
+	tasks, err := task.Find(task.ByRecentlyFinished(since, project, evergreen.RepotrackerVersionRequester).WithFields(
+		task.IdKey, task.DisplayNameKey, task.BuildVariantKey, task.RevisionKey,
+		task.RevisionOrderNumberKey, task.ExecutionKey, task.DisplayOnlyKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding recent tasks for project '%s'", project)
+	}
+
+	tasksByID := make(map[string]task.Task, len(tasks))
+	taskIDs := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		tasksByID[t.Id] = t
+		taskIDs = append(taskIDs, t.Id)
+	}
+
+	type testKey struct {
+		variant  string
+		taskName string
+		testFile string
+	}
+	runs := map[testKey][]Run{}
+	for start := 0; start < len(taskIDs); start += taskBatchSize {
+		end := util.Min(start+taskBatchSize, len(taskIDs))
+		results, err := testresult.Find(testresult.ByTaskIDs(taskIDs[start:end]).WithFields(
+			testresult.TaskIDKey, testresult.ExecutionKey, testresult.TestFileKey, testresult.StatusKey))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem finding test results for project '%s'", project)
+		}
+
+		for _, res := range results {
+			t, ok := tasksByID[res.TaskID]
+			if !ok {
+				continue
+			}
+			key := testKey{variant: t.BuildVariant, taskName: t.DisplayName, testFile: res.TestFile}
+			runs[key] = append(runs[key], Run{
+				TaskID:    res.TaskID,
+				Execution: res.Execution,
+				Revision:  t.Revision,
+				Order:     t.RevisionOrderNumber,
+				Status:    res.Status,
+			})
+		}
+	}
+
+	now := time.Now()
+	flaky := []FlakyTest{}
+	for key, testRuns := range runs {
+		ft := NewFlakyTest(project, key.variant, key.taskName, key.testFile, testRuns)
+		if !ft.IsFlaky() {
+			continue
+		}
+		ft.WindowStart = since
+		ft.UpdatedAt = now
+		flaky = append(flaky, ft)
+	}
+
+	return flaky, nil
+}
+
+// ReplaceForProject replaces the stored flaky tests of a project.
+func ReplaceForProject(project string, tests []FlakyTest) error {
+	if err := db.RemoveAll(Collection, bson.M{ProjectKey: project}); err != nil {
+		return errors.Wrapf(err, "problem removing flaky tests for project '%s'", project)
+	}
+	if len(tests) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(tests))
+	for idx := range tests {
+		docs[idx] = tests[idx]
+	}
+	return errors.Wrapf(db.InsertMany(Collection, docs...), "problem inserting flaky tests for project '%s'", project)
+}
+
+// ByProject returns a query for the flaky tests of a project with at least
+// the given score, from the flakiest down. The variant is optional.
+func ByProject(project, variant string, minScore float64) db.Q {
+	q := bson.M{
+		ProjectKey: project,
+		ScoreKey:   bson.M{"$gte": minScore},
+	}
+	if variant != "" {
+		q[BuildVariantKey] = variant
+	}
+	return db.Query(q).Sort([]string{"-" + ScoreKey, BuildVariantKey, TaskNameKey, TestFileKey})
+}
+
+// Find returns all flaky tests that satisfy the query.
+func Find(query db.Q) ([]FlakyTest, error) {
+	tests := []FlakyTest{}
+	err := db.FindAllQ(Collection, query, &tests)
+	return tests, errors.WithStack(err)
+}
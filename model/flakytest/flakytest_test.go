@@ -0,0 +1,152 @@
+package flakytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func TestNewFlakyTest(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("ConsistentlyPassing", func(t *testing.T) {
+		ft := NewFlakyTest("p", "bv", "t", "f", []Run{
+			{TaskID: "t1", Revision: "r1", Order: 1, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t2", Revision: "r2", Order: 2, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t3", Revision: "r3", Order: 3, Status: evergreen.TestSucceededStatus},
+		})
+		assert.Equal(3, ft.Runs)
+		assert.Zero(ft.Failures)
+		assert.Zero(ft.Score)
+		assert.False(ft.IsFlaky())
+	})
+
+	t.Run("ConsistentlyFailing", func(t *testing.T) {
+		ft := NewFlakyTest("p", "bv", "t", "f", []Run{
+			{TaskID: "t1", Revision: "r1", Order: 1, Status: evergreen.TestFailedStatus},
+			{TaskID: "t1", Revision: "r1", Order: 1, Execution: 1, Status: evergreen.TestFailedStatus},
+			{TaskID: "t2", Revision: "r2", Order: 2, Status: evergreen.TestFailedStatus},
+		})
+		assert.Equal(3, ft.Failures)
+		assert.Equal(1, ft.Restarts)
+		assert.Zero(ft.Score)
+		assert.False(ft.IsFlaky())
+	})
+
+	t.Run("Regression", func(t *testing.T) {
+		ft := NewFlakyTest("p", "bv", "t", "f", []Run{
+			{TaskID: "t4", Revision: "r4", Order: 4, Status: evergreen.TestFailedStatus},
+			{TaskID: "t3", Revision: "r3", Order: 3, Status: evergreen.TestFailedStatus},
+			{TaskID: "t2", Revision: "r2", Order: 2, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t1", Revision: "r1", Order: 1, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t5", Revision: "r5", Order: 5, Status: evergreen.TestFailedStatus},
+		})
+		assert.Equal(1, ft.Transitions)
+		assert.Zero(ft.FlippedRevisions)
+		assert.InDelta(0.125, ft.Score, 0.0001)
+		assert.Equal("t5", ft.LastFailingTask)
+		assert.Equal("r5", ft.LastFailingRevision)
+	})
+
+	t.Run("FlipsOnRerun", func(t *testing.T) {
+		ft := NewFlakyTest("p", "bv", "t", "f", []Run{
+			{TaskID: "t1", Revision: "r1", Order: 1, Execution: 1, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t1", Revision: "r1", Order: 1, Execution: 0, Status: evergreen.TestFailedStatus},
+			{TaskID: "t2", Revision: "r2", Order: 2, Execution: 0, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t2", Revision: "r2", Order: 2, Execution: 1, Status: evergreen.TestSkippedStatus},
+		})
+		assert.Equal(3, ft.Runs)
+		assert.Equal(1, ft.Restarts)
+		assert.Equal(1, ft.Transitions)
+		assert.Equal(2, ft.Revisions)
+		assert.Equal(1, ft.FlippedRevisions)
+		assert.InDelta(0.75, ft.Score, 0.0001)
+		assert.Equal("t1", ft.LastFailingTask)
+		assert.Equal(0, ft.LastFailingExecution)
+		assert.True(ft.IsFlaky())
+	})
+
+	t.Run("Alternating", func(t *testing.T) {
+		ft := NewFlakyTest("p", "bv", "t", "f", []Run{
+			{TaskID: "t1", Revision: "r1", Order: 1, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t2", Revision: "r2", Order: 2, Status: evergreen.TestSilentlyFailedStatus},
+			{TaskID: "t3", Revision: "r3", Order: 3, Status: evergreen.TestSucceededStatus},
+			{TaskID: "t4", Revision: "r4", Order: 4, Status: evergreen.TestFailedStatus},
+		})
+		assert.Equal(2, ft.Failures)
+		assert.Equal(3, ft.Transitions)
+		assert.InDelta(0.5, ft.Score, 0.0001)
+	})
+}
+
+func TestDetectAndReplace(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	require.NoError(db.ClearCollections(Collection, task.Collection, testresult.Collection))
+
+	since := time.Now().Add(-time.Hour)
+	finished := time.Now().Add(-time.Minute)
+	tasks := []task.Task{
+		{Id: "t1", Project: "proj", BuildVariant: "bv", DisplayName: "unit", Revision: "r1", RevisionOrderNumber: 1,
+			Execution: 1, Status: evergreen.TaskSucceeded, FinishTime: finished, Requester: evergreen.RepotrackerVersionRequester},
+		{Id: "t2", Project: "proj", BuildVariant: "bv", DisplayName: "unit", Revision: "r2", RevisionOrderNumber: 2,
+			Status: evergreen.TaskFailed, FinishTime: finished, Requester: evergreen.RepotrackerVersionRequester},
+		{Id: "t3", Project: "proj", BuildVariant: "bv", DisplayName: "unit", Revision: "r3", RevisionOrderNumber: 3,
+			Status: evergreen.TaskFailed, FinishTime: finished, Requester: evergreen.PatchVersionRequester},
+		{Id: "t4", Project: "other", BuildVariant: "bv", DisplayName: "unit", Revision: "r1", RevisionOrderNumber: 1,
+			Status: evergreen.TaskFailed, FinishTime: finished, Requester: evergreen.RepotrackerVersionRequester},
+	}
+	for _, t := range tasks {
+		require.NoError(t.Insert())
+	}
+	results := []testresult.TestResult{
+		{TaskID: "t1", Execution: 0, TestFile: "flaky", Status: evergreen.TestFailedStatus},
+		{TaskID: "t1", Execution: 1, TestFile: "flaky", Status: evergreen.TestSucceededStatus},
+		{TaskID: "t1", Execution: 0, TestFile: "stable", Status: evergreen.TestSucceededStatus},
+		{TaskID: "t1", Execution: 1, TestFile: "stable", Status: evergreen.TestSucceededStatus},
+		{TaskID: "t2", Execution: 0, TestFile: "flaky", Status: evergreen.TestFailedStatus},
+		{TaskID: "t2", Execution: 0, TestFile: "stable", Status: evergreen.TestSucceededStatus},
+		{TaskID: "t3", Execution: 0, TestFile: "stable", Status: evergreen.TestFailedStatus},
+		{TaskID: "t4", Execution: 0, TestFile: "flaky", Status: evergreen.TestFailedStatus},
+	}
+	require.NoError(testresult.InsertMany(results))
+
+	flaky, err := Detect("proj", since)
+	require.NoError(err)
+	require.Len(flaky, 1)
+	assert.Equal("bv", flaky[0].BuildVariant)
+	assert.Equal("unit", flaky[0].TaskName)
+	assert.Equal("flaky", flaky[0].TestFile)
+	assert.Equal(3, flaky[0].Runs)
+	assert.Equal(1, flaky[0].FlippedRevisions)
+
+	require.NoError(ReplaceForProject("proj", flaky))
+	require.NoError(ReplaceForProject("other", []FlakyTest{{ID: "other", Project: "other", Score: 1}}))
+	stored, err := Find(ByProject("proj", "", 0))
+	require.NoError(err)
+	require.Len(stored, 1)
+	assert.Equal(flaky[0].ID, stored[0].ID)
+
+	stored, err = Find(ByProject("proj", "bv", 0.9))
+	require.NoError(err)
+	assert.Empty(stored)
+
+	require.NoError(ReplaceForProject("proj", nil))
+	stored, err = Find(ByProject("proj", "", 0))
+	require.NoError(err)
+	assert.Empty(stored)
+	stored, err = Find(ByProject("other", "", 0))
+	require.NoError(err)
+	assert.Len(stored, 1)
+}
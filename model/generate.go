@@ -2,6 +2,7 @@ package model
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/build"
@@ -154,6 +155,42 @@ func (g *GeneratedProject) Save(p *Project, v *version.Version, t *task.Task, pm
 	return nil
 }
 
+// GeneratedVariant is a build variant that a generated project adds tasks
+// to, and whether the build variant itself is new to the project.
+type GeneratedVariant struct {
+	Name         string
+	New          bool
+	Tasks        []string
+	DisplayTasks []string
+}
+
+// Additions returns the build variants and tasks that the generated project
+// adds to a project, where p is the project with the generated project
+// merged in and cachedProject is the project as it was before. Task groups
+// are expanded into their tasks.
+func (g *GeneratedProject) Additions(p *Project, cachedProject *projectMaps) []GeneratedVariant {
+	variants := []GeneratedVariant{}
+	for _, bv := range g.BuildVariants {
+		_, exists := cachedProject.buildVariants[bv.Name]
+		pairs := appendTasks(TaskVariantPairs{}, bv, p)
+		variant := GeneratedVariant{
+			Name:         bv.Name,
+			New:          !exists,
+			Tasks:        []string{},
+			DisplayTasks: []string{},
+		}
+		for _, pair := range pairs.ExecTasks {
+			variant.Tasks = append(variant.Tasks, pair.TaskName)
+		}
+		for _, pair := range pairs.DisplayTasks {
+			variant.DisplayTasks = append(variant.DisplayTasks, pair.TaskName)
+		}
+		variants = append(variants, variant)
+	}
+	sort.Slice(variants, func(i, j int) bool { return variants[i].Name < variants[j].Name })
+	return variants
+}
+
 func cacheProjectData(p *Project) projectMaps {
 	cachedProject := projectMaps{
 		buildVariants: map[string]struct{}{},
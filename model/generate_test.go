@@ -8,6 +8,8 @@ import (
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/mongodb/grip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -491,3 +493,61 @@ func (s *GenerateSuite) TestSaveNewBuildsAndTasks() {
 	s.Len(builds, 3)
 	s.Len(tasks, 6)
 }
+
+func TestGeneratedProjectAdditions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := `
+tasks:
+  - name: generator
+    commands:
+      - command: generate.tasks
+buildvariants:
+  - name: linux
+    run_on:
+      - ubuntu1604-test
+    tasks:
+      - name: generator
+`
+	p := &Project{}
+	require.NoError(LoadProjectInto([]byte(config), "proj", p))
+	cachedProject := cacheProjectData(p)
+
+	g, err := ParseProjectFromJSON([]byte(`
+tasks:
+  - name: lint
+  - name: test_a
+  - name: test_b
+task_groups:
+  - name: tests
+    tasks:
+      - test_a
+      - test_b
+buildvariants:
+  - name: linux
+    tasks:
+      - name: lint
+  - name: windows
+    run_on:
+      - windows-64-vs2015-test
+    tasks:
+      - name: tests
+    display_tasks:
+      - name: all_tests
+        execution_tasks:
+          - test_a
+          - test_b
+`))
+	require.NoError(err)
+	require.NoError(g.validateGeneratedProject(p, cachedProject))
+
+	merged, err := g.addGeneratedProjectToConfig(config, cachedProject)
+	require.NoError(err)
+	require.NoError(LoadProjectInto([]byte(merged), "proj", p))
+
+	assert.Equal([]GeneratedVariant{
+		{Name: "linux", New: false, Tasks: []string{"lint"}, DisplayTasks: []string{}},
+		{Name: "windows", New: true, Tasks: []string{"test_a", "test_b"}, DisplayTasks: []string{"all_tests"}},
+	}, g.Additions(p, &cachedProject))
+}
@@ -329,6 +329,16 @@ func ByRunningTaskId(taskId string) db.Q {
 	return db.Query(bson.D{{Name: RunningTaskKey, Value: taskId}})
 }
 
+// ByRunningTaskProjects returns the running hosts that are running a task of
+// any of the given projects.
+func ByRunningTaskProjects(projects []string) db.Q {
+	return db.Query(bson.M{
+		StatusKey:             evergreen.HostRunning,
+		RunningTaskKey:        bson.M{"$exists": true},
+		RunningTaskProjectKey: bson.M{"$in": projects},
+	})
+}
+
 // ByDynamicWithinTime is a query that returns all dynamic hosts running between a certain time and another time.
 func ByDynamicWithinTime(startTime, endTime time.Time) db.Q {
 	return db.Query(
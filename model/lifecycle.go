@@ -449,6 +449,10 @@ func CreateBuildFromVersion(project *Project, v *version.Version, taskIds TaskId
 	rev := v.Revision
 	if evergreen.IsPatchRequester(v.Requester) {
 		rev = fmt.Sprintf("patch_%s_%s", v.Revision, v.Id)
+	} else if v.Requester == evergreen.PeriodicBuildRequester {
+		rev = fmt.Sprintf("periodic_%s_%s", v.Revision, v.Id)
+	} else if v.Requester == evergreen.TriggerRequester {
+		rev = fmt.Sprintf("trigger_%s_%s", v.Revision, v.Id)
 	}
 
 	// create a new build id
@@ -21,11 +21,14 @@ const (
 
 //nolint: deadcode, megacheck
 var (
-	idKey         = bsonutil.MustHaveTag(Notification{}, "ID")
-	subscriberKey = bsonutil.MustHaveTag(Notification{}, "Subscriber")
-	payloadKey    = bsonutil.MustHaveTag(Notification{}, "Payload")
-	sentAtKey     = bsonutil.MustHaveTag(Notification{}, "SentAt")
-	errorKey      = bsonutil.MustHaveTag(Notification{}, "Error")
+	idKey          = bsonutil.MustHaveTag(Notification{}, "ID")
+	subscriberKey  = bsonutil.MustHaveTag(Notification{}, "Subscriber")
+	payloadKey     = bsonutil.MustHaveTag(Notification{}, "Payload")
+	sentAtKey      = bsonutil.MustHaveTag(Notification{}, "SentAt")
+	errorKey       = bsonutil.MustHaveTag(Notification{}, "Error")
+	digestKey      = bsonutil.MustHaveTag(Notification{}, "Digest")
+	attemptsKey    = bsonutil.MustHaveTag(Notification{}, "Attempts")
+	nextAttemptKey = bsonutil.MustHaveTag(Notification{}, "NextAttempt")
 )
 
 type unmarshalNotification struct {
@@ -33,8 +36,12 @@ type unmarshalNotification struct {
 	Subscriber event.Subscriber `bson:"subscriber"`
 	Payload    bson.Raw         `bson:"payload"`
 
-	SentAt time.Time `bson:"sent_at,omitempty"`
-	Error  string    `bson:"error,omitempty"`
+	SentAt time.Time   `bson:"sent_at,omitempty"`
+	Error  string      `bson:"error,omitempty"`
+	Digest *DigestItem `bson:"digest,omitempty"`
+
+	Attempts    int       `bson:"attempts,omitempty"`
+	NextAttempt time.Time `bson:"next_attempt,omitempty"`
 }
 
 func (n *Notification) SetBSON(raw bson.Raw) error {
@@ -60,6 +67,12 @@ func (n *Notification) SetBSON(raw bson.Raw) error {
 	case event.SlackSubscriberType:
 		n.Payload = &SlackPayload{}
 
+	case event.TeamsSubscriberType:
+		n.Payload = &TeamsPayload{}
+
+	case event.MattermostSubscriberType:
+		n.Payload = &MattermostPayload{}
+
 	case event.GithubPullRequestSubscriberType:
 		n.Payload = &message.GithubStatus{}
 
@@ -75,6 +88,9 @@ func (n *Notification) SetBSON(raw bson.Raw) error {
 	n.Subscriber = temp.Subscriber
 	n.SentAt = temp.SentAt
 	n.Error = temp.Error
+	n.Digest = temp.Digest
+	n.Attempts = temp.Attempts
+	n.NextAttempt = temp.NextAttempt
 
 	return nil
 }
@@ -0,0 +1,192 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// DeadLetterCollection holds the webhook notifications that failed to be
+	// sent on every attempt.
+	DeadLetterCollection = "notifications_dead_letter"
+)
+
+// DeadLetter is a webhook notification that ran out of retries. Its ID is
+// the ID of the notification.
+type DeadLetter struct {
+	ID         string                `bson:"_id"`
+	Subscriber event.Subscriber      `bson:"subscriber"`
+	Payload    util.EvergreenWebhook `bson:"payload"`
+	Attempts   int                   `bson:"attempts"`
+	Error      string                `bson:"error"`
+	CreatedAt  time.Time             `bson:"created_at"`
+}
+
+var (
+	deadLetterIDKey        = bsonutil.MustHaveTag(DeadLetter{}, "ID")
+	deadLetterCreatedAtKey = bsonutil.MustHaveTag(DeadLetter{}, "CreatedAt")
+)
+
+// MarkRetry records a failed attempt to send the notification, and when to
+// send it again.
+func (n *Notification) MarkRetry(sendErr error, attempts int, next time.Time) error {
+	if len(n.ID) == 0 {
+		return errors.New("notification has no ID")
+	}
+	if sendErr == nil {
+		return errors.New("cannot retry a notification without an error")
+	}
+
+	next = next.Truncate(time.Millisecond)
+	update := bson.M{
+		"$set": bson.M{
+			errorKey:       sendErr.Error(),
+			attemptsKey:    attempts,
+			nextAttemptKey: next,
+		},
+	}
+	if err := db.UpdateId(Collection, n.ID, update); err != nil {
+		return errors.Wrap(err, "failed to schedule retry of notification")
+	}
+	n.Error = sendErr.Error()
+	n.Attempts = attempts
+	n.NextAttempt = next
+
+	return nil
+}
+
+// ClearRetry unsets the retry time and error of a notification that is about
+// to be sent again.
+func (n *Notification) ClearRetry() error {
+	if len(n.ID) == 0 {
+		return errors.New("notification has no ID")
+	}
+
+	update := bson.M{
+		"$unset": bson.M{
+			errorKey:       1,
+			nextAttemptKey: 1,
+		},
+	}
+	if err := db.UpdateId(Collection, n.ID, update); err != nil {
+		return errors.Wrap(err, "failed to clear retry of notification")
+	}
+	n.Error = ""
+	n.NextAttempt = time.Time{}
+
+	return nil
+}
+
+// FindRetryableNotifications returns the notifications that are due to be
+// sent again.
+func FindRetryableNotifications(now time.Time) ([]Notification, error) {
+	notifications := []Notification{}
+	err := db.FindAllQ(Collection, db.Query(bson.M{
+		nextAttemptKey: bson.M{"$lte": now},
+	}), &notifications)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find notifications to retry")
+	}
+
+	return notifications, nil
+}
+
+// MoveToDeadLetter stops retrying a webhook notification, and saves it in the
+// dead-letter collection, where it can be inspected and replayed.
+func (n *Notification) MoveToDeadLetter(sendErr error, attempts int) error {
+	if len(n.ID) == 0 {
+		return errors.New("notification has no ID")
+	}
+	if sendErr == nil {
+		return errors.New("cannot dead-letter a notification without an error")
+	}
+	payload, ok := n.Payload.(*util.EvergreenWebhook)
+	if !ok {
+		return errors.Errorf("cannot dead-letter notification '%s' with payload of type %T", n.ID, n.Payload)
+	}
+
+	letter := DeadLetter{
+		ID:         n.ID,
+		Subscriber: n.Subscriber,
+		Payload:    *payload,
+		Attempts:   attempts,
+		Error:      sendErr.Error(),
+		CreatedAt:  time.Now().Truncate(time.Millisecond),
+	}
+	if _, err := db.Upsert(DeadLetterCollection, bson.M{deadLetterIDKey: n.ID}, letter); err != nil {
+		return errors.Wrap(err, "failed to save dead letter")
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			errorKey:    sendErr.Error(),
+			attemptsKey: attempts,
+		},
+		"$unset": bson.M{
+			nextAttemptKey: 1,
+		},
+	}
+	if err := db.UpdateId(Collection, n.ID, update); err != nil {
+		return errors.Wrap(err, "failed to update dead-lettered notification")
+	}
+	n.Error = sendErr.Error()
+	n.Attempts = attempts
+	n.NextAttempt = time.Time{}
+
+	return nil
+}
+
+// FindDeadLetters returns the newest dead letters, up to the limit. A limit
+// of zero returns all of them.
+func FindDeadLetters(limit int) ([]DeadLetter, error) {
+	letters := []DeadLetter{}
+	q := db.Query(bson.M{}).Sort([]string{"-" + deadLetterCreatedAtKey}).Limit(limit)
+	if err := db.FindAllQ(DeadLetterCollection, q, &letters); err != nil {
+		return nil, errors.Wrap(err, "failed to find dead letters")
+	}
+
+	return letters, nil
+}
+
+// FindDeadLetter returns the dead letter of a notification, or nil if there
+// is none.
+func FindDeadLetter(id string) (*DeadLetter, error) {
+	letter := DeadLetter{}
+	err := db.FindOneQ(DeadLetterCollection, db.Query(bson.M{deadLetterIDKey: id}), &letter)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find dead letter")
+	}
+
+	return &letter, nil
+}
+
+// Replay removes a notification from the dead-letter collection, and
+// schedules it to be sent again with a fresh set of attempts.
+func (l *DeadLetter) Replay(now time.Time) error {
+	_, err := db.Upsert(Collection, bson.M{idKey: l.ID}, bson.M{
+		"$set": bson.M{
+			subscriberKey:  l.Subscriber,
+			payloadKey:     l.Payload,
+			attemptsKey:    0,
+			nextAttemptKey: now.Truncate(time.Millisecond),
+		},
+		"$unset": bson.M{
+			errorKey: 1,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to reschedule notification")
+	}
+
+	return errors.Wrap(db.Remove(DeadLetterCollection, bson.M{deadLetterIDKey: l.ID}), "failed to remove dead letter")
+}
@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DigestItem summarises the event of a notification that is waiting to be
+// sent as part of a digest, with enough detail to group it by version and
+// variant.
+type DigestItem struct {
+	SubscriptionID string    `bson:"subscription_id"`
+	Object         string    `bson:"object"`
+	ID             string    `bson:"id"`
+	DisplayName    string    `bson:"display_name"`
+	Project        string    `bson:"project"`
+	Version        string    `bson:"version"`
+	Revision       string    `bson:"revision,omitempty"`
+	BuildVariant   string    `bson:"build_variant,omitempty"`
+	Status         string    `bson:"status"`
+	URL            string    `bson:"url"`
+	CreatedAt      time.Time `bson:"created_at"`
+	// SentIn is the ID of the digest notification that the item was sent in.
+	SentIn string `bson:"sent_in,omitempty"`
+}
+
+var (
+	digestItemSubscriptionIDKey = bsonutil.MustHaveTag(DigestItem{}, "SubscriptionID")
+	digestItemCreatedAtKey      = bsonutil.MustHaveTag(DigestItem{}, "CreatedAt")
+	digestItemSentInKey         = bsonutil.MustHaveTag(DigestItem{}, "SentIn")
+)
+
+func pendingDigestQuery() bson.M {
+	return bson.M{
+		digestKey: bson.M{"$exists": true},
+		sentAtKey: time.Time{},
+	}
+}
+
+// FindPendingDigestSubscriptions returns the IDs of the subscriptions that
+// have notifications waiting to be sent in a digest.
+func FindPendingDigestSubscriptions() ([]string, error) {
+	pipeline := []bson.M{
+		{"$match": pendingDigestQuery()},
+		{"$group": bson.M{"_id": "$" + bsonutil.GetDottedKeyName(digestKey, digestItemSubscriptionIDKey)}},
+	}
+
+	out := []struct {
+		ID string `bson:"_id"`
+	}{}
+	if err := db.Aggregate(Collection, pipeline, &out); err != nil {
+		return nil, errors.Wrap(err, "failed to find subscriptions with pending digests")
+	}
+
+	ids := make([]string, 0, len(out))
+	for _, doc := range out {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+// FindPendingDigest returns the notifications of a subscription that are
+// waiting to be sent in a digest, oldest first.
+func FindPendingDigest(subscriptionID string) ([]Notification, error) {
+	query := pendingDigestQuery()
+	query[bsonutil.GetDottedKeyName(digestKey, digestItemSubscriptionIDKey)] = subscriptionID
+
+	notifications := []Notification{}
+	err := db.FindAllQ(Collection, db.Query(query).Sort([]string{bsonutil.GetDottedKeyName(digestKey, digestItemCreatedAtKey)}), &notifications)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find pending digest for subscription '%s'", subscriptionID)
+	}
+	return notifications, nil
+}
+
+// MarkDigested marks notifications as sent, since they were summarised in
+// the digest notification with the given ID.
+func MarkDigested(digestID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := db.UpdateAll(Collection, bson.M{
+		idKey: bson.M{"$in": ids},
+	}, bson.M{
+		"$set": bson.M{
+			sentAtKey: time.Now().Truncate(time.Millisecond),
+			bsonutil.GetDottedKeyName(digestKey, digestItemSentInKey): digestID,
+		},
+	})
+	return errors.Wrapf(err, "failed to mark notifications as sent in digest '%s'", digestID)
+}
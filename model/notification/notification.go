@@ -1,6 +1,7 @@
 package notification
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -53,6 +54,15 @@ type Notification struct {
 
 	SentAt time.Time `bson:"sent_at"`
 	Error  string    `bson:"error,omitempty"`
+
+	// Digest is set on notifications for subscriptions with a digest, which
+	// are sent as part of a digest rather than on their own.
+	Digest *DigestItem `bson:"digest,omitempty"`
+
+	// Attempts is the number of times sending a webhook notification has
+	// failed, and NextAttempt is when it will be sent again, if it's set.
+	Attempts    int       `bson:"attempts,omitempty"`
+	NextAttempt time.Time `bson:"next_attempt,omitempty"`
 }
 
 // SenderKey returns an evergreen.SenderKey to get a grip sender for this
@@ -74,6 +84,12 @@ func (n *Notification) SenderKey() (evergreen.SenderKey, error) {
 	case event.SlackSubscriberType:
 		return evergreen.SenderSlack, nil
 
+	case event.TeamsSubscriberType:
+		return evergreen.SenderTeams, nil
+
+	case event.MattermostSubscriberType:
+		return evergreen.SenderMattermost, nil
+
 	case event.GithubPullRequestSubscriberType:
 		return evergreen.SenderGithubStatus, nil
 
@@ -159,6 +175,42 @@ func (n *Notification) Composer() (message.Composer, error) {
 
 		return message.NewSlackMessage(level.Notice, *sub, payload.Body, payload.Attachments), nil
 
+	case event.TeamsSubscriberType:
+		sub, ok := n.Subscriber.Target.(*string)
+		if !ok {
+			return nil, errors.New("teams subscriber is invalid")
+		}
+
+		payload, ok := n.Payload.(*TeamsPayload)
+		if !ok || payload == nil {
+			return nil, errors.New("teams payload is invalid")
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal teams payload")
+		}
+
+		return util.NewChatWebhookMessage(*sub, body), nil
+
+	case event.MattermostSubscriberType:
+		sub, ok := n.Subscriber.Target.(*string)
+		if !ok {
+			return nil, errors.New("mattermost subscriber is invalid")
+		}
+
+		payload, ok := n.Payload.(*MattermostPayload)
+		if !ok || payload == nil {
+			return nil, errors.New("mattermost payload is invalid")
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal mattermost payload")
+		}
+
+		return util.NewChatWebhookMessage(*sub, body), nil
+
 	case event.GithubPullRequestSubscriberType:
 		sub := n.Subscriber.Target.(*event.GithubPullRequestSubscriber)
 		payload, ok := n.Payload.(*message.GithubStatus)
@@ -232,6 +284,8 @@ type NotificationStats struct {
 	EvergreenWebhook  int `json:"evergreen_webhook" bson:"evergreen_webhook" yaml:"evergreen_webhook"`
 	Email             int `json:"email" bson:"email" yaml:"email"`
 	Slack             int `json:"slack" bson:"slack" yaml:"slack"`
+	Teams             int `json:"teams" bson:"teams" yaml:"teams"`
+	Mattermost        int `json:"mattermost" bson:"mattermost" yaml:"mattermost"`
 }
 
 func CollectUnsentNotificationStats() (*NotificationStats, error) {
@@ -285,6 +339,12 @@ func CollectUnsentNotificationStats() (*NotificationStats, error) {
 		case event.SlackSubscriberType:
 			nStats.Slack = data.Count
 
+		case event.TeamsSubscriberType:
+			nStats.Teams = data.Count
+
+		case event.MattermostSubscriberType:
+			nStats.Mattermost = data.Count
+
 		default:
 			grip.Error(message.Fields{
 				"message": fmt.Sprintf("unknown subscriber %s", data.Key),
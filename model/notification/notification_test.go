@@ -30,7 +30,7 @@ func (s *notificationSuite) SetupSuite() {
 }
 
 func (s *notificationSuite) SetupTest() {
-	s.NoError(db.Clear(Collection))
+	s.NoError(db.ClearCollections(Collection, DeadLetterCollection))
 	s.n = Notification{
 		Subscriber: event.Subscriber{
 			Type: event.GithubPullRequestSubscriberType,
@@ -205,6 +205,71 @@ func (s *notificationSuite) TestWebhookPayload() {
 	s.True(c.Loggable())
 }
 
+func (s *notificationSuite) TestWebhookRetryAndDeadLetter() {
+	s.n.ID = "1"
+	s.n.Subscriber.Type = event.EvergreenWebhookSubscriberType
+	s.n.Subscriber.Target = event.WebhookSubscriber{
+		URL:    "https://example.com",
+		Secret: []byte("secret"),
+	}
+	s.n.Payload = &util.EvergreenWebhook{
+		NotificationID: "1",
+		URL:            "https://example.com",
+		Secret:         []byte("secret"),
+		Body:           []byte(`{"iama": "potato"}`),
+	}
+	s.NoError(InsertMany(s.n))
+
+	now := time.Now().Truncate(time.Millisecond)
+	s.Error(s.n.MarkRetry(nil, 1, now))
+	s.NoError(s.n.MarkRetry(errors.New("500"), 1, now.Add(time.Minute)))
+
+	retryable, err := FindRetryableNotifications(now)
+	s.NoError(err)
+	s.Empty(retryable)
+	retryable, err = FindRetryableNotifications(now.Add(time.Minute))
+	s.NoError(err)
+	s.Require().Len(retryable, 1)
+	s.Equal(1, retryable[0].Attempts)
+	s.Equal("500", retryable[0].Error)
+
+	s.NoError(retryable[0].ClearRetry())
+	n, err := Find(s.n.ID)
+	s.NoError(err)
+	s.Require().NotNil(n)
+	s.Zero(n.NextAttempt)
+	s.Empty(n.Error)
+	s.Equal(1, n.Attempts)
+
+	s.NoError(n.MoveToDeadLetter(errors.New("502"), 2))
+	letters, err := FindDeadLetters(0)
+	s.NoError(err)
+	s.Require().Len(letters, 1)
+	s.Equal(s.n.ID, letters[0].ID)
+	s.Equal(2, letters[0].Attempts)
+	s.Equal("502", letters[0].Error)
+	s.Equal(`{"iama": "potato"}`, string(letters[0].Payload.Body))
+	retryable, err = FindRetryableNotifications(now.Add(time.Hour))
+	s.NoError(err)
+	s.Empty(retryable)
+
+	s.NoError(letters[0].Replay(now))
+	letter, err := FindDeadLetter(s.n.ID)
+	s.NoError(err)
+	s.Nil(letter)
+	retryable, err = FindRetryableNotifications(now)
+	s.NoError(err)
+	s.Require().Len(retryable, 1)
+	s.Zero(retryable[0].Attempts)
+	s.Empty(retryable[0].Error)
+	s.Equal(`{"iama": "potato"}`, string(retryable[0].Payload.(*util.EvergreenWebhook).Body))
+
+	// only webhooks can be dead-lettered
+	s.n.ID = "2"
+	s.n.Payload = "not a webhook"
+	s.Error(s.n.MoveToDeadLetter(errors.New("502"), 2))
+}
+
 func (s *notificationSuite) TestJIRACommentPayload() {
 	s.n.ID = "1"
 	s.n.Subscriber.Type = event.JIRACommentSubscriberType
@@ -318,6 +383,58 @@ func (s *notificationSuite) TestSlackPayload() {
 	s.True(c.Loggable())
 }
 
+func (s *notificationSuite) TestTeamsPayload() {
+	s.n.ID = "1"
+	s.n.Subscriber.Type = event.TeamsSubscriberType
+	target := "https://outlook.office.com/webhook/abc"
+	s.n.Subscriber.Target = &target
+	s.n.Payload = NewTeamsPayload("Hi", "Hi there", "https://example.com")
+
+	s.NoError(InsertMany(s.n))
+
+	n, err := Find(s.n.ID)
+	s.NoError(err)
+	s.NotNil(n)
+
+	s.Equal(s.n, *n)
+
+	c, err := n.Composer()
+	s.NoError(err)
+	s.Require().NotNil(c)
+	s.True(c.Loggable())
+	raw, ok := c.Raw().(*util.ChatWebhook)
+	s.Require().True(ok)
+	s.Equal(target, raw.URL)
+	s.Contains(string(raw.Body), `"@type":"MessageCard"`)
+}
+
+func (s *notificationSuite) TestMattermostPayload() {
+	s.n.ID = "1"
+	s.n.Subscriber.Type = event.MattermostSubscriberType
+	target := "https://mattermost.example.com/hooks/abc"
+	s.n.Subscriber.Target = &target
+	s.n.Payload = &MattermostPayload{
+		Text:        "Hi",
+		Attachments: []message.SlackAttachment{},
+	}
+
+	s.NoError(InsertMany(s.n))
+
+	n, err := Find(s.n.ID)
+	s.NoError(err)
+	s.NotNil(n)
+
+	s.Equal(s.n, *n)
+
+	c, err := n.Composer()
+	s.NoError(err)
+	s.Require().NotNil(c)
+	s.True(c.Loggable())
+	raw, ok := c.Raw().(*util.ChatWebhook)
+	s.Require().True(ok)
+	s.Equal(`{"text":"Hi"}`, string(raw.Body))
+}
+
 func (s *notificationSuite) TestGithubPayload() {
 	s.n.ID = "1"
 	s.n.Subscriber.Type = event.GithubPullRequestSubscriberType
@@ -346,7 +463,8 @@ func (s *notificationSuite) TestGithubPayload() {
 func (s *notificationSuite) TestCollectUnsentNotificationStats() {
 	types := []string{event.GithubPullRequestSubscriberType, event.EmailSubscriberType,
 		event.SlackSubscriberType, event.EvergreenWebhookSubscriberType,
-		event.JIRACommentSubscriberType, event.JIRAIssueSubscriberType}
+		event.JIRACommentSubscriberType, event.JIRAIssueSubscriberType,
+		event.TeamsSubscriberType, event.MattermostSubscriberType}
 
 	n := []Notification{}
 	// add one of every notification, unsent
@@ -6,3 +6,76 @@ type SlackPayload struct {
 	Body        string                    `bson:"body"`
 	Attachments []message.SlackAttachment `bson:"attachments"`
 }
+
+// MattermostPayload is the body of a Mattermost incoming webhook. Mattermost
+// accepts Slack-style message attachments.
+type MattermostPayload struct {
+	Text        string                    `bson:"text" json:"text"`
+	Attachments []message.SlackAttachment `bson:"attachments" json:"attachments,omitempty"`
+}
+
+// TeamsPayload is an Office 365 connector card, the body of a Microsoft Teams
+// incoming webhook.
+type TeamsPayload struct {
+	Type            string         `bson:"type" json:"@type"`
+	Context         string         `bson:"context" json:"@context"`
+	Summary         string         `bson:"summary" json:"summary"`
+	ThemeColor      string         `bson:"theme_color" json:"themeColor,omitempty"`
+	Title           string         `bson:"title" json:"title,omitempty"`
+	Text            string         `bson:"text" json:"text"`
+	Sections        []TeamsSection `bson:"sections" json:"sections,omitempty"`
+	PotentialAction []TeamsAction  `bson:"potential_action" json:"potentialAction,omitempty"`
+}
+
+type TeamsSection struct {
+	ActivityTitle string      `bson:"activity_title" json:"activityTitle,omitempty"`
+	Text          string      `bson:"text" json:"text,omitempty"`
+	Facts         []TeamsFact `bson:"facts" json:"facts,omitempty"`
+	Markdown      bool        `bson:"markdown" json:"markdown"`
+}
+
+type TeamsFact struct {
+	Name  string `bson:"name" json:"name"`
+	Value string `bson:"value" json:"value"`
+}
+
+type TeamsAction struct {
+	Type    string              `bson:"type" json:"@type"`
+	Name    string              `bson:"name" json:"name"`
+	Targets []TeamsActionTarget `bson:"targets" json:"targets"`
+}
+
+type TeamsActionTarget struct {
+	OS  string `bson:"os" json:"os"`
+	URI string `bson:"uri" json:"uri"`
+}
+
+const (
+	teamsMessageCardType    = "MessageCard"
+	teamsMessageCardContext = "https://schema.org/extensions"
+	teamsOpenURIActionType  = "OpenUri"
+)
+
+// NewTeamsPayload creates a Teams message card with the given summary and
+// text, linking to url.
+func NewTeamsPayload(summary, text, url string) *TeamsPayload {
+	payload := &TeamsPayload{
+		Type:    teamsMessageCardType,
+		Context: teamsMessageCardContext,
+		Summary: summary,
+		Text:    text,
+	}
+	if url != "" {
+		payload.PotentialAction = []TeamsAction{
+			{
+				Type: teamsOpenURIActionType,
+				Name: "View in Evergreen",
+				Targets: []TeamsActionTarget{
+					{OS: "default", URI: url},
+				},
+			},
+		}
+	}
+
+	return payload
+}
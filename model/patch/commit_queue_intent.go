@@ -0,0 +1,190 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// CommitQueueIntentType represents patch intents created by the commit
+	// queue to test a pull request before merging it.
+	CommitQueueIntentType = "commit-queue"
+
+	// CommitQueueAlias is a special alias to specify the variants and tasks
+	// that must pass before a pull request is merged by the commit queue.
+	CommitQueueAlias = "__commit_queue"
+)
+
+// commitQueueIntent represents an intent to test a pull request in the commit
+// queue of a project on top of the current tip of the branch and all pull
+// requests ahead of it in the queue.
+type commitQueueIntent struct {
+	// DocumentID is the id of the patch that will be created.
+	DocumentID string `bson:"_id"`
+
+	// ProjectID is the identifier of the project whose queue the pull
+	// request is in.
+	ProjectID string `bson:"project"`
+
+	// BaseOwner, BaseRepo and BaseBranch are where the pull requests will
+	// be merged.
+	BaseOwner  string `bson:"base_owner"`
+	BaseRepo   string `bson:"base_repo"`
+	BaseBranch string `bson:"base_branch"`
+
+	// PRNumbers are the pull requests ahead of the tested pull request in
+	// the queue, in order, followed by the tested pull request.
+	PRNumbers []int `bson:"pr_numbers"`
+
+	// User is the Evergreen user who enqueued the tested pull request.
+	User string `bson:"user"`
+
+	// CreatedAt is the time that this intent was stored in the database
+	CreatedAt time.Time `bson:"created_at"`
+
+	// Processed indicates whether a patch intent has been processed by the amboy queue.
+	Processed bool `bson:"processed"`
+
+	// ProcessedAt is the time that this intent was processed
+	ProcessedAt time.Time `bson:"processed_at"`
+
+	// IntentType indicates the type of the patch intent, i.e., CommitQueueIntentType
+	IntentType string `bson:"intent_type"`
+}
+
+// BSON fields for the patches
+// nolint
+var (
+	commitQueueDocumentIDKey  = bsonutil.MustHaveTag(commitQueueIntent{}, "DocumentID")
+	commitQueueProcessedKey   = bsonutil.MustHaveTag(commitQueueIntent{}, "Processed")
+	commitQueueProcessedAtKey = bsonutil.MustHaveTag(commitQueueIntent{}, "ProcessedAt")
+)
+
+// NewCommitQueueIntent creates an intent to test the last of prNumbers on top
+// of the others. The id of the patch must be the id of the intent.
+func NewCommitQueueIntent(patchID bson.ObjectId, project, owner, repo, branch, user string, prNumbers []int) (Intent, error) {
+	if !patchID.Valid() {
+		return nil, errors.New("invalid patch id")
+	}
+	if project == "" {
+		return nil, errors.New("no project provided")
+	}
+	if owner == "" || repo == "" || branch == "" {
+		return nil, errors.New("no repository or branch provided")
+	}
+	if user == "" {
+		return nil, errors.New("no user provided")
+	}
+	if len(prNumbers) == 0 {
+		return nil, errors.New("no pull requests provided")
+	}
+	for _, pr := range prNumbers {
+		if pr <= 0 {
+			return nil, errors.Errorf("invalid pull request number %d", pr)
+		}
+	}
+
+	return &commitQueueIntent{
+		DocumentID: patchID.Hex(),
+		ProjectID:  project,
+		BaseOwner:  owner,
+		BaseRepo:   repo,
+		BaseBranch: branch,
+		PRNumbers:  prNumbers,
+		User:       user,
+		IntentType: CommitQueueIntentType,
+	}, nil
+}
+
+// CommitQueuePRNumbers returns the pull requests a commit queue intent tests,
+// with the tested pull request last.
+func CommitQueuePRNumbers(intent Intent) ([]int, error) {
+	c, ok := intent.(*commitQueueIntent)
+	if !ok {
+		return nil, errors.Errorf("intent '%s' is not a commit queue intent", intent.ID())
+	}
+	return c.PRNumbers, nil
+}
+
+// Insert inserts a patch intent in the database.
+func (c *commitQueueIntent) Insert() error {
+	c.CreatedAt = time.Now().Round(time.Millisecond)
+	if err := db.Insert(IntentCollection, c); err != nil {
+		c.CreatedAt = time.Time{}
+		return err
+	}
+
+	return nil
+}
+
+// SetProcessed should be called by an amboy queue after creating a patch from an intent.
+func (c *commitQueueIntent) SetProcessed() error {
+	c.Processed = true
+	c.ProcessedAt = time.Now().Round(time.Millisecond)
+	return updateOneIntent(
+		bson.M{commitQueueDocumentIDKey: c.DocumentID},
+		bson.M{"$set": bson.M{
+			commitQueueProcessedKey:   c.Processed,
+			commitQueueProcessedAtKey: c.ProcessedAt,
+		}},
+	)
+}
+
+func (c *commitQueueIntent) IsProcessed() bool {
+	return c.Processed
+}
+
+func (c *commitQueueIntent) GetType() string {
+	return CommitQueueIntentType
+}
+
+func (c *commitQueueIntent) ID() string {
+	return c.DocumentID
+}
+
+func (c *commitQueueIntent) ShouldFinalizePatch() bool {
+	return true
+}
+
+func (c *commitQueueIntent) RequesterIdentity() string {
+	return evergreen.PatchVersionRequester
+}
+
+func (c *commitQueueIntent) GetAlias() string {
+	return CommitQueueAlias
+}
+
+// NewPatch creates a patch from the intent. The diffs of the pull requests
+// are added by the patch intent processor.
+func (c *commitQueueIntent) NewPatch() *Patch {
+	tested := c.PRNumbers[len(c.PRNumbers)-1]
+	description := fmt.Sprintf("Commit queue merge test of '%s/%s' pull request #%d",
+		c.BaseOwner, c.BaseRepo, tested)
+	if len(c.PRNumbers) > 1 {
+		ahead := make([]string, 0, len(c.PRNumbers)-1)
+		for _, pr := range c.PRNumbers[:len(c.PRNumbers)-1] {
+			ahead = append(ahead, fmt.Sprintf("#%d", pr))
+		}
+		description += fmt.Sprintf(" on top of %s", strings.Join(ahead, ", "))
+	}
+
+	return &Patch{
+		Alias:       CommitQueueAlias,
+		Description: description,
+		Author:      c.User,
+		Project:     c.ProjectID,
+		Status:      evergreen.PatchCreated,
+		GithubPatchData: GithubPatch{
+			BaseOwner:  c.BaseOwner,
+			BaseRepo:   c.BaseRepo,
+			BaseBranch: c.BaseBranch,
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestNewCommitQueueIntent(t *testing.T) {
+	assert := assert.New(t)
+	patchID := bson.NewObjectId()
+
+	intent, err := NewCommitQueueIntent(patchID, "mci", "evergreen-ci", "evergreen", "master", "octocat", []int{1, 2})
+	assert.NoError(err)
+	assert.Implements((*Intent)(nil), intent)
+	assert.Equal(patchID.Hex(), intent.ID())
+	assert.Equal(CommitQueueIntentType, intent.GetType())
+	assert.Equal(CommitQueueAlias, intent.GetAlias())
+	assert.Equal(evergreen.PatchVersionRequester, intent.RequesterIdentity())
+	assert.True(intent.ShouldFinalizePatch())
+	assert.False(intent.IsProcessed())
+
+	prNumbers, err := CommitQueuePRNumbers(intent)
+	assert.NoError(err)
+	assert.Equal([]int{1, 2}, prNumbers)
+
+	p := intent.NewPatch()
+	assert.Equal("octocat", p.Author)
+	assert.Equal("mci", p.Project)
+	assert.Equal("Commit queue merge test of 'evergreen-ci/evergreen' pull request #2 on top of #1", p.Description)
+	assert.Equal("master", p.GithubPatchData.BaseBranch)
+	assert.False(p.IsGithubPRPatch())
+
+	_, err = NewCommitQueueIntent(bson.ObjectId(""), "mci", "evergreen-ci", "evergreen", "master", "octocat", []int{1})
+	assert.Error(err)
+	_, err = NewCommitQueueIntent(patchID, "", "evergreen-ci", "evergreen", "master", "octocat", []int{1})
+	assert.Error(err)
+	_, err = NewCommitQueueIntent(patchID, "mci", "evergreen-ci", "", "master", "octocat", []int{1})
+	assert.Error(err)
+	_, err = NewCommitQueueIntent(patchID, "mci", "evergreen-ci", "evergreen", "master", "", []int{1})
+	assert.Error(err)
+	_, err = NewCommitQueueIntent(patchID, "mci", "evergreen-ci", "evergreen", "master", "octocat", nil)
+	assert.Error(err)
+	_, err = NewCommitQueueIntent(patchID, "mci", "evergreen-ci", "evergreen", "master", "octocat", []int{0})
+	assert.Error(err)
+}
@@ -31,8 +31,9 @@ func GetIntent(intentType string) (Intent, bool) {
 func init() {
 	intentFactoryRegistry = &patchIntentFactoryRegistry{
 		r: map[string]patchIntentFactory{
-			GithubIntentType: func() Intent { return &githubIntent{} },
-			CliIntentType:    func() Intent { return &cliIntent{} },
+			GithubIntentType:      func() Intent { return &githubIntent{} },
+			CliIntentType:         func() Intent { return &cliIntent{} },
+			CommitQueueIntentType: func() Intent { return &commitQueueIntent{} },
 		},
 	}
 }
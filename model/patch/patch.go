@@ -247,12 +247,25 @@ func (p *Patch) ConfigChanged(remotePath string) bool {
 					return true
 				}
 			}
-			return false
 		}
 	}
 	return false
 }
 
+// ChangedFiles returns the names of the files in the project's repository
+// that the patch changes, not including files in modules.
+func (p *Patch) ChangedFiles() []string {
+	files := []string{}
+	for _, patchPart := range p.Patches {
+		if patchPart.ModuleName == "" {
+			for _, summary := range patchPart.PatchSet.Summary {
+				files = append(files, summary.Name)
+			}
+		}
+	}
+	return files
+}
+
 // SetActivated sets the patch to activated in the db
 func (p *Patch) SetActivated(versionId string) error {
 	p.Version = versionId
@@ -106,9 +106,59 @@ func IncludePatchDependencies(project *Project, tvpairs []TVPair) []TVPair {
 
 // MakePatchedConfig takes in the path to a remote configuration a stringified version
 // of the current project and returns an unmarshalled version of the project
-// with the patch applied
-func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, projectConfig string) (
+// with the patch applied. Files included by the patched configuration are
+// fetched with fetch, and also have the patch applied.
+func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, projectConfig string, fetch IncludeFetcher) (
 	*Project, error) {
+	data, patched, err := patchFile(ctx, p, remoteConfigPath, projectConfig)
+	if err != nil {
+		return nil, err
+	}
+	if !patched {
+		return nil, errors.New("no patch on project")
+	}
+
+	data, err = ResolveIncludes(ctx, data, NewPatchedIncludeFetcher(p, fetch))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem resolving includes of patched config")
+	}
+
+	project := &Project{}
+	if err = LoadProjectInto(data, p.Project, project); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return project, nil
+}
+
+// NewPatchedIncludeFetcher returns an IncludeFetcher that applies the patch
+// to the included files of the project that it changes.
+func NewPatchedIncludeFetcher(p *patch.Patch, fetch IncludeFetcher) IncludeFetcher {
+	if fetch == nil {
+		return nil
+	}
+	return func(ctx context.Context, include ProjectInclude, module *Module) ([]byte, error) {
+		changed := include.Module == "" && p.ConfigChanged(include.FileName)
+		data, err := fetch(ctx, include, module)
+		if err != nil && !(changed && thirdparty.IsFileNotFound(errors.Cause(err))) {
+			return nil, err
+		}
+		if !changed {
+			return data, nil
+		}
+
+		data, _, err = patchFile(ctx, p, include.FileName, string(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem patching included file '%s'", include)
+		}
+		return data, nil
+	}
+}
+
+// patchFile applies the parts of the patch to the main project that change
+// the file at the given path to its contents, returning the patched
+// contents and whether there were any such parts.
+func patchFile(ctx context.Context, p *patch.Patch, remoteConfigPath, projectConfig string) ([]byte, bool, error) {
+	patched := false
 	for _, patchPart := range p.Patches {
 		// we only need to patch the main project and not any other modules
 		if patchPart.ModuleName != "" {
@@ -120,23 +170,23 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		if patchPart.PatchSet.Patch == "" {
 			reader, err := db.GetGridFile(patch.GridFSPrefix, patchPart.PatchSet.PatchFileId)
 			if err != nil {
-				return nil, errors.Wrap(err, "Can't fetch patch file from gridfs")
+				return nil, false, errors.Wrap(err, "Can't fetch patch file from gridfs")
 			}
 			defer reader.Close()
 			bytes, err := ioutil.ReadAll(reader)
 			if err != nil {
-				return nil, errors.Wrap(err, "Can't read patch file contents from gridfs")
+				return nil, false, errors.Wrap(err, "Can't read patch file contents from gridfs")
 			}
 
 			patchFilePath, err = util.WriteToTempFile(string(bytes))
 			if err != nil {
-				return nil, errors.Wrap(err, "could not write temporary patch file")
+				return nil, false, errors.Wrap(err, "could not write temporary patch file")
 			}
 
 		} else {
 			patchFilePath, err = util.WriteToTempFile(patchPart.PatchSet.Patch)
 			if err != nil {
-				return nil, errors.Wrap(err, "could not write temporary patch file")
+				return nil, false, errors.Wrap(err, "could not write temporary patch file")
 			}
 		}
 
@@ -144,7 +194,7 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		// write project configuration
 		configFilePath, err := util.WriteToTempFile(projectConfig)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not write config file")
+			return nil, false, errors.Wrap(err, "could not write config file")
 		}
 		defer os.Remove(configFilePath) //nolint: evg
 
@@ -160,16 +210,16 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		)[0]
 		err = os.RemoveAll(filepath.Join(workingDirectory, parentDir))
 		if err != nil {
-			return nil, errors.WithStack(err)
+			return nil, false, errors.WithStack(err)
 		}
 		if err = os.MkdirAll(filepath.Dir(localConfigPath), 0755); err != nil {
-			return nil, errors.WithStack(err)
+			return nil, false, errors.WithStack(err)
 		}
 		// rename the temporary config file name to the remote config
 		// file path if we are patching an existing remote config
 		if len(projectConfig) > 0 {
 			if err = os.Rename(configFilePath, localConfigPath); err != nil {
-				return nil, errors.Wrapf(err, "could not rename file '%v' to '%v'",
+				return nil, false, errors.Wrapf(err, "could not rename file '%v' to '%v'",
 					configFilePath, localConfigPath)
 			}
 			defer os.Remove(localConfigPath)
@@ -197,24 +247,22 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 			true)
 
 		if err = patchCmd.SetOutput(output); err != nil {
-			return nil, errors.Wrap(err, "problem configuring command output")
+			return nil, false, errors.Wrap(err, "problem configuring command output")
 		}
 
 		if err = patchCmd.Run(ctx); err != nil {
-			return nil, errors.Errorf("could not run patch command: %v", err)
+			return nil, false, errors.Errorf("could not run patch command: %v", err)
 		}
-		// read in the patched config file
+		// read in the patched config file, which the next patch for the
+		// project, if any, is applied on top of
 		data, err := ioutil.ReadFile(localConfigPath)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not read patched config file")
-		}
-		project := &Project{}
-		if err = LoadProjectInto(data, p.Project, project); err != nil {
-			return nil, errors.WithStack(err)
+			return nil, false, errors.Wrap(err, "could not read patched config file")
 		}
-		return project, nil
+		projectConfig = string(data)
+		patched = true
 	}
-	return nil, errors.New("no patch on project")
+	return []byte(projectConfig), patched, nil
 }
 
 // Finalizes a patch:
@@ -280,6 +328,11 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 		}).TVPairsToVariantTasks()
 	}
 
+	if project.HasPathFilters() {
+		patchVersion.Skipped = project.SkipUnaffected(p.ChangedFiles())
+		tasks = project.withoutSkipped(tasks)
+	}
+
 	taskIds := NewPatchTaskIdTable(project, patchVersion, tasks)
 	variantsProcessed := map[string]bool{}
 	for _, vt := range p.VariantsTasks {
@@ -287,12 +340,13 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 			continue
 		}
 
-		var buildId string
-		var displayNames []string
-		for _, dt := range vt.DisplayTasks {
-			displayNames = append(displayNames, dt.Name)
-		}
 		taskNames := tasks.ExecTasks.TaskNames(vt.Variant)
+		if len(taskNames) == 0 {
+			// every task in the variant was skipped
+			continue
+		}
+		var buildId string
+		displayNames := tasks.DisplayTasks.TaskNames(vt.Variant)
 		buildId, err = CreateBuildFromVersion(project, patchVersion, taskIds, vt.Variant, true, taskNames, displayNames, "")
 		if err != nil {
 			return nil, errors.WithStack(err)
@@ -51,7 +51,7 @@ func TestMakePatchedConfig(t *testing.T) {
 			}
 			projectBytes, err := ioutil.ReadFile(filepath.Join(cwd, "testdata", "project.config"))
 			So(err, ShouldBeNil)
-			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, string(projectBytes))
+			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, string(projectBytes), nil)
 			So(err, ShouldBeNil)
 			So(project, ShouldNotBeNil)
 			So(len(project.Tasks), ShouldEqual, 2)
@@ -70,7 +70,7 @@ func TestMakePatchedConfig(t *testing.T) {
 				}},
 			}
 
-			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, "")
+			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, "", nil)
 			So(err, ShouldBeNil)
 			So(project, ShouldNotBeNil)
 			So(len(project.Tasks), ShouldEqual, 1)
@@ -74,6 +74,14 @@ type BuildVariantTaskUnit struct {
 	// currently unsupported (TODO EVG-578)
 	ExecTimeoutSecs int   `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
 	Stepback        *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	// Retry overrides the retry policy of the task definition
+	Retry *RetryPolicy `yaml:"retry,omitempty" bson:"retry,omitempty"`
+
+	// Paths and IgnorePaths limit the task to versions that change files
+	// matching Paths and not matching IgnorePaths.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
 }
 
 func (b BuildVariant) Get(name string) (BuildVariantTaskUnit, error) {
@@ -130,6 +138,9 @@ func (bvt *BuildVariantTaskUnit) Populate(pt ProjectTask) {
 	if bvt.Stepback == nil {
 		bvt.Stepback = pt.Stepback
 	}
+	if bvt.Retry == nil {
+		bvt.Retry = pt.Retry
+	}
 }
 
 // UnmarshalYAML allows tasks to be referenced as single selector strings.
@@ -178,6 +189,11 @@ type BuildVariant struct {
 	// provided for the task
 	RunOn []string `yaml:"run_on,omitempty" bson:"run_on"`
 
+	// Paths and IgnorePaths limit the build variant to versions that change
+	// files matching Paths and not matching IgnorePaths.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
+
 	// all of the tasks/groups to be run on the build variant, compile through tests.
 	Tasks        []BuildVariantTaskUnit `yaml:"tasks,omitempty" bson:"tasks"`
 	DisplayTasks []DisplayTask          `yaml:"display_tasks,omitempty" bson:"display_tasks,omitempty"`
@@ -331,6 +347,18 @@ type ProjectTask struct {
 	//   3. false = overriding the project setting with false
 	Patchable *bool `yaml:"patchable,omitempty" bson:"patchable,omitempty"`
 	Stepback  *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	Retry *RetryPolicy `yaml:"retry,omitempty" bson:"retry,omitempty"`
+}
+
+// RetryPolicy describes when a failed task is automatically restarted.
+// MaxAttempts is the total number of times the task may run, including the
+// first; On lists the kinds of failure that are retried; and BackoffSecs, if
+// set, delays the first retry, doubling for each retry after it.
+type RetryPolicy struct {
+	MaxAttempts int      `yaml:"max_attempts,omitempty" bson:"max_attempts"`
+	On          []string `yaml:"on,omitempty" bson:"on"`
+	BackoffSecs int      `yaml:"backoff_secs,omitempty" bson:"backoff_secs,omitempty"`
 }
 
 // TaskIdTable is a map of [variant, task display name]->[task id].
@@ -442,6 +470,10 @@ func NewTaskIdTable(p *Project, v *version.Version) TaskIdConfig {
 		rev := v.Revision
 		if evergreen.IsPatchRequester(v.Requester) {
 			rev = fmt.Sprintf("patch_%s_%s", v.Revision, v.Id)
+		} else if v.Requester == evergreen.PeriodicBuildRequester {
+			rev = fmt.Sprintf("periodic_%s_%s", v.Revision, v.Id)
+		} else if v.Requester == evergreen.TriggerRequester {
+			rev = fmt.Sprintf("trigger_%s_%s", v.Revision, v.Id)
 		}
 		for _, t := range bv.Tasks {
 			if tg := p.FindTaskGroup(t.Name); tg != nil {
@@ -521,6 +553,10 @@ func generateIdsForVariant(vt TVPair, proj *Project, v *version.Version, tasks T
 	rev := v.Revision
 	if evergreen.IsPatchRequester(v.Requester) {
 		rev = fmt.Sprintf("patch_%s_%s", v.Revision, v.Id)
+	} else if v.Requester == evergreen.PeriodicBuildRequester {
+		rev = fmt.Sprintf("periodic_%s_%s", v.Revision, v.Id)
+	} else if v.Requester == evergreen.TriggerRequester {
+		rev = fmt.Sprintf("trigger_%s_%s", v.Revision, v.Id)
 	}
 	for _, t := range projBV.Tasks { // create Ids for each task that can run on the variant and is requested by the patch.
 		if util.StringSliceContains(taskNamesForVariant, t.Name) {
@@ -593,6 +629,23 @@ func populateExpansions(d *distro.Distro, v *version.Version, bv *BuildVariant,
 		expansions.Put("revision_order_id", strconv.Itoa(v.RevisionOrderNumber))
 	}
 
+	if v.Upstream != nil {
+		expansions.Put("upstream_trigger_id", v.Upstream.TriggerID)
+		expansions.Put("upstream_project", v.Upstream.Project)
+		expansions.Put("upstream_version_id", v.Upstream.VersionId)
+		expansions.Put("upstream_revision", v.Upstream.Revision)
+		expansions.Put("upstream_status", v.Upstream.Status)
+		if v.Upstream.BuildId != "" {
+			expansions.Put("upstream_build_id", v.Upstream.BuildId)
+			expansions.Put("upstream_build_variant", v.Upstream.BuildVariant)
+		}
+		links := make([]string, 0, len(v.Upstream.Artifacts))
+		for _, file := range v.Upstream.Artifacts {
+			links = append(links, file.Link)
+		}
+		expansions.Put("upstream_artifact_urls", strings.Join(links, " "))
+	}
+
 	for _, e := range d.Expansions {
 		expansions.Put(e.Key, e.Value)
 	}
@@ -1057,6 +1110,52 @@ func (p *Project) BuildProjectTVPairs(patchDoc *patch.Patch, alias string) {
 	patchDoc.SyncVariantsTasks(tasks.TVPairsToVariantTasks())
 }
 
+// BuildPeriodicBuildTVPairs returns the variants and tasks selected by a
+// periodic build definition, along with their display tasks and
+// dependencies.
+func (p *Project) BuildPeriodicBuildTVPairs(def PeriodicBuildDefinition) (TaskVariantPairs, error) {
+	if def.Alias != "" {
+		return p.BuildAliasTVPairs(def.Alias)
+	}
+	return p.buildTVPairsWithDependencies([]ProjectAlias{{Variant: def.Variant, Task: def.Task}})
+}
+
+// BuildAliasTVPairs returns the variants and tasks selected by a project
+// alias, which must be defined, along with their display tasks and
+// dependencies.
+func (p *Project) BuildAliasTVPairs(alias string) (TaskVariantPairs, error) {
+	aliases, err := FindAliasInProject(p.Identifier, alias)
+	if err != nil {
+		return TaskVariantPairs{}, errors.Wrapf(err, "problem finding alias '%s'", alias)
+	}
+	if len(aliases) == 0 {
+		return TaskVariantPairs{}, errors.Errorf("alias '%s' is not defined", alias)
+	}
+	return p.buildTVPairsWithDependencies(aliases)
+}
+
+func (p *Project) buildTVPairsWithDependencies(aliases []ProjectAlias) (TaskVariantPairs, error) {
+	pairs, displayTaskPairs, err := p.BuildProjectTVPairsWithAliases(aliases)
+	if err != nil {
+		return TaskVariantPairs{}, errors.WithStack(err)
+	}
+
+	variants := []string{}
+	taskNames := []string{}
+	for _, pair := range append(pairs, displayTaskPairs...) {
+		if !util.StringSliceContains(variants, pair.Variant) {
+			variants = append(variants, pair.Variant)
+		}
+		if !util.StringSliceContains(taskNames, pair.TaskName) {
+			taskNames = append(taskNames, pair.TaskName)
+		}
+	}
+
+	tasks := extractDisplayTasks(pairs, taskNames, variants, p)
+	tasks.ExecTasks = IncludePatchDependencies(p, tasks.ExecTasks)
+	return tasks, nil
+}
+
 // TasksThatCallCommand returns a map of tasks that call a given command.
 func (p *Project) TasksThatCallCommand(find string) map[string]int {
 	// get all functions that call `generate.tasks`
@@ -1128,6 +1227,13 @@ func (p *Project) BuildProjectTVPairsWithAlias(alias string) ([]TVPair, []TVPair
 		return nil, nil, err
 	}
 
+	return p.BuildProjectTVPairsWithAliases(vars)
+}
+
+// BuildProjectTVPairsWithAliases returns variants and tasks matched by any
+// of the given aliases.
+func (p *Project) BuildProjectTVPairsWithAliases(vars []ProjectAlias) ([]TVPair, []TVPair, error) {
+	var err error
 	pairs := []TVPair{}
 	displayTaskPairs := []TVPair{}
 	for _, v := range vars {
@@ -1176,13 +1282,15 @@ func (p *Project) BuildProjectTVPairsWithAlias(alias string) ([]TVPair, []TVPair
 }
 
 // FetchVersionsAndAssociatedBuilds is a helper function to fetch a group of versions and their associated builds.
+// Only versions created by one of the given requesters are fetched.
 // Returns the versions themselves, as well as a map of version id -> the
 // builds that are a part of the version (unsorted).
-func FetchVersionsAndAssociatedBuilds(project *Project, skip int, numVersions int) ([]version.Version, map[string][]build.Build, error) {
+func FetchVersionsAndAssociatedBuilds(project *Project, skip int, numVersions int, requesters []string) ([]version.Version, map[string][]build.Build, error) {
 
 	// fetch the versions from the db
-	versionsFromDB, err := version.Find(version.ByProjectId(project.Identifier).
+	versionsFromDB, err := version.Find(version.ByProjectIdAndRequesters(project.Identifier, requesters).
 		WithFields(
+			version.RequesterKey,
 			version.RevisionKey,
 			version.ErrorsKey,
 			version.WarningsKey,
@@ -0,0 +1,314 @@
+package model
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// redactedValue replaces secret values in the project settings that are
+// saved in the event log.
+const redactedValue = "{REDACTED}"
+
+// ProjectSettings is a snapshot of everything about a project that can be
+// edited from the project settings page.
+type ProjectSettings struct {
+	ProjectRef    ProjectRef           `bson:"proj_ref" json:"proj_ref"`
+	Vars          ProjectVars          `bson:"vars" json:"vars"`
+	Aliases       []ProjectAlias       `bson:"aliases" json:"aliases"`
+	Subscriptions []event.Subscription `bson:"subscriptions" json:"subscriptions"`
+}
+
+// ProjectChangeEvent is a logged change to the settings of a project.
+type ProjectChangeEvent struct {
+	GUID      string          `json:"guid"`
+	User      string          `json:"user"`
+	EventType string          `json:"event_type"`
+	Timestamp time.Time       `json:"ts"`
+	Before    ProjectSettings `json:"before"`
+	After     ProjectSettings `json:"after"`
+}
+
+// GetProjectSettings returns a snapshot of the current settings of a
+// project, or nil if the project does not exist.
+func GetProjectSettings(projectID string) (*ProjectSettings, error) {
+	ref, err := FindOneProjectRef(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding project '%s'", projectID)
+	}
+	if ref == nil {
+		return nil, nil
+	}
+	vars, err := FindOneProjectVars(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding variables for project '%s'", projectID)
+	}
+	if vars == nil {
+		vars = &ProjectVars{Id: projectID}
+	}
+	aliases, err := FindAliasesForProject(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding aliases for project '%s'", projectID)
+	}
+	subscriptions, err := event.FindSubscriptionsByOwner(projectID, event.OwnerTypeProject)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding subscriptions for project '%s'", projectID)
+	}
+
+	return &ProjectSettings{
+		ProjectRef:    *ref,
+		Vars:          *vars,
+		Aliases:       aliases,
+		Subscriptions: subscriptions,
+	}, nil
+}
+
+// redacted returns a copy of the settings in which the values of private
+// variables, the secrets of webhook subscriptions, and the URLs of chat
+// webhook subscriptions are removed, so that the copy is safe to save in the
+// event log.
+func (s *ProjectSettings) redacted() ProjectSettings {
+	out := *s
+
+	out.Vars.Vars = map[string]string{}
+	for k, v := range s.Vars.Vars {
+		out.Vars.Vars[k] = v
+	}
+	out.Vars.RedactPrivateVars()
+
+	out.Subscriptions = make([]event.Subscription, len(s.Subscriptions))
+	for i, sub := range s.Subscriptions {
+		if webhook, ok := sub.Subscriber.Target.(*event.WebhookSubscriber); ok {
+			sub.Subscriber.Target = &event.WebhookSubscriber{
+				URL:    webhook.URL,
+				Secret: []byte(redactedValue),
+			}
+		}
+		if isChatWebhook(sub.Subscriber) {
+			sub.Subscriber.Target = redactedValue
+		}
+		out.Subscriptions[i] = sub
+	}
+
+	return out
+}
+
+// isChatWebhook returns true if the subscriber posts to a chat webhook,
+// whose URL is a secret.
+func isChatWebhook(subscriber event.Subscriber) bool {
+	return subscriber.Type == event.TeamsSubscriberType || subscriber.Type == event.MattermostSubscriberType
+}
+
+// LogProjectAdded records the creation of a project by a user.
+func LogProjectAdded(projectID, user string) error {
+	settings, err := GetProjectSettings(projectID)
+	if err != nil {
+		return errors.Wrap(err, "error getting settings of new project")
+	}
+	if settings == nil {
+		return errors.Errorf("project '%s' does not exist", projectID)
+	}
+
+	return event.LogProjectEvent(event.EventTypeProjectAdded, projectID, user, &ProjectSettings{}, settings.redacted())
+}
+
+// LogProjectModified records a change to the settings of a project by a
+// user. Nothing is logged if the settings did not change.
+func LogProjectModified(projectID, user string, before, after *ProjectSettings) error {
+	if before == nil || after == nil {
+		return errors.New("cannot log a project change without both before and after settings")
+	}
+	redactedBefore := before.redacted()
+	redactedAfter := after.redacted()
+	if reflect.DeepEqual(redactedBefore, redactedAfter) {
+		return nil
+	}
+
+	return event.LogProjectEvent(event.EventTypeProjectModified, projectID, user, redactedBefore, redactedAfter)
+}
+
+// FindProjectChangeEvents returns the newest n changes to the settings of a
+// project made before the given time.
+func FindProjectChangeEvents(projectID string, before time.Time, n int) ([]ProjectChangeEvent, error) {
+	events, err := event.Find(event.AllLogCollection, event.ProjectEventsBefore(projectID, before, n))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding events for project '%s'", projectID)
+	}
+
+	out := make([]ProjectChangeEvent, 0, len(events))
+	for _, e := range events {
+		change, err := projectChangeEventFromLog(e)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading event for project '%s'", projectID)
+		}
+		out = append(out, *change)
+	}
+
+	return out, nil
+}
+
+// FindProjectChangeEvent returns the logged change to the settings of a
+// project with the given GUID, or nil if there is none.
+func FindProjectChangeEvent(projectID, guid string) (*ProjectChangeEvent, error) {
+	events, err := event.Find(event.AllLogCollection, event.ProjectEventByGUID(projectID, guid))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding event '%s' for project '%s'", guid, projectID)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	return projectChangeEventFromLog(events[0])
+}
+
+func projectChangeEventFromLog(e event.EventLogEntry) (*ProjectChangeEvent, error) {
+	data, ok := e.Data.(*event.ProjectChangeEventData)
+	if !ok {
+		return nil, errors.Errorf("event '%s' has unexpected data of type %T", e.ID, e.Data)
+	}
+
+	change := &ProjectChangeEvent{
+		GUID:      data.GUID,
+		User:      data.User,
+		EventType: e.EventType,
+		Timestamp: e.Timestamp,
+	}
+	if err := data.Before.Unmarshal(&change.Before); err != nil {
+		return nil, errors.Wrap(err, "error reading settings before change")
+	}
+	if err := data.After.Unmarshal(&change.After); err != nil {
+		return nil, errors.Wrap(err, "error reading settings after change")
+	}
+
+	return change, nil
+}
+
+// RevertProjectSettings restores the settings of a project to their state
+// after the logged change with the given GUID, and logs the revert as a new
+// change made by the user. Redacted secrets keep their current values, so a
+// revert fails if it would restore a secret that no longer exists.
+func RevertProjectSettings(projectID, guid, user string) error {
+	change, err := FindProjectChangeEvent(projectID, guid)
+	if err != nil {
+		return err
+	}
+	if change == nil {
+		return errors.Errorf("unable to find event '%s' for project '%s'", guid, projectID)
+	}
+	current, err := GetProjectSettings(projectID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.Errorf("project '%s' does not exist", projectID)
+	}
+
+	target := change.After
+	if target.ProjectRef.Identifier != projectID {
+		return errors.Errorf("event '%s' has no settings to revert to", guid)
+	}
+	if err = target.restoreSecrets(current); err != nil {
+		return errors.Wrap(err, "unable to restore secrets")
+	}
+
+	if err = target.ProjectRef.Upsert(); err != nil {
+		return errors.Wrap(err, "error restoring project ref")
+	}
+	target.Vars.Id = projectID
+	if _, err = target.Vars.Upsert(); err != nil {
+		return errors.Wrap(err, "error restoring project variables")
+	}
+
+	for _, alias := range current.Aliases {
+		if err = RemoveProjectAlias(alias.ID.Hex()); err != nil {
+			return errors.Wrap(err, "error removing project alias")
+		}
+	}
+	for i := range target.Aliases {
+		if err = target.Aliases[i].Upsert(); err != nil {
+			return errors.Wrap(err, "error restoring project alias")
+		}
+	}
+
+	keep := map[string]bool{}
+	for i := range target.Subscriptions {
+		keep[target.Subscriptions[i].ID] = true
+	}
+	for _, sub := range current.Subscriptions {
+		if keep[sub.ID] {
+			continue
+		}
+		if err = event.RemoveSubscription(sub.ID); err != nil {
+			return errors.Wrap(err, "error removing project subscription")
+		}
+	}
+	for i := range target.Subscriptions {
+		if err = target.Subscriptions[i].Upsert(); err != nil {
+			return errors.Wrap(err, "error restoring project subscription")
+		}
+	}
+
+	after, err := GetProjectSettings(projectID)
+	if err != nil {
+		return err
+	}
+
+	return LogProjectModified(projectID, user, current, after)
+}
+
+// restoreSecrets replaces the redacted values in settings read from the
+// event log with the values from the current settings.
+func (s *ProjectSettings) restoreSecrets(current *ProjectSettings) error {
+	catcher := grip.NewBasicCatcher()
+	for k, v := range s.Vars.Vars {
+		if v != "" || !s.Vars.PrivateVars[k] {
+			continue
+		}
+		currentValue, ok := current.Vars.Vars[k]
+		if !ok {
+			catcher.Add(errors.Errorf("private variable '%s' no longer exists", k))
+			continue
+		}
+		s.Vars.Vars[k] = currentValue
+	}
+
+	currentSecrets := map[string][]byte{}
+	currentChatWebhooks := map[string]interface{}{}
+	for _, sub := range current.Subscriptions {
+		if webhook, ok := sub.Subscriber.Target.(*event.WebhookSubscriber); ok {
+			currentSecrets[sub.ID] = webhook.Secret
+		}
+		if isChatWebhook(sub.Subscriber) {
+			currentChatWebhooks[sub.ID] = sub.Subscriber.Target
+		}
+	}
+	for i, sub := range s.Subscriptions {
+		if isChatWebhook(sub.Subscriber) && sub.Subscriber.Target == redactedValue {
+			target, ok := currentChatWebhooks[sub.ID]
+			if !ok {
+				catcher.Add(errors.Errorf("URL of webhook subscription '%s' no longer exists", sub.ID))
+				continue
+			}
+			s.Subscriptions[i].Subscriber.Target = target
+			continue
+		}
+		webhook, ok := sub.Subscriber.Target.(*event.WebhookSubscriber)
+		if !ok || string(webhook.Secret) != redactedValue {
+			continue
+		}
+		secret, ok := currentSecrets[sub.ID]
+		if !ok {
+			catcher.Add(errors.Errorf("secret of webhook subscription '%s' no longer exists", sub.ID))
+			continue
+		}
+		s.Subscriptions[i].Subscriber.Target = &event.WebhookSubscriber{
+			URL:    webhook.URL,
+			Secret: secret,
+		}
+	}
+
+	return catcher.Resolve()
+}
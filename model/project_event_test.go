@@ -0,0 +1,132 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectSettingsRedacted(t *testing.T) {
+	assert := assert.New(t)
+
+	settings := &ProjectSettings{
+		ProjectRef: ProjectRef{Identifier: "mci"},
+		Vars: ProjectVars{
+			Id:          "mci",
+			Vars:        map[string]string{"public": "1", "private": "2"},
+			PrivateVars: map[string]bool{"private": true},
+		},
+		Subscriptions: []event.Subscription{
+			{
+				ID: "webhook",
+				Subscriber: event.Subscriber{
+					Type:   event.EvergreenWebhookSubscriberType,
+					Target: &event.WebhookSubscriber{URL: "https://example.com", Secret: []byte("secret")},
+				},
+			},
+			{
+				ID:         "teams",
+				Subscriber: event.NewTeamsSubscriber("https://example.com/webhook/token"),
+			},
+		},
+	}
+
+	redacted := settings.redacted()
+	assert.Equal("1", redacted.Vars.Vars["public"])
+	assert.Empty(redacted.Vars.Vars["private"])
+	webhook := redacted.Subscriptions[0].Subscriber.Target.(*event.WebhookSubscriber)
+	assert.Equal("https://example.com", webhook.URL)
+	assert.Equal(redactedValue, string(webhook.Secret))
+	assert.Equal(redactedValue, redacted.Subscriptions[1].Subscriber.Target)
+
+	// the original settings are not modified
+	assert.Equal("2", settings.Vars.Vars["private"])
+	assert.Equal("secret", string(settings.Subscriptions[0].Subscriber.Target.(*event.WebhookSubscriber).Secret))
+	assert.Equal("https://example.com/webhook/token", settings.Subscriptions[1].Subscriber.Target)
+
+	assert.NoError(redacted.restoreSecrets(settings))
+	assert.Equal("2", redacted.Vars.Vars["private"])
+	assert.Equal("secret", string(redacted.Subscriptions[0].Subscriber.Target.(*event.WebhookSubscriber).Secret))
+	assert.Equal("https://example.com/webhook/token", redacted.Subscriptions[1].Subscriber.Target)
+
+	redacted = settings.redacted()
+	assert.Error(redacted.restoreSecrets(&ProjectSettings{}))
+}
+
+func TestProjectEventLogAndRevert(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	require.NoError(db.ClearCollections(ProjectRefCollection, ProjectVarsCollection, ProjectAliasCollection,
+		event.SubscriptionsCollection, event.AllLogCollection))
+
+	ref := ProjectRef{Identifier: "mci", DisplayName: "first", Enabled: true}
+	require.NoError(ref.Insert())
+	vars := ProjectVars{
+		Id:          "mci",
+		Vars:        map[string]string{"a": "1", "secret": "hunter2"},
+		PrivateVars: map[string]bool{"secret": true},
+	}
+	require.NoError(vars.Insert())
+	alias := ProjectAlias{ProjectID: "mci", Alias: "__github", Variant: ".*", Task: ".*"}
+	require.NoError(alias.Upsert())
+	require.NoError(LogProjectAdded("mci", "me"))
+
+	before, err := GetProjectSettings("mci")
+	require.NoError(err)
+	require.NotNil(before)
+	ref.DisplayName = "second"
+	require.NoError(ref.Upsert())
+	vars.Vars["a"] = "2"
+	_, err = vars.Upsert()
+	require.NoError(err)
+	require.NoError(RemoveProjectAlias(alias.ID.Hex()))
+	after, err := GetProjectSettings("mci")
+	require.NoError(err)
+	require.NoError(LogProjectModified("mci", "you", before, after))
+
+	// unchanged settings are not logged
+	require.NoError(LogProjectModified("mci", "you", after, after))
+
+	events, err := FindProjectChangeEvents("mci", time.Now().Add(time.Minute), 10)
+	require.NoError(err)
+	require.Len(events, 2)
+	modified := events[0]
+	assert.Equal(event.EventTypeProjectModified, modified.EventType)
+	assert.Equal("you", modified.User)
+	assert.Equal("first", modified.Before.ProjectRef.DisplayName)
+	assert.Equal("second", modified.After.ProjectRef.DisplayName)
+	assert.Empty(modified.Before.Vars.Vars["secret"])
+	assert.Len(modified.Before.Aliases, 1)
+	assert.Len(modified.After.Aliases, 0)
+	added := events[1]
+	assert.Equal(event.EventTypeProjectAdded, added.EventType)
+	assert.Equal("me", added.User)
+
+	// revert to the settings before the modification
+	require.NoError(RevertProjectSettings("mci", added.GUID, "admin"))
+	reverted, err := GetProjectSettings("mci")
+	require.NoError(err)
+	assert.Equal("first", reverted.ProjectRef.DisplayName)
+	assert.Equal("1", reverted.Vars.Vars["a"])
+	assert.Equal("hunter2", reverted.Vars.Vars["secret"])
+	require.Len(reverted.Aliases, 1)
+	assert.Equal(alias.ID, reverted.Aliases[0].ID)
+
+	events, err = FindProjectChangeEvents("mci", time.Now().Add(time.Minute), 10)
+	require.NoError(err)
+	require.Len(events, 3)
+	assert.Equal("admin", events[0].User)
+
+	// a redacted variable that no longer exists cannot be restored
+	delete(vars.Vars, "secret")
+	_, err = vars.Upsert()
+	require.NoError(err)
+	assert.Error(RevertProjectSettings("mci", added.GUID, "admin"))
+
+	assert.Error(RevertProjectSettings("mci", "nonexistent", "admin"))
+}
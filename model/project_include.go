@@ -0,0 +1,336 @@
+package model
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Project configuration files can list other files in an "include" section.
+// The tasks, functions, build variants and task groups defined in the
+// included files are merged into the including file before it is parsed,
+// so that the rest of the parser, and the config stored with each version,
+// only ever see a single document. Included files come from the same
+// revision of the project's repository, or from one of its modules.
+
+const (
+	includeKey       = "include"
+	tasksKey         = "tasks"
+	taskGroupsKey    = "task_groups"
+	buildVariantsKey = "buildvariants"
+	functionsKey     = "functions"
+)
+
+// ProjectInclude is a file whose definitions are merged into the project
+// configuration that includes it. If Module is set, FileName is a path in
+// that module's repository, otherwise it is a path in the project's
+// repository.
+type ProjectInclude struct {
+	FileName string `yaml:"filename"`
+	Module   string `yaml:"module,omitempty"`
+}
+
+// UnmarshalYAML allows an include to be given as just a file name.
+func (pi *ProjectInclude) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var fileName string
+	if err := unmarshal(&fileName); err == nil {
+		pi.FileName = fileName
+		return nil
+	}
+
+	type include ProjectInclude
+	tmp := include{}
+	if err := unmarshal(&tmp); err != nil {
+		return err
+	}
+	*pi = ProjectInclude(tmp)
+	return nil
+}
+
+func (pi ProjectInclude) String() string {
+	if pi.Module == "" {
+		return pi.FileName
+	}
+	return fmt.Sprintf("%s:%s", pi.Module, pi.FileName)
+}
+
+// IncludeFetcher returns the contents of an included file. module is the
+// project module named by the include, or nil if it names none.
+type IncludeFetcher func(ctx context.Context, include ProjectInclude, module *Module) ([]byte, error)
+
+// NewGithubIncludeFetcher returns an IncludeFetcher that reads included
+// files from GitHub, at the given revision of the project's repository, or
+// at the ref or branch of a module.
+func NewGithubIncludeFetcher(oauthToken, owner, repo, revision string) IncludeFetcher {
+	return func(ctx context.Context, include ProjectInclude, module *Module) ([]byte, error) {
+		fileOwner, fileRepo, ref := owner, repo, revision
+		if module != nil {
+			fileOwner, fileRepo = module.GetRepoOwnerAndName()
+			if fileOwner == "" || fileRepo == "" {
+				return nil, errors.Errorf("module '%s' has an invalid repo '%s'", module.Name, module.Repo)
+			}
+			ref = module.Ref
+			if ref == "" {
+				ref = module.Branch
+			}
+		}
+
+		file, err := thirdparty.GetGithubFile(ctx, oauthToken, fileOwner, fileRepo, include.FileName, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem fetching included file '%s'", include)
+		}
+		if file.Content == nil {
+			return nil, errors.Errorf("included file '%s' has no content", include)
+		}
+		data, err := base64.StdEncoding.DecodeString(*file.Content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem decoding included file '%s'", include)
+		}
+
+		return data, nil
+	}
+}
+
+// NewLocalIncludeFetcher returns an IncludeFetcher that reads included files
+// from disk, relative to root for the project's files and relative to the
+// path given in modulePaths for a module's files.
+func NewLocalIncludeFetcher(root string, modulePaths map[string]string) IncludeFetcher {
+	return func(_ context.Context, include ProjectInclude, module *Module) ([]byte, error) {
+		dir := root
+		if module != nil {
+			var ok bool
+			dir, ok = modulePaths[module.Name]
+			if !ok {
+				return nil, errors.Errorf("no local path given for module '%s' of included file '%s'", module.Name, include)
+			}
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, include.FileName))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem reading included file '%s'", include)
+		}
+
+		return data, nil
+	}
+}
+
+// ResolveIncludes returns the project configuration with the definitions of
+// each of its included files merged in and its include section removed. A
+// configuration without includes is returned unchanged. Included files may
+// only define tasks, functions, build variants and task groups, and may not
+// redefine anything defined elsewhere.
+func ResolveIncludes(ctx context.Context, yml []byte, fetch IncludeFetcher) ([]byte, error) {
+	header := struct {
+		Include []ProjectInclude `yaml:"include"`
+		Modules []Module         `yaml:"modules"`
+	}{}
+	if err := yaml.Unmarshal(yml, &header); err != nil {
+		return nil, errors.Wrap(err, "problem reading project includes")
+	}
+	if len(header.Include) == 0 {
+		return yml, nil
+	}
+	if fetch == nil {
+		return nil, errors.New("project has includes but no way to fetch them")
+	}
+
+	project := yaml.MapSlice{}
+	if err := yaml.Unmarshal(yml, &project); err != nil {
+		return nil, errors.Wrap(err, "problem reading project config")
+	}
+	project = removeKey(project, includeKey)
+
+	defined := newIncludeDefinitions()
+	defined.add("project config", project)
+
+	catcher := grip.NewBasicCatcher()
+	for _, include := range header.Include {
+		if include.FileName == "" {
+			catcher.Add(errors.New("include must have a file name"))
+			continue
+		}
+
+		var module *Module
+		if include.Module != "" {
+			for i := range header.Modules {
+				if header.Modules[i].Name == include.Module {
+					module = &header.Modules[i]
+					break
+				}
+			}
+			if module == nil {
+				catcher.Add(errors.Errorf("included file '%s' refers to undefined module '%s'", include.FileName, include.Module))
+				continue
+			}
+		}
+
+		data, err := fetch(ctx, include, module)
+		if err != nil {
+			catcher.Add(err)
+			continue
+		}
+		included := yaml.MapSlice{}
+		if err = yaml.Unmarshal(data, &included); err != nil {
+			catcher.Add(errors.Wrapf(err, "problem reading included file '%s'", include))
+			continue
+		}
+
+		valid := true
+		for _, item := range included {
+			key, _ := item.Key.(string)
+			switch key {
+			case tasksKey, taskGroupsKey, buildVariantsKey, functionsKey:
+			default:
+				valid = false
+				catcher.Add(errors.Errorf("included file '%s' may only define %s, %s, %s and %s, not '%v'",
+					include, tasksKey, taskGroupsKey, buildVariantsKey, functionsKey, item.Key))
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		catcher.Extend(defined.check(include.String(), included))
+		defined.add(include.String(), included)
+		project = mergeIncluded(project, included)
+	}
+	if catcher.HasErrors() {
+		return nil, catcher.Resolve()
+	}
+
+	out, err := yaml.Marshal(project)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem writing project config with includes")
+	}
+	return out, nil
+}
+
+// mergeIncluded appends the definitions in an included file to those of the
+// project.
+func mergeIncluded(project, included yaml.MapSlice) yaml.MapSlice {
+	for _, item := range included {
+		idx := -1
+		for i := range project {
+			if project[i].Key == item.Key {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			project = append(project, item)
+			continue
+		}
+
+		switch existing := project[idx].Value.(type) {
+		case []interface{}:
+			additions, _ := item.Value.([]interface{})
+			project[idx].Value = append(existing, additions...)
+		case yaml.MapSlice:
+			additions, _ := item.Value.(yaml.MapSlice)
+			project[idx].Value = append(existing, additions...)
+		default:
+			project[idx].Value = item.Value
+		}
+	}
+
+	return project
+}
+
+func removeKey(in yaml.MapSlice, key string) yaml.MapSlice {
+	out := yaml.MapSlice{}
+	for _, item := range in {
+		if item.Key != key {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// includeDefinitions tracks which file defines each task, task group, build
+// variant and function, in order to report duplicate definitions.
+type includeDefinitions map[string]map[string]string
+
+func newIncludeDefinitions() includeDefinitions {
+	return includeDefinitions{
+		tasksKey:         {},
+		taskGroupsKey:    {},
+		buildVariantsKey: {},
+		functionsKey:     {},
+	}
+}
+
+func (d includeDefinitions) add(source string, doc yaml.MapSlice) {
+	for section, names := range definedNames(doc) {
+		for _, name := range names {
+			if _, ok := d[section][name]; !ok {
+				d[section][name] = source
+			}
+		}
+	}
+}
+
+func (d includeDefinitions) check(source string, doc yaml.MapSlice) []error {
+	kinds := map[string]string{
+		tasksKey:         "task",
+		taskGroupsKey:    "task group",
+		buildVariantsKey: "build variant",
+		functionsKey:     "function",
+	}
+	errs := []error{}
+	for section, names := range definedNames(doc) {
+		seen := map[string]bool{}
+		for _, name := range names {
+			if other, ok := d[section][name]; ok {
+				errs = append(errs, errors.Errorf("%s '%s' in included file '%s' is already defined in '%s'",
+					kinds[section], name, source, other))
+			} else if seen[name] {
+				errs = append(errs, errors.Errorf("%s '%s' is defined more than once in included file '%s'",
+					kinds[section], name, source))
+			}
+			seen[name] = true
+		}
+	}
+	return errs
+}
+
+// definedNames returns the names of the definitions in each section of a
+// project config document.
+func definedNames(doc yaml.MapSlice) map[string][]string {
+	out := map[string][]string{}
+	for _, item := range doc {
+		section, _ := item.Key.(string)
+		switch value := item.Value.(type) {
+		case []interface{}:
+			if section != tasksKey && section != taskGroupsKey && section != buildVariantsKey {
+				continue
+			}
+			for _, def := range value {
+				fields, ok := def.(yaml.MapSlice)
+				if !ok {
+					continue
+				}
+				for _, field := range fields {
+					if field.Key == "name" || field.Key == "matrix_name" {
+						out[section] = append(out[section], fmt.Sprint(field.Value))
+						break
+					}
+				}
+			}
+		case yaml.MapSlice:
+			if section != functionsKey {
+				continue
+			}
+			for _, fn := range value {
+				out[section] = append(out[section], fmt.Sprint(fn.Key))
+			}
+		}
+	}
+	return out
+}
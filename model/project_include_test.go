@@ -0,0 +1,163 @@
+package model
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mapIncludeFetcher(files map[string]string) IncludeFetcher {
+	return func(_ context.Context, include ProjectInclude, module *Module) ([]byte, error) {
+		name := include.FileName
+		if module != nil {
+			name = module.Name + "/" + name
+		}
+		data, ok := files[name]
+		if !ok {
+			return nil, errors.Errorf("no file '%s'", name)
+		}
+		return []byte(data), nil
+	}
+}
+
+func TestResolveIncludes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	main := `
+include:
+  - tasks.yml
+  - filename: variants.yml
+    module: shared
+modules:
+  - name: shared
+    repo: git@github.com:evergreen-ci/shared.git
+    branch: master
+functions:
+  setup:
+    command: shell.exec
+tasks:
+  - name: compile
+`
+	fetch := mapIncludeFetcher(map[string]string{
+		"tasks.yml": `
+functions:
+  teardown:
+    command: shell.exec
+tasks:
+  - name: test
+    depends_on:
+      - name: compile
+    commands:
+      - func: setup
+task_groups:
+  - name: tests
+    tasks:
+      - test
+`,
+		"shared/variants.yml": `
+buildvariants:
+  - name: ubuntu
+    run_on: ubuntu1604-test
+    tasks:
+      - name: compile
+      - name: tests
+`,
+	})
+
+	resolved, err := ResolveIncludes(ctx, []byte(main), fetch)
+	require.NoError(err)
+	assert.NotContains(string(resolved), "include")
+
+	project := &Project{}
+	require.NoError(LoadProjectInto(resolved, "mci", project))
+	require.Len(project.Tasks, 2)
+	assert.Equal("compile", project.Tasks[0].Name)
+	assert.Equal("test", project.Tasks[1].Name)
+	assert.Len(project.Functions, 2)
+	assert.Contains(project.Functions, "teardown")
+	require.Len(project.TaskGroups, 1)
+	require.Len(project.BuildVariants, 1)
+	assert.Equal("ubuntu", project.BuildVariants[0].Name)
+	assert.Len(project.BuildVariants[0].Tasks, 2)
+
+	// an unresolved config cannot be loaded
+	assert.Error(LoadProjectInto([]byte(main), "mci", &Project{}))
+
+	// a config without includes is unchanged
+	unchanged, err := ResolveIncludes(ctx, []byte("tasks:\n- name: compile\n"), nil)
+	assert.NoError(err)
+	assert.Equal("tasks:\n- name: compile\n", string(unchanged))
+}
+
+func TestResolveIncludesErrors(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fetch := mapIncludeFetcher(map[string]string{
+		"duplicate_task.yml":     "tasks:\n  - name: compile\n",
+		"duplicate_function.yml": "functions:\n  setup:\n    command: shell.exec\n",
+		"repeated.yml":           "tasks:\n  - name: lint\n  - name: lint\n",
+		"other.yml":              "tasks:\n  - name: lint\n",
+		"pre.yml":                "pre:\n  - command: shell.exec\n",
+		"nested.yml":             "include:\n  - other.yml\n",
+	})
+	main := "functions:\n  setup:\n    command: shell.exec\ntasks:\n  - name: compile\ninclude:\n"
+
+	for name, include := range map[string]string{
+		"TaskDefinedInMain":          "  - duplicate_task.yml\n",
+		"FunctionDefinedInMain":      "  - duplicate_function.yml\n",
+		"TaskRepeatedInIncludedFile": "  - repeated.yml\n",
+		"TaskInTwoIncludedFiles":     "  - other.yml\n  - repeated.yml\n",
+		"UnsupportedSection":         "  - pre.yml\n",
+		"NestedInclude":              "  - nested.yml\n",
+		"MissingFile":                "  - missing.yml\n",
+		"UndefinedModule":            "  - filename: other.yml\n    module: nonexistent\n",
+		"NoFileName":                 "  - module: nonexistent\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ResolveIncludes(ctx, []byte(main+include), fetch)
+			assert.Error(err)
+		})
+	}
+
+	_, err := ResolveIncludes(ctx, []byte(main+"  - other.yml\n"), nil)
+	assert.Error(err)
+}
+
+func TestLocalIncludeFetcher(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	root, err := ioutil.TempDir("", "include")
+	require.NoError(err)
+	defer os.RemoveAll(root)
+	moduleDir := filepath.Join(root, "module")
+	require.NoError(os.MkdirAll(filepath.Join(root, "etc"), 0755))
+	require.NoError(os.MkdirAll(moduleDir, 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(root, "etc", "tasks.yml"), []byte("project"), 0644))
+	require.NoError(ioutil.WriteFile(filepath.Join(moduleDir, "tasks.yml"), []byte("module"), 0644))
+
+	fetch := NewLocalIncludeFetcher(root, map[string]string{"shared": moduleDir})
+	ctx := context.Background()
+
+	data, err := fetch(ctx, ProjectInclude{FileName: "etc/tasks.yml"}, nil)
+	assert.NoError(err)
+	assert.Equal("project", string(data))
+
+	data, err = fetch(ctx, ProjectInclude{FileName: "tasks.yml", Module: "shared"}, &Module{Name: "shared"})
+	assert.NoError(err)
+	assert.Equal("module", string(data))
+
+	_, err = fetch(ctx, ProjectInclude{FileName: "tasks.yml", Module: "other"}, &Module{Name: "other"})
+	assert.Error(err)
+	_, err = fetch(ctx, ProjectInclude{FileName: "missing.yml"}, nil)
+	assert.Error(err)
+}
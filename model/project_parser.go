@@ -59,6 +59,7 @@ type parserProject struct {
 	TaskGroups      []parserTaskGroup          `yaml:"task_groups,omitempty"`
 	Tasks           []parserTask               `yaml:"tasks,omitempty"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs,omitempty"`
+	Include         []ProjectInclude           `yaml:"include,omitempty"`
 
 	// Matrix code
 	Axes []matrixAxis `yaml:"axes,omitempty"`
@@ -97,6 +98,7 @@ type parserTask struct {
 	Tags            parserStringSlice   `yaml:"tags,omitempty"`
 	Patchable       *bool               `yaml:"patchable,omitempty"`
 	Stepback        *bool               `yaml:"stepback,omitempty"`
+	Retry           *RetryPolicy        `yaml:"retry,omitempty"`
 }
 
 type displayTask struct {
@@ -262,6 +264,8 @@ type parserBV struct {
 	DisplayTasks []displayTask      `yaml:"display_tasks,omitempty"`
 	DependsOn    parserDependencies `yaml:"depends_on,omitempty"`
 	Requires     taskSelectors      `yaml:"requires,omitempty"`
+	Paths        parserStringSlice  `yaml:"paths,omitempty"`
+	IgnorePaths  parserStringSlice  `yaml:"ignore_paths,omitempty"`
 
 	// internal matrix stuff
 	matrixId  string
@@ -312,8 +316,11 @@ type parserBVTaskUnit struct {
 	Requires        taskSelectors      `yaml:"requires,omitempty"`
 	ExecTimeoutSecs int                `yaml:"exec_timeout_secs,omitempty"`
 	Stepback        *bool              `yaml:"stepback,omitempty"`
+	Retry           *RetryPolicy       `yaml:"retry,omitempty"`
 	Distros         parserStringSlice  `yaml:"distros,omitempty"`
 	RunOn           parserStringSlice  `yaml:"run_on,omitempty"` // Alias for "Distros" TODO: deprecate Distros
+	Paths           parserStringSlice  `yaml:"paths,omitempty"`
+	IgnorePaths     parserStringSlice  `yaml:"ignore_paths,omitempty"`
 }
 
 // UnmarshalYAML allows the YAML parser to read both a single selector string or
@@ -442,6 +449,9 @@ func createIntermediateProject(yml []byte) (*parserProject, []error) {
 // the Project type that Evergreen actually uses. Errors are added to
 // pp.errors and pp.warnings and must be checked separately.
 func translateProject(pp *parserProject) (*Project, []error) {
+	if len(pp.Include) > 0 {
+		return nil, []error{errors.New("project includes must be resolved before the project can be loaded")}
+	}
 	// Transfer top level fields
 	proj := &Project{
 		Enabled:         pp.Enabled,
@@ -509,6 +519,7 @@ func evaluateTaskUnits(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, v
 			Tags:            pt.Tags,
 			Patchable:       pt.Patchable,
 			Stepback:        pt.Stepback,
+			Retry:           pt.Retry,
 		}
 		t.DependsOn, errs = evaluateDependsOn(tse.tagEval, tgse, vse, pt.DependsOn)
 		evalErrs = append(evalErrs, errs...)
@@ -564,6 +575,8 @@ func evaluateBuildVariants(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluato
 			Stepback:    pbv.Stepback,
 			RunOn:       pbv.RunOn,
 			Tags:        pbv.Tags,
+			Paths:       pbv.Paths,
+			IgnorePaths: pbv.IgnorePaths,
 		}
 		bv.Tasks, errs = evaluateBVTasks(tse, tgse, vse, pbv)
 		// evaluate any rules passed in during matrix construction
@@ -715,7 +728,10 @@ func evaluateBVTasks(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, vse
 				Priority:        pt.Priority,
 				ExecTimeoutSecs: pt.ExecTimeoutSecs,
 				Stepback:        pt.Stepback,
+				Retry:           pt.Retry,
 				Distros:         pt.Distros,
+				Paths:           pt.Paths,
+				IgnorePaths:     pt.IgnorePaths,
 			}
 
 			// Task-level dependencies in the variant override variant-level dependencies
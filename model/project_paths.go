@@ -0,0 +1,166 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/model/version"
+	ignore "github.com/sabhiram/go-git-ignore"
+)
+
+// pathFilter decides whether a build variant or task is affected by a set
+// of changed files. Paths and ignore paths are gitignore-style patterns, as
+// for a project's Ignore list.
+type pathFilter struct {
+	paths  *ignore.GitIgnore
+	ignore *ignore.GitIgnore
+}
+
+// newPathFilter returns nil if neither paths nor ignore paths are set,
+// since everything is then affected.
+func newPathFilter(paths, ignorePaths []string) *pathFilter {
+	if len(paths) == 0 && len(ignorePaths) == 0 {
+		return nil
+	}
+
+	// CompileIgnoreLines always returns a nil error.
+	f := &pathFilter{}
+	if len(paths) > 0 {
+		f.paths, _ = ignore.CompileIgnoreLines(paths...)
+	}
+	if len(ignorePaths) > 0 {
+		f.ignore, _ = ignore.CompileIgnoreLines(ignorePaths...)
+	}
+	return f
+}
+
+// affectedBy returns whether any of the files matches the paths and does
+// not match the ignore paths.
+func (f *pathFilter) affectedBy(files []string) bool {
+	if f == nil {
+		return true
+	}
+	for _, file := range files {
+		if f.paths != nil && !f.paths.MatchesPath(file) {
+			continue
+		}
+		if f.ignore != nil && f.ignore.MatchesPath(file) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// HasPathFilters returns whether any build variant or task of the project
+// is limited to changes to certain paths.
+func (p *Project) HasPathFilters() bool {
+	for _, bv := range p.BuildVariants {
+		if len(bv.Paths) > 0 || len(bv.IgnorePaths) > 0 {
+			return true
+		}
+		for _, t := range bv.Tasks {
+			if len(t.Paths) > 0 || len(t.IgnorePaths) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SkipUnaffected removes the build variants and tasks from the project that
+// are not affected by changes to the given files, and returns the reason
+// each was skipped. Since the tasks are removed, tasks that depend on them
+// no longer wait for them. Nothing is skipped if there are no changed
+// files, since it is then unknown what changed.
+func (p *Project) SkipUnaffected(files []string) []version.SkippedUnit {
+	if len(files) == 0 {
+		return nil
+	}
+
+	skipped := []version.SkippedUnit{}
+	variants := BuildVariants{}
+	for _, bv := range p.BuildVariants {
+		if !newPathFilter(bv.Paths, bv.IgnorePaths).affectedBy(files) {
+			skipped = append(skipped, version.SkippedUnit{
+				BuildVariant: bv.Name,
+				Reason:       fmt.Sprintf("no changed files match the paths of build variant '%s'", bv.Name),
+			})
+			continue
+		}
+
+		tasks := []BuildVariantTaskUnit{}
+		removed := map[string]bool{}
+		for _, t := range bv.Tasks {
+			if newPathFilter(t.Paths, t.IgnorePaths).affectedBy(files) {
+				tasks = append(tasks, t)
+				continue
+			}
+			skipped = append(skipped, version.SkippedUnit{
+				BuildVariant: bv.Name,
+				Task:         t.Name,
+				Reason:       fmt.Sprintf("no changed files match the paths of task '%s'", t.Name),
+			})
+			removed[t.Name] = true
+			if tg := p.FindTaskGroup(t.Name); tg != nil {
+				for _, groupTask := range tg.Tasks {
+					removed[groupTask] = true
+				}
+			}
+		}
+		if len(tasks) == 0 {
+			// every task was skipped, and each has a reason already
+			continue
+		}
+		if len(removed) > 0 {
+			bv.Tasks = tasks
+			bv.DisplayTasks = withoutExecutionTasks(bv.DisplayTasks, removed)
+		}
+
+		variants = append(variants, bv)
+	}
+	p.BuildVariants = variants
+
+	return skipped
+}
+
+// withoutExecutionTasks removes the given execution tasks from display
+// tasks, dropping display tasks that are left empty.
+func withoutExecutionTasks(displayTasks []DisplayTask, removed map[string]bool) []DisplayTask {
+	out := []DisplayTask{}
+	for _, dt := range displayTasks {
+		execTasks := []string{}
+		for _, et := range dt.ExecutionTasks {
+			if !removed[et] {
+				execTasks = append(execTasks, et)
+			}
+		}
+		if len(execTasks) > 0 {
+			out = append(out, DisplayTask{Name: dt.Name, ExecutionTasks: execTasks})
+		}
+	}
+	return out
+}
+
+// withoutSkipped returns the task/variant pairs that remain in the project
+// after SkipUnaffected.
+func (p *Project) withoutSkipped(pairs TaskVariantPairs) TaskVariantPairs {
+	out := TaskVariantPairs{}
+	for _, pair := range pairs.ExecTasks {
+		if p.FindTaskForVariant(pair.TaskName, pair.Variant) != nil {
+			out.ExecTasks = append(out.ExecTasks, pair)
+		}
+	}
+	for _, pair := range pairs.DisplayTasks {
+		bv := p.FindBuildVariant(pair.Variant)
+		if bv == nil {
+			continue
+		}
+		for _, dt := range bv.DisplayTasks {
+			if dt.Name == pair.TaskName {
+				out.DisplayTasks = append(out.DisplayTasks, pair)
+				break
+			}
+		}
+	}
+	return out
+}
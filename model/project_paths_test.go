@@ -0,0 +1,104 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipUnaffected(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+  - name: compile
+  - name: test-web
+    depends_on:
+      - name: compile
+  - name: test-docs
+  - name: lint
+task_groups:
+  - name: api
+    tasks:
+      - lint
+buildvariants:
+  - name: ubuntu
+    run_on: ubuntu1604-test
+    tasks:
+      - name: compile
+      - name: test-web
+        paths:
+          - web/
+      - name: test-docs
+        paths:
+          - docs/
+        ignore_paths:
+          - docs/*.txt
+      - name: api
+        paths:
+          - api/
+    display_tasks:
+      - name: tests
+        execution_tasks:
+          - test-web
+          - test-docs
+      - name: checks
+        execution_tasks:
+          - lint
+  - name: docs
+    run_on: ubuntu1604-test
+    paths:
+      - docs/
+    ignore_paths:
+      - "*.txt"
+    tasks:
+      - name: test-docs
+  - name: windows
+    run_on: windows-test
+    ignore_paths:
+      - "*.md"
+      - "*.txt"
+      - web/
+    tasks:
+      - name: compile
+`
+	project := &Project{}
+	require.NoError(LoadProjectInto([]byte(yml), "mci", project))
+	require.True(project.HasPathFilters())
+	assert.Equal([]string{"web/"}, project.FindTaskForVariant("test-web", "ubuntu").Paths)
+	assert.Equal([]string{"docs/"}, project.FindBuildVariant("docs").Paths)
+	assert.Equal([]string{"*.md", "*.txt", "web/"}, project.FindBuildVariant("windows").IgnorePaths)
+
+	// nothing is skipped if the changed files are unknown
+	assert.Empty(project.SkipUnaffected(nil))
+	assert.Len(project.BuildVariants, 3)
+
+	skipped := project.SkipUnaffected([]string{"web/index.html", "docs/notes.txt", "README.md"})
+	require.Len(skipped, 4)
+	assert.Equal("ubuntu", skipped[0].BuildVariant)
+	assert.Equal("test-docs", skipped[0].Task)
+	assert.Equal("api", skipped[1].Task)
+	assert.Equal("docs", skipped[2].BuildVariant)
+	assert.Equal("", skipped[2].Task)
+	assert.Equal("windows", skipped[3].BuildVariant)
+	for _, s := range skipped {
+		assert.NotEmpty(s.Reason)
+	}
+
+	require.Len(project.BuildVariants, 1)
+	ubuntu := project.FindBuildVariant("ubuntu")
+	require.Len(ubuntu.Tasks, 2)
+	assert.Equal("compile", ubuntu.Tasks[0].Name)
+	assert.Equal("test-web", ubuntu.Tasks[1].Name)
+	require.Len(ubuntu.DisplayTasks, 1)
+	assert.Equal([]string{"test-web"}, ubuntu.DisplayTasks[0].ExecutionTasks)
+
+	pairs := project.withoutSkipped(TaskVariantPairs{
+		ExecTasks:    TVPairSet{{"ubuntu", "test-web"}, {"ubuntu", "test-docs"}, {"ubuntu", "lint"}, {"docs", "test-docs"}},
+		DisplayTasks: TVPairSet{{"ubuntu", "tests"}, {"ubuntu", "checks"}},
+	})
+	assert.Equal(TVPairSet{{"ubuntu", "test-web"}}, pairs.ExecTasks)
+	assert.Equal(TVPairSet{{"ubuntu", "tests"}}, pairs.DisplayTasks)
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"regexp"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -43,6 +46,11 @@ type ProjectRef struct {
 	// Admins contain a list of users who are able to access the projects page.
 	Admins []string `bson:"admins" json:"admins"`
 
+	// DefaultRole is the role that every logged in user has on the project,
+	// in addition to the roles assigned to them. If unset, it is the
+	// scheduler role.
+	DefaultRole string `bson:"default_role,omitempty" json:"default_role,omitempty" yaml:"default_role"`
+
 	// The "Alerts" field is a map of trigger (e.g. 'task-failed') to
 	// the set of alert deliveries to be processed for that trigger.
 	Alerts map[string][]AlertConfig `bson:"alert_settings" json:"alert_config,omitempty"`
@@ -53,8 +61,169 @@ type ProjectRef struct {
 	// RepoDetails contain the details of the status of the consistency
 	// between what is in GitHub and what is in Evergreen
 	RepotrackerError *RepositoryErrorDetails `bson:"repotracker_error" json:"repotracker_error"`
+
+	// HourlyBudget is the maximum amount per hour that the cost_budget host
+	// allocator will spend on hosts running this project's tasks within any
+	// one distro. Zero means no cap.
+	HourlyBudget float64 `bson:"hourly_budget,omitempty" json:"hourly_budget,omitempty" yaml:"hourly_budget"`
+
+	// CommitQueue configures the queue that serializes merges of GitHub
+	// pull requests into the project's branch.
+	CommitQueue CommitQueueParams `bson:"commit_queue" json:"commit_queue" yaml:"commit_queue"`
+
+	// PeriodicBuilds are versions created on a schedule, independently of
+	// any new commits to the project's branch.
+	PeriodicBuilds []PeriodicBuildDefinition `bson:"periodic_builds,omitempty" json:"periodic_builds,omitempty" yaml:"periodic_builds"`
+
+	// Triggers create versions of the project when versions, or builds of
+	// versions, of other projects finish.
+	Triggers []TriggerDefinition `bson:"triggers,omitempty" json:"triggers,omitempty" yaml:"triggers"`
+}
+
+// CommitQueueParams are the commit queue settings of a project. MergeMethod
+// is the GitHub merge method used to merge pull requests: merge, squash or
+// rebase.
+type CommitQueueParams struct {
+	Enabled     bool   `bson:"enabled" json:"enabled" yaml:"enabled"`
+	MergeMethod string `bson:"merge_method" json:"merge_method" yaml:"merge_method"`
+}
+
+// PeriodicBuildDefinition schedules versions of a project at the revision
+// of its most recent mainline version. The tasks of each version are
+// selected either by a project alias or by variant and task regexes, in the
+// same way as patch aliases.
+type PeriodicBuildDefinition struct {
+	ID      string `bson:"id" json:"id" yaml:"id"`
+	Cron    string `bson:"cron" json:"cron" yaml:"cron"`
+	Alias   string `bson:"alias,omitempty" json:"alias,omitempty" yaml:"alias"`
+	Variant string `bson:"variant,omitempty" json:"variant,omitempty" yaml:"variant"`
+	Task    string `bson:"task,omitempty" json:"task,omitempty" yaml:"task"`
+	Message string `bson:"message,omitempty" json:"message,omitempty" yaml:"message"`
+}
+
+// Validate checks that the definition has an ID, a valid cron expression,
+// and selects tasks either by alias or by variant and task regexes.
+func (d *PeriodicBuildDefinition) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if d.ID == "" {
+		catcher.Add(errors.New("periodic build must have an ID"))
+	}
+	if _, err := util.ParseCron(d.Cron); err != nil {
+		catcher.Add(errors.Wrapf(err, "invalid cron for periodic build '%s'", d.ID))
+	}
+	if d.Alias != "" {
+		if d.Variant != "" || d.Task != "" {
+			catcher.Add(errors.Errorf("periodic build '%s' cannot have both an alias and a variant or task", d.ID))
+		}
+	} else {
+		if d.Variant == "" || d.Task == "" {
+			catcher.Add(errors.Errorf("periodic build '%s' must have either an alias or a variant and task", d.ID))
+		}
+		if _, err := regexp.Compile(d.Variant); err != nil {
+			catcher.Add(errors.Wrapf(err, "invalid variant regex for periodic build '%s'", d.ID))
+		}
+		if _, err := regexp.Compile(d.Task); err != nil {
+			catcher.Add(errors.Wrapf(err, "invalid task regex for periodic build '%s'", d.ID))
+		}
+	}
+	return catcher.Resolve()
+}
+
+// ValidatePeriodicBuilds validates each of the project's periodic build
+// definitions and checks that their IDs are unique.
+func (projectRef *ProjectRef) ValidatePeriodicBuilds() error {
+	catcher := grip.NewBasicCatcher()
+	ids := map[string]bool{}
+	for i := range projectRef.PeriodicBuilds {
+		def := &projectRef.PeriodicBuilds[i]
+		catcher.Add(def.Validate())
+		if ids[def.ID] {
+			catcher.Add(errors.Errorf("duplicate periodic build ID '%s'", def.ID))
+		}
+		ids[def.ID] = true
+	}
+	return catcher.Resolve()
 }
 
+const (
+	ProjectTriggerLevelVersion = "version"
+	ProjectTriggerLevelBuild   = "build"
+)
+
+// TriggerDefinition creates a version of a project when a mainline version
+// of an upstream project, or a build of one, finishes. Build triggers may
+// be restricted to variants and tasks matching regexes, in which case the
+// matching tasks of the build decide its status. The tasks of the created
+// version are selected by one of the project's aliases.
+type TriggerDefinition struct {
+	ID      string `bson:"id" json:"id" yaml:"id"`
+	Project string `bson:"project" json:"project" yaml:"project"`
+	Level   string `bson:"level" json:"level" yaml:"level"`
+	// Status is the upstream status which fires the trigger, one of
+	// "success" or "failed". Any finished status fires it if unset.
+	Status  string `bson:"status,omitempty" json:"status,omitempty" yaml:"status"`
+	Variant string `bson:"variant,omitempty" json:"variant,omitempty" yaml:"variant"`
+	Task    string `bson:"task,omitempty" json:"task,omitempty" yaml:"task"`
+	Alias   string `bson:"alias" json:"alias" yaml:"alias"`
+}
+
+// Validate checks that the definition has an ID, an upstream project, a
+// valid level and status, and an alias selecting the tasks to run.
+func (d *TriggerDefinition) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if d.ID == "" {
+		catcher.Add(errors.New("trigger must have an ID"))
+	}
+	if d.Project == "" {
+		catcher.Add(errors.Errorf("trigger '%s' must have an upstream project", d.ID))
+	}
+	if d.Alias == "" {
+		catcher.Add(errors.Errorf("trigger '%s' must have an alias", d.ID))
+	}
+	if d.Status != "" && d.Status != evergreen.VersionSucceeded && d.Status != evergreen.VersionFailed {
+		catcher.Add(errors.Errorf("invalid status '%s' for trigger '%s'", d.Status, d.ID))
+	}
+	switch d.Level {
+	case ProjectTriggerLevelVersion:
+		if d.Variant != "" || d.Task != "" {
+			catcher.Add(errors.Errorf("version trigger '%s' cannot have a variant or task", d.ID))
+		}
+	case ProjectTriggerLevelBuild:
+		if _, err := regexp.Compile(d.Variant); err != nil {
+			catcher.Add(errors.Wrapf(err, "invalid variant regex for trigger '%s'", d.ID))
+		}
+		if _, err := regexp.Compile(d.Task); err != nil {
+			catcher.Add(errors.Wrapf(err, "invalid task regex for trigger '%s'", d.ID))
+		}
+	default:
+		catcher.Add(errors.Errorf("invalid level '%s' for trigger '%s'", d.Level, d.ID))
+	}
+	return catcher.Resolve()
+}
+
+// ValidateTriggers validates each of the project's trigger definitions and
+// checks that their IDs are unique and that no trigger is on the project
+// itself.
+func (projectRef *ProjectRef) ValidateTriggers() error {
+	catcher := grip.NewBasicCatcher()
+	ids := map[string]bool{}
+	for i := range projectRef.Triggers {
+		def := &projectRef.Triggers[i]
+		catcher.Add(def.Validate())
+		if def.Project == projectRef.Identifier {
+			catcher.Add(errors.Errorf("trigger '%s' cannot be on its own project", def.ID))
+		}
+		if ids[def.ID] {
+			catcher.Add(errors.Errorf("duplicate trigger ID '%s'", def.ID))
+		}
+		ids[def.ID] = true
+	}
+	return catcher.Resolve()
+}
+
+// ValidMergeMethods are the merge methods a commit queue can use.
+var ValidMergeMethods = []string{"merge", "squash", "rebase"}
+
 // RepositoryErrorDetails indicates whether or not there is an invalid revision and if there is one,
 // what the guessed merge base revision is.
 type RepositoryErrorDetails struct {
@@ -105,6 +274,13 @@ var (
 	projectRefPRTestingEnabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "PRTestingEnabled")
 	projectRefPatchingDisabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "PatchingDisabled")
 	projectRefNotifyOnFailureKey    = bsonutil.MustHaveTag(ProjectRef{}, "NotifyOnBuildFailure")
+	projectRefHourlyBudgetKey       = bsonutil.MustHaveTag(ProjectRef{}, "HourlyBudget")
+	projectRefCommitQueueKey        = bsonutil.MustHaveTag(ProjectRef{}, "CommitQueue")
+	projectRefDefaultRoleKey        = bsonutil.MustHaveTag(ProjectRef{}, "DefaultRole")
+	projectRefPeriodicBuildsKey     = bsonutil.MustHaveTag(ProjectRef{}, "PeriodicBuilds")
+	projectRefTriggersKey           = bsonutil.MustHaveTag(ProjectRef{}, "Triggers")
+
+	triggerDefinitionProjectKey = bsonutil.MustHaveTag(TriggerDefinition{}, "Project")
 )
 
 const (
@@ -165,6 +341,44 @@ func FindAllTrackedProjectRefs() ([]ProjectRef, error) {
 	return projectRefs, err
 }
 
+// FindPeriodicProjectRefs returns all enabled project refs that define
+// periodic builds.
+func FindPeriodicProjectRefs() ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey:               true,
+			projectRefPeriodicBuildsKey + ".0": bson.M{"$exists": true},
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	return projectRefs, err
+}
+
+// FindDownstreamProjectRefs returns all enabled project refs with a
+// trigger on the given upstream project.
+func FindDownstreamProjectRefs(upstreamProject string) ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey: true,
+			bsonutil.GetDottedKeyName(projectRefTriggersKey, triggerDefinitionProjectKey): upstreamProject,
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	return projectRefs, err
+}
+
 // FindAllProjectRefs returns all project refs in the db
 func FindAllProjectRefs() ([]ProjectRef, error) {
 	projectRefs := []ProjectRef{}
@@ -316,12 +530,50 @@ func (projectRef *ProjectRef) Upsert() error {
 				projectRefPRTestingEnabledKey:   projectRef.PRTestingEnabled,
 				projectRefPatchingDisabledKey:   projectRef.PatchingDisabled,
 				projectRefNotifyOnFailureKey:    projectRef.NotifyOnBuildFailure,
+				projectRefHourlyBudgetKey:       projectRef.HourlyBudget,
+				projectRefCommitQueueKey:        projectRef.CommitQueue,
+				projectRefDefaultRoleKey:        projectRef.DefaultRole,
+				projectRefPeriodicBuildsKey:     projectRef.PeriodicBuilds,
+				projectRefTriggersKey:           projectRef.Triggers,
 			},
 		},
 	)
 	return err
 }
 
+// GetDefaultRole returns the role that every logged in user has on the
+// project.
+func (projectRef *ProjectRef) GetDefaultRole() string {
+	if projectRef.DefaultRole == "" {
+		return role.Scheduler
+	}
+	return projectRef.DefaultRole
+}
+
+// FindProjectHourlyBudgets returns a map of project identifier to hourly
+// budget for every project that has a budget set.
+func FindProjectHourlyBudgets() (map[string]float64, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{projectRefHourlyBudgetKey: bson.M{"$gt": 0}},
+		bson.M{ProjectRefIdentifierKey: 1, projectRefHourlyBudgetKey: 1},
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding project budgets")
+	}
+
+	budgets := make(map[string]float64, len(projectRefs))
+	for _, ref := range projectRefs {
+		budgets[ref.Identifier] = ref.HourlyBudget
+	}
+	return budgets, nil
+}
+
 // ProjectRef returns a string representation of a ProjectRef
 func (projectRef *ProjectRef) String() string {
 	return projectRef.Identifier
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/stretchr/testify/assert"
@@ -211,3 +212,62 @@ func TestFindOneProjectRefByRepoAndBranchWithPRTesting(t *testing.T) {
 	assert.Contains(err.Error(), "found 2 project refs, when 1 was expected")
 	require.Nil(projectRef)
 }
+
+func TestValidatePeriodicBuilds(t *testing.T) {
+	assert := assert.New(t)
+
+	projectRef := &ProjectRef{
+		Identifier: "proj",
+		PeriodicBuilds: []PeriodicBuildDefinition{
+			{ID: "nightly", Cron: "0 2 * * *", Alias: "nightly"},
+			{ID: "hourly-lint", Cron: "@hourly", Variant: "^linux$", Task: "lint"},
+		},
+	}
+	assert.NoError(projectRef.ValidatePeriodicBuilds())
+
+	for _, def := range []PeriodicBuildDefinition{
+		{Cron: "0 2 * * *", Alias: "nightly"},
+		{ID: "bad-cron", Cron: "0 2 * *", Alias: "nightly"},
+		{ID: "no-tasks", Cron: "@daily"},
+		{ID: "no-task", Cron: "@daily", Variant: ".*"},
+		{ID: "both", Cron: "@daily", Alias: "nightly", Variant: ".*", Task: ".*"},
+		{ID: "bad-regex", Cron: "@daily", Variant: "(", Task: ".*"},
+	} {
+		assert.Error(def.Validate(), def.ID)
+	}
+
+	projectRef.PeriodicBuilds = append(projectRef.PeriodicBuilds, projectRef.PeriodicBuilds[0])
+	assert.Error(projectRef.ValidatePeriodicBuilds())
+}
+
+func TestValidateTriggers(t *testing.T) {
+	assert := assert.New(t)
+
+	projectRef := &ProjectRef{
+		Identifier: "integration",
+		Triggers: []TriggerDefinition{
+			{ID: "lib-green", Project: "lib", Level: ProjectTriggerLevelVersion, Status: evergreen.VersionSucceeded, Alias: "smoke"},
+			{ID: "lib-linux", Project: "lib", Level: ProjectTriggerLevelBuild, Variant: "^linux", Task: "^package$", Alias: "smoke"},
+			{ID: "lib-any", Project: "lib", Level: ProjectTriggerLevelBuild, Alias: "smoke"},
+		},
+	}
+	assert.NoError(projectRef.ValidateTriggers())
+
+	for _, def := range []TriggerDefinition{
+		{Project: "lib", Level: ProjectTriggerLevelVersion, Alias: "smoke"},
+		{ID: "no-project", Level: ProjectTriggerLevelVersion, Alias: "smoke"},
+		{ID: "no-alias", Project: "lib", Level: ProjectTriggerLevelVersion},
+		{ID: "bad-level", Project: "lib", Level: "task", Alias: "smoke"},
+		{ID: "bad-status", Project: "lib", Level: ProjectTriggerLevelVersion, Status: "started", Alias: "smoke"},
+		{ID: "version-variant", Project: "lib", Level: ProjectTriggerLevelVersion, Variant: ".*", Alias: "smoke"},
+		{ID: "bad-regex", Project: "lib", Level: ProjectTriggerLevelBuild, Task: "(", Alias: "smoke"},
+	} {
+		assert.Error(def.Validate(), def.ID)
+	}
+
+	projectRef.Triggers = append(projectRef.Triggers, TriggerDefinition{ID: "self", Project: "integration", Level: ProjectTriggerLevelVersion, Alias: "smoke"})
+	assert.Error(projectRef.ValidateTriggers())
+
+	projectRef.Triggers = append(projectRef.Triggers[:3], projectRef.Triggers[0])
+	assert.Error(projectRef.ValidateTriggers())
+}
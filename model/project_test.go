@@ -443,6 +443,32 @@ func TestPopulateExpansions(t *testing.T) {
 	assert.Equal("octocat", expansions.Get("github_author"))
 	assert.Equal("42", expansions.Get("github_pr_number"))
 	assert.Equal("wut?", expansions.Get("github_org"))
+
+	v.Requester = evergreen.TriggerRequester
+	v.Upstream = &version.UpstreamVersion{
+		TriggerID:    "lib-linux",
+		Project:      "lib",
+		VersionId:    "lib_abc",
+		Revision:     "abc",
+		BuildId:      "lib_linux_abc",
+		BuildVariant: "linux",
+		Status:       evergreen.BuildSucceeded,
+		Artifacts: []version.UpstreamArtifact{
+			{Task: "package", Name: "tarball", Link: "https://example.com/lib.tgz"},
+			{Task: "package", Name: "docs", Link: "https://example.com/docs.tgz"},
+		},
+	}
+	expansions = populateExpansions(d, v, bv, taskDoc, nil)
+	assert.Len(map[string]string(*expansions), 25)
+	assert.False(expansions.Exists("is_patch"))
+	assert.Equal("lib-linux", expansions.Get("upstream_trigger_id"))
+	assert.Equal("lib", expansions.Get("upstream_project"))
+	assert.Equal("lib_abc", expansions.Get("upstream_version_id"))
+	assert.Equal("abc", expansions.Get("upstream_revision"))
+	assert.Equal(evergreen.BuildSucceeded, expansions.Get("upstream_status"))
+	assert.Equal("lib_linux_abc", expansions.Get("upstream_build_id"))
+	assert.Equal("linux", expansions.Get("upstream_build_variant"))
+	assert.Equal("https://example.com/lib.tgz https://example.com/docs.tgz", expansions.Get("upstream_artifact_urls"))
 }
 
 type projectSuite struct {
@@ -1000,6 +1026,27 @@ func (s *projectSuite) TestBuildProjectTVPairsWithExecutionTask() {
 	s.Require().Len(patchDoc.VariantsTasks, 2)
 }
 
+func (s *projectSuite) TestBuildPeriodicBuildTVPairs() {
+	tasks, err := s.project.BuildPeriodicBuildTVPairs(PeriodicBuildDefinition{ID: "nightly", Alias: "2tasks"})
+	s.Require().NoError(err)
+	s.Len(tasks.ExecTasks, 4)
+	s.Contains(tasks.ExecTasks, TVPair{Variant: "bv_1", TaskName: "a_task_2"})
+	s.Contains(tasks.ExecTasks, TVPair{Variant: "bv_1", TaskName: "b_task_2"})
+	s.Contains(tasks.ExecTasks, TVPair{Variant: "bv_2", TaskName: "a_task_2"})
+	s.Contains(tasks.ExecTasks, TVPair{Variant: "bv_2", TaskName: "b_task_2"})
+	s.Empty(tasks.DisplayTasks)
+
+	tasks, err = s.project.BuildPeriodicBuildTVPairs(PeriodicBuildDefinition{ID: "wow", Variant: "bv_1", Task: "wow_task"})
+	s.Require().NoError(err)
+	s.Contains(tasks.ExecTasks, TVPair{Variant: "bv_1", TaskName: "wow_task"})
+	s.Contains(tasks.ExecTasks, TVPair{Variant: "bv_1", TaskName: "a_task_1"})
+	s.Contains(tasks.ExecTasks, TVPair{Variant: "bv_2", TaskName: "a_task_1"})
+	s.Equal(TVPairSet{{Variant: "bv_1", TaskName: "memes"}}, tasks.DisplayTasks)
+
+	_, err = s.project.BuildPeriodicBuildTVPairs(PeriodicBuildDefinition{ID: "missing", Alias: "not_an_alias"})
+	s.Error(err)
+}
+
 func (s *projectSuite) TestNewPatchTaskIdTable() {
 	p := &Project{
 		Identifier: "project_identifier",
@@ -1111,14 +1158,41 @@ func (s *projectSuite) TestFetchVersionsAndAssociatedBuilds() {
 	}
 	s.NoError(b3.Insert())
 
-	versions, builds, err := FetchVersionsAndAssociatedBuilds(s.project, 0, 10)
+	versions, builds, err := FetchVersionsAndAssociatedBuilds(s.project, 0, 10, []string{evergreen.RepotrackerVersionRequester})
 	s.NoError(err)
+	s.Require().Len(versions, 3)
 	s.Equal(v3.Id, versions[0].Id)
 	s.Equal(v2.Id, versions[1].Id)
 	s.Equal(v1.Id, versions[2].Id)
 	s.Equal(b1.Id, builds[v1.Id][0].Id)
 	s.Equal(b2.Id, builds[v2.Id][0].Id)
 	s.Equal(b3.Id, builds[v3.Id][0].Id)
+
+	periodic := version.Version{
+		Id:                  "periodic",
+		Identifier:          s.project.Identifier,
+		Requester:           evergreen.PeriodicBuildRequester,
+		RevisionOrderNumber: 4,
+	}
+	s.NoError(periodic.Insert())
+	patchVersion := version.Version{
+		Id:                  "patch",
+		Identifier:          s.project.Identifier,
+		Requester:           evergreen.PatchVersionRequester,
+		RevisionOrderNumber: 5,
+	}
+	s.NoError(patchVersion.Insert())
+
+	versions, _, err = FetchVersionsAndAssociatedBuilds(s.project, 0, 10, evergreen.SystemVersionRequesters)
+	s.NoError(err)
+	s.Require().Len(versions, 4)
+	s.Equal(periodic.Id, versions[0].Id)
+	s.Equal(evergreen.PeriodicBuildRequester, versions[0].Requester)
+
+	versions, _, err = FetchVersionsAndAssociatedBuilds(s.project, 0, 10, []string{evergreen.PeriodicBuildRequester})
+	s.NoError(err)
+	s.Require().Len(versions, 1)
+	s.Equal(periodic.Id, versions[0].Id)
 }
 
 func (s *projectSuite) TestIsGenerateTask() {
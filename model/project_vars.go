@@ -1,8 +1,13 @@
 package model
 
 import (
+	"context"
+
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/secrets"
 	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -11,6 +16,7 @@ var (
 	projectVarIdKey   = bsonutil.MustHaveTag(ProjectVars{}, "Id")
 	projectVarsMapKey = bsonutil.MustHaveTag(ProjectVars{}, "Vars")
 	privateVarsMapKey = bsonutil.MustHaveTag(ProjectVars{}, "PrivateVars")
+	secretRefsMapKey  = bsonutil.MustHaveTag(ProjectVars{}, "SecretRefs")
 )
 
 const (
@@ -32,6 +38,12 @@ type ProjectVars struct {
 	//PrivateVars keeps track of which variables are private and should therefore not
 	//be returned to the UI server.
 	PrivateVars map[string]bool `bson:"private_vars" json:"private_vars"`
+
+	//SecretRefs maps variable names to references to values held in an
+	//external secret store, i.e. "keyring:api_key". References are relative
+	//to the project's own secrets. Only the reference is stored; the value is
+	//resolved on the server when the agent fetches vars.
+	SecretRefs map[string]string `bson:"secret_refs,omitempty" json:"secret_refs,omitempty"`
 }
 
 func FindOneProjectVars(projectId string) (*ProjectVars, error) {
@@ -64,6 +76,7 @@ func (projectVars *ProjectVars) Upsert() (*mgo.ChangeInfo, error) {
 			"$set": bson.M{
 				projectVarsMapKey: projectVars.Vars,
 				privateVarsMapKey: projectVars.PrivateVars,
+				secretRefsMapKey:  projectVars.SecretRefs,
 			},
 		},
 	)
@@ -88,3 +101,41 @@ func (projectVars *ProjectVars) RedactPrivateVars() {
 		}
 	}
 }
+
+// ValidateSecretRefs checks that every secret reference is well formed and
+// stays within the project's own secrets.
+func (projectVars *ProjectVars) ValidateSecretRefs() error {
+	catcher := grip.NewBasicCatcher()
+	for k, ref := range projectVars.SecretRefs {
+		if _, err := secrets.ParseReference(ref); err != nil {
+			catcher.Add(errors.Wrapf(err, "invalid reference for variable '%s'", k))
+		}
+	}
+	return catcher.Resolve()
+}
+
+// ResolveSecretRefs looks up the value of each secret reference using the
+// given providers and stores it in Vars. Resolved variables are always
+// marked private.
+func (projectVars *ProjectVars) ResolveSecretRefs(ctx context.Context, providers map[string]secrets.SecretProvider) error {
+	if len(projectVars.SecretRefs) == 0 {
+		return nil
+	}
+	if projectVars.Vars == nil {
+		projectVars.Vars = map[string]string{}
+	}
+	if projectVars.PrivateVars == nil {
+		projectVars.PrivateVars = map[string]bool{}
+	}
+
+	for k, ref := range projectVars.SecretRefs {
+		value, err := secrets.Resolve(ctx, providers, projectVars.Id, ref)
+		if err != nil {
+			return errors.Wrapf(err, "problem resolving variable '%s' for project '%s'", k, projectVars.Id)
+		}
+		projectVars.Vars[k] = value
+		projectVars.PrivateVars[k] = true
+	}
+
+	return nil
+}
@@ -1,9 +1,12 @@
 package model
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/secrets"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -70,3 +73,56 @@ func TestRedactPrivateVars(t *testing.T) {
 	projectVars.RedactPrivateVars()
 	assert.Equal("", projectVars.Vars["a"], "redacted variables should be empty strings")
 }
+
+type mockSecretProvider map[string]string
+
+func (p mockSecretProvider) GetSecret(_ context.Context, path string) (string, error) {
+	value, ok := p[path]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return value, nil
+}
+
+func TestProjectVarsSecretRefs(t *testing.T) {
+	assert := assert.New(t)
+
+	testutil.HandleTestingErr(db.Clear(ProjectVarsCollection), t,
+		"Error clearing collection")
+
+	vars := &ProjectVars{
+		Id:   "mongodb",
+		Vars: map[string]string{"a": "1"},
+		SecretRefs: map[string]string{
+			"token": "keyring:token",
+		},
+	}
+	assert.NoError(vars.ValidateSecretRefs())
+	_, err := vars.Upsert()
+	assert.NoError(err)
+
+	projectVarsFromDB, err := FindOneProjectVars("mongodb")
+	assert.NoError(err)
+	assert.Equal(vars.SecretRefs, projectVarsFromDB.SecretRefs)
+	assert.NotContains(projectVarsFromDB.Vars, "token")
+
+	providers := map[string]secrets.SecretProvider{
+		secrets.ProviderKeyring: mockSecretProvider{"mongodb/token": "secret"},
+	}
+	assert.NoError(projectVarsFromDB.ResolveSecretRefs(context.Background(), providers))
+	assert.Equal("secret", projectVarsFromDB.Vars["token"])
+	assert.True(projectVarsFromDB.PrivateVars["token"])
+	assert.Equal("1", projectVarsFromDB.Vars["a"])
+
+	projectVarsFromDB.SecretRefs["missing"] = "keyring:missing"
+	assert.Error(projectVarsFromDB.ResolveSecretRefs(context.Background(), providers))
+
+	projectVarsFromDB.SecretRefs["unconfigured"] = "http_kv:secret#value"
+	delete(projectVarsFromDB.SecretRefs, "missing")
+	assert.Error(projectVarsFromDB.ResolveSecretRefs(context.Background(), providers))
+
+	vars.SecretRefs["bad"] = "not-a-reference"
+	assert.Error(vars.ValidateSecretRefs())
+	vars.SecretRefs["bad"] = "keyring:../other/token"
+	assert.Error(vars.ValidateSecretRefs())
+}
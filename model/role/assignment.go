@@ -0,0 +1,117 @@
+package role
+
+import (
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Collection is the name of the role assignments collection in the database.
+const Collection = "role_assignments"
+
+// Assignment grants a role on a project to either a user or a group. Groups
+// are matched against the system roles of users, such as the ones mapped
+// from LDAP groups.
+type Assignment struct {
+	ProjectID string `bson:"project_id" json:"project_id"`
+	Role      string `bson:"role" json:"role"`
+	User      string `bson:"user,omitempty" json:"user,omitempty"`
+	Group     string `bson:"group,omitempty" json:"group,omitempty"`
+}
+
+var (
+	ProjectIDKey = bsonutil.MustHaveTag(Assignment{}, "ProjectID")
+	RoleKey      = bsonutil.MustHaveTag(Assignment{}, "Role")
+	UserKey      = bsonutil.MustHaveTag(Assignment{}, "User")
+	GroupKey     = bsonutil.MustHaveTag(Assignment{}, "Group")
+)
+
+// Validate checks that the assignment grants a built in role on a project to
+// exactly one of a user or a group.
+func (a *Assignment) Validate() error {
+	if a.ProjectID == "" {
+		return errors.New("role assignment must have a project")
+	}
+	if !IsValid(a.Role) {
+		return errors.Errorf("'%s' is not a valid role, must be one of %v", a.Role, Names())
+	}
+	if (a.User == "") == (a.Group == "") {
+		return errors.New("role assignment must be to exactly one of a user or a group")
+	}
+	return nil
+}
+
+func (a *Assignment) query() bson.M {
+	q := bson.M{
+		ProjectIDKey: a.ProjectID,
+		RoleKey:      a.Role,
+	}
+	if a.User != "" {
+		q[UserKey] = a.User
+	} else {
+		q[GroupKey] = a.Group
+	}
+	return q
+}
+
+// Upsert saves the assignment. Assigning a role that is already assigned
+// does nothing.
+func (a *Assignment) Upsert() error {
+	if err := a.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+	q := a.query()
+	_, err := db.Upsert(Collection, q, bson.M{"$set": q})
+	return errors.Wrapf(err, "problem assigning role '%s' on project '%s'", a.Role, a.ProjectID)
+}
+
+// Remove deletes the assignment, if it exists.
+func (a *Assignment) Remove() error {
+	if err := a.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+	err := db.RemoveAll(Collection, a.query())
+	return errors.Wrapf(err, "problem removing role '%s' on project '%s'", a.Role, a.ProjectID)
+}
+
+// FindByProject returns all of the roles assigned on a project.
+func FindByProject(projectID string) ([]Assignment, error) {
+	out := []Assignment{}
+	q := db.Query(bson.M{ProjectIDKey: projectID}).Sort([]string{RoleKey, UserKey, GroupKey})
+	if err := db.FindAllQ(Collection, q, &out); err != nil {
+		return nil, errors.Wrapf(err, "problem finding roles for project '%s'", projectID)
+	}
+	return out, nil
+}
+
+// FindForUser returns the roles assigned on a project to a user or to any of
+// their groups.
+func FindForUser(projectID, user string, groups []string) ([]Assignment, error) {
+	q := byUser(user, groups)
+	q[ProjectIDKey] = projectID
+
+	out := []Assignment{}
+	if err := db.FindAllQ(Collection, db.Query(q), &out); err != nil {
+		return nil, errors.Wrapf(err, "problem finding roles of '%s' for project '%s'", user, projectID)
+	}
+	return out, nil
+}
+
+// FindAllForUser returns the roles assigned on any project to a user or to
+// any of their groups.
+func FindAllForUser(user string, groups []string) ([]Assignment, error) {
+	out := []Assignment{}
+	if err := db.FindAllQ(Collection, db.Query(byUser(user, groups)), &out); err != nil {
+		return nil, errors.Wrapf(err, "problem finding roles of '%s'", user)
+	}
+	return out, nil
+}
+
+func byUser(user string, groups []string) bson.M {
+	subjects := []bson.M{{UserKey: user}}
+	if len(groups) > 0 {
+		subjects = append(subjects, bson.M{GroupKey: bson.M{"$in": groups}})
+	}
+	return bson.M{"$or": subjects}
+}
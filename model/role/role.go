@@ -0,0 +1,112 @@
+package role
+
+// Permission is an action on a project that a role may allow.
+type Permission string
+
+const (
+	// ViewProject allows viewing a project and its tasks, and trying out
+	// changes to them that are not saved.
+	ViewProject Permission = "view_project"
+	// SubmitPatches allows finalizing patches against a project.
+	SubmitPatches Permission = "submit_patches"
+	// TaskControl allows restarting and aborting tasks, builds and versions
+	// of a project, and setting their priority.
+	TaskControl Permission = "task_control"
+	// SpawnHosts allows spawning hosts to debug the tasks of a project.
+	SpawnHosts Permission = "spawn_hosts"
+	// EditProject allows changing the settings and variables of a project,
+	// and who has what role on it.
+	EditProject Permission = "edit_project"
+)
+
+// Names of the built in roles. Each role has the permissions of the ones
+// before it. Viewers can view projects, but cannot change anything.
+const (
+	Viewer    = "viewer"
+	Patcher   = "patcher"
+	Scheduler = "scheduler"
+	Admin     = "admin"
+)
+
+// Role is a named set of permissions on a project.
+type Role struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+}
+
+var roles = []Role{
+	{
+		Name:        Viewer,
+		Description: "can view the project",
+		Permissions: []Permission{ViewProject},
+	},
+	{
+		Name:        Patcher,
+		Description: "can view the project and submit patches",
+		Permissions: []Permission{ViewProject, SubmitPatches},
+	},
+	{
+		Name:        Scheduler,
+		Description: "can submit patches, restart, abort and prioritize tasks, and spawn hosts",
+		Permissions: []Permission{ViewProject, SubmitPatches, TaskControl, SpawnHosts},
+	},
+	{
+		Name:        Admin,
+		Description: "can do anything, including editing project settings, variables and roles",
+		Permissions: []Permission{ViewProject, SubmitPatches, TaskControl, SpawnHosts, EditProject},
+	},
+}
+
+// All returns the built in roles, from least to most permissive.
+func All() []Role {
+	out := make([]Role, len(roles))
+	copy(out, roles)
+	return out
+}
+
+// Get returns the role with the given name, or nil if there is none.
+func Get(name string) *Role {
+	for i := range roles {
+		if roles[i].Name == name {
+			r := roles[i]
+			return &r
+		}
+	}
+	return nil
+}
+
+// IsValid returns true if name is the name of a built in role.
+func IsValid(name string) bool {
+	return Get(name) != nil
+}
+
+// Names returns the names of the built in roles.
+func Names() []string {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// Has returns true if the role allows the permission.
+func (r *Role) Has(p Permission) bool {
+	for _, permission := range r.Permissions {
+		if permission == p {
+			return true
+		}
+	}
+	return false
+}
+
+// AnyHas returns true if any of the named roles allows the permission.
+// Unknown role names are ignored.
+func AnyHas(names []string, p Permission) bool {
+	for _, name := range names {
+		if r := Get(name); r != nil && r.Has(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRoles(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{Viewer, Patcher, Scheduler, Admin}, Names())
+	assert.True(IsValid(Scheduler))
+	assert.False(IsValid("owner"))
+	assert.Nil(Get("owner"))
+
+	// each role has the permissions of the ones before it
+	all := All()
+	for i := 1; i < len(all); i++ {
+		for _, p := range all[i-1].Permissions {
+			assert.True(all[i].Has(p), "%s should have %s", all[i].Name, p)
+		}
+	}
+
+	assert.True(Get(Viewer).Has(ViewProject))
+	assert.False(Get(Viewer).Has(SubmitPatches))
+	assert.True(Get(Patcher).Has(SubmitPatches))
+	assert.False(Get(Patcher).Has(TaskControl))
+	assert.True(Get(Scheduler).Has(SpawnHosts))
+	assert.False(Get(Scheduler).Has(EditProject))
+	assert.True(Get(Admin).Has(EditProject))
+
+	assert.False(AnyHas([]string{}, SubmitPatches))
+	assert.False(AnyHas([]string{Viewer, "owner"}, SubmitPatches))
+	assert.True(AnyHas([]string{Viewer, Patcher}, SubmitPatches))
+}
+
+func TestAssignmentValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&Assignment{ProjectID: "mci", Role: Admin, User: "me"}).Validate())
+	assert.NoError((&Assignment{ProjectID: "mci", Role: Viewer, Group: "developers"}).Validate())
+	assert.Error((&Assignment{Role: Admin, User: "me"}).Validate())
+	assert.Error((&Assignment{ProjectID: "mci", Role: "owner", User: "me"}).Validate())
+	assert.Error((&Assignment{ProjectID: "mci", Role: Admin}).Validate())
+	assert.Error((&Assignment{ProjectID: "mci", Role: Admin, User: "me", Group: "developers"}).Validate())
+}
+
+type AssignmentSuite struct {
+	suite.Suite
+}
+
+func TestAssignmentSuite(t *testing.T) {
+	suite.Run(t, new(AssignmentSuite))
+}
+
+func (s *AssignmentSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *AssignmentSuite) SetupTest() {
+	s.Require().NoError(db.Clear(Collection))
+}
+
+func (s *AssignmentSuite) TestUpsertAndRemove() {
+	a := Assignment{ProjectID: "mci", Role: Admin, User: "me"}
+	s.NoError(a.Upsert())
+	s.NoError(a.Upsert())
+	s.Error((&Assignment{ProjectID: "mci", Role: "owner", User: "me"}).Upsert())
+
+	assignments, err := FindByProject("mci")
+	s.NoError(err)
+	s.Require().Len(assignments, 1)
+	s.Equal(a, assignments[0])
+
+	s.NoError(a.Remove())
+	assignments, err = FindByProject("mci")
+	s.NoError(err)
+	s.Len(assignments, 0)
+}
+
+func (s *AssignmentSuite) TestFindForUser() {
+	s.NoError((&Assignment{ProjectID: "mci", Role: Admin, User: "me"}).Upsert())
+	s.NoError((&Assignment{ProjectID: "mci", Role: Patcher, Group: "developers"}).Upsert())
+	s.NoError((&Assignment{ProjectID: "mci", Role: Scheduler, User: "you"}).Upsert())
+	s.NoError((&Assignment{ProjectID: "other", Role: Scheduler, Group: "developers"}).Upsert())
+
+	assignments, err := FindForUser("mci", "me", nil)
+	s.NoError(err)
+	s.Require().Len(assignments, 1)
+	s.Equal(Admin, assignments[0].Role)
+
+	assignments, err = FindForUser("mci", "you", []string{"developers"})
+	s.NoError(err)
+	s.Len(assignments, 2)
+
+	assignments, err = FindAllForUser("them", []string{"developers"})
+	s.NoError(err)
+	s.Len(assignments, 2)
+
+	assignments, err = FindAllForUser("them", nil)
+	s.NoError(err)
+	s.Len(assignments, 0)
+}
@@ -48,6 +48,8 @@ var (
 	OldTaskIdKey            = bsonutil.MustHaveTag(Task{}, "OldTaskId")
 	ArchivedKey             = bsonutil.MustHaveTag(Task{}, "Archived")
 	RevisionOrderNumberKey  = bsonutil.MustHaveTag(Task{}, "RevisionOrderNumber")
+	AutoRetriesKey          = bsonutil.MustHaveTag(Task{}, "AutoRetries")
+	RetryAfterKey           = bsonutil.MustHaveTag(Task{}, "RetryAfter")
 	RequesterKey            = bsonutil.MustHaveTag(Task{}, "Requester")
 	StatusKey               = bsonutil.MustHaveTag(Task{}, "Status")
 	DetailsKey              = bsonutil.MustHaveTag(Task{}, "Details")
@@ -210,6 +212,8 @@ func ByStatusAndActivation(status string, active bool) db.Q {
 		StatusKey:    status,
 		//Filter out blacklisted tasks
 		PriorityKey: bson.M{"$gte": 0},
+		// Filter out tasks waiting out a retry backoff
+		RetryAfterKey: bson.M{"$not": bson.M{"$gt": time.Now()}},
 	})
 }
 
@@ -425,6 +429,8 @@ func scheduleableTasksQuery() bson.M {
 		StatusKey:    evergreen.TaskUndispatched,
 		//Filter out blacklisted tasks
 		PriorityKey: bson.M{"$gte": 0},
+		// Filter out tasks waiting out a retry backoff
+		RetryAfterKey: bson.M{"$not": bson.M{"$gt": time.Now()}},
 	}
 }
 
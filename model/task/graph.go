@@ -0,0 +1,245 @@
+package task
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+// DependencyGraph is the dependency graph of the tasks of a version, with
+// the timing of each task on the version's critical path. Times are offsets
+// from the start of the version, assuming each task starts as soon as the
+// tasks it depends on have finished.
+type DependencyGraph struct {
+	Nodes []DependencyGraphNode
+	// CriticalPath is the longest chain of dependent tasks, from first to
+	// last, which determines how long the version takes.
+	CriticalPath []string
+	// Length is the total duration of the critical path.
+	Length time.Duration
+}
+
+// DependencyGraphNode is a task in a dependency graph.
+type DependencyGraphNode struct {
+	TaskID       string
+	DisplayName  string
+	BuildVariant string
+	Status       string
+	DependsOn    []Dependency
+
+	// Duration is the actual duration of a finished task, or the
+	// expected duration of any other task.
+	Duration         time.Duration
+	ExpectedDuration bool
+
+	EarliestStart  time.Duration
+	EarliestFinish time.Duration
+	LatestStart    time.Duration
+	LatestFinish   time.Duration
+	// Slack is how much longer the task could take without making the
+	// version take longer.
+	Slack    time.Duration
+	Critical bool
+}
+
+// GraphDuration returns the duration of a task to use in a dependency
+// graph: how long it took if it has finished, and otherwise how long it is
+// expected to take. The returned bool is true if the duration is expected.
+func GraphDuration(t *Task) (time.Duration, bool) {
+	return GraphDurationFunc((*Task).FetchExpectedDuration)(t)
+}
+
+// GraphDurationFunc returns a function that finds the duration of a task in a
+// dependency graph like GraphDuration does, but using expected to find how
+// long a task is expected to take.
+func GraphDurationFunc(expected func(*Task) time.Duration) func(*Task) (time.Duration, bool) {
+	return func(t *Task) (time.Duration, bool) {
+		if t.IsFinished() && t.TimeTaken > 0 {
+			return t.TimeTaken, false
+		}
+		return expected(t), true
+	}
+}
+
+// NewDependencyGraph returns the dependency graph of the tasks, using the
+// duration function to find how long each task takes. Display tasks and
+// dependencies on tasks that are not in the list are left out. It is an
+// error for the dependencies to have a cycle.
+func NewDependencyGraph(tasks []Task, duration func(*Task) (time.Duration, bool)) (*DependencyGraph, error) {
+	g := &DependencyGraph{Nodes: []DependencyGraphNode{}, CriticalPath: []string{}}
+	index := map[string]int{}
+	for i := range tasks {
+		t := &tasks[i]
+		if t.DisplayOnly {
+			continue
+		}
+		d, expected := duration(t)
+		index[t.Id] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, DependencyGraphNode{
+			TaskID:           t.Id,
+			DisplayName:      t.DisplayName,
+			BuildVariant:     t.BuildVariant,
+			Status:           t.Status,
+			DependsOn:        t.DependsOn,
+			Duration:         d,
+			ExpectedDuration: expected,
+		})
+	}
+
+	// dependencies and dependents of each node, by index
+	deps := make([][]int, len(g.Nodes))
+	dependents := make([][]int, len(g.Nodes))
+	for i, node := range g.Nodes {
+		inGraph := []Dependency{}
+		for _, dep := range node.DependsOn {
+			j, ok := index[dep.TaskId]
+			if !ok {
+				continue
+			}
+			inGraph = append(inGraph, dep)
+			deps[i] = append(deps[i], j)
+			dependents[j] = append(dependents[j], i)
+		}
+		g.Nodes[i].DependsOn = inGraph
+	}
+
+	order, err := topologicalOrder(deps, dependents)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// forward pass for the earliest times
+	for _, i := range order {
+		for _, j := range deps[i] {
+			if g.Nodes[j].EarliestFinish > g.Nodes[i].EarliestStart {
+				g.Nodes[i].EarliestStart = g.Nodes[j].EarliestFinish
+			}
+		}
+		g.Nodes[i].EarliestFinish = g.Nodes[i].EarliestStart + g.Nodes[i].Duration
+		if g.Nodes[i].EarliestFinish > g.Length {
+			g.Length = g.Nodes[i].EarliestFinish
+		}
+	}
+
+	// backward pass for the latest times
+	for k := len(order) - 1; k >= 0; k-- {
+		i := order[k]
+		g.Nodes[i].LatestFinish = g.Length
+		for _, j := range dependents[i] {
+			if g.Nodes[j].LatestStart < g.Nodes[i].LatestFinish {
+				g.Nodes[i].LatestFinish = g.Nodes[j].LatestStart
+			}
+		}
+		g.Nodes[i].LatestStart = g.Nodes[i].LatestFinish - g.Nodes[i].Duration
+		g.Nodes[i].Slack = g.Nodes[i].LatestStart - g.Nodes[i].EarliestStart
+		g.Nodes[i].Critical = g.Nodes[i].Slack == 0
+	}
+
+	// walk back from the critical task that finishes last
+	current := -1
+	for i, node := range g.Nodes {
+		if node.Critical && node.EarliestFinish == g.Length && (current < 0 || node.TaskID < g.Nodes[current].TaskID) {
+			current = i
+		}
+	}
+	for current >= 0 {
+		g.CriticalPath = append([]string{g.Nodes[current].TaskID}, g.CriticalPath...)
+		next := -1
+		for _, j := range deps[current] {
+			if g.Nodes[j].Critical && g.Nodes[j].EarliestFinish == g.Nodes[current].EarliestStart &&
+				(next < 0 || g.Nodes[j].TaskID < g.Nodes[next].TaskID) {
+				next = j
+			}
+		}
+		current = next
+	}
+
+	return g, nil
+}
+
+// topologicalOrder returns the indexes of the nodes such that each node
+// comes after the nodes it depends on.
+func topologicalOrder(deps, dependents [][]int) ([]int, error) {
+	remaining := make([]int, len(deps))
+	ready := []int{}
+	for i := range deps {
+		remaining[i] = len(deps[i])
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	order := make([]int, 0, len(deps))
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		order = append(order, i)
+		for _, j := range dependents[i] {
+			remaining[j]--
+			if remaining[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+	if len(order) != len(deps) {
+		return nil, errors.New("task dependencies contain a cycle")
+	}
+
+	return order, nil
+}
+
+// DOT returns the graph in the Graphviz DOT language, with the tasks on the
+// critical path highlighted.
+func (g *DependencyGraph) DOT() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("digraph version {\n")
+	buf.WriteString("  rankdir=LR;\n")
+	buf.WriteString("  node [shape=box];\n")
+
+	nodes := make([]DependencyGraphNode, len(g.Nodes))
+	copy(nodes, g.Nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].TaskID < nodes[j].TaskID })
+
+	for _, node := range nodes {
+		duration := node.Duration.String()
+		if node.ExpectedDuration {
+			duration = "~" + duration
+		}
+		attrs := fmt.Sprintf("label=%q", fmt.Sprintf("%s\n%s\n%s, %s, slack %s",
+			node.DisplayName, node.BuildVariant, node.Status, duration, node.Slack))
+		if node.Critical {
+			attrs += ", color=red, penwidth=2"
+		}
+		fmt.Fprintf(buf, "  %q [%s];\n", node.TaskID, attrs)
+	}
+
+	critical := map[string]bool{}
+	for i := 1; i < len(g.CriticalPath); i++ {
+		critical[g.CriticalPath[i-1]+"\x00"+g.CriticalPath[i]] = true
+	}
+	for _, node := range nodes {
+		for _, dep := range node.DependsOn {
+			attrs := ""
+			if dep.Status != "" && dep.Status != evergreen.TaskSucceeded {
+				attrs = fmt.Sprintf("label=%q", dep.Status)
+			}
+			if critical[dep.TaskId+"\x00"+node.TaskID] {
+				if attrs != "" {
+					attrs += ", "
+				}
+				attrs += "color=red, penwidth=2"
+			}
+			if attrs != "" {
+				attrs = " [" + attrs + "]"
+			}
+			fmt.Fprintf(buf, "  %q -> %q%s;\n", dep.TaskId, node.TaskID, attrs)
+		}
+	}
+
+	buf.WriteString("}\n")
+	return buf.String()
+}
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// compile -> test -> push, compile -> lint, with an unrelated docs task
+	// and a dependency on a task from another version
+	tasks := []Task{
+		{Id: "compile", DisplayName: "compile", Status: evergreen.TaskSucceeded, TimeTaken: 10 * time.Minute},
+		{Id: "test", DisplayName: "test", Status: evergreen.TaskStarted, ExpectedDuration: 30 * time.Minute,
+			DependsOn: []Dependency{{TaskId: "compile", Status: evergreen.TaskSucceeded}}},
+		{Id: "lint", DisplayName: "lint", Status: evergreen.TaskUndispatched, ExpectedDuration: 5 * time.Minute,
+			DependsOn: []Dependency{{TaskId: "compile", Status: evergreen.TaskSucceeded}, {TaskId: "other_version"}}},
+		{Id: "push", DisplayName: "push", Status: evergreen.TaskUndispatched, ExpectedDuration: 2 * time.Minute,
+			DependsOn: []Dependency{{TaskId: "test", Status: evergreen.TaskSucceeded}, {TaskId: "lint", Status: AllStatuses}}},
+		{Id: "docs", DisplayName: "docs", Status: evergreen.TaskSucceeded, TimeTaken: 20 * time.Minute},
+		{Id: "display", DisplayName: "display", DisplayOnly: true, ExecutionTasks: []string{"test", "lint"}},
+	}
+	duration := GraphDurationFunc(func(t *Task) time.Duration { return t.ExpectedDuration })
+
+	g, err := NewDependencyGraph(tasks, duration)
+	require.NoError(err)
+	require.Len(g.Nodes, 5)
+	assert.Equal(42*time.Minute, g.Length)
+	assert.Equal([]string{"compile", "test", "push"}, g.CriticalPath)
+
+	nodes := map[string]DependencyGraphNode{}
+	for _, node := range g.Nodes {
+		nodes[node.TaskID] = node
+	}
+	assert.False(nodes["compile"].ExpectedDuration)
+	assert.True(nodes["test"].ExpectedDuration)
+	assert.True(nodes["test"].Critical)
+	assert.Equal(10*time.Minute, nodes["test"].EarliestStart)
+	assert.Equal(40*time.Minute, nodes["push"].EarliestStart)
+
+	assert.False(nodes["lint"].Critical)
+	assert.Equal(25*time.Minute, nodes["lint"].Slack)
+	assert.Len(nodes["lint"].DependsOn, 1)
+	assert.Equal(22*time.Minute, nodes["docs"].Slack)
+
+	dot := g.DOT()
+	assert.Contains(dot, `"compile" -> "test" [color=red, penwidth=2];`)
+	assert.Contains(dot, `"lint" -> "push" [label="*"];`)
+	assert.NotContains(dot, "other_version")
+
+	// finished tasks without a time taken use their expected duration
+	d, expected := duration(&Task{Status: evergreen.TaskFailed, ExpectedDuration: time.Minute})
+	assert.Equal(time.Minute, d)
+	assert.True(expected)
+
+	// dependencies may not have a cycle
+	tasks[0].DependsOn = []Dependency{{TaskId: "push"}}
+	_, err = NewDependencyGraph(tasks, duration)
+	assert.Error(err)
+}
@@ -103,6 +103,11 @@ type Task struct {
 	Archived            bool   `bson:"archived,omitempty" json:"archived,omitempty"`
 	RevisionOrderNumber int    `bson:"order,omitempty" json:"order,omitempty"`
 
+	// AutoRetries is the number of times the task has been restarted by its
+	// retry policy. If RetryAfter is set, the task is not scheduled before it.
+	AutoRetries int       `bson:"auto_retries,omitempty" json:"auto_retries,omitempty"`
+	RetryAfter  time.Time `bson:"retry_after,omitempty" json:"retry_after,omitempty"`
+
 	// task requester - this is used to help tell the
 	// reason this task was created. e.g. it could be
 	// because the repotracker requested it (via tracking the
@@ -872,7 +877,9 @@ func displayTaskPriority(status string) int {
 }
 
 // Reset sets the task state to be activated, with a new secret,
-// undispatched status and zero time on Start, Scheduled, Dispatch and FinishTime
+// undispatched status and zero time on Start, Scheduled, Dispatch and FinishTime.
+// The task's automatic retries are cleared, so that it gets all of the attempts
+// of its retry policy again.
 func (t *Task) Reset() error {
 
 	if t.DisplayOnly {
@@ -893,6 +900,8 @@ func (t *Task) Reset() error {
 	t.StartTime = util.ZeroTime
 	t.ScheduledTime = util.ZeroTime
 	t.FinishTime = util.ZeroTime
+	t.RetryAfter = time.Time{}
+	t.AutoRetries = 0
 	reset := bson.M{
 		"$set": bson.M{
 			ActivatedKey:     true,
@@ -904,7 +913,9 @@ func (t *Task) Reset() error {
 			FinishTimeKey:    util.ZeroTime,
 		},
 		"$unset": bson.M{
-			DetailsKey: "",
+			DetailsKey:     "",
+			RetryAfterKey:  "",
+			AutoRetriesKey: "",
 		},
 	}
 
@@ -916,6 +927,23 @@ func (t *Task) Reset() error {
 	)
 }
 
+// SetAutoRetry records that the task has been restarted by its retry policy
+// for the given number of times, and that it should not be scheduled before
+// retryAfter.
+func (t *Task) SetAutoRetry(retries int, retryAfter time.Time) error {
+	set := bson.M{AutoRetriesKey: retries}
+	if !util.IsZeroTime(retryAfter) {
+		set[RetryAfterKey] = retryAfter
+	}
+	if err := UpdateOne(bson.M{IdKey: t.Id}, bson.M{"$set": set}); err != nil {
+		return errors.Wrapf(err, "problem recording retry of task %s", t.Id)
+	}
+
+	t.AutoRetries = retries
+	t.RetryAfter = retryAfter
+	return nil
+}
+
 // Reset sets the task state to be activated, with a new secret,
 // undispatched status and zero time on Start, Scheduled, Dispatch and FinishTime
 func ResetTasks(taskIds []string) error {
@@ -940,7 +968,9 @@ func ResetTasks(taskIds []string) error {
 			FinishTimeKey:    util.ZeroTime,
 		},
 		"$unset": bson.M{
-			DetailsKey: "",
+			DetailsKey:     "",
+			RetryAfterKey:  "",
+			AutoRetriesKey: "",
 		},
 	}
 
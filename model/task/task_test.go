@@ -1065,3 +1065,28 @@ func TestFindOneIdOldOrNew(t *testing.T) {
 	assert.Equal(1, task01.Execution)
 	assert.Len(task01.LocalTestResults, 1)
 }
+
+func TestFindSchedulableSkipsRetryBackoff(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.Clear(Collection))
+
+	tasks := []Task{
+		{Id: "ready", Activated: true, Status: evergreen.TaskUndispatched, DistroId: "d"},
+		{Id: "backoff-over", Activated: true, Status: evergreen.TaskUndispatched, DistroId: "d", RetryAfter: time.Now().Add(-time.Minute)},
+		{Id: "backoff", Activated: true, Status: evergreen.TaskUndispatched, DistroId: "d", RetryAfter: time.Now().Add(time.Hour)},
+	}
+	for _, task := range tasks {
+		require.NoError(task.Insert())
+	}
+
+	schedulable, err := FindSchedulable("d")
+	require.NoError(err)
+	ids := []string{}
+	for _, task := range schedulable {
+		ids = append(ids, task.Id)
+	}
+	assert.Len(ids, 2)
+	assert.Contains(ids, "ready")
+	assert.Contains(ids, "backoff-over")
+}
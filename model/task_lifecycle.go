@@ -410,7 +410,6 @@ func MarkEnd(t *task.Task, caller string, finishTime time.Time, detail *apimodel
 		return err
 	}
 	status := t.ResultStatus()
-	event.LogTaskFinished(t.Id, t.Execution, t.HostId, status)
 
 	if t.IsPartOfDisplay() {
 		if err = t.DisplayTask.UpdateDisplayTask(); err != nil {
@@ -426,6 +425,21 @@ func MarkEnd(t *task.Task, caller string, finishTime time.Time, detail *apimodel
 		}
 	}
 
+	// restart the task if its retry policy covers this failure; the task
+	// is only logged as finished, and stepback and build status are only
+	// evaluated, once the retries are exhausted
+	retried, err := tryAutoRetryTask(t, detail)
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "problem automatically retrying task",
+			"task":    t.Id,
+		}))
+	}
+	if retried {
+		return nil
+	}
+	event.LogTaskFinished(t.Id, t.Execution, t.HostId, status)
+
 	// activate/deactivate other task if this is not a patch request's task
 	if !evergreen.IsPatchRequester(t.Requester) {
 		if t.IsPartOfDisplay() {
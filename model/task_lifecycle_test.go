@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/testutil"
@@ -16,6 +17,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
 )
 
 var (
@@ -1711,3 +1713,112 @@ func TestMarkEndRequiresAllTasksToFinishToUpdateBuildStatus(t *testing.T) {
 	assert.NoError(MarkEnd(&testTask, "", time.Now(), details, false, &updates))
 	assert.Equal(evergreen.BuildFailed, updates.BuildNewStatus)
 }
+
+func TestMarkEndAutoRetry(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(task.Collection, task.OldCollection, build.Collection,
+		version.Collection, ProjectRefCollection, event.AllLogCollection))
+
+	ref := &ProjectRef{Identifier: "sample"}
+	require.NoError(ref.Insert())
+
+	config := `
+tasks:
+- name: flaky
+  retry:
+    max_attempts: 2
+    on: [system-failure]
+    backoff_secs: 60
+buildvariants:
+- name: bv
+  tasks:
+  - name: flaky
+`
+	v := &version.Version{
+		Id:         "v1",
+		Identifier: ref.Identifier,
+		Revision:   "abc",
+		Requester:  evergreen.RepotrackerVersionRequester,
+		Status:     evergreen.VersionStarted,
+		Config:     config,
+	}
+	require.NoError(v.Insert())
+	b := &build.Build{
+		Id:      "b1",
+		Version: v.Id,
+		Status:  evergreen.BuildStarted,
+		Tasks:   []build.TaskCache{{Id: "t1", Status: evergreen.TaskStarted, Activated: true}},
+	}
+	require.NoError(b.Insert())
+	t1 := &task.Task{
+		Id:           "t1",
+		DisplayName:  "flaky",
+		BuildVariant: "bv",
+		BuildId:      b.Id,
+		Version:      v.Id,
+		Project:      ref.Identifier,
+		Revision:     v.Revision,
+		Activated:    true,
+		Status:       evergreen.TaskStarted,
+	}
+	require.NoError(t1.Insert())
+
+	// a test failure is not covered by the policy
+	detail := &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: evergreen.CommandTypeTest}
+	assert.NoError(MarkEnd(t1, "test", time.Now(), detail, false, &StatusChanges{}))
+	dbTask, err := task.FindOne(task.ById(t1.Id))
+	require.NoError(err)
+	assert.Equal(evergreen.TaskFailed, dbTask.Status)
+	assert.Equal(0, dbTask.Execution)
+
+	// a system failure is retried after the backoff
+	require.NoError(task.UpdateOne(bson.M{task.IdKey: t1.Id}, bson.M{"$set": bson.M{task.StatusKey: evergreen.TaskStarted}}))
+	t1.Status = evergreen.TaskStarted
+	detail = &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: evergreen.CommandTypeSystem}
+	assert.NoError(MarkEnd(t1, "test", time.Now(), detail, false, &StatusChanges{}))
+	dbTask, err = task.FindOne(task.ById(t1.Id))
+	require.NoError(err)
+	assert.Equal(evergreen.TaskUndispatched, dbTask.Status)
+	assert.Equal(1, dbTask.Execution)
+	assert.Equal(1, dbTask.AutoRetries)
+	assert.True(dbTask.RetryAfter.After(time.Now()))
+	schedulable, err := task.FindSchedulable("")
+	require.NoError(err)
+	assert.Len(schedulable, 0)
+
+	// the second failure exhausts the attempts
+	require.NoError(task.UpdateOne(bson.M{task.IdKey: t1.Id}, bson.M{"$set": bson.M{task.StatusKey: evergreen.TaskStarted}}))
+	dbTask.Status = evergreen.TaskStarted
+	assert.NoError(MarkEnd(dbTask, "test", time.Now(), detail, false, &StatusChanges{}))
+	dbTask, err = task.FindOne(task.ById(t1.Id))
+	require.NoError(err)
+	assert.Equal(evergreen.TaskFailed, dbTask.Status)
+	assert.Equal(1, dbTask.Execution)
+
+	// the retried execution is logged as retried rather than finished
+	events, err := event.Find(event.AllLogCollection, event.TaskEventsInOrder(t1.Id))
+	require.NoError(err)
+	eventTypes := []string{}
+	for _, e := range events {
+		if e.EventType == event.TaskFinished || e.EventType == event.TaskAutoRetried {
+			eventTypes = append(eventTypes, e.EventType)
+		}
+	}
+	assert.Equal([]string{event.TaskFinished, event.TaskAutoRetried, event.TaskFinished}, eventTypes)
+
+	// a manual restart gets all of the attempts again
+	assert.NoError(TryResetTask(t1.Id, "user", evergreen.UIPackage, nil))
+	dbTask, err = task.FindOne(task.ById(t1.Id))
+	require.NoError(err)
+	assert.Equal(2, dbTask.Execution)
+	assert.Equal(0, dbTask.AutoRetries)
+	require.NoError(task.UpdateOne(bson.M{task.IdKey: t1.Id}, bson.M{"$set": bson.M{task.StatusKey: evergreen.TaskStarted}}))
+	dbTask.Status = evergreen.TaskStarted
+	assert.NoError(MarkEnd(dbTask, "test", time.Now(), detail, false, &StatusChanges{}))
+	dbTask, err = task.FindOne(task.ById(t1.Id))
+	require.NoError(err)
+	assert.Equal(evergreen.TaskUndispatched, dbTask.Status)
+	assert.Equal(3, dbTask.Execution)
+	assert.Equal(1, dbTask.AutoRetries)
+}
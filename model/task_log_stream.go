@@ -0,0 +1,157 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// taskLogSubscriptionBufferSize is the number of messages a subscriber can
+// fall behind by before it is dropped.
+const taskLogSubscriptionBufferSize = 1000
+
+// TaskLogSubscription receives the log messages of a task execution as agents
+// send them to this app server.
+//
+// Messages is closed when the subscription is closed, or if the subscriber
+// falls too far behind. A subscriber that falls behind can recover the
+// messages it missed from the database with FindTaskLogMessagesAfter.
+type TaskLogSubscription struct {
+	TaskId    string
+	Execution int
+	Messages  <-chan apimodels.LogMessage
+
+	messages chan apimodels.LogMessage
+	closed   bool
+}
+
+type taskLogBroker struct {
+	mu   sync.Mutex
+	subs map[string]map[*TaskLogSubscription]struct{}
+}
+
+// taskLogStreams is the process-wide pub/sub of incoming task logs, which
+// needs no external broker but only reaches subscribers of the app server
+// that received the logs.
+var taskLogStreams = &taskLogBroker{
+	subs: map[string]map[*TaskLogSubscription]struct{}{},
+}
+
+// SubscribeTaskLogs starts receiving the log messages of a task execution.
+// The subscription must be closed when it is no longer needed.
+func SubscribeTaskLogs(taskId string, execution int) *TaskLogSubscription {
+	messages := make(chan apimodels.LogMessage, taskLogSubscriptionBufferSize)
+	sub := &TaskLogSubscription{
+		TaskId:    taskId,
+		Execution: execution,
+		Messages:  messages,
+		messages:  messages,
+	}
+
+	taskLogStreams.mu.Lock()
+	defer taskLogStreams.mu.Unlock()
+
+	if taskLogStreams.subs[taskId] == nil {
+		taskLogStreams.subs[taskId] = map[*TaskLogSubscription]struct{}{}
+	}
+	taskLogStreams.subs[taskId][sub] = struct{}{}
+
+	return sub
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *TaskLogSubscription) Close() {
+	taskLogStreams.mu.Lock()
+	defer taskLogStreams.mu.Unlock()
+
+	taskLogStreams.remove(s)
+}
+
+// remove must be called with the lock held.
+func (b *taskLogBroker) remove(s *TaskLogSubscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.messages)
+
+	delete(b.subs[s.TaskId], s)
+	if len(b.subs[s.TaskId]) == 0 {
+		delete(b.subs, s.TaskId)
+	}
+}
+
+func (s *TaskLogSubscription) send(msg apimodels.LogMessage) bool {
+	select {
+	case s.messages <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// PublishTaskLog sends a batch of log messages to the subscribers of its task
+// execution. It never blocks: subscribers that cannot keep up are dropped.
+func PublishTaskLog(taskLog *TaskLog) {
+	if taskLog == nil || len(taskLog.Messages) == 0 {
+		return
+	}
+
+	taskLogStreams.mu.Lock()
+	defer taskLogStreams.mu.Unlock()
+
+	for sub := range taskLogStreams.subs[taskLog.TaskId] {
+		if sub.Execution != taskLog.Execution {
+			continue
+		}
+		for _, msg := range taskLog.Messages {
+			if !sub.send(msg) {
+				taskLogStreams.remove(sub)
+				break
+			}
+		}
+	}
+}
+
+// FindTaskLogMessagesAfter returns the log messages of a task execution
+// logged after the given time, oldest first, optionally filtered by type.
+func FindTaskLogMessagesAfter(taskId string, execution int, ts time.Time, msgTypes []string) ([]apimodels.LogMessage, error) {
+	session, db, err := getSessionAndDB()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	// a batch is stamped when it is sent, after all of its messages
+	query := bson.M{
+		TaskLogTaskIdKey:    taskId,
+		TaskLogExecutionKey: execution,
+		TaskLogTimestampKey: bson.M{"$gte": ts},
+	}
+
+	taskLogs := []TaskLog{}
+	err = db.C(TaskLogCollection).Find(query).Sort(TaskLogTimestampKey).All(&taskLogs)
+	if err != nil && err != mgo.ErrNotFound {
+		return nil, errors.Wrapf(err, "problem finding logs for task '%s'", taskId)
+	}
+
+	msgs := []apimodels.LogMessage{}
+	for _, taskLog := range taskLogs {
+		for _, msg := range taskLog.Messages {
+			if !msg.Timestamp.After(ts) {
+				continue
+			}
+			if len(msgTypes) > 0 && !util.StringSliceContains(msgTypes, msg.Type) {
+				continue
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogSubscriptionsReceiveMessagesOfTheirExecution(t *testing.T) {
+	sub := SubscribeTaskLogs("stream-task", 1)
+	defer sub.Close()
+	oldSub := SubscribeTaskLogs("stream-task", 0)
+	defer oldSub.Close()
+	otherSub := SubscribeTaskLogs("other-task", 1)
+	defer otherSub.Close()
+
+	msgs := []apimodels.LogMessage{
+		{Type: apimodels.TaskLogPrefix, Message: "one", Timestamp: time.Now()},
+		{Type: apimodels.AgentLogPrefix, Message: "two", Timestamp: time.Now()},
+	}
+	PublishTaskLog(&TaskLog{TaskId: "stream-task", Execution: 1, Messages: msgs})
+
+	require.Len(t, sub.Messages, 2)
+	assert.Equal(t, msgs[0], <-sub.Messages)
+	assert.Equal(t, msgs[1], <-sub.Messages)
+	assert.Len(t, oldSub.Messages, 0)
+	assert.Len(t, otherSub.Messages, 0)
+}
+
+func TestTaskLogSubscriptionClose(t *testing.T) {
+	sub := SubscribeTaskLogs("closed-task", 0)
+	sub.Close()
+	sub.Close()
+
+	_, ok := <-sub.Messages
+	assert.False(t, ok)
+
+	// publishing after every subscriber is gone is a no-op
+	PublishTaskLog(&TaskLog{TaskId: "closed-task", Messages: []apimodels.LogMessage{{Message: "one"}}})
+	taskLogStreams.mu.Lock()
+	defer taskLogStreams.mu.Unlock()
+	assert.NotContains(t, taskLogStreams.subs, "closed-task")
+}
+
+func TestTaskLogSubscriptionIsDroppedWhenItFallsBehind(t *testing.T) {
+	slow := SubscribeTaskLogs("busy-task", 0)
+	defer slow.Close()
+
+	msgs := make([]apimodels.LogMessage, taskLogSubscriptionBufferSize)
+	PublishTaskLog(&TaskLog{TaskId: "busy-task", Messages: msgs})
+	fast := SubscribeTaskLogs("busy-task", 0)
+	defer fast.Close()
+	PublishTaskLog(&TaskLog{TaskId: "busy-task", Messages: msgs[:1]})
+
+	received := 0
+	for range slow.Messages {
+		received++
+	}
+	assert.Equal(t, taskLogSubscriptionBufferSize, received)
+	assert.Len(t, fast.Messages, 1)
+}
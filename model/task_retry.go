@@ -0,0 +1,155 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// kinds of failure a RetryPolicy can retry
+	RetryOnSystemFailure = "system-failure"
+	RetryOnTimeout       = "timeout"
+	RetryOnTestFailure   = "test-failure"
+
+	// maxRetryBackoff bounds the delay before an automatic retry
+	maxRetryBackoff = time.Hour
+
+	retryCaller = "retry-policy"
+)
+
+var validRetryOn = []string{RetryOnSystemFailure, RetryOnTimeout, RetryOnTestFailure}
+
+// Validate checks that the retry policy is well formed.
+func (r *RetryPolicy) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	maxAttempts := evergreen.MaxTaskExecution + 1
+	if r.MaxAttempts < 1 || r.MaxAttempts > maxAttempts {
+		catcher.Add(errors.Errorf("max_attempts must be between 1 and %d", maxAttempts))
+	}
+	if len(r.On) == 0 {
+		catcher.Add(errors.Errorf("on must list at least one of %v", validRetryOn))
+	}
+	for _, on := range r.On {
+		if !util.StringSliceContains(validRetryOn, on) {
+			catcher.Add(errors.Errorf("'%s' is not a valid failure type, must be one of %v", on, validRetryOn))
+		}
+	}
+	if r.BackoffSecs < 0 {
+		catcher.Add(errors.New("backoff_secs cannot be negative"))
+	}
+	return catcher.Resolve()
+}
+
+// Matches returns the kind of failure described by detail and whether the
+// policy retries it.
+func (r *RetryPolicy) Matches(detail *apimodels.TaskEndDetail) (string, bool) {
+	if detail == nil || detail.Status != evergreen.TaskFailed {
+		return "", false
+	}
+
+	var reason string
+	switch {
+	case detail.TimedOut:
+		reason = RetryOnTimeout
+	case detail.Type == evergreen.CommandTypeSystem:
+		reason = RetryOnSystemFailure
+	case detail.Type == evergreen.CommandTypeTest || detail.Type == "":
+		reason = RetryOnTestFailure
+	default:
+		return "", false
+	}
+
+	return reason, util.StringSliceContains(r.On, reason)
+}
+
+// Backoff returns how long to wait before starting the retry that follows
+// the given number of previous automatic retries.
+func (r *RetryPolicy) Backoff(previousRetries int) time.Duration {
+	if r.BackoffSecs <= 0 {
+		return 0
+	}
+	backoff := time.Duration(r.BackoffSecs) * time.Second
+	for i := 0; i < previousRetries && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
+
+// getRetryPolicy returns the retry policy for the task from its build variant
+// or task definition, or nil if the task has none.
+func getRetryPolicy(t *task.Task) (*RetryPolicy, error) {
+	project, err := FindProjectFromTask(t)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	bvt := project.FindTaskForVariant(t.DisplayName, t.BuildVariant)
+	if bvt == nil {
+		return nil, nil
+	}
+	return bvt.Retry, nil
+}
+
+// tryAutoRetryTask restarts a task that has just finished if its retry policy
+// covers the failure and it has attempts left. It returns whether the task was
+// restarted.
+func tryAutoRetryTask(t *task.Task, detail *apimodels.TaskEndDetail) (bool, error) {
+	if t.DisplayOnly || t.IsPartOfDisplay() || t.Execution >= evergreen.MaxTaskExecution {
+		return false, nil
+	}
+	if detail == nil || detail.Status != evergreen.TaskFailed {
+		return false, nil
+	}
+
+	policy, err := getRetryPolicy(t)
+	if err != nil {
+		return false, errors.Wrapf(err, "problem finding retry policy for task %s", t.Id)
+	}
+	if policy == nil || t.AutoRetries+1 >= policy.MaxAttempts {
+		return false, nil
+	}
+	reason, ok := policy.Matches(detail)
+	if !ok {
+		return false, nil
+	}
+
+	execution := t.Execution
+	retries := t.AutoRetries
+	// resetting the task clears its retries, so that manual restarts get
+	// the full number of attempts; they are set again below
+	if err = resetTask(t.Id, retryCaller); err != nil {
+		return false, errors.Wrapf(err, "problem restarting task %s", t.Id)
+	}
+
+	var retryAfter time.Time
+	if backoff := policy.Backoff(retries); backoff > 0 {
+		retryAfter = time.Now().Add(backoff)
+	}
+	if err = t.SetAutoRetry(retries+1, retryAfter); err != nil {
+		return true, errors.WithStack(err)
+	}
+	event.LogTaskAutoRetried(t.Id, execution, t.HostId, t.ResultStatus(), reason, retryAfter)
+
+	grip.Info(message.Fields{
+		"message":      "automatically retrying task",
+		"task":         t.Id,
+		"execution":    execution,
+		"reason":       reason,
+		"auto_retries": t.AutoRetries,
+		"max_attempts": policy.MaxAttempts,
+		"retry_after":  retryAfter,
+	})
+
+	return true, nil
+}
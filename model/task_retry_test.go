@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&RetryPolicy{MaxAttempts: 2, On: []string{RetryOnTimeout}}).Validate())
+	assert.NoError((&RetryPolicy{MaxAttempts: evergreen.MaxTaskExecution + 1, On: validRetryOn, BackoffSecs: 60}).Validate())
+
+	assert.Error((&RetryPolicy{On: []string{RetryOnTimeout}}).Validate())
+	assert.Error((&RetryPolicy{MaxAttempts: evergreen.MaxTaskExecution + 2, On: []string{RetryOnTimeout}}).Validate())
+	assert.Error((&RetryPolicy{MaxAttempts: 2}).Validate())
+	assert.Error((&RetryPolicy{MaxAttempts: 2, On: []string{"setup-failure"}}).Validate())
+	assert.Error((&RetryPolicy{MaxAttempts: 2, On: []string{RetryOnTimeout}, BackoffSecs: -1}).Validate())
+}
+
+func TestRetryPolicyMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := &RetryPolicy{MaxAttempts: 3, On: []string{RetryOnSystemFailure, RetryOnTimeout}}
+
+	reason, ok := policy.Matches(&apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: evergreen.CommandTypeSystem})
+	assert.True(ok)
+	assert.Equal(RetryOnSystemFailure, reason)
+
+	reason, ok = policy.Matches(&apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: evergreen.CommandTypeTest, TimedOut: true})
+	assert.True(ok)
+	assert.Equal(RetryOnTimeout, reason)
+
+	reason, ok = policy.Matches(&apimodels.TaskEndDetail{Status: evergreen.TaskFailed})
+	assert.False(ok)
+	assert.Equal(RetryOnTestFailure, reason)
+
+	_, ok = policy.Matches(&apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: evergreen.CommandTypeSetup})
+	assert.False(ok)
+	_, ok = policy.Matches(&apimodels.TaskEndDetail{Status: evergreen.TaskSucceeded, Type: evergreen.CommandTypeSystem})
+	assert.False(ok)
+	_, ok = policy.Matches(nil)
+	assert.False(ok)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Zero((&RetryPolicy{}).Backoff(2))
+
+	policy := &RetryPolicy{BackoffSecs: 30}
+	assert.Equal(30*time.Second, policy.Backoff(0))
+	assert.Equal(60*time.Second, policy.Backoff(1))
+	assert.Equal(120*time.Second, policy.Backoff(2))
+	assert.Equal(maxRetryBackoff, policy.Backoff(20))
+}
+
+func TestRetryPolicyParsing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+- name: compile
+  retry:
+    max_attempts: 3
+    on: [system-failure]
+    backoff_secs: 10
+- name: test
+buildvariants:
+- name: bv
+  tasks:
+  - name: compile
+  - name: test
+    retry:
+      max_attempts: 2
+      on: [test-failure, timeout]
+`
+	p := &Project{}
+	require.NoError(LoadProjectInto([]byte(yml), "id", p))
+
+	compile := p.FindTaskForVariant("compile", "bv")
+	require.NotNil(compile)
+	require.NotNil(compile.Retry)
+	assert.Equal(3, compile.Retry.MaxAttempts)
+	assert.Equal([]string{RetryOnSystemFailure}, compile.Retry.On)
+	assert.Equal(10, compile.Retry.BackoffSecs)
+
+	test := p.FindTaskForVariant("test", "bv")
+	require.NotNil(test)
+	require.NotNil(test.Retry)
+	assert.Equal(2, test.Retry.MaxAttempts)
+	assert.Equal([]string{RetryOnTestFailure, RetryOnTimeout}, test.Retry.On)
+}
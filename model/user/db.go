@@ -22,7 +22,15 @@ var (
 	CreatedAtKey    = bsonutil.MustHaveTag(DBUser{}, "CreatedAt")
 	SettingsKey     = bsonutil.MustHaveTag(DBUser{}, "Settings")
 	APIKeyKey       = bsonutil.MustHaveTag(DBUser{}, "APIKey")
+	APITokensKey    = bsonutil.MustHaveTag(DBUser{}, "APITokens")
 	PubKeysKey      = bsonutil.MustHaveTag(DBUser{}, "PubKeys")
+	SystemRolesKey  = bsonutil.MustHaveTag(DBUser{}, "SystemRoles")
+	LoginCacheKey   = bsonutil.MustHaveTag(DBUser{}, "LoginCache")
+)
+
+var (
+	LoginCacheTokenKey = bsonutil.MustHaveTag(LoginCache{}, "Token")
+	LoginCacheTTLKey   = bsonutil.MustHaveTag(LoginCache{}, "TTL")
 )
 
 var (
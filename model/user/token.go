@@ -0,0 +1,175 @@
+package user
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// APITokenPrefix starts every API token, which distinguishes them from the
+// legacy API key of a user.
+const APITokenPrefix = "evgtok_"
+
+// Scopes of API tokens. Every token can make read only requests, and the
+// patch and hosts scopes can also submit patches or manage spawn hosts.
+const (
+	APITokenScopeRead  = "read"
+	APITokenScopePatch = "patch"
+	APITokenScopeHosts = "hosts"
+)
+
+// ValidAPITokenScopes are the scopes that an API token may have.
+var ValidAPITokenScopes = []string{APITokenScopeRead, APITokenScopePatch, APITokenScopeHosts}
+
+// APIToken is a named credential that a user can give to scripts and CI
+// jobs instead of their API key. Only a hash of the token is stored.
+type APIToken struct {
+	Name       string    `bson:"name" json:"name"`
+	Hash       string    `bson:"hash" json:"-"`
+	Scope      string    `bson:"scope" json:"scope"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+	ExpiresAt  time.Time `bson:"expires_at,omitempty" json:"expires_at"`
+	LastUsedAt time.Time `bson:"last_used_at,omitempty" json:"last_used_at"`
+}
+
+var (
+	APITokenNameKey       = bsonutil.MustHaveTag(APIToken{}, "Name")
+	APITokenHashKey       = bsonutil.MustHaveTag(APIToken{}, "Hash")
+	APITokenScopeKey      = bsonutil.MustHaveTag(APIToken{}, "Scope")
+	APITokenCreatedAtKey  = bsonutil.MustHaveTag(APIToken{}, "CreatedAt")
+	APITokenExpiresAtKey  = bsonutil.MustHaveTag(APIToken{}, "ExpiresAt")
+	APITokenLastUsedAtKey = bsonutil.MustHaveTag(APIToken{}, "LastUsedAt")
+)
+
+// IsAPIToken returns true if the key is an API token rather than an API key.
+func IsAPIToken(key string) bool {
+	return strings.HasPrefix(key, APITokenPrefix)
+}
+
+// HashAPIToken returns the hash of a token that is stored in its place.
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired returns true if the token has an expiry that has passed.
+func (t *APIToken) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// GetAPIToken returns the user's token that matches the given token, or nil
+// if there is none. It does not check whether the token has expired.
+func (u *DBUser) GetAPIToken(token string) *APIToken {
+	if !IsAPIToken(token) {
+		return nil
+	}
+	hash := []byte(HashAPIToken(token))
+	for i := range u.APITokens {
+		if subtle.ConstantTimeCompare([]byte(u.APITokens[i].Hash), hash) == 1 {
+			return &u.APITokens[i]
+		}
+	}
+	return nil
+}
+
+// AddAPIToken creates a new token for the user with the given name and scope
+// and returns it. The token never expires if ttl is zero. Since only its hash
+// is stored, the returned token cannot be retrieved again later.
+func (u *DBUser) AddAPIToken(name, scope string, ttl time.Duration) (string, error) {
+	if name == "" {
+		return "", errors.New("API token must have a name")
+	}
+	if !util.StringSliceContains(ValidAPITokenScopes, scope) {
+		return "", errors.Errorf("'%s' is not a valid API token scope, must be one of %v", scope, ValidAPITokenScopes)
+	}
+	if ttl < 0 {
+		return "", errors.New("API token expiry cannot be in the past")
+	}
+	for _, t := range u.APITokens {
+		if t.Name == name {
+			return "", errors.Errorf("user '%s' already has an API token named '%s'", u.Id, name)
+		}
+	}
+
+	token := APITokenPrefix + util.RandomString() + util.RandomString()
+	apiToken := APIToken{
+		Name:      name,
+		Hash:      HashAPIToken(token),
+		Scope:     scope,
+		CreatedAt: time.Now(),
+	}
+	if ttl > 0 {
+		apiToken.ExpiresAt = apiToken.CreatedAt.Add(ttl)
+	}
+
+	userWithoutToken := bson.M{
+		IdKey: u.Id,
+		bsonutil.GetDottedKeyName(APITokensKey, APITokenNameKey): bson.M{"$ne": name},
+	}
+	update := bson.M{
+		"$push": bson.M{APITokensKey: apiToken},
+	}
+	if err := UpdateOne(userWithoutToken, update); err != nil {
+		return "", errors.Wrapf(err, "problem adding API token '%s'", name)
+	}
+
+	u.APITokens = append(u.APITokens, apiToken)
+	return token, nil
+}
+
+// RevokeAPIToken deletes the user's token with the given name, without
+// affecting their other tokens.
+func (u *DBUser) RevokeAPIToken(name string) error {
+	found := false
+	tokens := make([]APIToken, 0, len(u.APITokens))
+	for _, t := range u.APITokens {
+		if t.Name == name {
+			found = true
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	if !found {
+		return errors.Errorf("user '%s' has no API token named '%s'", u.Id, name)
+	}
+
+	update := bson.M{
+		"$pull": bson.M{APITokensKey: bson.M{APITokenNameKey: name}},
+	}
+	if err := UpdateOne(bson.M{IdKey: u.Id}, update); err != nil {
+		return errors.Wrapf(err, "problem revoking API token '%s'", name)
+	}
+
+	u.APITokens = tokens
+	return nil
+}
+
+// MarkAPITokenUsed records that the user's token with the given name was
+// used at the given time.
+func (u *DBUser) MarkAPITokenUsed(name string, at time.Time) error {
+	query := bson.M{
+		IdKey: u.Id,
+		bsonutil.GetDottedKeyName(APITokensKey, APITokenNameKey): name,
+	}
+	update := bson.M{
+		"$set": bson.M{bsonutil.GetDottedKeyName(APITokensKey, "$", APITokenLastUsedAtKey): at},
+	}
+	if err := db.Update(Collection, query, update); err != nil {
+		return errors.Wrapf(err, "problem recording use of API token '%s'", name)
+	}
+
+	for i := range u.APITokens {
+		if u.APITokens[i].Name == name {
+			u.APITokens[i].LastUsedAt = at
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAPIToken(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsAPIToken(APITokenPrefix + "abc"))
+	assert.False(IsAPIToken("abc"))
+	assert.False(IsAPIToken(""))
+}
+
+func TestGetAPIToken(t *testing.T) {
+	assert := assert.New(t)
+
+	u := &DBUser{
+		Id: "me",
+		APITokens: []APIToken{
+			{Name: "ci", Hash: HashAPIToken(APITokenPrefix + "ci"), Scope: APITokenScopePatch},
+			{Name: "hosts", Hash: HashAPIToken(APITokenPrefix + "hosts"), Scope: APITokenScopeHosts},
+		},
+	}
+
+	token := u.GetAPIToken(APITokenPrefix + "hosts")
+	if assert.NotNil(token) {
+		assert.Equal("hosts", token.Name)
+	}
+	assert.Nil(u.GetAPIToken(APITokenPrefix + "other"))
+	assert.Nil(u.GetAPIToken(HashAPIToken(APITokenPrefix + "ci")))
+	assert.Nil(u.GetAPIToken("ci"))
+}
+
+func TestAPITokenIsExpired(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	assert.False((&APIToken{}).IsExpired(now))
+	assert.False((&APIToken{ExpiresAt: now.Add(time.Hour)}).IsExpired(now))
+	assert.True((&APIToken{ExpiresAt: now}).IsExpired(now))
+	assert.True((&APIToken{ExpiresAt: now.Add(-time.Hour)}).IsExpired(now))
+}
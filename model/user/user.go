@@ -22,7 +22,16 @@ type DBUser struct {
 	CreatedAt    time.Time    `bson:"created_at"`
 	Settings     UserSettings `bson:"settings"`
 	APIKey       string       `bson:"apikey"`
+	APITokens    []APIToken   `bson:"api_tokens,omitempty"`
 	SystemRoles  []string     `bson:"roles"`
+	LoginCache   LoginCache   `bson:"login_cache,omitempty"`
+}
+
+// LoginCache is a login session token of a user manager that does not issue
+// its own tokens, and when the user last logged in with it.
+type LoginCache struct {
+	Token string    `bson:"token,omitempty"`
+	TTL   time.Time `bson:"ttl,omitempty"`
 }
 
 type GithubUser struct {
@@ -37,11 +46,16 @@ type PubKey struct {
 }
 
 type UserSettings struct {
-	Timezone      string                  `json:"timezone" bson:"timezone"`
-	NewWaterfall  bool                    `json:"new_waterfall" bson:"new_waterfall"`
-	GithubUser    GithubUser              `json:"github_user" bson:"github_user,omitempty"`
-	SlackUsername string                  `bson:"slack_username,omitempty" json:"slack_username,omitempty"`
-	Notifications NotificationPreferences `bson:"notifications,omitempty" json:"notifications,omitempty"`
+	Timezone      string     `json:"timezone" bson:"timezone"`
+	NewWaterfall  bool       `json:"new_waterfall" bson:"new_waterfall"`
+	GithubUser    GithubUser `json:"github_user" bson:"github_user,omitempty"`
+	SlackUsername string     `bson:"slack_username,omitempty" json:"slack_username,omitempty"`
+	// TeamsWebhookURL and MattermostWebhookURL are the incoming webhooks
+	// that notifications are posted to when a user prefers Teams or
+	// Mattermost.
+	TeamsWebhookURL      string                  `bson:"teams_webhook_url,omitempty" json:"teams_webhook_url,omitempty"`
+	MattermostWebhookURL string                  `bson:"mattermost_webhook_url,omitempty" json:"mattermost_webhook_url,omitempty"`
+	Notifications        NotificationPreferences `bson:"notifications,omitempty" json:"notifications,omitempty"`
 }
 
 type NotificationPreferences struct {
@@ -53,13 +67,17 @@ type NotificationPreferences struct {
 	SpawnHostExpirationID string                     `bson:"spawn_host_expiration_id,omitempty" json:"-"`
 	SpawnHostOutcome      UserSubscriptionPreference `bson:"spawn_host_outcome" json:"spawn_host_outcome"`
 	SpawnHostOutcomeID    string                     `bson:"spawn_host_outcome_id,omitempty" json:"-"`
+	CommitQueue           UserSubscriptionPreference `bson:"commit_queue" json:"commit_queue"`
+	CommitQueueID         string                     `bson:"commit_queue_id,omitempty" json:"-"`
 }
 
 type UserSubscriptionPreference string
 
 const (
-	PreferenceEmail UserSubscriptionPreference = event.EmailSubscriberType
-	PreferenceSlack UserSubscriptionPreference = event.SlackSubscriberType
+	PreferenceEmail      UserSubscriptionPreference = event.EmailSubscriberType
+	PreferenceSlack      UserSubscriptionPreference = event.SlackSubscriberType
+	PreferenceTeams      UserSubscriptionPreference = event.TeamsSubscriberType
+	PreferenceMattermost UserSubscriptionPreference = event.MattermostSubscriberType
 )
 
 func (u *DBUser) Username() string     { return u.Id }
@@ -170,7 +188,8 @@ func (u *DBUser) IncPatchNumber() (int, error) {
 
 func IsValidSubscriptionPreference(in string) bool {
 	switch in {
-	case event.EmailSubscriberType, event.SlackSubscriberType, "":
+	case event.EmailSubscriberType, event.SlackSubscriberType, event.TeamsSubscriberType,
+		event.MattermostSubscriberType, "":
 		return true
 	default:
 		return false
@@ -137,3 +137,59 @@ func (s *UserTestSuite) TestFindByGithubUID() {
 	s.NoError(err)
 	s.Nil(u)
 }
+
+func (s *UserTestSuite) TestAddAPIToken() {
+	token, err := s.users[0].AddAPIToken("ci", APITokenScopePatch, time.Hour)
+	s.NoError(err)
+	s.True(IsAPIToken(token))
+	s.Require().Len(s.users[0].APITokens, 1)
+	s.NotEqual(token, s.users[0].APITokens[0].Hash)
+
+	dbUser, err := FindOne(ById(s.users[0].Id))
+	s.NoError(err)
+	s.Require().NotNil(dbUser)
+	apiToken := dbUser.GetAPIToken(token)
+	s.Require().NotNil(apiToken)
+	s.Equal("ci", apiToken.Name)
+	s.Equal(APITokenScopePatch, apiToken.Scope)
+	s.False(apiToken.ExpiresAt.IsZero())
+
+	_, err = s.users[0].AddAPIToken("ci", APITokenScopeRead, 0)
+	s.Error(err)
+	_, err = s.users[0].AddAPIToken("other", "admin", 0)
+	s.Error(err)
+	_, err = s.users[0].AddAPIToken("", APITokenScopeRead, 0)
+	s.Error(err)
+}
+
+func (s *UserTestSuite) TestRevokeAPIToken() {
+	ciToken, err := s.users[0].AddAPIToken("ci", APITokenScopePatch, 0)
+	s.NoError(err)
+	hostsToken, err := s.users[0].AddAPIToken("hosts", APITokenScopeHosts, 0)
+	s.NoError(err)
+
+	s.NoError(s.users[0].RevokeAPIToken("ci"))
+	s.Error(s.users[0].RevokeAPIToken("ci"))
+
+	dbUser, err := FindOne(ById(s.users[0].Id))
+	s.NoError(err)
+	s.Require().NotNil(dbUser)
+	s.Nil(dbUser.GetAPIToken(ciToken))
+	s.NotNil(dbUser.GetAPIToken(hostsToken))
+}
+
+func (s *UserTestSuite) TestMarkAPITokenUsed() {
+	token, err := s.users[0].AddAPIToken("ci", APITokenScopeRead, 0)
+	s.NoError(err)
+
+	now := time.Now().Round(time.Millisecond)
+	s.NoError(s.users[0].MarkAPITokenUsed("ci", now))
+	s.Error(s.users[0].MarkAPITokenUsed("missing", now))
+
+	dbUser, err := FindOne(ById(s.users[0].Id))
+	s.NoError(err)
+	s.Require().NotNil(dbUser)
+	apiToken := dbUser.GetAPIToken(token)
+	s.Require().NotNil(apiToken)
+	s.True(now.Equal(apiToken.LastUsedAt))
+}
@@ -1,9 +1,12 @@
 package model
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/anser/bsonutil"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -21,6 +24,60 @@ func SetUserAPIKey(userId, newKey string) error {
 	return errors.Wrapf(user.UpdateOne(bson.M{user.IdKey: userId}, update), "problem setting api key for user %s", userId)
 }
 
+// SetUserRoles replaces the system roles of a user.
+func SetUserRoles(userId string, roles []string) error {
+	update := bson.M{"$set": bson.M{user.SystemRolesKey: roles}}
+	return errors.Wrapf(user.UpdateOne(bson.M{user.IdKey: userId}, update), "problem setting roles for user %s", userId)
+}
+
+// PutLoginCache records that a user has logged in, returning the user's
+// login token. The user keeps their existing token, if they have one, so
+// that logging in again does not end their other sessions.
+func PutLoginCache(userId string) (string, error) {
+	u, err := FindUserByID(userId)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	token := u.LoginCache.Token
+	if token == "" {
+		token = util.RandomString()
+	}
+	update := bson.M{"$set": bson.M{
+		bsonutil.GetDottedKeyName(user.LoginCacheKey, user.LoginCacheTokenKey): token,
+		bsonutil.GetDottedKeyName(user.LoginCacheKey, user.LoginCacheTTLKey):   time.Now(),
+	}}
+	if err = user.UpdateOne(bson.M{user.IdKey: userId}, update); err != nil {
+		return "", errors.Wrapf(err, "problem caching login for user %s", userId)
+	}
+
+	return token, nil
+}
+
+// GetLoginCache returns the user with the given login token, returning an
+// error if there is no such user or if they logged in more than expireAfter
+// ago.
+func GetLoginCache(token string, expireAfter time.Duration) (*user.DBUser, error) {
+	if token == "" {
+		return nil, errors.New("login token is empty")
+	}
+
+	u, err := user.FindOne(db.Query(bson.M{
+		bsonutil.GetDottedKeyName(user.LoginCacheKey, user.LoginCacheTokenKey): token,
+	}))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding user by login token")
+	}
+	if u == nil {
+		return nil, errors.New("no user has the login token")
+	}
+	if time.Since(u.LoginCache.TTL) > expireAfter {
+		return nil, errors.Errorf("login of user %s has expired", u.Id)
+	}
+
+	return u, nil
+}
+
 func FindUserByID(id string) (*user.DBUser, error) {
 	t, err := user.FindOne(user.ById(id))
 	if err != nil {
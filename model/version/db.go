@@ -40,6 +40,10 @@ var (
 	IdentifierKey          = bsonutil.MustHaveTag(Version{}, "Identifier")
 	RemoteKey              = bsonutil.MustHaveTag(Version{}, "Remote")
 	RemoteURLKey           = bsonutil.MustHaveTag(Version{}, "RemotePath")
+
+	// bson fields linking the versions of project triggers
+	UpstreamKey             = bsonutil.MustHaveTag(Version{}, "Upstream")
+	DownstreamVersionIdsKey = bsonutil.MustHaveTag(Version{}, "DownstreamVersionIds")
 )
 
 // ById returns a db.Q object which will filter on {_id : <the id param>}
@@ -127,6 +131,16 @@ func ByProjectId(projectId string) db.Q {
 		})
 }
 
+// ByProjectIdAndRequesters finds all versions within a project that were
+// created by any of the given requesters.
+func ByProjectIdAndRequesters(projectId string, requesters []string) db.Q {
+	return db.Query(
+		bson.M{
+			IdentifierKey: projectId,
+			RequesterKey:  bson.M{"$in": requesters},
+		})
+}
+
 // ByProjectId finds all versions within a project, ordered by most recently created to oldest.
 // The requester controls if it should search patch or non-patch versions.
 func ByMostRecentForRequester(projectId, requester string) db.Q {
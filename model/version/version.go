@@ -6,6 +6,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
@@ -48,9 +49,21 @@ type Version struct {
 	Errors   []string `bson:"errors,omitempty" json:"errors,omitempty"`
 	Warnings []string `bson:"warnings,omitempty" json:"warnings,omitempty"`
 
+	// Skipped lists the build variants and tasks that were not created for
+	// this version because none of the files it changed match their paths.
+	Skipped []SkippedUnit `bson:"skipped,omitempty" json:"skipped,omitempty"`
+
 	// AuthorID is an optional reference to the Evergreen user that authored
 	// this comment, if they can be identified
 	AuthorID string `bson:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// Upstream is the version, or build of a version, of another project
+	// whose completion triggered this version, if a project trigger
+	// created it.
+	Upstream *UpstreamVersion `bson:"upstream,omitempty" json:"upstream,omitempty"`
+	// DownstreamVersionIds are the versions of other projects that project
+	// triggers created when this version, or one of its builds, finished.
+	DownstreamVersionIds []string `bson:"downstream_version_ids,omitempty" json:"downstream_version_ids,omitempty"`
 }
 
 func (v *Version) LastSuccessful() (*Version, error) {
@@ -77,6 +90,22 @@ func (self *Version) Insert() error {
 	return db.Insert(Collection, self)
 }
 
+// AddDownstreamVersion records that a project trigger created the version
+// with the given ID when this version, or one of its builds, finished.
+func (v *Version) AddDownstreamVersion(downstreamId string) error {
+	err := UpdateOne(
+		bson.M{IdKey: v.Id},
+		bson.M{"$addToSet": bson.M{DownstreamVersionIdsKey: downstreamId}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "problem adding downstream version '%s' to version '%s'", downstreamId, v.Id)
+	}
+	if !util.StringSliceContains(v.DownstreamVersionIds, downstreamId) {
+		v.DownstreamVersionIds = append(v.DownstreamVersionIds, downstreamId)
+	}
+	return nil
+}
+
 // BuildStatus stores metadata relating to each build
 type BuildStatus struct {
 	BuildVariant string    `bson:"build_variant" json:"id"`
@@ -85,6 +114,34 @@ type BuildStatus struct {
 	BuildId      string    `bson:"build_id,omitempty" json:"build_id,omitempty"`
 }
 
+// SkippedUnit is a build variant, or a task of a build variant, that was
+// not created for a version, along with the reason why.
+type SkippedUnit struct {
+	BuildVariant string `bson:"build_variant" json:"build_variant"`
+	Task         string `bson:"task,omitempty" json:"task,omitempty"`
+	Reason       string `bson:"reason" json:"reason"`
+}
+
+// UpstreamVersion describes the completion of a version, or of a build of
+// a version, that triggered a version of a downstream project.
+type UpstreamVersion struct {
+	TriggerID    string             `bson:"trigger_id" json:"trigger_id"`
+	Project      string             `bson:"project" json:"project"`
+	VersionId    string             `bson:"version_id" json:"version_id"`
+	Revision     string             `bson:"revision" json:"revision"`
+	BuildId      string             `bson:"build_id,omitempty" json:"build_id,omitempty"`
+	BuildVariant string             `bson:"build_variant,omitempty" json:"build_variant,omitempty"`
+	Status       string             `bson:"status" json:"status"`
+	Artifacts    []UpstreamArtifact `bson:"artifacts,omitempty" json:"artifacts,omitempty"`
+}
+
+// UpstreamArtifact is a file uploaded by a task of an upstream version.
+type UpstreamArtifact struct {
+	Task string `bson:"task" json:"task"`
+	Name string `bson:"name" json:"name"`
+	Link string `bson:"link" json:"link"`
+}
+
 var (
 	BuildStatusVariantKey    = bsonutil.MustHaveTag(BuildStatus{}, "BuildVariant")
 	BuildStatusActivatedKey  = bsonutil.MustHaveTag(BuildStatus{}, "Activated")
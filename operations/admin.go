@@ -27,6 +27,7 @@ func Admin() cli.Command {
 			listEvents(),
 			revert(),
 			fetchAllProjectConfigs(),
+			adminRoles(),
 		},
 	}
 }
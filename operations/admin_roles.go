@@ -0,0 +1,178 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const (
+	roleFlagName  = "role"
+	userFlagName  = "user"
+	groupFlagName = "group"
+)
+
+func adminRoles() cli.Command {
+	return cli.Command{
+		Name:   "roles",
+		Usage:  "manage who has what role on a project",
+		Before: setPlainLogger,
+		Subcommands: []cli.Command{
+			adminRolesList(),
+			adminRolesGrant(),
+			adminRolesRevoke(),
+		},
+	}
+}
+
+func addRoleAssignmentFlags(flags ...cli.Flag) []cli.Flag {
+	return append(addProjectFlag(flags...),
+		cli.StringFlag{
+			Name:  joinFlagNames(roleFlagName, "r"),
+			Usage: "specify the name of a role (viewer, patcher, scheduler or admin)",
+		},
+		cli.StringFlag{
+			Name:  joinFlagNames(userFlagName, "u"),
+			Usage: "specify the user who has the role",
+		},
+		cli.StringFlag{
+			Name:  joinFlagNames(groupFlagName, "g"),
+			Usage: "specify the group that has the role",
+		},
+	)
+}
+
+func requireRoleAssignmentFlags(c *cli.Context) error {
+	if (c.String(userFlagName) == "") == (c.String(groupFlagName) == "") {
+		return errors.Errorf("must specify one and only one of: --%s, --%s", userFlagName, groupFlagName)
+	}
+	return nil
+}
+
+func roleAssignmentFromFlags(c *cli.Context) model.APIRoleAssignment {
+	return model.APIRoleAssignment{
+		ProjectID: model.ToAPIString(c.String(projectFlagName)),
+		Role:      model.ToAPIString(c.String(roleFlagName)),
+		User:      model.ToAPIString(c.String(userFlagName)),
+		Group:     model.ToAPIString(c.String(groupFlagName)),
+	}
+}
+
+func adminRolesList() cli.Command {
+	return cli.Command{
+		Name:  "list",
+		Usage: "list the built in roles, or the roles assigned on a project",
+		Flags: addProjectFlag(),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			projectID := c.String(projectFlagName)
+			if projectID == "" {
+				var roles []model.APIRole
+				roles, err = client.GetRoles(ctx)
+				if err != nil {
+					return errors.Wrap(err, "error fetching roles")
+				}
+				for _, r := range roles {
+					permissions := make([]string, 0, len(r.Permissions))
+					for _, p := range r.Permissions {
+						permissions = append(permissions, model.FromAPIString(p))
+					}
+					grip.Infof("%s: %s [%s]", model.FromAPIString(r.Name), model.FromAPIString(r.Description), strings.Join(permissions, ", "))
+				}
+				return nil
+			}
+
+			assignments, err := client.GetProjectRoles(ctx, projectID)
+			if err != nil {
+				return errors.Wrap(err, "error fetching project roles")
+			}
+			if len(assignments) == 0 {
+				grip.Infof("no roles are assigned on '%s'", projectID)
+				return nil
+			}
+			for _, assignment := range assignments {
+				subject := fmt.Sprintf("user %s", model.FromAPIString(assignment.User))
+				if group := model.FromAPIString(assignment.Group); group != "" {
+					subject = fmt.Sprintf("group %s", group)
+				}
+				grip.Infof("%s: %s", model.FromAPIString(assignment.Role), subject)
+			}
+
+			return nil
+		},
+	}
+}
+
+func adminRolesGrant() cli.Command {
+	return cli.Command{
+		Name:   "grant",
+		Usage:  "grant a role on a project to a user or a group",
+		Flags:  addRoleAssignmentFlags(),
+		Before: mergeBeforeFuncs(requireStringFlag(projectFlagName), requireStringFlag(roleFlagName), requireRoleAssignmentFlags),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if err = client.AssignProjectRole(ctx, roleAssignmentFromFlags(c)); err != nil {
+				return errors.Wrap(err, "error granting role")
+			}
+
+			grip.Infof("granted role '%s' on '%s'", c.String(roleFlagName), c.String(projectFlagName))
+			return nil
+		},
+	}
+}
+
+func adminRolesRevoke() cli.Command {
+	return cli.Command{
+		Name:   "revoke",
+		Usage:  "revoke a role on a project from a user or a group",
+		Flags:  addRoleAssignmentFlags(),
+		Before: mergeBeforeFuncs(requireStringFlag(projectFlagName), requireStringFlag(roleFlagName), requireRoleAssignmentFlags),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if err = client.RemoveProjectRole(ctx, roleAssignmentFromFlags(c)); err != nil {
+				return errors.Wrap(err, "error revoking role")
+			}
+
+			grip.Infof("revoked role '%s' on '%s'", c.String(roleFlagName), c.String(projectFlagName))
+			return nil
+		},
+	}
+}
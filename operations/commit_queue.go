@@ -0,0 +1,141 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const prNumberFlagName = "pr"
+
+func CommitQueue() cli.Command {
+	return cli.Command{
+		Name:   "commit-queue",
+		Usage:  "for managing the commit queue of a project",
+		Before: setPlainLogger,
+		Subcommands: []cli.Command{
+			commitQueueList(),
+			commitQueueMerge(),
+			commitQueueDelete(),
+		},
+	}
+}
+
+func addPRNumberFlag(flags ...cli.Flag) []cli.Flag {
+	return append(flags, cli.IntFlag{
+		Name:  prNumberFlagName,
+		Usage: "specify the number of a GitHub pull request",
+	})
+}
+
+func requirePRNumberFlag(c *cli.Context) error {
+	if c.Int(prNumberFlagName) <= 0 {
+		return errors.New("must specify a pull request number")
+	}
+	return nil
+}
+
+func commitQueueList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list the pull requests in the commit queue of a project",
+		Flags:  addProjectFlag(),
+		Before: requireStringFlag(projectFlagName),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			cq, err := client.GetCommitQueue(ctx, c.String(projectFlagName))
+			if err != nil {
+				return errors.Wrap(err, "error fetching commit queue")
+			}
+
+			if len(cq.Queue) == 0 {
+				grip.Infof("the commit queue of '%s' is empty", model.FromAPIString(cq.ProjectID))
+				return nil
+			}
+
+			for i, item := range cq.Queue {
+				line := fmt.Sprintf("%d: #%d (enqueued by %s)", i, item.PRNumber, model.FromAPIString(item.Author))
+				if version := model.FromAPIString(item.Version); version != "" {
+					line = fmt.Sprintf("%s testing in patch %s", line, version)
+				}
+				grip.Info(line)
+			}
+
+			return nil
+		},
+	}
+}
+
+func commitQueueMerge() cli.Command {
+	return cli.Command{
+		Name:   "merge",
+		Usage:  "add a pull request to the commit queue of a project",
+		Flags:  addProjectFlag(addPRNumberFlag()...),
+		Before: mergeBeforeFuncs(requireStringFlag(projectFlagName), requirePRNumberFlag),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			position, err := client.EnqueueCommitQueueItem(ctx, c.String(projectFlagName), c.Int(prNumberFlagName))
+			if err != nil {
+				return errors.Wrap(err, "error enqueueing pull request")
+			}
+
+			grip.Infof("pull request #%d is at position %d in the commit queue", c.Int(prNumberFlagName), position)
+			return nil
+		},
+	}
+}
+
+func commitQueueDelete() cli.Command {
+	return cli.Command{
+		Name:   "delete",
+		Usage:  "remove a pull request from the commit queue of a project",
+		Flags:  addProjectFlag(addPRNumberFlag()...),
+		Before: mergeBeforeFuncs(requireStringFlag(projectFlagName), requirePRNumberFlag),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if err = client.DeleteCommitQueueItem(ctx, c.String(projectFlagName), c.Int(prNumberFlagName)); err != nil {
+				return errors.Wrap(err, "error removing pull request")
+			}
+
+			grip.Infof("removed pull request #%d from the commit queue", c.Int(prNumberFlagName))
+			return nil
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package operations
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 
@@ -19,7 +20,7 @@ func Evaluate() cli.Command {
 	return cli.Command{
 		Name:  "evaluate",
 		Usage: "reads a project configuration and expands tags and matrix definitions, printing the expanded definitions",
-		Flags: addPathFlag(
+		Flags: addPathFlag(addIncludeFlags(
 			cli.BoolFlag{
 				Name:  taskFlagName,
 				Usage: "only show task and function definitions",
@@ -27,7 +28,7 @@ func Evaluate() cli.Command {
 			cli.BoolFlag{
 				Name:  variantsFlagName,
 				Usage: "only show variant definitions",
-			}),
+			})...),
 		Before: requirePathFlag,
 		Action: func(c *cli.Context) error {
 			path := c.String(pathFlagName)
@@ -39,6 +40,11 @@ func Evaluate() cli.Command {
 				return errors.Wrap(err, "error reading project config")
 			}
 
+			configBytes, err = resolveLocalIncludes(context.Background(), c, configBytes)
+			if err != nil {
+				return errors.Wrap(err, "error resolving project includes")
+			}
+
 			p := &model.Project{}
 			err = model.LoadProjectInto(configBytes, "", p)
 			if err != nil {
@@ -23,6 +23,9 @@ const (
 	startTimeFlagName  = "time"
 	limitFlagName      = "limit"
 
+	includeRootFlagName   = "include-root"
+	includeModuleFlagName = "include-module"
+
 	anserDryRunFlagName      = "dry-run"
 	anserLimitFlagName       = "limit"
 	anserTargetFlagName      = "target"
@@ -46,6 +49,19 @@ func addPathFlag(flags ...cli.Flag) []cli.Flag {
 	})
 }
 
+func addIncludeFlags(flags ...cli.Flag) []cli.Flag {
+	return append(flags,
+		cli.StringFlag{
+			Name:  includeRootFlagName,
+			Usage: "directory that files included by the project configuration are relative to",
+			Value: ".",
+		},
+		cli.StringSliceFlag{
+			Name:  includeModuleFlagName,
+			Usage: "local checkout of a module with files included by the project configuration, as NAME=PATH",
+		})
+}
+
 func addOutputPath(flags ...cli.Flag) []cli.Flag {
 	return append(flags, cli.StringFlag{
 		Name:  joinFlagNames(pathFlagName, "filename", "file", "f"),
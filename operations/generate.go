@@ -0,0 +1,123 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func Generate() cli.Command {
+	return cli.Command{
+		Name:   "generate",
+		Usage:  "for working with files for the generate.tasks command",
+		Before: setPlainLogger,
+		Subcommands: []cli.Command{
+			generateValidate(),
+		},
+	}
+}
+
+func generateValidate() cli.Command {
+	const taskFlagName = "task"
+
+	return cli.Command{
+		Name:      "validate",
+		Usage:     "check the files a task would pass to generate.tasks, and show the variants and tasks they would add",
+		ArgsUsage: "FILE...",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(taskFlagName, "t"),
+				Usage: "specify the ID of the task that runs generate.tasks",
+			},
+		},
+		Before: mergeBeforeFuncs(
+			requireStringFlag(taskFlagName),
+			func(c *cli.Context) error {
+				if c.NArg() == 0 {
+					return errors.New("must specify at least one file to validate")
+				}
+				return nil
+			},
+		),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			files, err := readGeneratedFiles(c.Args())
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			dryRun, err := client.GenerateTasksDryRun(ctx, c.String(taskFlagName), files)
+			if err != nil {
+				return errors.Wrap(err, "error validating generated tasks")
+			}
+			printGenerateDryRun(dryRun)
+			return nil
+		},
+	}
+}
+
+// readGeneratedFiles reads files for generate.tasks, converting YAML files
+// to JSON as the agent does.
+func readGeneratedFiles(fileNames []string) ([]json.RawMessage, error) {
+	files := []json.RawMessage{}
+	for _, fn := range fileNames {
+		data, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem reading file '%s'", fn)
+		}
+		if util.IsYAMLFile(fn) {
+			data, err = util.YAMLToJSON(data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "problem converting YAML file '%s' to JSON", fn)
+			}
+		} else if !json.Valid(data) {
+			return nil, errors.Errorf("file '%s' is not valid JSON", fn)
+		}
+		files = append(files, json.RawMessage(data))
+	}
+	return files, nil
+}
+
+func printGenerateDryRun(dryRun *model.APIGenerateTasksDryRun) {
+	if len(dryRun.BuildVariants) == 0 {
+		fmt.Println("The generated files are valid but would not add any tasks.")
+		return
+	}
+
+	fmt.Println("The generated files are valid and would add:")
+	for _, bv := range dryRun.BuildVariants {
+		label := "existing build variant"
+		if bv.New {
+			label = "new build variant"
+		}
+		fmt.Printf("\n%s '%s':\n", label, model.FromAPIString(bv.Name))
+		for _, t := range bv.Tasks {
+			fmt.Printf("\t%s\n", model.FromAPIString(t))
+		}
+		if len(bv.DisplayTasks) > 0 {
+			names := make([]string, 0, len(bv.DisplayTasks))
+			for _, dt := range bv.DisplayTasks {
+				names = append(names, model.FromAPIString(dt))
+			}
+			fmt.Printf("\tdisplay tasks: %s\n", strings.Join(names, ", "))
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package operations
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGeneratedFiles(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "generated-files")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	yamlFile := filepath.Join(dir, "generated.yaml")
+	jsonFile := filepath.Join(dir, "generated.json")
+	badFile := filepath.Join(dir, "bad.json")
+	require.NoError(ioutil.WriteFile(yamlFile, []byte("tasks:\n  - name: lint\n"), 0644))
+	require.NoError(ioutil.WriteFile(jsonFile, []byte(`{"tasks": [{"name": "test"}]}`), 0644))
+	require.NoError(ioutil.WriteFile(badFile, []byte("tasks:\n  - name: lint\n"), 0644))
+
+	files, err := readGeneratedFiles([]string{yamlFile, jsonFile})
+	require.NoError(err)
+	require.Len(files, 2)
+	assert.JSONEq(`{"tasks": [{"name": "lint"}]}`, string(files[0]))
+	assert.JSONEq(`{"tasks": [{"name": "test"}]}`, string(files[1]))
+
+	_, err = readGeneratedFiles([]string{badFile})
+	assert.Error(err)
+
+	_, err = readGeneratedFiles([]string{filepath.Join(dir, "missing.json")})
+	assert.Error(err)
+}
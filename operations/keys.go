@@ -4,7 +4,10 @@ import (
 	"context"
 	"io/ioutil"
 	"strings"
+	"time"
 
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
@@ -19,6 +22,7 @@ func Keys() cli.Command {
 			keysAdd(),
 			keysList(),
 			keysDelete(),
+			keysTokens(),
 		},
 	}
 }
@@ -177,3 +181,167 @@ func keysDelete() cli.Command {
 		},
 	}
 }
+
+func keysTokens() cli.Command {
+	return cli.Command{
+		Name:  "tokens",
+		Usage: "manage named, scoped API tokens to use in place of your API key",
+		Subcommands: []cli.Command{
+			keysTokensCreate(),
+			keysTokensList(),
+			keysTokensRevoke(),
+		},
+	}
+}
+
+func keysTokensCreate() cli.Command {
+	const (
+		tokenNameFlagName    = "name"
+		tokenScopeFlagName   = "scope"
+		tokenExpiresFlagName = "expires"
+	)
+
+	return cli.Command{
+		Name:  "create",
+		Usage: "create an API token, which is only shown once",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(tokenNameFlagName, "n"),
+				Usage: "specify the name of the token",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(tokenScopeFlagName, "s"),
+				Usage: "specify what the token may do (read, patch or hosts)",
+				Value: user.APITokenScopeRead,
+			},
+			cli.DurationFlag{
+				Name:  joinFlagNames(tokenExpiresFlagName, "e"),
+				Usage: "specify how long until the token expires, in hours (e.g. 720h); it never expires if unset",
+			},
+		},
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			requireStringFlag(tokenNameFlagName),
+			requireStringValueChoices(tokenScopeFlagName, user.ValidAPITokenScopes),
+			func(c *cli.Context) error {
+				if c.Duration(tokenExpiresFlagName) < 0 {
+					return errors.New("token expiry cannot be in the past")
+				}
+				if c.Duration(tokenExpiresFlagName)%time.Hour != 0 {
+					return errors.New("token expiry must be a whole number of hours")
+				}
+				return nil
+			}),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			expiresInHours := int(c.Duration(tokenExpiresFlagName) / time.Hour)
+			token, err := client.CreateAPIToken(ctx, c.String(tokenNameFlagName), c.String(tokenScopeFlagName), expiresInHours)
+			if err != nil {
+				return errors.Wrap(err, "problem creating API token")
+			}
+
+			grip.Infof("Created API token '%s' with scope '%s'. Use it as the api_token in your evergreen configuration, "+
+				"or as the Api-Key header; it will not be shown again:\n%s",
+				model.FromAPIString(token.Name), model.FromAPIString(token.Scope), model.FromAPIString(token.Token))
+
+			return nil
+		},
+	}
+}
+
+func keysTokensList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list the API tokens of the current user",
+		Before: setPlainLogger,
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			tokens, err := client.GetAPITokens(ctx)
+			if err != nil {
+				return errors.Wrap(err, "problem fetching API tokens")
+			}
+
+			if len(tokens) == 0 {
+				grip.Info("No API tokens found")
+				return nil
+			}
+			for _, token := range tokens {
+				grip.Infof("Name: '%s', Scope: '%s', Created: %s, Expires: %s, Last used: %s",
+					model.FromAPIString(token.Name), model.FromAPIString(token.Scope),
+					formatTokenTime(token.CreatedAt, "never"), formatTokenTime(token.ExpiresAt, "never"),
+					formatTokenTime(token.LastUsedAt, "never"))
+			}
+
+			return nil
+		},
+	}
+}
+
+func formatTokenTime(t model.APITime, zero string) string {
+	if time.Time(t).IsZero() {
+		return zero
+	}
+	return time.Time(t).Local().Format(time.RFC3339)
+}
+
+func keysTokensRevoke() cli.Command {
+	return cli.Command{
+		Name:  "revoke",
+		Usage: "revoke an API token of the current user, without affecting the others",
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			func(c *cli.Context) error {
+				if c.NArg() != 1 || c.Args().Get(0) == "" {
+					return errors.New("must specify the name of one token to revoke")
+				}
+				return nil
+			}),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			name := c.Args().Get(0)
+			if err := client.RevokeAPIToken(ctx, name); err != nil {
+				return errors.Wrap(err, "problem revoking API token")
+			}
+
+			grip.Infof("Successfully revoked API token: '%s'", name)
+
+			return nil
+		},
+	}
+}
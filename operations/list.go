@@ -284,6 +284,12 @@ func loadLocalConfig(filepath string) (*model.Project, error) {
 		return nil, errors.Wrap(err, "error reading project config")
 	}
 
+	// included files are read relative to the working directory
+	configBytes, err = model.ResolveIncludes(context.Background(), configBytes, model.NewLocalIncludeFetcher(".", nil))
+	if err != nil {
+		return nil, errors.Wrap(err, "error resolving project includes")
+	}
+
 	project := &model.Project{}
 	err = model.LoadProjectInto(configBytes, "", project)
 	if err != nil {
@@ -68,6 +68,7 @@ type ClientSettings struct {
 	APIServerHost string              `json:"api_server_host" yaml:"api_server_host,omitempty"`
 	UIServerHost  string              `json:"ui_server_host" yaml:"ui_server_host,omitempty"`
 	APIKey        string              `json:"api_key" yaml:"api_key,omitempty"`
+	APIToken      string              `json:"api_token" yaml:"api_token,omitempty"`
 	User          string              `json:"user" yaml:"user,omitempty"`
 	Projects      []ClientProjectConf `json:"projects" yaml:"projects,omitempty"`
 	Admin         ClientAdminConf     `json:"admin" yaml:"admin,omitempty"`
@@ -112,11 +113,20 @@ func (s *ClientSettings) Write(fn string) error {
 	return errors.Wrap(ioutil.WriteFile(fn, yamlData, 0644), "could not write file")
 }
 
+// credential returns the API token, which is preferred when the settings
+// have one, or otherwise the API key.
+func (s *ClientSettings) credential() string {
+	if s.APIToken != "" {
+		return s.APIToken
+	}
+	return s.APIKey
+}
+
 func (s *ClientSettings) GetRestCommunicator(ctx context.Context) client.Communicator {
 	c := client.NewCommunicator(s.APIServerHost)
 
 	c.SetAPIUser(s.User)
-	c.SetAPIKey(s.APIKey)
+	c.SetAPIKey(s.credential())
 
 	banner, err := c.GetBannerMessage(ctx)
 	if err != nil {
@@ -152,7 +162,7 @@ func (s *ClientSettings) getLegacyClients() (*legacyClient, *legacyClient, error
 		APIRoot:   s.APIServerHost,
 		APIRootV2: s.APIServerHost + "/rest/v2",
 		User:      s.User,
-		APIKey:    s.APIKey,
+		APIKey:    s.credential(),
 		UIRoot:    s.UIServerHost,
 	}
 
@@ -160,7 +170,7 @@ func (s *ClientSettings) getLegacyClients() (*legacyClient, *legacyClient, error
 		APIRoot:   apiURL.Scheme + "://" + apiURL.Host + "/rest/v1",
 		APIRootV2: apiURL.Scheme + "://" + apiURL.Host + "/rest/v2",
 		User:      s.User,
-		APIKey:    s.APIKey,
+		APIKey:    s.credential(),
 		UIRoot:    s.UIServerHost,
 	}
 
@@ -0,0 +1,221 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const localRunID = "local"
+
+func RunLocal() cli.Command {
+	const (
+		taskFlagName           = "task"
+		variantFlagName        = "variant"
+		outputFlagName         = "output"
+		workDirFlagName        = "workdir"
+		revisionFlagName       = "revision"
+		expansionFlagName      = "expansion"
+		expansionsFileFlagName = "expansions-file"
+	)
+
+	return cli.Command{
+		Name:  "run-local",
+		Usage: "run a task from a project configuration on this machine",
+		Flags: addPathFlag(addIncludeFlags(
+			cli.StringFlag{
+				Name:  joinFlagNames(taskFlagName, "t"),
+				Usage: "name of the task to run",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(variantFlagName, "v"),
+				Usage: "name of the build variant to run the task on",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(outputFlagName, "o"),
+				Usage: "directory to write logs, test results and artifacts to",
+				Value: "evergreen-local",
+			},
+			cli.StringFlag{
+				Name:  workDirFlagName,
+				Usage: "directory to create the task's working directory in (defaults to a temporary directory)",
+			},
+			cli.StringFlag{
+				Name:  revisionFlagName,
+				Usage: "revision to set the ${revision} expansion to",
+			},
+			cli.StringSliceFlag{
+				Name:  expansionFlagName,
+				Usage: "expansion to set for the task, as KEY=VALUE; may specify more than once",
+			},
+			cli.StringFlag{
+				Name:  expansionsFileFlagName,
+				Usage: "YAML file of expansions to set for the task",
+			},
+		)...),
+		Before: mergeBeforeFuncs(
+			requirePathFlag,
+			requireStringFlag(taskFlagName),
+			requireStringFlag(variantFlagName),
+		),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			data, err := ioutil.ReadFile(c.String(pathFlagName))
+			if err != nil {
+				return errors.Wrap(err, "problem reading project configuration")
+			}
+			data, err = resolveLocalIncludes(ctx, c, data)
+			if err != nil {
+				return errors.Wrap(err, "problem resolving project includes")
+			}
+
+			expansions, err := readLocalExpansions(c.String(expansionsFileFlagName), c.StringSlice(expansionFlagName))
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			workDir := c.String(workDirFlagName)
+			if workDir == "" {
+				workDir, err = ioutil.TempDir("", "evergreen-local")
+				if err != nil {
+					return errors.Wrap(err, "problem creating working directory")
+				}
+				defer os.RemoveAll(workDir)
+			} else if err = os.MkdirAll(workDir, 0755); err != nil {
+				return errors.Wrapf(err, "problem creating working directory '%s'", workDir)
+			}
+			outputDir, err := filepath.Abs(c.String(outputFlagName))
+			if err != nil {
+				return errors.Wrap(err, "problem finding output directory")
+			}
+
+			opts, taskGroup, err := makeLocalTaskOptions(data, c.String(taskFlagName), c.String(variantFlagName), c.String(revisionFlagName))
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			opts.Distro.WorkDir = workDir
+			opts.Expansions = expansions
+			opts.OutputDir = outputDir
+			opts.Output = os.Stdout
+
+			comm, err := client.NewLocal(opts)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			agt := agent.New(agent.Options{
+				HostID:           localRunID,
+				LogPrefix:        filepath.Join(outputDir, "evg.agent"),
+				WorkingDirectory: workDir,
+				Cleanup:          true,
+			}, comm)
+
+			td := client.TaskData{ID: opts.Task.Id, Secret: localRunID}
+			if err = agt.RunLocalTask(ctx, td, taskGroup); err != nil {
+				return errors.Wrap(err, "problem running task")
+			}
+
+			detail := comm.EndTaskDetail()
+			if detail == nil {
+				return errors.New("task did not finish")
+			}
+			fmt.Printf("\nTask '%s' on '%s' finished with status '%s'.\n", opts.Task.DisplayName, opts.Task.BuildVariant, detail.Status)
+			fmt.Printf("Logs, test results and artifacts are in '%s'.\n", outputDir)
+			if detail.Status != evergreen.TaskSucceeded {
+				return errors.Errorf("task finished with status '%s'", detail.Status)
+			}
+			return nil
+		},
+	}
+}
+
+// makeLocalTaskOptions returns the task, version, project ref and distro for
+// running a task of the project locally, and the name of the task's task
+// group, if any.
+func makeLocalTaskOptions(data []byte, taskName, variant, revision string) (client.LocalOptions, string, error) {
+	project := &model.Project{}
+	if err := model.LoadProjectInto(data, localRunID, project); err != nil {
+		return client.LocalOptions{}, "", errors.Wrap(err, "problem loading project configuration")
+	}
+	if project.FindBuildVariant(variant) == nil {
+		return client.LocalOptions{}, "", errors.Errorf("build variant '%s' is not defined", variant)
+	}
+	bvt := project.FindTaskForVariant(taskName, variant)
+	if bvt == nil {
+		return client.LocalOptions{}, "", errors.Errorf("task '%s' does not run on build variant '%s'", taskName, variant)
+	}
+	var taskGroup string
+	if tg := project.FindTaskGroup(bvt.Name); tg != nil {
+		taskGroup = tg.Name
+	}
+
+	now := time.Now()
+	v := &version.Version{
+		Id:         localRunID,
+		CreateTime: now,
+		Identifier: localRunID,
+		Revision:   revision,
+		Config:     string(data),
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}
+	t := &task.Task{
+		Id:           util.CleanName(fmt.Sprintf("%s_%s_%s", localRunID, variant, taskName)),
+		Secret:       localRunID,
+		CreateTime:   now,
+		DisplayName:  taskName,
+		BuildVariant: variant,
+		BuildId:      util.CleanName(fmt.Sprintf("%s_%s", localRunID, variant)),
+		Project:      localRunID,
+		Version:      v.Id,
+		Revision:     revision,
+		Requester:    v.Requester,
+		TaskGroup:    taskGroup,
+	}
+
+	return client.LocalOptions{
+		Task:       t,
+		Version:    v,
+		ProjectRef: &model.ProjectRef{Identifier: localRunID},
+		Distro:     &distro.Distro{Id: localRunID},
+	}, taskGroup, nil
+}
+
+// readLocalExpansions returns the expansions in the given YAML file, if any,
+// updated with the given KEY=VALUE pairs.
+func readLocalExpansions(fileName string, pairs []string) (map[string]string, error) {
+	expansions := map[string]string{}
+	if fileName != "" {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem reading expansions file '%s'", fileName)
+		}
+		if err = yaml.Unmarshal(data, &expansions); err != nil {
+			return nil, errors.Wrapf(err, "problem parsing expansions file '%s'", fileName)
+		}
+	}
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("expansion '%s' must be given as KEY=VALUE", pair)
+		}
+		expansions[parts[0]] = parts[1]
+	}
+	return expansions, nil
+}
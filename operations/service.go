@@ -81,10 +81,14 @@ func startSystemCronJobs(ctx context.Context, env evergreen.Environment) {
 		units.PopulateHostMonitoring(env),
 		units.PopulateTaskMonitoring(),
 		units.PopulateEventAlertProcessing(1),
+		units.PopulateEventDigestJobs(1),
+		units.PopulateWebhookRetryJobs(),
 		units.PopulateBackgroundStatsJobs(env, 0),
 		units.PopulateLastContainerFinishTimeJobs(),
 		units.PopulateParentDecommissionJobs(),
 		units.PopulatePeriodicNotificationJobs(1),
+		units.PopulateCommitQueueJobs(0),
+		units.PopulatePeriodicBuildJobs(),
 		units.PopulateContainerStateJobs(env),
 		units.PopulateOldestImageRemovalJobs()))
 
@@ -100,7 +104,8 @@ func startSystemCronJobs(ctx context.Context, env evergreen.Environment) {
 
 	amboy.IntervalQueueOperation(ctx, env.RemoteQueue(), 15*time.Minute, time.Now(), opts, amboy.GroupQueueOperationFactory(
 		units.PopulateCatchupJobs(30),
-		units.PopulateHostAlertJobs(20)))
+		units.PopulateHostAlertJobs(20),
+		units.PopulateFlakyTestDetectionJobs(60)))
 
 	////////////////////////////////////////////////////////////////////////
 	//
@@ -0,0 +1,87 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func Task() cli.Command {
+	return cli.Command{
+		Name:  "task",
+		Usage: "inspect evergreen tasks",
+		Subcommands: []cli.Command{
+			taskLogs(),
+		},
+	}
+}
+
+func taskLogs() cli.Command {
+	const (
+		taskFlagName      = "task"
+		followFlagName    = "follow"
+		typeFlagName      = "type"
+		executionFlagName = "execution"
+	)
+
+	return cli.Command{
+		Name:  "logs",
+		Usage: "print the logs of a task, optionally following them while it runs",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(taskFlagName, "t"),
+				Usage: "specify the ID of a task",
+			},
+			cli.BoolFlag{
+				Name:  joinFlagNames(followFlagName, "f"),
+				Usage: "keep printing new log messages until the task finishes",
+			},
+			cli.StringSliceFlag{
+				Name:  typeFlagName,
+				Usage: "only print logs of this type (task, agent or system); may specify more than once",
+			},
+			cli.IntFlag{
+				Name:  executionFlagName,
+				Usage: "specify the execution of the task, defaulting to the latest",
+				Value: -1,
+			},
+		},
+		Before: requireStringFlag(taskFlagName),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			comm := conf.GetRestCommunicator(ctx)
+			defer comm.Close()
+
+			opts := client.TaskLogStreamOptions{
+				TaskID: c.String(taskFlagName),
+				Types:  c.StringSlice(typeFlagName),
+				Follow: c.Bool(followFlagName),
+			}
+			if execution := c.Int(executionFlagName); execution >= 0 {
+				opts.Execution = &execution
+			}
+
+			err = comm.StreamTaskLogs(ctx, opts, func(msg model.APILogMessage) error {
+				fmt.Printf("[%s] [%s] %s\n",
+					time.Time(msg.Timestamp).Format(time.RFC3339),
+					model.FromAPIString(msg.Severity),
+					model.FromAPIString(msg.Message))
+				return nil
+			})
+			return errors.Wrapf(err, "problem reading logs for task '%s'", opts.TaskID)
+		},
+	}
+}
@@ -1,23 +1,34 @@
 package operations
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
+	restmodel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/service"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
 
 const (
 	prettyStringFormat = "%-25s %-15s %-40s%-40s %-40s %-40s \n"
-	timeFormat         = "2006-01-02T15:04:05"
-	csvFormat          = "csv"
-	prettyFormat       = "pretty"
-	jsonFormat         = "json"
+	// score, runs, failures, variant, task, test file
+	flakyPrettyStringFormat = "%-7s %-6s %-9s %-40s %-40s %s\n"
+	timeFormat              = "2006-01-02T15:04:05"
+	csvFormat               = "csv"
+	prettyFormat            = "pretty"
+	jsonFormat              = "json"
 )
 
 func TestHistory() cli.Command {
@@ -33,12 +44,14 @@ func TestHistory() cli.Command {
 		formatFlagName     = "format"
 		limitFlagName      = "limit"
 		requestFlagName    = "request-source"
+		flakyFlagName      = "flaky"
+		minScoreFlagName   = "min-score"
 	)
 
 	return cli.Command{
 		Name:  "test-history",
 		Usage: "view the execution history of specific task",
-		Flags: mergeFlagSlices(addProjectFlag(), addTasksFlag(), addOutputPath(
+		Flags: mergeFlagSlices(addProjectFlag(), addTasksFlag(), addVariantsFlag(), addOutputPath(
 			cli.StringSliceFlag{
 				Name:  taskStatusFlagName,
 				Usage: "task status, either fail, pass, sysfail, or timeout ",
@@ -79,6 +92,14 @@ func TestHistory() cli.Command {
 			cli.StringFlag{
 				Name:  joinFlagNames(requestFlagName, "r"),
 				Usage: "include 'patch', 'commit' or 'all' builds. Only shows commit builds if not specified",
+			},
+			cli.BoolFlag{
+				Name:  flakyFlagName,
+				Usage: "list the project's flaky tests, from the flakiest down, instead of test history",
+			},
+			cli.Float64Flag{
+				Name:  minScoreFlagName,
+				Usage: "with --flaky, only list tests with at least this flakiness score, between 0 and 1",
 			})),
 		Before: mergeBeforeFuncs(
 			requireStringLengthIfSpecified(beforeRevFlagName, 40),
@@ -88,12 +109,21 @@ func TestHistory() cli.Command {
 			requireStringSliceValueChoices(taskStatusFlagName, []string{"pass", "fail", "silentfail", "skip", "timeout"}),
 			requireStringSliceValueChoices(testStatusFlagName, []string{"pass", "fail", "sysfail", "timeout"}),
 			func(c *cli.Context) error {
-				if c.String(formatFlagName) != prettyFormat && c.String(pathFlagName) != "" {
+				if c.String(formatFlagName) != prettyFormat && c.String(pathFlagName) == "" {
 					return errors.New("must specify a filepath for csv and json output")
 				}
 				return nil
 			},
 			func(c *cli.Context) error {
+				if c.Bool(flakyFlagName) {
+					if c.String(projectFlagName) == "" {
+						return errors.New("must specify a project")
+					}
+					if score := c.Float64(minScoreFlagName); score < 0 || score > 1 {
+						return errors.New("min score must be between 0 and 1")
+					}
+					return nil
+				}
 				if c.Int(limitFlagName) == 0 {
 					if c.String(beforeRevFlagName) == "" || c.String(afterRevFlagName) != "" {
 						return errors.New("must specify either a limit or before/after revision")
@@ -109,6 +139,11 @@ func TestHistory() cli.Command {
 			format := c.String(formatFlagName)
 			outputPath := c.String(pathFlagName)
 
+			if c.Bool(flakyFlagName) {
+				return printFlakyTests(c.String(projectFlagName), c.StringSlice(variantsFlagName), c.StringSlice(tasksFlagName),
+					c.Float64(minScoreFlagName), c.Int(limitFlagName), format, outputPath, confPath)
+			}
+
 			var err error
 			// parse dates into time.Time values
 			beforeDate := time.Time{}
@@ -200,6 +235,84 @@ func TestHistory() cli.Command {
 	}
 }
 
+// printFlakyTests fetches the flaky tests of a project and prints them, or
+// writes them to the output path as JSON or CSV.
+func printFlakyTests(project string, variants, tasks []string, minScore float64, limit int, format, outputPath, confPath string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf, err := NewClientSettings(confPath)
+	if err != nil {
+		return errors.Wrap(err, "problem loading configuration")
+	}
+	client := conf.GetRestCommunicator(ctx)
+	defer client.Close()
+
+	if len(variants) == 0 {
+		variants = []string{""}
+	}
+	tests := []restmodel.APIFlakyTest{}
+	for _, variant := range variants {
+		variantTests, err := client.GetFlakyTests(ctx, project, variant, minScore, limit)
+		if err != nil {
+			return errors.Wrapf(err, "problem fetching flaky tests for project '%s'", project)
+		}
+		for _, ft := range variantTests {
+			if len(tasks) == 0 || util.StringSliceContains(tasks, restmodel.FromAPIString(ft.TaskName)) {
+				tests = append(tests, ft)
+			}
+		}
+	}
+	sort.SliceStable(tests, func(i, j int) bool { return tests[i].Score > tests[j].Score })
+	if limit > 0 && len(tests) > limit {
+		tests = tests[:limit]
+	}
+
+	switch format {
+	case jsonFormat:
+		out, err := json.MarshalIndent(tests, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "problem marshalling flaky tests")
+		}
+		return errors.WithStack(ioutil.WriteFile(outputPath, out, 0644))
+	case csvFormat:
+		buf := &bytes.Buffer{}
+		w := csv.NewWriter(buf)
+		catcher := grip.NewBasicCatcher()
+		catcher.Add(w.Write([]string{"score", "variant", "task", "test_file", "runs", "failures", "restarts", "flipped_revisions", "last_failing_task"}))
+		for _, ft := range tests {
+			catcher.Add(w.Write([]string{
+				strconv.FormatFloat(ft.Score, 'f', 3, 64),
+				restmodel.FromAPIString(ft.BuildVariant),
+				restmodel.FromAPIString(ft.TaskName),
+				restmodel.FromAPIString(ft.TestFile),
+				strconv.Itoa(ft.Runs),
+				strconv.Itoa(ft.Failures),
+				strconv.Itoa(ft.Restarts),
+				strconv.Itoa(ft.FlippedRevisions),
+				restmodel.FromAPIString(ft.LastFailingTask),
+			}))
+		}
+		w.Flush()
+		catcher.Add(w.Error())
+		if catcher.HasErrors() {
+			return errors.Wrap(catcher.Resolve(), "problem writing flaky tests")
+		}
+		return errors.WithStack(ioutil.WriteFile(outputPath, buf.Bytes(), 0644))
+	}
+
+	if len(tests) == 0 {
+		fmt.Printf("no flaky tests found for project '%s'\n", project)
+		return nil
+	}
+	fmt.Printf(flakyPrettyStringFormat, "Score", "Runs", "Failures", "Variant", "Task Name", "Test File")
+	for _, ft := range tests {
+		fmt.Printf(flakyPrettyStringFormat, strconv.FormatFloat(ft.Score, 'f', 3, 64), strconv.Itoa(ft.Runs), strconv.Itoa(ft.Failures),
+			restmodel.FromAPIString(ft.BuildVariant), restmodel.FromAPIString(ft.TaskName), restmodel.FromAPIString(ft.TestFile))
+	}
+	return nil
+}
+
 func testHistoryGetTaskStatuses(stats []string) []string {
 	taskStatuses := []string{}
 	for _, s := range stats {
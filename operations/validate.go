@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strings"
 
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/validator"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
@@ -14,7 +16,7 @@ func Validate() cli.Command {
 	return cli.Command{
 		Name:   "validate",
 		Usage:  "verify that an evergreen project config is valid",
-		Flags:  addPathFlag(),
+		Flags:  addPathFlag(addIncludeFlags()...),
 		Before: requirePathFlag,
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
@@ -39,6 +41,10 @@ func Validate() cli.Command {
 			if err != nil {
 				return err
 			}
+			confFile, err = resolveLocalIncludes(ctx, c, confFile)
+			if err != nil {
+				return errors.Wrap(err, "problem resolving project includes")
+			}
 
 			projErrors, err := ac.ValidateLocalConfig(confFile)
 			if err != nil {
@@ -68,3 +74,18 @@ func Validate() cli.Command {
 		},
 	}
 }
+
+// resolveLocalIncludes merges the files included by a project configuration
+// into it, reading them from the directories given on the command line.
+func resolveLocalIncludes(ctx context.Context, c *cli.Context, data []byte) ([]byte, error) {
+	modulePaths := map[string]string{}
+	for _, module := range c.StringSlice(includeModuleFlagName) {
+		parts := strings.SplitN(module, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("module '%s' must be given as NAME=PATH", module)
+		}
+		modulePaths[parts[0]] = parts[1]
+	}
+
+	return model.ResolveIncludes(ctx, data, model.NewLocalIncludeFetcher(c.String(includeRootFlagName), modulePaths))
+}
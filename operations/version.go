@@ -1,9 +1,16 @@
 package operations
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
 
@@ -12,9 +19,101 @@ func Version() cli.Command {
 		Name:    "version",
 		Aliases: []string{"v"},
 		Usage:   "prints the revision of the current binary",
+		Subcommands: []cli.Command{
+			versionGraph(),
+		},
 		Action: func(c *cli.Context) error {
 			fmt.Println(evergreen.ClientVersion)
 			return nil
 		},
 	}
 }
+
+func versionGraph() cli.Command {
+	const (
+		versionIDFlagName = "id"
+		dotFlagName       = "dot"
+	)
+
+	return cli.Command{
+		Name:  "graph",
+		Usage: "show the task dependency graph and critical path of a version",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(versionIDFlagName, "i"),
+				Usage: "specify the ID of the version",
+			},
+			cli.BoolFlag{
+				Name:  dotFlagName,
+				Usage: "print the graph in the Graphviz DOT language",
+			},
+		},
+		Before: requireStringFlag(versionIDFlagName),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			confPath := c.Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			versionID := c.String(versionIDFlagName)
+			if c.Bool(dotFlagName) {
+				dot, err := client.GetVersionGraphDOT(ctx, versionID)
+				if err != nil {
+					return errors.Wrap(err, "error fetching version graph")
+				}
+				fmt.Print(dot)
+				return nil
+			}
+
+			graph, err := client.GetVersionGraph(ctx, versionID)
+			if err != nil {
+				return errors.Wrap(err, "error fetching version graph")
+			}
+			printVersionGraph(graph)
+			return nil
+		},
+	}
+}
+
+// printVersionGraph prints the critical path of a version followed by its
+// tasks, from the least slack to the most.
+func printVersionGraph(graph *model.APIVersionGraph) {
+	path := make([]string, 0, len(graph.CriticalPath))
+	for _, id := range graph.CriticalPath {
+		path = append(path, model.FromAPIString(id))
+	}
+	fmt.Printf("Critical path (%s): %s\n\n", graph.Length.ToDuration(), strings.Join(path, " -> "))
+
+	tasks := make([]model.APIVersionGraphTask, len(graph.Tasks))
+	copy(tasks, graph.Tasks)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Slack != tasks[j].Slack {
+			return tasks[i].Slack < tasks[j].Slack
+		}
+		return tasks[i].EarliestStart < tasks[j].EarliestStart
+	})
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "Task\tVariant\tStatus\tDuration\tStart\tSlack\t")
+	for _, t := range tasks {
+		duration := t.Duration.ToDuration().String()
+		if t.ExpectedDuration {
+			duration = "~" + duration
+		}
+		name := model.FromAPIString(t.DisplayName)
+		if t.Critical {
+			name = "* " + name
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t\n", name, model.FromAPIString(t.BuildVariant),
+			model.FromAPIString(t.Status), duration, t.EarliestStart.ToDuration(), t.Slack.ToDuration())
+	}
+	w.Flush()
+}
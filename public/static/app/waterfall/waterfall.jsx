@@ -35,12 +35,14 @@ function getParameterByName(name, url) {
   return decodeURIComponent(results[2].replace(/\+/g, " "));
 }
 
-function updateURLParams(bvFilter, taskFilter, skip, baseURL) {
+function updateURLParams(bvFilter, taskFilter, requester, skip, baseURL) {
   var params = {};
   if (bvFilter && bvFilter != '')
     params["bv_filter"]= bvFilter;
   if (taskFilter && taskFilter != '')
     params["task_filter"]= taskFilter; 
+  if (requester && requester != '')
+    params["requester"]= requester;
   params["skip"] = skip
 
   var paramString = generateURLParameters(params);
@@ -96,6 +98,7 @@ class Root extends React.Component{
     const href = window.location.href
     var buildVariantFilter = getParameterByName('bv_filter', href) || ''
     var taskFilter = getParameterByName('task_filter', href) || ''
+    var requester = getParameterByName('requester', href) || ''
 
     var collapsed = localStorage.getItem("collapsed") == "true";
 
@@ -104,6 +107,7 @@ class Root extends React.Component{
       shortenCommitMessage: true,
       buildVariantFilter: buildVariantFilter,
       taskFilter: taskFilter,
+      requester: requester,
       data: this.props.data,
     }
 
@@ -112,6 +116,7 @@ class Root extends React.Component{
     this.handleHeaderLinkClick = this.handleHeaderLinkClick.bind(this);
     this.handleBuildVariantFilter = this.handleBuildVariantFilter.bind(this);
     this.handleTaskFilter = this.handleTaskFilter.bind(this);
+    this.handleRequesterFilter = this.handleRequesterFilter.bind(this);
     this.loadDataPortion = _.debounce(this.loadDataPortion, 100)
   }
 
@@ -135,13 +140,15 @@ class Root extends React.Component{
     }
   }
 
-  loadDataPortion(filter) {
-    var params = filter ? {bv_filter: filter} : {}
+  loadDataPortion(filter, requester) {
+    var params = {}
+    if (filter) params.bv_filter = filter
+    if (requester) params.requester = requester
     http.get(`/rest/v1/waterfall/${this.props.project}`, {params})
       .then(({data}) => {
         this.updatePaginationContext(data)
         this.setState({data})
-        updateURLParams(filter, this.state.taskFilter, this.currentSkip, this.baseURL);
+        updateURLParams(filter, this.state.taskFilter, requester, this.currentSkip, this.baseURL);
       })
   }
 
@@ -151,16 +158,22 @@ class Root extends React.Component{
   }
 
   handleBuildVariantFilter(filter) {
-    this.loadDataPortion(filter)
-    updateURLParams(filter, this.state.taskFilter, this.currentSkip, this.baseURL);
+    this.loadDataPortion(filter, this.state.requester)
+    updateURLParams(filter, this.state.taskFilter, this.state.requester, this.currentSkip, this.baseURL);
     this.setState({buildVariantFilter: filter});
   }
 
   handleTaskFilter(filter) {
-    updateURLParams(this.state.buildVariantFilter, filter, this.currentSkip, this.baseURL);
+    updateURLParams(this.state.buildVariantFilter, filter, this.state.requester, this.currentSkip, this.baseURL);
     this.setState({taskFilter: filter});
   }
 
+  handleRequesterFilter(requester) {
+    this.loadDataPortion(this.state.buildVariantFilter, requester)
+    updateURLParams(this.state.buildVariantFilter, this.state.taskFilter, requester, this.currentSkip, this.baseURL);
+    this.setState({requester: requester});
+  }
+
   handleHeaderLinkClick(shortenMessage) {
     this.setState({shortenCommitMessage: !shortenMessage});
   }
@@ -187,8 +200,10 @@ class Root extends React.Component{
           prevSkip={this.prevSkip} 
           buildVariantFilter={this.state.buildVariantFilter}
           taskFilter={this.state.taskFilter}
+          requester={this.state.requester}
           buildVariantFilterFunc={this.handleBuildVariantFilter}
           taskFilterFunc={this.handleTaskFilter}
+          requesterFilterFunc={this.handleRequesterFilter}
         /> 
         <Headers 
           shortenCommitMessage={this.state.shortenCommitMessage} 
@@ -218,8 +233,10 @@ function Toolbar ({collapsed,
   prevSkip, 
   buildVariantFilter, 
   taskFilter,
+  requester,
   buildVariantFilterFunc, 
-  taskFilterFunc}) {
+  taskFilterFunc,
+  requesterFilterFunc}) {
 
   var Form = ReactBootstrap.Form;
   return (
@@ -239,12 +256,17 @@ function Toolbar ({collapsed,
             currentFilter={taskFilter} 
             disabled={collapsed}
           />
+          <RequesterFilter 
+            filterFunction={requesterFilterFunc} 
+            requester={requester} 
+          />
           <PageButtons 
             nextSkip={nextSkip} 
             prevSkip={prevSkip} 
             baseURL={baseURL}
             buildVariantFilter={buildVariantFilter} 
             taskFilter={taskFilter} 
+            requester={requester} 
           />
         </Form>
       </div>
@@ -252,7 +274,7 @@ function Toolbar ({collapsed,
   )
 };
 
-function PageButtons ({prevSkip, nextSkip, baseURL, buildVariantFilter, taskFilter}) {
+function PageButtons ({prevSkip, nextSkip, baseURL, buildVariantFilter, taskFilter, requester}) {
   var ButtonGroup = ReactBootstrap.ButtonGroup;
 
   var nextURL= "";
@@ -271,6 +293,10 @@ function PageButtons ({prevSkip, nextSkip, baseURL, buildVariantFilter, taskFilt
     nextURLParams["task_filter"] = taskFilter;
     prevURLParams["task_filter"] = taskFilter;
   }
+  if (requester && requester != '') {
+    nextURLParams["requester"] = requester;
+    prevURLParams["requester"] = requester;
+  }
   nextURL = "?" + generateURLParameters(nextURLParams);
   prevURL = "?" + generateURLParameters(prevURLParams);
   return (
@@ -308,6 +334,20 @@ class FilterBox extends React.Component {
   }
 }
 
+// RequesterFilter limits the waterfall to the versions created by commits or
+// by periodic builds
+function RequesterFilter ({requester, filterFunction}) {
+  return (
+    <select className="form-control waterfall-form-item" value={requester}
+            onChange={(event) => filterFunction(event.target.value)}>
+      <option value="">All versions</option>
+      <option value="gitter_request">Commits</option>
+      <option value="periodic_build_request">Periodic builds</option>
+      <option value="trigger_request">Triggered builds</option>
+    </select>
+  )
+}
+
 class CollapseButton extends React.Component{
   constructor(props){
     super(props);
@@ -45,10 +45,11 @@ function getParameterByName(name, url) {
   return decodeURIComponent(results[2].replace(/\+/g, " "));
 }
 
-function updateURLParams(bvFilter, taskFilter, skip, baseURL) {
+function updateURLParams(bvFilter, taskFilter, requester, skip, baseURL) {
   var params = {};
   if (bvFilter && bvFilter != '') params["bv_filter"] = bvFilter;
   if (taskFilter && taskFilter != '') params["task_filter"] = taskFilter;
+  if (requester && requester != '') params["requester"] = requester;
   params["skip"] = skip;
 
   var paramString = generateURLParameters(params);
@@ -120,6 +121,7 @@ var Root = function (_React$Component2) {
     var href = window.location.href;
     var buildVariantFilter = getParameterByName('bv_filter', href) || '';
     var taskFilter = getParameterByName('task_filter', href) || '';
+    var requester = getParameterByName('requester', href) || '';
 
     var collapsed = localStorage.getItem("collapsed") == "true";
 
@@ -128,6 +130,7 @@ var Root = function (_React$Component2) {
       shortenCommitMessage: true,
       buildVariantFilter: buildVariantFilter,
       taskFilter: taskFilter,
+      requester: requester,
       data: _this2.props.data
 
       // Handle state for a collapsed view, as well as shortened header commit messages
@@ -135,6 +138,7 @@ var Root = function (_React$Component2) {
     _this2.handleHeaderLinkClick = _this2.handleHeaderLinkClick.bind(_this2);
     _this2.handleBuildVariantFilter = _this2.handleBuildVariantFilter.bind(_this2);
     _this2.handleTaskFilter = _this2.handleTaskFilter.bind(_this2);
+    _this2.handleRequesterFilter = _this2.handleRequesterFilter.bind(_this2);
     _this2.loadDataPortion = _.debounce(_this2.loadDataPortion, 100);
     return _this2;
   }
@@ -162,16 +166,18 @@ var Root = function (_React$Component2) {
     }
   }, {
     key: "loadDataPortion",
-    value: function loadDataPortion(filter) {
+    value: function loadDataPortion(filter, requester) {
       var _this3 = this;
 
-      var params = filter ? { bv_filter: filter } : {};
+      var params = {};
+      if (filter) params.bv_filter = filter;
+      if (requester) params.requester = requester;
       http.get("/rest/v1/waterfall/" + this.props.project, { params: params }).then(function (_ref) {
         var data = _ref.data;
 
         _this3.updatePaginationContext(data);
         _this3.setState({ data: data });
-        updateURLParams(filter, _this3.state.taskFilter, _this3.currentSkip, _this3.baseURL);
+        updateURLParams(filter, _this3.state.taskFilter, requester, _this3.currentSkip, _this3.baseURL);
       });
     }
   }, {
@@ -183,16 +189,23 @@ var Root = function (_React$Component2) {
   }, {
     key: "handleBuildVariantFilter",
     value: function handleBuildVariantFilter(filter) {
-      this.loadDataPortion(filter);
-      updateURLParams(filter, this.state.taskFilter, this.currentSkip, this.baseURL);
+      this.loadDataPortion(filter, this.state.requester);
+      updateURLParams(filter, this.state.taskFilter, this.state.requester, this.currentSkip, this.baseURL);
       this.setState({ buildVariantFilter: filter });
     }
   }, {
     key: "handleTaskFilter",
     value: function handleTaskFilter(filter) {
-      updateURLParams(this.state.buildVariantFilter, filter, this.currentSkip, this.baseURL);
+      updateURLParams(this.state.buildVariantFilter, filter, this.state.requester, this.currentSkip, this.baseURL);
       this.setState({ taskFilter: filter });
     }
+  }, {
+    key: "handleRequesterFilter",
+    value: function handleRequesterFilter(requester) {
+      this.loadDataPortion(this.state.buildVariantFilter, requester);
+      updateURLParams(this.state.buildVariantFilter, this.state.taskFilter, requester, this.currentSkip, this.baseURL);
+      this.setState({ requester: requester });
+    }
   }, {
     key: "handleHeaderLinkClick",
     value: function handleHeaderLinkClick(shortenMessage) {
@@ -223,8 +236,10 @@ var Root = function (_React$Component2) {
           prevSkip: this.prevSkip,
           buildVariantFilter: this.state.buildVariantFilter,
           taskFilter: this.state.taskFilter,
+          requester: this.state.requester,
           buildVariantFilterFunc: this.handleBuildVariantFilter,
-          taskFilterFunc: this.handleTaskFilter
+          taskFilterFunc: this.handleTaskFilter,
+          requesterFilterFunc: this.handleRequesterFilter
         }),
         React.createElement(Headers, {
           shortenCommitMessage: this.state.shortenCommitMessage,
@@ -258,8 +273,10 @@ function Toolbar(_ref2) {
       prevSkip = _ref2.prevSkip,
       buildVariantFilter = _ref2.buildVariantFilter,
       taskFilter = _ref2.taskFilter,
+      requester = _ref2.requester,
       buildVariantFilterFunc = _ref2.buildVariantFilterFunc,
-      taskFilterFunc = _ref2.taskFilterFunc;
+      taskFilterFunc = _ref2.taskFilterFunc,
+      requesterFilterFunc = _ref2.requesterFilterFunc;
 
 
   var Form = ReactBootstrap.Form;
@@ -285,12 +302,17 @@ function Toolbar(_ref2) {
           currentFilter: taskFilter,
           disabled: collapsed
         }),
+        React.createElement(RequesterFilter, {
+          filterFunction: requesterFilterFunc,
+          requester: requester
+        }),
         React.createElement(PageButtons, {
           nextSkip: nextSkip,
           prevSkip: prevSkip,
           baseURL: baseURL,
           buildVariantFilter: buildVariantFilter,
-          taskFilter: taskFilter
+          taskFilter: taskFilter,
+          requester: requester
         })
       )
     )
@@ -302,7 +324,8 @@ function PageButtons(_ref3) {
       nextSkip = _ref3.nextSkip,
       baseURL = _ref3.baseURL,
       buildVariantFilter = _ref3.buildVariantFilter,
-      taskFilter = _ref3.taskFilter;
+      taskFilter = _ref3.taskFilter,
+      requester = _ref3.requester;
 
   var ButtonGroup = ReactBootstrap.ButtonGroup;
 
@@ -322,6 +345,10 @@ function PageButtons(_ref3) {
     nextURLParams["task_filter"] = taskFilter;
     prevURLParams["task_filter"] = taskFilter;
   }
+  if (requester && requester != '') {
+    nextURLParams["requester"] = requester;
+    prevURLParams["requester"] = requester;
+  }
   nextURL = "?" + generateURLParameters(nextURLParams);
   prevURL = "?" + generateURLParameters(prevURLParams);
   return React.createElement(
@@ -381,6 +408,41 @@ var FilterBox = function (_React$Component3) {
   return FilterBox;
 }(React.Component);
 
+// RequesterFilter limits the waterfall to the versions created by commits or
+// by periodic builds
+function RequesterFilter(_ref5) {
+  var requester = _ref5.requester,
+      filterFunction = _ref5.filterFunction;
+
+  return React.createElement(
+    "select",
+    { className: "form-control waterfall-form-item", value: requester,
+      onChange: function onChange(event) {
+        return filterFunction(event.target.value);
+      } },
+    React.createElement(
+      "option",
+      { value: "" },
+      "All versions"
+    ),
+    React.createElement(
+      "option",
+      { value: "gitter_request" },
+      "Commits"
+    ),
+    React.createElement(
+      "option",
+      { value: "periodic_build_request" },
+      "Periodic builds"
+    ),
+    React.createElement(
+      "option",
+      { value: "trigger_request" },
+      "Triggered builds"
+    )
+  );
+}
+
 var CollapseButton = function (_React$Component4) {
   _inherits(CollapseButton, _React$Component4);
 
@@ -149,9 +149,13 @@ mciModule.controller('AdminSettingsController', ['$scope', '$window', 'mciAdminR
     cli_updates_disabled: "cli_updates",
     background_stats_disabled: "background stats",
     "task_logging_disabled": "task logging",
+    flaky_test_detection_disabled: "flaky test detection",
+    commit_queue_disabled: "commit queue",
     event_processing_disabled: "event_processing",
     jira_notifications_disabled: "jira_notifications",
     slack_notifications_disabled: "slack_notifications",
+    teams_notifications_disabled: "teams_notifications",
+    mattermost_notifications_disabled: "mattermost_notifications",
     email_notifications_disabled: "email_notifications",
     webhook_notifications_disabled: "webhook_notifications",
     github_status_api_disabled: "github_status_api"
@@ -247,6 +251,15 @@ mciModule.controller('AdminSettingsController', ['$scope', '$window', 'mciAdminR
     return user;
   }
 
+  $scope.transformLDAPGroupRole = function(chip) {
+    var pieces = chip.split(":");
+    if (pieces.length !== 2 || pieces[0] === "" || pieces[1] === "") {
+      alert("Input must be in the format of group:role");
+      return null;
+    }
+    return {group: pieces[0], role: pieces[1]};
+  }
+
   $scope.addKVpair = function(chip, property) {
     var obj = {};
     pieces = chip.split(":");
@@ -22,6 +22,12 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
   $scope.newProjectMessage="";
 
   $scope.isDirty = false;
+  // the kinds of versions a project subscription can be for
+  $scope.subscriptionRequesters = [
+    {value: "gitter_request", label: "commits"},
+    {value: "periodic_build_request", label: "periodic builds"},
+    {value: "trigger_request", label: "project triggers"},
+  ];
   $scope.triggers = [
     {
       trigger: "outcome",
@@ -186,6 +192,16 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
     }
   };
 
+  // defaultRoles are the roles that a project may give to logged in users
+  // with no other role on it. Leaving it unset keeps the scheduler role.
+  $scope.defaultRoles = [
+    {value: "", label: "scheduler (default)"},
+    {value: "viewer", label: "viewer"},
+    {value: "patcher", label: "patcher"},
+    {value: "scheduler", label: "scheduler"},
+    {value: "admin", label: "admin"},
+  ];
+
   // addAdmin adds an admin name to the settingsFormData's list of admins
   $scope.addAdmin = function(){
     $scope.settingsFormData.admins.push($scope.admin_name);
@@ -265,6 +281,7 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
         }
         $scope.projectVars = data.ProjectVars.vars || {};
         $scope.privateVars = data.ProjectVars.private_vars || {};
+        $scope.secretRefs = data.ProjectVars.secret_refs || {};
         $scope.githubHookID = data.github_hook.hook_id || 0;
         $scope.prTestingConflicts = data.pr_testing_conflicting_refs || [];
         $scope.prTestingEnabled = data.ProjectRef.pr_testing_enabled || false;
@@ -291,6 +308,7 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
           identifier : $scope.projectRef.identifier,
           project_vars: $scope.projectVars,
           private_vars: $scope.privateVars,
+          secret_refs: $scope.secretRefs,
           display_name : $scope.projectRef.display_name,
           remote_path:$scope.projectRef.remote_path,
           batch_time: parseInt($scope.projectRef.batch_time),
@@ -305,9 +323,13 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
           alert_config: $scope.projectRef.alert_config || {},
           repotracker_error: $scope.projectRef.repotracker_error || {},
           admins : $scope.projectRef.admins || [],
+          default_role: $scope.projectRef.default_role || "",
           setup_github_hook: $scope.githubHookID != 0,
           tracks_push_events: data.ProjectRef.tracks_push_events || false,
           pr_testing_enabled: data.ProjectRef.pr_testing_enabled || false,
+          commit_queue: data.ProjectRef.commit_queue || {enabled: false, merge_method: "squash"},
+          periodic_builds: data.ProjectRef.periodic_builds || [],
+          triggers: data.ProjectRef.triggers || [],
           notify_on_failure: $scope.projectRef.notify_on_failure,
           force_repotracker_run: false,
           delete_aliases: [],
@@ -315,6 +337,8 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
         };
 
         $scope.subscriptions = _.map(data.subscriptions || [], function(v) {
+          var requester = _.findWhere(v.selectors || [], {type: "requester"});
+          v.requester = requester ? requester.data : "gitter_request";
           t = lookupTrigger($scope.triggers, v.trigger, v.resource_type);
           if (!t) {
             return v;
@@ -380,6 +404,12 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
     if ($scope.patch_alias) {
       $scope.addPatchAlias();
     }
+    if ($scope.periodic_build) {
+      $scope.addPeriodicBuild();
+    }
+    if ($scope.project_trigger) {
+      $scope.addProjectTrigger();
+    }
     for (var i = 0; i < $scope.patch_aliases.length; i++) {
       var alias = $scope.patch_aliases[i];
       if (alias.tags_temp) {
@@ -390,6 +420,10 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
     $scope.settingsFormData.subscriptions = _.filter($scope.subscriptions, function(d) {
         return d.changed;
     });
+    _.each($scope.settingsFormData.subscriptions, function(d) {
+        d.selectors = _.reject(d.selectors || [], function(s) { return s.type === "requester"; });
+        d.selectors.push({type: "requester", data: d.requester || "gitter_request"});
+    });
 
     $scope.settingsFormData.project_aliases = $scope.github_aliases.concat($scope.patch_aliases);
     if ($scope.admin_name) {
@@ -424,6 +458,20 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
     }
   };
 
+  $scope.addSecretRef = function() {
+    if ($scope.secret_ref.name && $scope.secret_ref.ref) {
+      $scope.settingsFormData.secret_refs[$scope.secret_ref.name] = $scope.secret_ref.ref;
+      $scope.secret_ref.name = "";
+      $scope.secret_ref.ref = "";
+      $scope.isDirty = true;
+    }
+  };
+
+  $scope.removeSecretRef = function(name) {
+    delete $scope.settingsFormData.secret_refs[name];
+    $scope.isDirty = true;
+  };
+
   $scope.addGithubAlias = function() {
     if ($scope.github_alias.variant && $scope.github_alias.task) {
       item = Object.assign({}, $scope.github_alias)
@@ -445,6 +493,47 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
     }
   };
 
+  $scope.validPeriodicBuild = function(build) {
+    if (!build || !build.id || !build.cron) {
+      return false;
+    }
+    return !!build.alias !== !!(build.variant && build.task);
+  };
+
+  $scope.addPeriodicBuild = function() {
+    if ($scope.validPeriodicBuild($scope.periodic_build)) {
+      item = Object.assign({}, $scope.periodic_build)
+      $scope.settingsFormData.periodic_builds = $scope.settingsFormData.periodic_builds.concat([item]);
+      delete $scope.periodic_build
+    }
+  };
+
+  $scope.removePeriodicBuild = function(i) {
+    $scope.settingsFormData.periodic_builds.splice(i, 1);
+    $scope.isDirty = true;
+  };
+
+  $scope.validProjectTrigger = function(trigger) {
+    return !!(trigger && trigger.id && trigger.project && trigger.level && trigger.alias);
+  };
+
+  $scope.addProjectTrigger = function() {
+    if ($scope.validProjectTrigger($scope.project_trigger)) {
+      item = Object.assign({status: ""}, $scope.project_trigger)
+      if (item.level !== "build") {
+        delete item.variant;
+        delete item.task;
+      }
+      $scope.settingsFormData.triggers = $scope.settingsFormData.triggers.concat([item]);
+      delete $scope.project_trigger
+    }
+  };
+
+  $scope.removeProjectTrigger = function(i) {
+    $scope.settingsFormData.triggers.splice(i, 1);
+    $scope.isDirty = true;
+  };
+
   $scope.removeProjectVar = function(name) {
     delete $scope.settingsFormData.project_vars[name];
     delete $scope.settingsFormData.private_vars[name];
@@ -552,6 +641,7 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
 
       $mdDialog.show(promise).then(function(data){
           data.changed = true;
+          data.requester = "gitter_request";
           $scope.isDirty = true;
           $scope.subscriptions.push(data);
       });
@@ -567,6 +657,11 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
       });
   };
 
+  $scope.changeSubscriptionRequester = function(subscription) {
+      subscription.changed = true;
+      $scope.isDirty = true;
+  };
+
   $scope.removeSubscription = function(index) {
       if ($scope.subscriptions[index] && $scope.subscriptions[index].id) {
           $scope.settingsFormData.delete_subscriptions.push($scope.subscriptions[index].id);
@@ -1,6 +1,8 @@
 const SUBSCRIPTION_JIRA_COMMENT = 'jira-comment';
 const SUBSCRIPTION_JIRA_ISSUE = 'jira-issue';
 const SUBSCRIPTION_SLACK = 'slack';
+const SUBSCRIPTION_TEAMS = 'teams';
+const SUBSCRIPTION_MATTERMOST = 'mattermost';
 const SUBSCRIPTION_EMAIL = 'email';
 const SUBSCRIPTION_EVERGREEN_WEBHOOK = 'evergreen-webhook';
 const DEFAULT_SUBSCRIPTION_METHODS = [
@@ -12,6 +14,14 @@ const DEFAULT_SUBSCRIPTION_METHODS = [
         value: SUBSCRIPTION_SLACK,
         label: "sending a slack message",
     },
+    {
+        value: SUBSCRIPTION_TEAMS,
+        label: "posting to a Microsoft Teams channel",
+    },
+    {
+        value: SUBSCRIPTION_MATTERMOST,
+        label: "posting to a Mattermost channel",
+    },
     {
         value: SUBSCRIPTION_JIRA_COMMENT,
         label: "making a comment on a JIRA issue",
@@ -39,6 +49,12 @@ function subscriberLabel(subscriber) {
     }else if (subscriber.type === SUBSCRIPTION_SLACK) {
         return "Send a slack message to " + subscriber.target;
 
+    }else if (subscriber.type === SUBSCRIPTION_TEAMS) {
+        return "Post to Microsoft Teams webhook " + subscriber.target;
+
+    }else if (subscriber.type === SUBSCRIPTION_MATTERMOST) {
+        return "Post to Mattermost webhook " + subscriber.target;
+
     }else if (subscriber.type === SUBSCRIPTION_EMAIL) {
         return "Send an email to " + subscriber.target;
 
@@ -171,6 +187,10 @@ function subCtrl($scope, $mdDialog, mciUserSettingsService) {
         }else if ($scope.method.value === SUBSCRIPTION_SLACK) {
             return $scope.targets[SUBSCRIPTION_SLACK].match("(#|@).+") !== null
 
+        }else if ($scope.method.value === SUBSCRIPTION_TEAMS ||
+                  $scope.method.value === SUBSCRIPTION_MATTERMOST) {
+            return $scope.targets[$scope.method.value].match("https?://.+") !== null
+
         }else if ($scope.method.value === SUBSCRIPTION_EMAIL) {
             return $scope.targets[SUBSCRIPTION_EMAIL].match(".+@.+") !== null
 
@@ -222,6 +242,12 @@ function subCtrl($scope, $mdDialog, mciUserSettingsService) {
             if (!$scope.targets[SUBSCRIPTION_SLACK]) {
                 $scope.targets[SUBSCRIPTION_SLACK] = "@" + resp.data.slack_username || "";
             }
+            if (!$scope.targets[SUBSCRIPTION_TEAMS]) {
+                $scope.targets[SUBSCRIPTION_TEAMS] = resp.data.teams_webhook_url || "";
+            }
+            if (!$scope.targets[SUBSCRIPTION_MATTERMOST]) {
+                $scope.targets[SUBSCRIPTION_MATTERMOST] = resp.data.mattermost_webhook_url || "";
+            }
         }, error: function(resp) {
             console.log("failed to fetch user settings: ", resp);
         }});
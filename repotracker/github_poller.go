@@ -73,13 +73,19 @@ func (gRepoPoller *GithubRepositoryPoller) GetRemoteConfig(ctx context.Context,
 
 	projectFileBytes, err := base64.StdEncoding.DecodeString(*githubFile.Content)
 	if err != nil {
-		return nil, thirdparty.FileDecodeError{err.Error()}
+		return nil, thirdparty.FileDecodeError{Message: err.Error()}
+	}
+
+	fetch := model.NewGithubIncludeFetcher(gRepoPoller.OauthToken, projectRef.Owner, projectRef.Repo, projectFileRevision)
+	projectFileBytes, err = model.ResolveIncludes(ctx, projectFileBytes, fetch)
+	if err != nil {
+		return nil, thirdparty.YAMLFormatError{Message: err.Error()}
 	}
 
 	projectConfig = &model.Project{}
 	err = model.LoadProjectInto(projectFileBytes, projectRef.Identifier, projectConfig)
 	if err != nil {
-		return nil, thirdparty.YAMLFormatError{err.Error()}
+		return nil, thirdparty.YAMLFormatError{Message: err.Error()}
 	}
 
 	return projectConfig, nil
@@ -0,0 +1,153 @@
+package repotracker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// systemVersionAuthor is the author of the versions Evergreen creates on a
+// project's behalf.
+const systemVersionAuthor = "evergreen"
+
+// PeriodicVersionId returns the ID of the version created by a periodic
+// build definition for the given scheduled time.
+func PeriodicVersionId(ref *model.ProjectRef, def model.PeriodicBuildDefinition, scheduled time.Time) string {
+	return util.CleanName(fmt.Sprintf("%s_%s_%s", ref.String(), def.ID, scheduled.UTC().Format(build.IdTimeLayout)))
+}
+
+// CreatePeriodicVersion creates and activates the version of a periodic
+// build definition for the given scheduled time, at the revision and with
+// the configuration of the project's most recent mainline version. If the
+// version already exists, it is returned unchanged.
+func CreatePeriodicVersion(ref *model.ProjectRef, def model.PeriodicBuildDefinition, scheduled time.Time) (*version.Version, error) {
+	msg := def.Message
+	if msg == "" {
+		msg = fmt.Sprintf("periodic build '%s'", def.ID)
+	}
+	v := &version.Version{
+		Id:         PeriodicVersionId(ref, def, scheduled),
+		CreateTime: scheduled,
+		Message:    msg,
+		Requester:  evergreen.PeriodicBuildRequester,
+	}
+
+	return createVersionFromMainline(ref, v, func(project *model.Project) (model.TaskVariantPairs, error) {
+		tasks, err := project.BuildPeriodicBuildTVPairs(def)
+		return tasks, errors.Wrapf(err, "problem selecting tasks for periodic build '%s'", def.ID)
+	})
+}
+
+// createVersionFromMainline creates and activates a version of a project
+// which Evergreen creates on the project's behalf, at the revision and with
+// the configuration of the project's most recent mainline version. The
+// given version must have its ID, create time, message and requester set.
+// If a version with the same ID already exists, it is returned unchanged.
+func createVersionFromMainline(ref *model.ProjectRef, v *version.Version, selectTasks func(*model.Project) (model.TaskVariantPairs, error)) (*version.Version, error) {
+	existing, err := version.FindOne(version.ById(v.Id))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding version '%s'", v.Id)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	base, err := version.FindOne(version.ByMostRecentForRequester(ref.Identifier, evergreen.RepotrackerVersionRequester))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding most recent version of project '%s'", ref.Identifier)
+	}
+	if base == nil {
+		return nil, errors.Errorf("project '%s' has no versions to build from", ref.Identifier)
+	}
+
+	project := &model.Project{}
+	if err = model.LoadProjectInto([]byte(base.Config), ref.Identifier, project); err != nil {
+		return nil, errors.Wrapf(err, "problem loading project config of version '%s'", base.Id)
+	}
+
+	tasks, err := selectTasks(project)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks.ExecTasks) == 0 {
+		return nil, errors.Errorf("version '%s' does not select any tasks", v.Id)
+	}
+
+	number, err := model.GetNewRevisionOrderNumber(ref.Identifier)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	v.Revision = base.Revision
+	v.Author = systemVersionAuthor
+	v.Status = evergreen.VersionCreated
+	v.RevisionOrderNumber = number
+	v.Config = base.Config
+	v.Owner = ref.Owner
+	v.Repo = ref.Repo
+	v.Branch = ref.Branch
+	v.RepoKind = ref.RepoKind
+	v.Identifier = ref.Identifier
+	v.RemotePath = ref.RemotePath
+
+	taskIds := model.NewPatchTaskIdTable(project, v, tasks)
+	variantsProcessed := map[string]bool{}
+	for _, pair := range tasks.ExecTasks {
+		if variantsProcessed[pair.Variant] {
+			continue
+		}
+		variantsProcessed[pair.Variant] = true
+
+		var buildId string
+		buildId, err = model.CreateBuildFromVersion(project, v, taskIds, pair.Variant, true,
+			tasks.ExecTasks.TaskNames(pair.Variant), tasks.DisplayTasks.TaskNames(pair.Variant), "")
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		v.BuildIds = append(v.BuildIds, buildId)
+		v.BuildVariants = append(v.BuildVariants, version.BuildStatus{
+			BuildVariant: pair.Variant,
+			Activated:    true,
+			ActivateAt:   v.CreateTime,
+			BuildId:      buildId,
+		})
+	}
+
+	if err = v.Insert(); err != nil {
+		for _, buildStatus := range v.BuildVariants {
+			if buildErr := model.DeleteBuild(buildStatus.BuildId); buildErr != nil {
+				grip.Error(message.WrapError(buildErr, message.Fields{
+					"runner":     RunnerName,
+					"message":    "issue deleting build",
+					"version_id": v.Id,
+					"build_id":   buildStatus.BuildId,
+				}))
+			}
+		}
+		if db.IsDuplicateKey(err) {
+			return version.FindOne(version.ById(v.Id))
+		}
+		return nil, errors.Wrapf(err, "problem inserting version '%s'", v.Id)
+	}
+
+	grip.Info(message.Fields{
+		"runner":    RunnerName,
+		"message":   "created version from mainline",
+		"project":   ref.Identifier,
+		"requester": v.Requester,
+		"version":   v.Id,
+		"revision":  v.Revision,
+		"builds":    len(v.BuildIds),
+	})
+
+	return v, nil
+}
@@ -0,0 +1,58 @@
+package repotracker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// TriggeredVersionId returns the ID of the version created by a project
+// trigger for an upstream version. A trigger creates at most one version
+// for each upstream version, even if several of its builds fire it.
+func TriggeredVersionId(ref *model.ProjectRef, def model.TriggerDefinition, upstream *version.UpstreamVersion) string {
+	return util.CleanName(fmt.Sprintf("%s_%s_%s", ref.String(), def.ID, upstream.VersionId))
+}
+
+// CreateTriggeredVersion creates and activates the version of a project
+// trigger for an upstream version, at the revision and with the
+// configuration of the project's most recent mainline version, and records
+// it as downstream of the upstream version. If the version already exists,
+// it is returned unchanged.
+func CreateTriggeredVersion(ref *model.ProjectRef, def model.TriggerDefinition, upstream *version.UpstreamVersion) (*version.Version, error) {
+	upstreamVersion, err := version.FindOne(version.ById(upstream.VersionId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding upstream version '%s'", upstream.VersionId)
+	}
+	if upstreamVersion == nil {
+		return nil, errors.Errorf("upstream version '%s' not found", upstream.VersionId)
+	}
+
+	triggeredBy := *upstream
+	triggeredBy.TriggerID = def.ID
+	v := &version.Version{
+		Id:         TriggeredVersionId(ref, def, upstream),
+		CreateTime: time.Now(),
+		Message:    fmt.Sprintf("triggered by '%s' of %s version '%s'", def.ID, upstream.Project, upstream.VersionId),
+		Requester:  evergreen.TriggerRequester,
+		Upstream:   &triggeredBy,
+	}
+
+	v, err = createVersionFromMainline(ref, v, func(project *model.Project) (model.TaskVariantPairs, error) {
+		tasks, err := project.BuildAliasTVPairs(def.Alias)
+		return tasks, errors.Wrapf(err, "problem selecting tasks for trigger '%s'", def.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = upstreamVersion.AddDownstreamVersion(v.Id); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,102 @@
+package repotracker
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTriggeredVersion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(model.ProjectRefCollection, model.ProjectAliasCollection,
+		model.RepositoriesCollection, version.Collection, build.Collection, task.Collection))
+
+	ref := &model.ProjectRef{
+		Identifier: "integration",
+		Enabled:    true,
+		Triggers: []model.TriggerDefinition{
+			{ID: "lib-green", Project: "lib", Level: model.ProjectTriggerLevelVersion, Status: evergreen.VersionSucceeded, Alias: "smoke"},
+		},
+	}
+	require.NoError(ref.Insert())
+	alias := &model.ProjectAlias{ProjectID: "integration", Alias: "smoke", Variant: "^linux$", Task: "^smoke$"}
+	require.NoError(alias.Upsert())
+
+	upstreamVersion := &version.Version{
+		Id:         "lib_abc",
+		Identifier: "lib",
+		Revision:   "abc",
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(upstreamVersion.Insert())
+	require.NoError((&version.Version{
+		Id:                  "integration_def",
+		Identifier:          "integration",
+		Revision:            "def",
+		Requester:           evergreen.RepotrackerVersionRequester,
+		RevisionOrderNumber: 1,
+		Config: `
+buildvariants:
+- name: linux
+  run_on: [distro]
+  tasks:
+  - name: smoke
+  - name: full
+tasks:
+- name: smoke
+- name: full
+`,
+	}).Insert())
+
+	upstream := &version.UpstreamVersion{
+		Project:   "lib",
+		VersionId: "lib_abc",
+		Revision:  "abc",
+		Status:    evergreen.VersionSucceeded,
+		Artifacts: []version.UpstreamArtifact{{Task: "package", Name: "tarball", Link: "https://example.com/lib.tgz"}},
+	}
+	v, err := CreateTriggeredVersion(ref, ref.Triggers[0], upstream)
+	require.NoError(err)
+	require.NotNil(v)
+	assert.Equal(TriggeredVersionId(ref, ref.Triggers[0], upstream), v.Id)
+	assert.Equal(evergreen.TriggerRequester, v.Requester)
+	assert.Equal("def", v.Revision)
+	require.NotNil(v.Upstream)
+	assert.Equal("lib-green", v.Upstream.TriggerID)
+	assert.Equal("lib_abc", v.Upstream.VersionId)
+
+	tasks, err := task.Find(task.ByVersion(v.Id))
+	require.NoError(err)
+	require.Len(tasks, 1)
+	assert.Equal("smoke", tasks[0].DisplayName)
+	assert.Equal(evergreen.TriggerRequester, tasks[0].Requester)
+	assert.True(tasks[0].Activated)
+
+	// both versions link to each other
+	dbVersion, err := version.FindOne(version.ById(v.Id))
+	require.NoError(err)
+	require.NotNil(dbVersion.Upstream)
+	assert.Equal("https://example.com/lib.tgz", dbVersion.Upstream.Artifacts[0].Link)
+	dbUpstream, err := version.FindOne(version.ById("lib_abc"))
+	require.NoError(err)
+	assert.Equal([]string{v.Id}, dbUpstream.DownstreamVersionIds)
+
+	// firing the trigger again for the same upstream version does not
+	// create another version
+	_, err = CreateTriggeredVersion(ref, ref.Triggers[0], upstream)
+	require.NoError(err)
+	count, err := version.Count(version.ByMostRecentForRequester("integration", evergreen.TriggerRequester))
+	require.NoError(err)
+	assert.Equal(1, count)
+	dbUpstream, err = version.FindOne(version.ById("lib_abc"))
+	require.NoError(err)
+	assert.Len(dbUpstream.DownstreamVersionIds, 1)
+}
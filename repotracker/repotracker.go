@@ -319,15 +319,17 @@ func (repoTracker *RepoTracker) StoreRevisions(ctx context.Context, revisions []
 		}
 		v.Config = string(projectYamlBytes)
 
-		// "Ignore" a version if all changes are to ignored files
-		if len(project.Ignore) > 0 {
+		// "Ignore" a version if all changes are to ignored files, and skip
+		// the variants and tasks that none of the changes affect
+		if len(project.Ignore) > 0 || project.HasPathFilters() {
 			filenames, err := repoTracker.GetChangedFiles(ctx, revision)
 			if err != nil {
-				return nil, errors.Wrap(err, "error checking GitHub for ignored files")
+				return nil, errors.Wrap(err, "error checking GitHub for changed files")
 			}
-			if project.IgnoresAllFiles(filenames) {
+			if len(project.Ignore) > 0 && project.IgnoresAllFiles(filenames) {
 				v.Ignored = true
 			}
+			v.Skipped = project.SkipUnaffected(filenames)
 		}
 
 		// We rebind newestVersion each iteration, so the last binding will be the newest version
@@ -648,6 +650,10 @@ func makeBuildBreakSubscriber(userID string) (*event.Subscriber, error) {
 			subscriber.Target = u.Email()
 		} else if preference == user.PreferenceSlack {
 			subscriber.Target = u.Settings.SlackUsername
+		} else if preference == user.PreferenceTeams {
+			subscriber.Target = u.Settings.TeamsWebhookURL
+		} else if preference == user.PreferenceMattermost {
+			subscriber.Target = u.Settings.MattermostWebhookURL
 		} else {
 			return nil, errors.Errorf("invalid subscription preference for build break: %s", preference)
 		}
@@ -151,6 +151,16 @@ type Communicator interface {
 	// Delete a key with specified name from the current authenticated user
 	DeletePublicKey(context.Context, string) error
 
+	// GetAPITokens fetches the API tokens of the current authenticated user
+	GetAPITokens(context.Context) ([]restmodel.APIUserToken, error)
+	// CreateAPIToken creates a named API token with a scope for the current
+	// authenticated user, which expires after the given number of hours, or
+	// never if it is zero
+	CreateAPIToken(context.Context, string, string, int) (*restmodel.APIUserToken, error)
+	// RevokeAPIToken deletes the named API token of the current authenticated
+	// user
+	RevokeAPIToken(context.Context, string) error
+
 	// List variant/task aliases
 	ListAliases(context.Context, string) ([]model.ProjectAlias, error)
 
@@ -160,4 +170,41 @@ type Communicator interface {
 	// GetSubscriptions fetches the subscriptions for the user defined
 	// in the local evergreen yaml
 	GetSubscriptions(context.Context) ([]event.Subscription, error)
+
+	// GetFlakyTests fetches the flaky tests of a project, from the flakiest
+	// down, optionally filtered by build variant and minimum score
+	GetFlakyTests(context.Context, string, string, float64, int) ([]restmodel.APIFlakyTest, error)
+
+	// GetCommitQueue fetches the commit queue of a project
+	GetCommitQueue(context.Context, string) (*restmodel.APICommitQueue, error)
+	// EnqueueCommitQueueItem adds a pull request to the commit queue of a
+	// project and returns its position in the queue
+	EnqueueCommitQueueItem(context.Context, string, int) (int, error)
+	// DeleteCommitQueueItem removes a pull request from the commit queue of
+	// a project
+	DeleteCommitQueueItem(context.Context, string, int) error
+
+	// GetVersionGraph fetches the task dependency graph of a version, with
+	// its critical path and the slack of each task
+	GetVersionGraph(context.Context, string) (*restmodel.APIVersionGraph, error)
+	// GetVersionGraphDOT fetches the task dependency graph of a version in
+	// the Graphviz DOT language
+	GetVersionGraphDOT(context.Context, string) (string, error)
+
+	// GenerateTasksDryRun validates files for the `generate.tasks` command of
+	// a task and returns the build variants and tasks they would add
+	GenerateTasksDryRun(context.Context, string, []json.RawMessage) (*restmodel.APIGenerateTasksDryRun, error)
+
+	// GetRoles fetches the built in roles
+	GetRoles(context.Context) ([]restmodel.APIRole, error)
+	// GetProjectRoles fetches the roles assigned on a project
+	GetProjectRoles(context.Context, string) ([]restmodel.APIRoleAssignment, error)
+	// AssignProjectRole grants a role on a project to a user or a group
+	AssignProjectRole(context.Context, restmodel.APIRoleAssignment) error
+	// RemoveProjectRole revokes a role on a project from a user or a group
+	RemoveProjectRole(context.Context, restmodel.APIRoleAssignment) error
+
+	// StreamTaskLogs reads the logs of a task, passing each message to the
+	// handler, and optionally follows them until the task finishes
+	StreamTaskLogs(context.Context, TaskLogStreamOptions, func(restmodel.APILogMessage) error) error
 }
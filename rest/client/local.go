@@ -0,0 +1,483 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/manifest"
+	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/logging"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/pkg/errors"
+)
+
+const (
+	localTestResultsFile = "test_results.json"
+	localArtifactsFile   = "artifacts.json"
+	localTestLogsDir     = "test_logs"
+	localJSONDataDir     = "json"
+)
+
+// LocalOptions describes the task that a Local communicator serves and
+// where it writes the task's output.
+type LocalOptions struct {
+	Task       *task.Task
+	Version    *version.Version
+	ProjectRef *serviceModel.ProjectRef
+	Distro     *distro.Distro
+	Expansions map[string]string
+
+	// OutputDir is the directory that logs, test results, test logs,
+	// artifacts and JSON data are written to.
+	OutputDir string
+	// Output, if set, also receives the task and agent logs.
+	Output io.Writer
+}
+
+// Local is a Communicator that runs a single task without an API server. It
+// serves the task, version, project and distro it was created with, and
+// writes everything the task reports to files in its output directory.
+// Operations that need the API server return an error.
+type Local struct {
+	opts LocalOptions
+
+	hostID          string
+	hostSecret      string
+	endTaskDetail   *apimodels.TaskEndDetail
+	testResults     []task.TestResult
+	artifacts       []*artifact.File
+	keyVal          map[string]int64
+	testLogCount    int
+	lastMessageSent time.Time
+
+	mu sync.RWMutex
+}
+
+// NewLocal returns a Local communicator for the task described by opts,
+// creating its output directory.
+func NewLocal(opts LocalOptions) (*Local, error) {
+	if opts.Task == nil || opts.Version == nil || opts.ProjectRef == nil || opts.Distro == nil {
+		return nil, errors.New("a local communicator requires a task, version, project ref and distro")
+	}
+	if opts.OutputDir == "" {
+		return nil, errors.New("a local communicator requires an output directory")
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "problem creating output directory '%s'", opts.OutputDir)
+	}
+
+	return &Local{
+		opts:            opts,
+		keyVal:          map[string]int64{},
+		lastMessageSent: time.Now(),
+	}, nil
+}
+
+func localUnsupported(operation string) error {
+	return errors.Errorf("%s requires an Evergreen API server and is not supported when running a task locally", operation)
+}
+
+// localFileName makes a name safe to use as a file name.
+func localFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func (c *Local) writeJSON(path string, data interface{}) error {
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "problem marshalling '%s'", path)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "problem creating directory for '%s'", path)
+	}
+	return errors.Wrapf(ioutil.WriteFile(path, out, 0644), "problem writing '%s'", path)
+}
+
+// EndTaskDetail returns the final status of the task, or nil if it has not
+// finished.
+func (c *Local) EndTaskDetail() *apimodels.TaskEndDetail {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.endTaskDetail
+}
+
+// TestResults returns the test results the task has reported.
+func (c *Local) TestResults() []task.TestResult {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.testResults
+}
+
+func (c *Local) SetTimeoutStart(time.Duration) {}
+func (c *Local) SetTimeoutMax(time.Duration)   {}
+func (c *Local) SetMaxAttempts(int)            {}
+func (c *Local) SetHostID(hostID string)       { c.hostID = hostID }
+func (c *Local) SetHostSecret(secret string)   { c.hostSecret = secret }
+func (c *Local) GetHostID() string             { return c.hostID }
+func (c *Local) GetHostSecret() string         { return c.hostSecret }
+func (c *Local) SetAPIUser(string)             {}
+func (c *Local) SetAPIKey(string)              {}
+func (c *Local) Close()                        {}
+
+func (c *Local) UpdateLastMessageTime() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastMessageSent = time.Now()
+}
+
+func (c *Local) LastMessageAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastMessageSent
+}
+
+func (c *Local) StartTask(context.Context, TaskData) error { return nil }
+
+func (c *Local) EndTask(_ context.Context, detail *apimodels.TaskEndDetail, _ TaskData) (*apimodels.EndTaskResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.endTaskDetail = detail
+	return &apimodels.EndTaskResponse{}, nil
+}
+
+func (c *Local) GetTask(context.Context, TaskData) (*task.Task, error) {
+	t := *c.opts.Task
+	return &t, nil
+}
+
+func (c *Local) GetProjectRef(context.Context, TaskData) (*serviceModel.ProjectRef, error) {
+	ref := *c.opts.ProjectRef
+	return &ref, nil
+}
+
+func (c *Local) GetDistro(context.Context, TaskData) (*distro.Distro, error) {
+	d := *c.opts.Distro
+	return &d, nil
+}
+
+func (c *Local) GetVersion(context.Context, TaskData) (*version.Version, error) {
+	v := *c.opts.Version
+	return &v, nil
+}
+
+func (c *Local) Heartbeat(context.Context, TaskData) (bool, error) { return false, nil }
+
+func (c *Local) FetchExpansionVars(context.Context, TaskData) (*apimodels.ExpansionVars, error) {
+	vars := map[string]string{}
+	for k, v := range c.opts.Expansions {
+		vars[k] = v
+	}
+	return &apimodels.ExpansionVars{Vars: vars, PrivateVars: map[string]bool{}}, nil
+}
+
+func (c *Local) GetNextTask(context.Context, *apimodels.GetNextTaskDetails) (*apimodels.NextTaskResponse, error) {
+	return nil, localUnsupported("getting the next task")
+}
+
+// GetLoggerProducer returns loggers that pass log messages to
+// SendLogMessages, as the agent's loggers do.
+func (c *Local) GetLoggerProducer(ctx context.Context, td TaskData) LoggerProducer {
+	exec := newLogSender(ctx, c, apimodels.AgentLogPrefix, td)
+	grip.CatchWarning(exec.SetFormatter(send.MakeDefaultFormatter()))
+	task := newTimeoutLogSender(ctx, c, apimodels.TaskLogPrefix, td)
+	grip.CatchWarning(task.SetFormatter(send.MakeDefaultFormatter()))
+	system := newLogSender(ctx, c, apimodels.SystemLogPrefix, td)
+	grip.CatchWarning(system.SetFormatter(send.MakeDefaultFormatter()))
+
+	return &logHarness{
+		execution: logging.MakeGrip(exec),
+		task:      logging.MakeGrip(task),
+		system:    logging.MakeGrip(system),
+	}
+}
+
+// SendLogMessages appends the messages to the log file for their channel,
+// and writes task and agent messages to the output, if any.
+func (c *Local) SendLogMessages(_ context.Context, _ TaskData, msgs []apimodels.LogMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files := map[string]*os.File{}
+	defer func() {
+		for _, f := range files {
+			grip.Warning(f.Close())
+		}
+	}()
+
+	for _, msg := range msgs {
+		var name string
+		switch msg.Type {
+		case apimodels.TaskLogPrefix:
+			name = "task.log"
+		case apimodels.SystemLogPrefix:
+			name = "system.log"
+		default:
+			name = "agent.log"
+		}
+
+		f, ok := files[name]
+		if !ok {
+			var err error
+			f, err = os.OpenFile(filepath.Join(c.opts.OutputDir, name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return errors.Wrapf(err, "problem opening log file '%s'", name)
+			}
+			files[name] = f
+		}
+
+		line := fmt.Sprintf("[%s] %s\n", msg.Timestamp.Format("2006/01/02 15:04:05.000"), msg.Message)
+		if _, err := f.WriteString(line); err != nil {
+			return errors.Wrapf(err, "problem writing to log file '%s'", name)
+		}
+		if c.opts.Output != nil && msg.Type != apimodels.SystemLogPrefix {
+			if _, err := io.WriteString(c.opts.Output, line); err != nil {
+				return errors.Wrap(err, "problem writing log output")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Local) SendProcessInfo(context.Context, TaskData, []*message.ProcessInfo) error { return nil }
+func (c *Local) SendSystemInfo(context.Context, TaskData, *message.SystemInfo) error     { return nil }
+
+// SendTestResults adds the results to the test results file.
+func (c *Local) SendTestResults(_ context.Context, _ TaskData, results *task.LocalTestResults) error {
+	if results == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.testResults = append(c.testResults, results.Results...)
+	return c.writeJSON(filepath.Join(c.opts.OutputDir, localTestResultsFile), c.testResults)
+}
+
+// SendTestLog writes the test log to its own file and returns the path to
+// it as the log's ID.
+func (c *Local) SendTestLog(_ context.Context, _ TaskData, log *serviceModel.TestLog) (string, error) {
+	if log == nil {
+		return "", nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.testLogCount++
+	path := filepath.Join(c.opts.OutputDir, localTestLogsDir, fmt.Sprintf("%d_%s.log", c.testLogCount, localFileName(log.Name)))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", errors.Wrap(err, "problem creating test log directory")
+	}
+	data := strings.Join(log.Lines, "\n")
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		return "", errors.Wrapf(err, "problem writing test log '%s'", log.Name)
+	}
+
+	return path, nil
+}
+
+func (c *Local) GetTaskPatch(context.Context, TaskData) (*patchmodel.Patch, error) {
+	return nil, localUnsupported("fetching the task's patch")
+}
+
+func (c *Local) GetPatchFile(context.Context, TaskData, string) (string, error) {
+	return "", localUnsupported("fetching a patch file")
+}
+
+// AttachFiles adds the files to the artifacts file.
+func (c *Local) AttachFiles(_ context.Context, _ TaskData, files []*artifact.File) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.artifacts = append(c.artifacts, files...)
+	return c.writeJSON(filepath.Join(c.opts.OutputDir, localArtifactsFile), c.artifacts)
+}
+
+func (c *Local) GetManifest(context.Context, TaskData) (*manifest.Manifest, error) {
+	return nil, localUnsupported("loading the manifest")
+}
+
+func (c *Local) S3Copy(context.Context, TaskData, *apimodels.S3CopyRequest) error {
+	return localUnsupported("s3Copy.copy")
+}
+
+// KeyValInc increments a counter that only lasts as long as the Local
+// communicator.
+func (c *Local) KeyValInc(_ context.Context, _ TaskData, kv *serviceModel.KeyVal) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keyVal[kv.Key]++
+	kv.Value = c.keyVal[kv.Key]
+	return nil
+}
+
+// PostJSONData writes the data to a file named for the path.
+func (c *Local) PostJSONData(_ context.Context, _ TaskData, path string, data interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.writeJSON(filepath.Join(c.opts.OutputDir, localJSONDataDir, localFileName(path)+".json"), data)
+}
+
+func (c *Local) GetJSONData(context.Context, TaskData, string, string, string) ([]byte, error) {
+	return nil, localUnsupported("json.get")
+}
+
+func (c *Local) GetJSONHistory(context.Context, TaskData, bool, string, string) ([]byte, error) {
+	return nil, localUnsupported("json.get_history")
+}
+
+func (c *Local) GenerateTasks(context.Context, TaskData, []json.RawMessage) error {
+	return localUnsupported("generate.tasks")
+}
+
+func (c *Local) CreateHost(context.Context, TaskData, apimodels.CreateHost) error {
+	return localUnsupported("host.create")
+}
+
+func (c *Local) ListHosts(context.Context, TaskData) ([]model.APIHost, error) {
+	return nil, localUnsupported("host.list")
+}
+
+// The REST API methods are not used by task commands.
+
+func (c *Local) SetBannerMessage(context.Context, string, evergreen.BannerTheme) error {
+	return localUnsupported("setting the banner")
+}
+func (c *Local) GetBannerMessage(context.Context) (string, error) {
+	return "", localUnsupported("getting the banner")
+}
+func (c *Local) SetServiceFlags(context.Context, *model.APIServiceFlags) error {
+	return localUnsupported("setting service flags")
+}
+func (c *Local) GetServiceFlags(context.Context) (*model.APIServiceFlags, error) {
+	return nil, localUnsupported("getting service flags")
+}
+func (c *Local) RestartRecentTasks(context.Context, time.Time, time.Time) error {
+	return localUnsupported("restarting tasks")
+}
+func (c *Local) GetSettings(context.Context) (*evergreen.Settings, error) {
+	return nil, localUnsupported("getting settings")
+}
+func (c *Local) UpdateSettings(context.Context, *model.APIAdminSettings) (*model.APIAdminSettings, error) {
+	return nil, localUnsupported("updating settings")
+}
+func (c *Local) GetEvents(context.Context, time.Time, int) ([]interface{}, error) {
+	return nil, localUnsupported("getting events")
+}
+func (c *Local) RevertSettings(context.Context, string) error {
+	return localUnsupported("reverting settings")
+}
+func (c *Local) GetHostsByUser(context.Context, string) ([]*model.APIHost, error) {
+	return nil, localUnsupported("getting hosts")
+}
+func (c *Local) CreateSpawnHost(context.Context, string, string) (*model.APIHost, error) {
+	return nil, localUnsupported("creating a spawn host")
+}
+func (c *Local) TerminateSpawnHost(context.Context, string) error {
+	return localUnsupported("terminating a spawn host")
+}
+func (c *Local) ChangeSpawnHostPassword(context.Context, string, string) error {
+	return localUnsupported("changing a spawn host password")
+}
+func (c *Local) ExtendSpawnHostExpiration(context.Context, string, int) error {
+	return localUnsupported("extending a spawn host")
+}
+func (c *Local) GetHosts(context.Context, func([]*model.APIHost) error) error {
+	return localUnsupported("getting hosts")
+}
+func (c *Local) GetDistrosList(context.Context) ([]model.APIDistro, error) {
+	return nil, localUnsupported("getting distros")
+}
+func (c *Local) GetCurrentUsersKeys(context.Context) ([]model.APIPubKey, error) {
+	return nil, localUnsupported("getting keys")
+}
+func (c *Local) AddPublicKey(context.Context, string, string) error {
+	return localUnsupported("adding a key")
+}
+func (c *Local) DeletePublicKey(context.Context, string) error {
+	return localUnsupported("deleting a key")
+}
+func (c *Local) GetAPITokens(context.Context) ([]model.APIUserToken, error) {
+	return nil, localUnsupported("getting API tokens")
+}
+func (c *Local) CreateAPIToken(context.Context, string, string, int) (*model.APIUserToken, error) {
+	return nil, localUnsupported("creating an API token")
+}
+func (c *Local) RevokeAPIToken(context.Context, string) error {
+	return localUnsupported("revoking an API token")
+}
+func (c *Local) ListAliases(context.Context, string) ([]serviceModel.ProjectAlias, error) {
+	return nil, localUnsupported("listing aliases")
+}
+func (c *Local) GetClientConfig(context.Context) (*evergreen.ClientConfig, error) {
+	return nil, localUnsupported("getting the client config")
+}
+func (c *Local) GetSubscriptions(context.Context) ([]event.Subscription, error) {
+	return nil, localUnsupported("getting subscriptions")
+}
+func (c *Local) GetFlakyTests(context.Context, string, string, float64, int) ([]model.APIFlakyTest, error) {
+	return nil, localUnsupported("getting flaky tests")
+}
+func (c *Local) GetCommitQueue(context.Context, string) (*model.APICommitQueue, error) {
+	return nil, localUnsupported("getting the commit queue")
+}
+func (c *Local) EnqueueCommitQueueItem(context.Context, string, int) (int, error) {
+	return 0, localUnsupported("enqueueing a commit queue item")
+}
+func (c *Local) DeleteCommitQueueItem(context.Context, string, int) error {
+	return localUnsupported("deleting a commit queue item")
+}
+func (c *Local) GetVersionGraph(context.Context, string) (*model.APIVersionGraph, error) {
+	return nil, localUnsupported("getting a version graph")
+}
+func (c *Local) GetVersionGraphDOT(context.Context, string) (string, error) {
+	return "", localUnsupported("getting a version graph")
+}
+func (c *Local) GenerateTasksDryRun(context.Context, string, []json.RawMessage) (*model.APIGenerateTasksDryRun, error) {
+	return nil, localUnsupported("validating generated tasks")
+}
+func (c *Local) GetRoles(context.Context) ([]model.APIRole, error) {
+	return nil, localUnsupported("getting roles")
+}
+func (c *Local) GetProjectRoles(context.Context, string) ([]model.APIRoleAssignment, error) {
+	return nil, localUnsupported("getting project roles")
+}
+func (c *Local) AssignProjectRole(context.Context, model.APIRoleAssignment) error {
+	return localUnsupported("assigning a project role")
+}
+func (c *Local) RemoveProjectRole(context.Context, model.APIRoleAssignment) error {
+	return localUnsupported("removing a project role")
+}
+func (c *Local) StreamTaskLogs(context.Context, TaskLogStreamOptions, func(model.APILogMessage) error) error {
+	return localUnsupported("streaming task logs")
+}
+
+var _ Communicator = &Local{}
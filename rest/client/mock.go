@@ -545,3 +545,128 @@ func (c *Mock) GetSubscriptions(_ context.Context) ([]event.Subscription, error)
 		},
 	}, nil
 }
+
+func (c *Mock) GetFlakyTests(_ context.Context, project, variant string, minScore float64, limit int) ([]model.APIFlakyTest, error) {
+	return []model.APIFlakyTest{
+		{
+			Project:      model.ToAPIString(project),
+			BuildVariant: model.ToAPIString("variant"),
+			TaskName:     model.ToAPIString("task"),
+			TestFile:     model.ToAPIString("test"),
+			Score:        0.5,
+		},
+	}, nil
+}
+
+func (c *Mock) GetCommitQueue(_ context.Context, projectID string) (*model.APICommitQueue, error) {
+	return &model.APICommitQueue{
+		ProjectID: model.ToAPIString(projectID),
+		Queue: []model.APICommitQueueItem{
+			{
+				PRNumber: 1,
+				Author:   model.ToAPIString("user"),
+			},
+		},
+	}, nil
+}
+
+func (c *Mock) EnqueueCommitQueueItem(_ context.Context, projectID string, prNumber int) (int, error) {
+	return 0, nil
+}
+
+func (c *Mock) DeleteCommitQueueItem(_ context.Context, projectID string, prNumber int) error {
+	return nil
+}
+
+func (c *Mock) GetAPITokens(_ context.Context) ([]model.APIUserToken, error) {
+	return []model.APIUserToken{
+		{
+			Name:      model.ToAPIString("ci"),
+			Scope:     model.ToAPIString("read"),
+			CreatedAt: model.NewTime(time.Now()),
+		},
+	}, nil
+}
+
+func (c *Mock) CreateAPIToken(_ context.Context, name, scope string, expiresInHours int) (*model.APIUserToken, error) {
+	token := &model.APIUserToken{
+		Name:      model.ToAPIString(name),
+		Scope:     model.ToAPIString(scope),
+		Token:     model.ToAPIString("evgtok_" + name),
+		CreatedAt: model.NewTime(time.Now()),
+	}
+	if expiresInHours > 0 {
+		token.ExpiresAt = model.NewTime(time.Now().Add(time.Duration(expiresInHours) * time.Hour))
+	}
+	return token, nil
+}
+
+func (c *Mock) RevokeAPIToken(_ context.Context, name string) error {
+	return nil
+}
+
+func (c *Mock) GetVersionGraph(_ context.Context, versionID string) (*model.APIVersionGraph, error) {
+	return &model.APIVersionGraph{
+		VersionId:    model.ToAPIString(versionID),
+		Length:       model.NewAPIDuration(time.Minute),
+		CriticalPath: []model.APIString{model.ToAPIString("task")},
+		Tasks: []model.APIVersionGraphTask{
+			{
+				TaskId:         model.ToAPIString("task"),
+				DisplayName:    model.ToAPIString("task"),
+				Duration:       model.NewAPIDuration(time.Minute),
+				EarliestFinish: model.NewAPIDuration(time.Minute),
+				LatestFinish:   model.NewAPIDuration(time.Minute),
+				Critical:       true,
+			},
+		},
+	}, nil
+}
+
+func (c *Mock) GetVersionGraphDOT(_ context.Context, versionID string) (string, error) {
+	return "digraph version {\n  \"task\";\n}\n", nil
+}
+
+func (c *Mock) GenerateTasksDryRun(_ context.Context, taskID string, jsonBytes []json.RawMessage) (*model.APIGenerateTasksDryRun, error) {
+	return &model.APIGenerateTasksDryRun{
+		TaskId:        model.ToAPIString(taskID),
+		BuildVariants: []model.APIGeneratedVariant{},
+	}, nil
+}
+
+func (c *Mock) GetRoles(_ context.Context) ([]model.APIRole, error) {
+	return []model.APIRole{
+		{
+			Name:        model.ToAPIString("viewer"),
+			Description: model.ToAPIString("can view the project"),
+			Permissions: []model.APIString{},
+		},
+	}, nil
+}
+
+func (c *Mock) GetProjectRoles(_ context.Context, projectID string) ([]model.APIRoleAssignment, error) {
+	return []model.APIRoleAssignment{
+		{
+			ProjectID: model.ToAPIString(projectID),
+			Role:      model.ToAPIString("admin"),
+			User:      model.ToAPIString("user"),
+		},
+	}, nil
+}
+
+func (c *Mock) AssignProjectRole(_ context.Context, assignment model.APIRoleAssignment) error {
+	return nil
+}
+
+func (c *Mock) RemoveProjectRole(_ context.Context, assignment model.APIRoleAssignment) error {
+	return nil
+}
+
+func (c *Mock) StreamTaskLogs(_ context.Context, opts TaskLogStreamOptions, handler func(model.APILogMessage) error) error {
+	return handler(model.APILogMessage{
+		Type:      model.ToAPIString(apimodels.TaskLogPrefix),
+		Severity:  model.ToAPIString(apimodels.LogInfoPrefix),
+		Message:   model.ToAPIString("task log message"),
+		Timestamp: model.NewTime(time.Now()),
+	})
+}
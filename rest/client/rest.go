@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -553,6 +555,355 @@ func (c *communicatorImpl) GetClientConfig(ctx context.Context) (*evergreen.Clie
 	return &config, nil
 }
 
+func (c *communicatorImpl) GetFlakyTests(ctx context.Context, project, variant string, minScore float64, limit int) ([]model.APIFlakyTest, error) {
+	query := url.Values{}
+	if variant != "" {
+		query.Set("variant", variant)
+	}
+	if minScore > 0 {
+		query.Set("min_score", strconv.FormatFloat(minScore, 'f', -1, 64))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/flaky_tests?%s", project, query.Encode()),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem fetching flaky tests")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	tests := []model.APIFlakyTest{}
+	if err = util.ReadJSONInto(resp.Body, &tests); err != nil {
+		return nil, errors.Wrap(err, "error parsing flaky tests")
+	}
+
+	return tests, nil
+}
+
+func (c *communicatorImpl) GetCommitQueue(ctx context.Context, projectID string) (*model.APICommitQueue, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("commit_queue/%s", projectID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem fetching commit queue")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	cq := &model.APICommitQueue{}
+	if err = util.ReadJSONInto(resp.Body, cq); err != nil {
+		return nil, errors.Wrap(err, "error parsing commit queue")
+	}
+
+	return cq, nil
+}
+
+func (c *communicatorImpl) EnqueueCommitQueueItem(ctx context.Context, projectID string, prNumber int) (int, error) {
+	info := requestInfo{
+		method:  put,
+		version: apiVersion2,
+		path:    fmt.Sprintf("commit_queue/%s/%d", projectID, prNumber),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return -1, errors.Wrapf(err, "problem enqueueing pull request #%d", prNumber)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return -1, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	position := model.APICommitQueuePosition{}
+	if err = util.ReadJSONInto(resp.Body, &position); err != nil {
+		return -1, errors.Wrap(err, "error parsing commit queue position")
+	}
+
+	return position.Position, nil
+}
+
+func (c *communicatorImpl) DeleteCommitQueueItem(ctx context.Context, projectID string, prNumber int) error {
+	info := requestInfo{
+		method:  delete,
+		version: apiVersion2,
+		path:    fmt.Sprintf("commit_queue/%s/%d", projectID, prNumber),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "problem removing pull request #%d from the commit queue", prNumber)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *communicatorImpl) GetAPITokens(ctx context.Context) ([]model.APIUserToken, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    "tokens",
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem fetching API tokens")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	tokens := []model.APIUserToken{}
+	if err = util.ReadJSONInto(resp.Body, &tokens); err != nil {
+		return nil, errors.Wrap(err, "error parsing API tokens")
+	}
+
+	return tokens, nil
+}
+
+func (c *communicatorImpl) CreateAPIToken(ctx context.Context, name, scope string, expiresInHours int) (*model.APIUserToken, error) {
+	info := requestInfo{
+		method:  post,
+		version: apiVersion2,
+		path:    "tokens",
+	}
+	body := struct {
+		Name           string `json:"name"`
+		Scope          string `json:"scope"`
+		ExpiresInHours int    `json:"expires_in_hours"`
+	}{
+		Name:           name,
+		Scope:          scope,
+		ExpiresInHours: expiresInHours,
+	}
+
+	resp, err := c.request(ctx, info, body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem creating API token '%s'", name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	token := &model.APIUserToken{}
+	if err = util.ReadJSONInto(resp.Body, token); err != nil {
+		return nil, errors.Wrap(err, "error parsing API token")
+	}
+
+	return token, nil
+}
+
+func (c *communicatorImpl) RevokeAPIToken(ctx context.Context, name string) error {
+	info := requestInfo{
+		method:  delete,
+		version: apiVersion2,
+		path:    fmt.Sprintf("tokens/%s", url.PathEscape(name)),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "problem revoking API token '%s'", name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *communicatorImpl) GetVersionGraph(ctx context.Context, versionID string) (*model.APIVersionGraph, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("versions/%s/graph", versionID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem fetching version graph")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	graph := &model.APIVersionGraph{}
+	if err = util.ReadJSONInto(resp.Body, graph); err != nil {
+		return nil, errors.Wrap(err, "error parsing version graph")
+	}
+
+	return graph, nil
+}
+
+func (c *communicatorImpl) GetVersionGraphDOT(ctx context.Context, versionID string) (string, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("versions/%s/graph?format=dot", versionID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return "", errors.Wrap(err, "problem fetching version graph")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	dot, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "error reading version graph")
+	}
+
+	return string(dot), nil
+}
+
+func (c *communicatorImpl) GenerateTasksDryRun(ctx context.Context, taskID string, jsonBytes []json.RawMessage) (*model.APIGenerateTasksDryRun, error) {
+	info := requestInfo{
+		method:  post,
+		version: apiVersion2,
+		path:    fmt.Sprintf("tasks/%s/generate/dry_run", taskID),
+	}
+
+	resp, err := c.request(ctx, info, jsonBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem validating generated tasks")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg := gimlet.ErrorResponse{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+		}
+		return nil, errors.Wrap(errMsg, "generated tasks are invalid")
+	}
+
+	dryRun := &model.APIGenerateTasksDryRun{}
+	if err = util.ReadJSONInto(resp.Body, dryRun); err != nil {
+		return nil, errors.Wrap(err, "error parsing generated tasks")
+	}
+
+	return dryRun, nil
+}
+
+func (c *communicatorImpl) GetRoles(ctx context.Context) ([]model.APIRole, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    "roles",
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "problem fetching roles")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	roles := []model.APIRole{}
+	if err = util.ReadJSONInto(resp.Body, &roles); err != nil {
+		return nil, errors.Wrap(err, "error parsing roles")
+	}
+
+	return roles, nil
+}
+
+func (c *communicatorImpl) GetProjectRoles(ctx context.Context, projectID string) ([]model.APIRoleAssignment, error) {
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/roles", projectID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem fetching roles for project '%s'", projectID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	assignments := []model.APIRoleAssignment{}
+	if err = util.ReadJSONInto(resp.Body, &assignments); err != nil {
+		return nil, errors.Wrap(err, "error parsing role assignments")
+	}
+
+	return assignments, nil
+}
+
+func (c *communicatorImpl) AssignProjectRole(ctx context.Context, assignment model.APIRoleAssignment) error {
+	projectID := model.FromAPIString(assignment.ProjectID)
+	info := requestInfo{
+		method:  post,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/roles", projectID),
+	}
+
+	resp, err := c.request(ctx, info, assignment)
+	if err != nil {
+		return errors.Wrapf(err, "problem assigning role on project '%s'", projectID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *communicatorImpl) RemoveProjectRole(ctx context.Context, assignment model.APIRoleAssignment) error {
+	projectID := model.FromAPIString(assignment.ProjectID)
+	query := url.Values{}
+	query.Set("role", model.FromAPIString(assignment.Role))
+	if user := model.FromAPIString(assignment.User); user != "" {
+		query.Set("user", user)
+	}
+	if group := model.FromAPIString(assignment.Group); group != "" {
+		query.Set("group", group)
+	}
+	info := requestInfo{
+		method:  delete,
+		version: apiVersion2,
+		path:    fmt.Sprintf("projects/%s/roles?%s", projectID, query.Encode()),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "problem removing role on project '%s'", projectID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("bad status from api server: %v", resp.StatusCode)
+	}
+
+	return nil
+}
+
 func (c *communicatorImpl) GetSubscriptions(ctx context.Context) ([]event.Subscription, error) {
 	info := requestInfo{
 		path:    fmt.Sprintf("/subscriptions?owner=%s&type=person", c.apiUser),
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// maxTaskLogEventSize is the largest server-sent event a task log stream
+// reader accepts, which bounds the length of a single log line.
+const maxTaskLogEventSize = 4 * 1024 * 1024
+
+// TaskLogStreamOptions select the logs StreamTaskLogs reads.
+type TaskLogStreamOptions struct {
+	TaskID string
+	// Execution is the task execution to read, defaulting to the latest.
+	Execution *int
+	// Types are the log types to read (task, agent or system), defaulting
+	// to all of them.
+	Types []string
+	// Follow keeps reading the messages the agent sends until the task
+	// finishes, rather than stopping after the stored messages.
+	Follow bool
+}
+
+// StreamTaskLogs reads the logs of a task from the server, passing each
+// message to the handler. If follow is true, it keeps reading the messages
+// the agent sends, reconnecting whenever the server closes the stream, until
+// the task finishes or the context is canceled.
+func (c *communicatorImpl) StreamTaskLogs(ctx context.Context, opts TaskLogStreamOptions, handler func(model.APILogMessage) error) error {
+	var since time.Time
+	failures := 0
+	for {
+		last, done, err := c.readTaskLogStream(ctx, opts, since, handler)
+		if !last.IsZero() {
+			since = last
+			failures = 0
+		}
+		if done {
+			return errors.WithStack(err)
+		}
+		if ctx.Err() != nil {
+			return errors.New("reading task logs canceled")
+		}
+		if err != nil {
+			failures++
+			if failures >= c.maxAttempts {
+				return errors.Wrapf(err, "problem reading logs for task '%s' after %d attempts", opts.TaskID, failures)
+			}
+			grip.Debug(errors.Wrapf(err, "problem reading logs for task '%s', reconnecting", opts.TaskID))
+		}
+	}
+}
+
+// readTaskLogStream reads a single connection of a task log stream. It
+// returns the timestamp of the last message read, and whether reading is
+// done, because the stream ended or because of an error that reconnecting
+// cannot fix.
+func (c *communicatorImpl) readTaskLogStream(ctx context.Context, opts TaskLogStreamOptions, since time.Time, handler func(model.APILogMessage) error) (time.Time, bool, error) {
+	var last time.Time
+
+	params := url.Values{}
+	params.Set("follow", strconv.FormatBool(opts.Follow))
+	if len(opts.Types) > 0 {
+		params.Set("type", strings.Join(opts.Types, ","))
+	}
+	if opts.Execution != nil {
+		params.Set("execution", strconv.Itoa(*opts.Execution))
+	}
+	if !since.IsZero() {
+		params.Set("since", since.Format(time.RFC3339Nano))
+	}
+	info := requestInfo{
+		method:  get,
+		version: apiVersion2,
+		path:    fmt.Sprintf("tasks/%s/logs/stream?%s", opts.TaskID, params.Encode()),
+	}
+
+	resp, err := c.retryRequest(ctx, info, nil)
+	if err != nil {
+		return last, true, errors.Wrapf(err, "problem requesting logs for task '%s'", opts.TaskID)
+	}
+	defer resp.Body.Close()
+
+	events := newServerSentEventReader(resp.Body)
+	for {
+		event, err := events.next()
+		if err == io.EOF {
+			return last, false, nil
+		}
+		if err != nil {
+			return last, false, errors.WithStack(err)
+		}
+
+		if event.name == model.TaskLogStreamEndEvent {
+			return last, true, nil
+		}
+
+		msg := model.APILogMessage{}
+		if err = json.Unmarshal([]byte(event.data), &msg); err != nil {
+			return last, true, errors.Wrap(err, "problem parsing log message")
+		}
+		if err = handler(msg); err != nil {
+			return last, true, errors.WithStack(err)
+		}
+		if ts, err := time.Parse(time.RFC3339Nano, event.id); err == nil {
+			last = ts
+		}
+	}
+}
+
+type serverSentEvent struct {
+	id   string
+	name string
+	data string
+}
+
+// serverSentEventReader reads the events of a text/event-stream response,
+// skipping comments.
+type serverSentEventReader struct {
+	scanner *bufio.Scanner
+}
+
+func newServerSentEventReader(r io.Reader) *serverSentEventReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxTaskLogEventSize)
+	return &serverSentEventReader{scanner: scanner}
+}
+
+// next returns the next event, or io.EOF if the stream ends cleanly.
+func (r *serverSentEventReader) next() (*serverSentEvent, error) {
+	event := &serverSentEvent{}
+	data := []string{}
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			if len(data) == 0 && event.name == "" {
+				continue
+			}
+			event.data = strings.Join(data, "\n")
+			return event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "id":
+			event.id = value
+		case "event":
+			event.name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "problem reading event stream")
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerSentEventReader(t *testing.T) {
+	reader := newServerSentEventReader(strings.NewReader(
+		": keepalive\n\n" +
+			"id: 1\ndata: one\n\n" +
+			"data: two\ndata: lines\n\n" +
+			"event: end\ndata: {}\n\n" +
+			"id: 2\ndata: incomplete"))
+
+	event, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, &serverSentEvent{id: "1", data: "one"}, event)
+
+	event, err = reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, &serverSentEvent{data: "two\nlines"}, event)
+
+	event, err = reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, &serverSentEvent{name: "end", data: "{}"}, event)
+
+	_, err = reader.next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestStreamTaskLogsReconnectsUntilEnd(t *testing.T) {
+	start := time.Now().UTC().Round(time.Second)
+	writeMessage := func(w io.Writer, n int) {
+		ts := start.Add(time.Duration(n) * time.Second)
+		msg, err := json.Marshal(model.APILogMessage{
+			Message:   model.ToAPIString(strconv.Itoa(n)),
+			Timestamp: model.NewTime(ts),
+		})
+		assert.NoError(t, err)
+		fmt.Fprintf(w, "id: %s\ndata: %s\n\n", ts.Format(time.RFC3339Nano), msg)
+	}
+
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		attempt := len(queries)
+		mu.Unlock()
+
+		assert.Equal(t, "/rest/v2/tasks/t1/logs/stream", r.URL.Path)
+		switch attempt {
+		case 1:
+			// the stream reaches its maximum duration
+			writeMessage(w, 1)
+			writeMessage(w, 2)
+		case 2:
+			writeMessage(w, 3)
+			fmt.Fprintf(w, "event: %s\ndata: {}\n\n", model.TaskLogStreamEndEvent)
+		default:
+			assert.Fail(t, "reconnected after the end event")
+		}
+	}))
+	defer server.Close()
+
+	comm := NewCommunicator(server.URL)
+	defer comm.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msgs := []string{}
+	err := comm.StreamTaskLogs(ctx, TaskLogStreamOptions{TaskID: "t1", Types: []string{"task"}, Follow: true},
+		func(msg model.APILogMessage) error {
+			msgs = append(msgs, model.FromAPIString(msg.Message))
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, msgs)
+
+	require.Len(t, queries, 2)
+	assert.NotContains(t, queries[0], "since")
+	assert.Contains(t, queries[0], "type=task")
+	assert.Contains(t, queries[0], "follow=true")
+	assert.Contains(t, queries[1], "since="+url.QueryEscape(start.Add(2*time.Second).Format(time.RFC3339Nano)))
+}
+
+func TestStreamTaskLogsStopsOnHandlerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "id: 2018-01-01T00:00:00Z\ndata: {\"message\": \"one\", \"timestamp\": null}\n\n")
+	}))
+	defer server.Close()
+
+	comm := NewCommunicator(server.URL)
+	defer comm.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := comm.StreamTaskLogs(ctx, TaskLogStreamOptions{TaskID: "t1"}, func(msg model.APILogMessage) error {
+		return fmt.Errorf("stop")
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stop")
+}
@@ -0,0 +1,306 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/google/go-github/github"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DBCommitQueueConnector is a struct that implements the commit queue related
+// methods from the Connector through interactions with the backing database.
+type DBCommitQueueConnector struct{}
+
+// FindCommitQueueByID returns the commit queue of a project. A project whose
+// queue has never been used has an empty queue.
+func (cc *DBCommitQueueConnector) FindCommitQueueByID(projectID string) (*commitqueue.CommitQueue, error) {
+	if _, err := findCommitQueueProject(projectID); err != nil {
+		return nil, err
+	}
+
+	cq, err := commitqueue.FindOneId(projectID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if cq == nil {
+		cq = &commitqueue.CommitQueue{ProjectID: projectID, Queue: []commitqueue.CommitQueueItem{}}
+	}
+
+	return cq, nil
+}
+
+// EnqueueCommitQueueItem adds a pull request to the commit queue of a project
+// and returns its position in the queue.
+func (cc *DBCommitQueueConnector) EnqueueCommitQueueItem(projectID string, item commitqueue.CommitQueueItem) (int, error) {
+	if _, err := findCommitQueueProject(projectID); err != nil {
+		return -1, err
+	}
+
+	cq, err := commitqueue.FindOneId(projectID)
+	if err != nil {
+		return -1, errors.WithStack(err)
+	}
+	if cq != nil && cq.FindItem(item.PRNumber) >= 0 {
+		return -1, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("pull request #%d is already in the commit queue", item.PRNumber),
+		}
+	}
+
+	return commitqueue.Enqueue(projectID, item)
+}
+
+// RemoveCommitQueueItem removes a pull request from the commit queue of a
+// project, aborting its patch and the patches of the pull requests behind it.
+// It returns false if the pull request was not queued.
+func (cc *DBCommitQueueConnector) RemoveCommitQueueItem(projectID string, prNumber int, caller string) (bool, error) {
+	cq, err := commitqueue.FindOneId(projectID)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if cq == nil {
+		return false, nil
+	}
+	idx := cq.FindItem(prNumber)
+	if idx < 0 {
+		return false, nil
+	}
+	version := cq.Queue[idx].Version
+
+	if _, err = cq.Remove(prNumber); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	cleared, err := cq.ClearVersions(idx)
+	catcher.Add(err)
+	if version != "" {
+		cleared = append(cleared, version)
+	}
+	for _, patchID := range cleared {
+		if !bson.IsObjectIdHex(patchID) {
+			continue
+		}
+		p, err := patch.FindOne(patch.ById(bson.ObjectIdHex(patchID)))
+		if err != nil {
+			catcher.Add(err)
+			continue
+		}
+		if p != nil {
+			catcher.Add(model.CancelPatch(p, caller))
+		}
+	}
+
+	return true, errors.Wrap(catcher.Resolve(), "problem aborting commit queue patches")
+}
+
+// GetGitHubPR returns a pull request of a GitHub repository.
+func (cc *DBCommitQueueConnector) GetGitHubPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	githubOauthToken, err := evergreen.GetEnvironment().Settings().GetGithubOauthToken()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return thirdparty.GetGithubPullRequest(ctx, githubOauthToken, owner, repo, prNumber)
+}
+
+// EnqueuePRFromGithubComment adds the pull request a comment was left on to
+// the commit queue of the project tracking its base branch. The commenter
+// must be a member of the configured GitHub organization, must have linked
+// their GitHub account to an Evergreen user, and must be either an admin of
+// the project or the author of the pull request.
+func (cc *DBCommitQueueConnector) EnqueuePRFromGithubComment(ctx context.Context, event *github.IssueCommentEvent) (int, error) {
+	owner, repo, prNumber, err := verifyIssueCommentEventForEnqueue(event)
+	if err != nil {
+		return -1, err
+	}
+
+	settings := evergreen.GetEnvironment().Settings()
+	githubOauthToken, err := settings.GetGithubOauthToken()
+	if err != nil {
+		return -1, errors.WithStack(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if settings.GithubPRCreatorOrg == "" {
+		return -1, errors.New("Github PR testing not configured correctly; requires a Github org to authenticate against")
+	}
+	isMember, err := thirdparty.GithubUserInOrganization(ctx, githubOauthToken, settings.GithubPRCreatorOrg, event.Sender.GetLogin())
+	if err != nil {
+		return -1, errors.Wrap(err, "problem checking organization membership")
+	}
+	if !isMember {
+		return -1, gimlet.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("'%s' is not a member of '%s'", event.Sender.GetLogin(), settings.GithubPRCreatorOrg),
+		}
+	}
+
+	u, err := user.FindByGithubUID(event.Sender.GetID())
+	if err != nil {
+		return -1, errors.Wrap(err, "problem finding user")
+	}
+	if u == nil {
+		return -1, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("GitHub user '%s' is not linked to an Evergreen user", event.Sender.GetLogin()),
+		}
+	}
+
+	pr, err := thirdparty.GetGithubPullRequest(ctx, githubOauthToken, owner, repo, prNumber)
+	if err != nil {
+		return -1, errors.WithStack(err)
+	}
+	projectRef, err := model.FindOneProjectRefByRepoAndBranchWithPRTesting(owner, repo, pr.GetBase().GetRef())
+	if err != nil {
+		return -1, errors.WithStack(err)
+	}
+	if projectRef == nil {
+		return -1, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("no project tests pull requests against '%s/%s' branch '%s'", owner, repo, pr.GetBase().GetRef()),
+		}
+	}
+
+	isAdmin, err := auth.HasProjectPermission(settings.SuperUsers, u, projectRef, role.EditProject)
+	if err != nil {
+		return -1, errors.WithStack(err)
+	}
+	if !isAdmin && event.Sender.GetID() != pr.GetUser().GetID() {
+		return -1, gimlet.ErrorResponse{
+			StatusCode: http.StatusForbidden,
+			Message: fmt.Sprintf("user '%s' is neither an admin of '%s' nor the author of pull request #%d",
+				u.Id, projectRef.Identifier, prNumber),
+		}
+	}
+
+	return cc.EnqueueCommitQueueItem(projectRef.Identifier, commitqueue.CommitQueueItem{
+		PRNumber:     prNumber,
+		Author:       u.Id,
+		GithubAuthor: pr.GetUser().GetLogin(),
+	})
+}
+
+func findCommitQueueProject(projectID string) (*model.ProjectRef, error) {
+	projectRef, err := model.FindOneProjectRef(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding project '%s'", projectID)
+	}
+	if projectRef == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project with id '%s' not found", projectID),
+		}
+	}
+	if !projectRef.CommitQueue.Enabled {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("commit queue is not enabled for project '%s'", projectID),
+		}
+	}
+
+	return projectRef, nil
+}
+
+func verifyIssueCommentEventForEnqueue(event *github.IssueCommentEvent) (string, string, int, error) {
+	if event.Issue == nil || event.Issue.Number == nil || event.Issue.PullRequestLinks == nil ||
+		event.Repo == nil || event.Repo.Owner == nil || event.Repo.Owner.Login == nil ||
+		event.Repo.Name == nil || event.Sender == nil || event.Sender.Login == nil ||
+		event.Sender.ID == nil {
+		return "", "", 0, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "pull request comment data is malformed",
+		}
+	}
+
+	return *event.Repo.Owner.Login, *event.Repo.Name, *event.Issue.Number, nil
+}
+
+// MockCommitQueueConnector stores a cached set of commit queues that are
+// queried against by the implementations of the Connector interface's commit
+// queue related functions.
+type MockCommitQueueConnector struct {
+	CachedCommitQueues map[string][]commitqueue.CommitQueueItem
+	CachedPRs          map[string]*github.PullRequest
+}
+
+func (mc *MockCommitQueueConnector) FindCommitQueueByID(projectID string) (*commitqueue.CommitQueue, error) {
+	queue, ok := mc.CachedCommitQueues[projectID]
+	if !ok {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project with id '%s' not found", projectID),
+		}
+	}
+
+	return &commitqueue.CommitQueue{ProjectID: projectID, Queue: queue}, nil
+}
+
+func (mc *MockCommitQueueConnector) EnqueueCommitQueueItem(projectID string, item commitqueue.CommitQueueItem) (int, error) {
+	if mc.CachedCommitQueues == nil {
+		mc.CachedCommitQueues = map[string][]commitqueue.CommitQueueItem{}
+	}
+	for _, queued := range mc.CachedCommitQueues[projectID] {
+		if queued.PRNumber == item.PRNumber {
+			return -1, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("pull request #%d is already in the commit queue", item.PRNumber),
+			}
+		}
+	}
+	mc.CachedCommitQueues[projectID] = append(mc.CachedCommitQueues[projectID], item)
+
+	return len(mc.CachedCommitQueues[projectID]) - 1, nil
+}
+
+func (mc *MockCommitQueueConnector) RemoveCommitQueueItem(projectID string, prNumber int, caller string) (bool, error) {
+	queue := mc.CachedCommitQueues[projectID]
+	for i := range queue {
+		if queue[i].PRNumber == prNumber {
+			mc.CachedCommitQueues[projectID] = append(queue[:i], queue[i+1:]...)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (mc *MockCommitQueueConnector) GetGitHubPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	pr, ok := mc.CachedPRs[fmt.Sprintf("%s/%s#%d", owner, repo, prNumber)]
+	if !ok {
+		return nil, errors.Errorf("pull request '%s/%s' #%d not found", owner, repo, prNumber)
+	}
+
+	return pr, nil
+}
+
+func (mc *MockCommitQueueConnector) EnqueuePRFromGithubComment(ctx context.Context, event *github.IssueCommentEvent) (int, error) {
+	owner, repo, prNumber, err := verifyIssueCommentEventForEnqueue(event)
+	if err != nil {
+		return -1, err
+	}
+
+	return mc.EnqueueCommitQueueItem(fmt.Sprintf("%s/%s", owner, repo), commitqueue.CommitQueueItem{
+		PRNumber: prNumber,
+		Author:   *event.Sender.Login,
+	})
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"sort"
+
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+)
+
+// DBFlakyTestConnector is a struct that implements the flaky test related
+// methods from the Connector through interactions with the backing database.
+type DBFlakyTestConnector struct{}
+
+// FindFlakyTests returns the flaky tests of a project with at least the given
+// score, from the flakiest down.
+func (fc *DBFlakyTestConnector) FindFlakyTests(project, variant string, minScore float64, limit int) ([]flakytest.FlakyTest, error) {
+	return flakytest.Find(flakytest.ByProject(project, variant, minScore).Limit(limit))
+}
+
+// MockFlakyTestConnector stores a cached set of flaky tests that are queried
+// against by the implementations of the Connector interface's flaky test
+// related functions.
+type MockFlakyTestConnector struct {
+	CachedFlakyTests []flakytest.FlakyTest
+	StoredError      error
+}
+
+func (mfc *MockFlakyTestConnector) FindFlakyTests(project, variant string, minScore float64, limit int) ([]flakytest.FlakyTest, error) {
+	if mfc.StoredError != nil {
+		return nil, mfc.StoredError
+	}
+
+	tests := []flakytest.FlakyTest{}
+	for _, ft := range mfc.CachedFlakyTests {
+		if ft.Project != project || ft.Score < minScore {
+			continue
+		}
+		if variant != "" && ft.BuildVariant != variant {
+			continue
+		}
+		tests = append(tests, ft)
+	}
+	sort.SliceStable(tests, func(i, j int) bool { return tests[i].Score > tests[j].Score })
+	if limit > 0 && len(tests) > limit {
+		tests = tests[:limit]
+	}
+
+	return tests, nil
+}
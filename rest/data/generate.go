@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/validator"
@@ -16,19 +17,54 @@ type GenerateConnector struct{}
 
 // GenerateTasks parses JSON files for `generate.tasks` and creates the new builds and tasks.
 func (gc *GenerateConnector) GenerateTasks(taskID string, jsonBytes []json.RawMessage) error {
+	g, err := mergeGeneratedProjects(taskID, jsonBytes)
+	if err != nil {
+		return err
+	}
+	p, v, t, pm, err := g.NewVersion()
+	if err != nil {
+		return err
+	}
+	if err = validateGeneratedVersion(p); err != nil {
+		return err
+	}
+	return g.Save(p, v, t, pm)
+}
+
+// GenerateTasksDryRun parses and validates JSON files for `generate.tasks`
+// as GenerateTasks does, and returns the build variants and tasks that
+// would be added to the version without changing it.
+func (gc *GenerateConnector) GenerateTasksDryRun(taskID string, jsonBytes []json.RawMessage) ([]model.GeneratedVariant, error) {
+	g, err := mergeGeneratedProjects(taskID, jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	p, _, _, pm, err := g.NewVersion()
+	if err != nil {
+		return nil, err
+	}
+	if err = validateGeneratedVersion(p); err != nil {
+		return nil, err
+	}
+	return g.Additions(p, pm), nil
+}
+
+func mergeGeneratedProjects(taskID string, jsonBytes []json.RawMessage) (*model.GeneratedProject, error) {
 	projects, err := ParseProjects(jsonBytes)
 	if err != nil {
-		return gimlet.ErrorResponse{
+		return nil, gimlet.ErrorResponse{
 			StatusCode: http.StatusBadRequest,
 			Message:    errors.Wrap(err, "error parsing JSON from `generate.tasks`").Error(),
 		}
 	}
 	g := model.MergeGeneratedProjects(projects)
 	g.TaskID = taskID
-	p, v, t, pm, err := g.NewVersion()
-	if err != nil {
-		return err
-	}
+	return g, nil
+}
+
+// validateGeneratedVersion runs the project validator on a project with
+// generated tasks merged in.
+func validateGeneratedVersion(p *model.Project) error {
 	syntaxErrs, err := validator.CheckProjectSyntax(p)
 	if err != nil {
 		return err
@@ -46,7 +82,7 @@ func (gc *GenerateConnector) GenerateTasks(taskID string, jsonBytes []json.RawMe
 			Message:    fmt.Sprintf("project semantics is invalid: %s", validator.ValidationErrorsToString(semanticErrs)),
 		}
 	}
-	return g.Save(p, v, t, pm)
+	return nil
 }
 
 func ParseProjects(jsonBytes []json.RawMessage) ([]model.GeneratedProject, error) {
@@ -70,3 +106,25 @@ type MockGenerateConnector struct{}
 func (gc *MockGenerateConnector) GenerateTasks(taskID string, jsonBytes []json.RawMessage) error {
 	return nil
 }
+
+// GenerateTasksDryRun returns the build variants and tasks in the generated
+// projects as new, without validating them against a version.
+func (gc *MockGenerateConnector) GenerateTasksDryRun(taskID string, jsonBytes []json.RawMessage) ([]model.GeneratedVariant, error) {
+	g, err := mergeGeneratedProjects(taskID, jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+	variants := []model.GeneratedVariant{}
+	for _, bv := range g.BuildVariants {
+		variant := model.GeneratedVariant{Name: bv.Name, New: true, Tasks: []string{}, DisplayTasks: []string{}}
+		for _, t := range bv.Tasks {
+			variant.Tasks = append(variant.Tasks, t.Name)
+		}
+		for _, dt := range bv.DisplayTasks {
+			variant.DisplayTasks = append(variant.DisplayTasks, dt.Name)
+		}
+		variants = append(variants, variant)
+	}
+	sort.Slice(variants, func(i, j int) bool { return variants[i].Name < variants[j].Name })
+	return variants, nil
+}
@@ -15,6 +15,7 @@ type DBConnector struct {
 	DBDistroConnector
 	DBHostConnector
 	DBTestConnector
+	DBFlakyTestConnector
 	DBMetricsConnector
 	DBBuildConnector
 	DBVersionConnector
@@ -30,6 +31,9 @@ type DBConnector struct {
 	DBSubscriptionConnector
 	NotificationConnector
 	DBCreateHostConnector
+	DBCommitQueueConnector
+	DBTaskLogConnector
+	DBRoleConnector
 }
 
 func (ctx *DBConnector) GetSuperUsers() []string   { return ctx.superUsers }
@@ -50,6 +54,7 @@ type MockConnector struct {
 	MockDistroConnector
 	MockHostConnector
 	MockTestConnector
+	MockFlakyTestConnector
 	MockMetricsConnector
 	MockBuildConnector
 	MockVersionConnector
@@ -65,6 +70,9 @@ type MockConnector struct {
 	MockSubscriptionConnector
 	MockNotificationConnector
 	MockCreateHostConnector
+	MockCommitQueueConnector
+	MockTaskLogConnector
+	MockRoleConnector
 }
 
 func (ctx *MockConnector) GetSuperUsers() []string   { return ctx.superUsers }
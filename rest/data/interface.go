@@ -6,12 +6,17 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/model/user"
@@ -69,10 +74,23 @@ type Connector interface {
 
 	// FindProjects is a method to find projects as ordered by name
 	FindProjects(string, int, int, bool) ([]model.ProjectRef, error)
+	// GetProjectEventLog returns the most recent changes to the settings of
+	// a project made before a given time.
+	GetProjectEventLog(string, time.Time, int) ([]restModel.APIProjectEvent, error)
+	// RevertProjectTo restores the settings of a project to a snapshot from
+	// its event log on behalf of a user.
+	RevertProjectTo(string, string, string) error
+	// FindProjectSecretRefs returns the references to external secrets that
+	// a project's variables hold.
+	FindProjectSecretRefs(string) (map[string]string, error)
+	// UpdateProjectSecretRefs sets and removes references to external
+	// secrets of a project's variables, as made by the given user.
+	UpdateProjectSecretRefs(string, string, map[string]string, []string) error
 	// FindProjectByBranch is a method to find the projectref given a branch name.
 	FindProjectByBranch(string) (*model.ProjectRef, error)
-	// GetVersionsAndVariants returns recent versions for a project
-	GetVersionsAndVariants(int, int, *model.Project) (*restModel.VersionVariantData, error)
+	// GetVersionsAndVariants returns recent versions for a project that were
+	// created by any of the given requesters.
+	GetVersionsAndVariants(int, int, *model.Project, []string) (*restModel.VersionVariantData, error)
 
 	// FindByProjectAndCommit is a method to find a set of tasks which ran as part of
 	// certain version in a project. It takes the projectId, commit hash, and a taskId
@@ -84,6 +102,11 @@ type Connector interface {
 	// limit, and sort to provide additional control over the results.
 	FindTestsByTaskId(string, string, string, int, int) ([]testresult.TestResult, error)
 
+	// FindFlakyTests returns the flaky tests of a project, from the flakiest
+	// down. It takes the project, an optional build variant, the minimum
+	// score, and a limit.
+	FindFlakyTests(string, string, float64, int) ([]flakytest.FlakyTest, error)
+
 	// FindUserById is a method to find a specific user given its ID.
 	FindUserById(string) (gimlet.User, error)
 
@@ -145,6 +168,9 @@ type Connector interface {
 
 	// RestartVersion restarts all completed tasks of a version given its ID and the caller.
 	RestartVersion(string, string) error
+	// GetVersionGraph returns the dependency graph of the tasks of a version
+	// given its ID.
+	GetVersionGraph(string) (*task.DependencyGraph, error)
 	// SetPatchPriority and SetPatchActivated change the status of the input patch
 	SetPatchPriority(string, int64) error
 	SetPatchActivated(string, string, bool) error
@@ -174,6 +200,15 @@ type Connector interface {
 	DeletePublicKey(*user.DBUser, string) error
 	UpdateSettings(*user.DBUser, user.UserSettings) error
 
+	// AddAPIToken creates a named, scoped API token for a user that expires
+	// after the given duration, or never if it is zero, and returns it.
+	AddAPIToken(*user.DBUser, string, string, time.Duration) (string, error)
+	// RevokeAPIToken deletes the named API token of a user.
+	RevokeAPIToken(*user.DBUser, string) error
+	// MarkAPITokenUsed records that the named API token of a user was just
+	// used.
+	MarkAPITokenUsed(*user.DBUser, string) error
+
 	AddPatchIntent(patch.Intent, amboy.Queue) error
 
 	SetHostStatus(*host.Host, string, string) error
@@ -194,16 +229,59 @@ type Connector interface {
 
 	// GenerateTasks parses JSON files for `generate.tasks` and creates the new builds and tasks.
 	GenerateTasks(string, []json.RawMessage) error
+	// GenerateTasksDryRun validates JSON files for `generate.tasks` and returns the
+	// build variants and tasks that would be added, without changing the version.
+	GenerateTasksDryRun(string, []json.RawMessage) ([]model.GeneratedVariant, error)
 
-	// SaveSubscriptions saves a set of notification subscriptions
-	SaveSubscriptions([]event.Subscription) error
+	// SaveSubscriptions saves a set of notification subscriptions on behalf
+	// of a user
+	SaveSubscriptions(string, []event.Subscription) error
 	// GetSubscriptions returns the subscriptions that belong to a user
 	GetSubscriptions(string, event.OwnerType) ([]restModel.APISubscription, error)
 	DeleteSubscription(id string) error
 
 	// Notifications
 	GetNotificationsStats() (*restModel.APIEventStats, error)
+	// GetDeadLetters returns the newest webhook notifications that ran out
+	// of retries, up to the limit.
+	GetDeadLetters(limit int) ([]notification.DeadLetter, error)
+	// ReplayDeadLetter schedules a webhook notification that ran out of
+	// retries to be sent again.
+	ReplayDeadLetter(id string) error
 
 	// ListHostsForTask lists running hosts scoped to the task or the task's build.
 	ListHostsForTask(string) ([]host.Host, error)
+
+	// FindTaskLogMessagesAfter returns the stored log messages of a task
+	// execution logged after the given time, oldest first, optionally
+	// filtered by message type.
+	FindTaskLogMessagesAfter(string, int, time.Time, []string) ([]apimodels.LogMessage, error)
+	// SubscribeTaskLogs starts receiving the log messages of a task
+	// execution as they are sent to this app server.
+	SubscribeTaskLogs(string, int) *model.TaskLogSubscription
+
+	// FindCommitQueueByID returns the commit queue of a project.
+	FindCommitQueueByID(string) (*commitqueue.CommitQueue, error)
+	// EnqueueCommitQueueItem adds a pull request to the commit queue of a
+	// project and returns its position in the queue.
+	EnqueueCommitQueueItem(string, commitqueue.CommitQueueItem) (int, error)
+	// RemoveCommitQueueItem removes a pull request from the commit queue of a
+	// project on behalf of a user, returning false if it was not queued.
+	RemoveCommitQueueItem(string, int, string) (bool, error)
+	// GetGitHubPR returns a pull request of a GitHub repository, given its
+	// owner, name, and pull request number.
+	GetGitHubPR(context.Context, string, string, int) (*github.PullRequest, error)
+	// EnqueuePRFromGithubComment adds the pull request a GitHub comment was
+	// left on to the commit queue of its project.
+	EnqueuePRFromGithubComment(context.Context, *github.IssueCommentEvent) (int, error)
+
+	// FindRoleAssignmentsByProject returns all of the roles assigned on a
+	// project.
+	FindRoleAssignmentsByProject(string) ([]role.Assignment, error)
+	// FindRoleAssignmentsForUser returns the roles assigned on a project to
+	// a user or to any of their groups.
+	FindRoleAssignmentsForUser(string, string, []string) ([]role.Assignment, error)
+	// AssignRole and RemoveRole grant and revoke a role on a project.
+	AssignRole(role.Assignment) error
+	RemoveRole(role.Assignment) error
 }
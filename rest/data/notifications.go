@@ -1,7 +1,9 @@
 package data
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
@@ -44,8 +46,52 @@ func (c *NotificationConnector) GetNotificationsStats() (*restModel.APIEventStat
 	return &stats, nil
 }
 
-type MockNotificationConnector struct{}
+func (c *NotificationConnector) GetDeadLetters(limit int) ([]notification.DeadLetter, error) {
+	return notification.FindDeadLetters(limit)
+}
+
+func (c *NotificationConnector) ReplayDeadLetter(id string) error {
+	letter, err := notification.FindDeadLetter(id)
+	if err != nil {
+		return err
+	}
+	if letter == nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("dead letter for notification '%s' not found", id),
+		}
+	}
+
+	return letter.Replay(time.Now())
+}
+
+type MockNotificationConnector struct {
+	CachedDeadLetters []notification.DeadLetter
+	Replayed          []string
+}
 
 func (c *MockNotificationConnector) GetNotificationsStats() (*restModel.APIEventStats, error) {
 	return nil, errors.New("not implemented")
 }
+
+func (c *MockNotificationConnector) GetDeadLetters(limit int) ([]notification.DeadLetter, error) {
+	if limit > 0 && limit < len(c.CachedDeadLetters) {
+		return c.CachedDeadLetters[:limit], nil
+	}
+	return c.CachedDeadLetters, nil
+}
+
+func (c *MockNotificationConnector) ReplayDeadLetter(id string) error {
+	for i := range c.CachedDeadLetters {
+		if c.CachedDeadLetters[i].ID == id {
+			c.CachedDeadLetters = append(c.CachedDeadLetters[:i], c.CachedDeadLetters[i+1:]...)
+			c.Replayed = append(c.Replayed, id)
+			return nil
+		}
+	}
+
+	return gimlet.ErrorResponse{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("dead letter for notification '%s' not found", id),
+	}
+}
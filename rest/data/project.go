@@ -1,7 +1,13 @@
 package data
 
 import (
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/evergreen-ci/evergreen/model"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
 	"github.com/pkg/errors"
 )
 
@@ -19,11 +25,115 @@ func (pc *DBProjectConnector) FindProjects(key string, limit int, sortDir int, i
 	return projects, nil
 }
 
+// GetProjectEventLog returns the newest n changes to the settings of a
+// project made before the given time.
+func (pc *DBProjectConnector) GetProjectEventLog(projectID string, before time.Time, n int) ([]restModel.APIProjectEvent, error) {
+	events, err := model.FindProjectChangeEvents(projectID, before, n)
+	if err != nil {
+		return nil, err
+	}
+	out := []restModel.APIProjectEvent{}
+	for _, evt := range events {
+		apiEvent := restModel.APIProjectEvent{}
+		if err = apiEvent.BuildFromService(evt); err != nil {
+			return nil, errors.Wrapf(err, "problem converting event '%s' for project '%s'", evt.GUID, projectID)
+		}
+		out = append(out, apiEvent)
+	}
+
+	return out, nil
+}
+
+// RevertProjectTo restores the settings of a project to their state after
+// the change with the given GUID.
+func (pc *DBProjectConnector) RevertProjectTo(projectID, guid, user string) error {
+	change, err := model.FindProjectChangeEvent(projectID, guid)
+	if err != nil {
+		return err
+	}
+	if change == nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("unable to find event '%s' for project '%s'", guid, projectID),
+		}
+	}
+
+	return model.RevertProjectSettings(projectID, guid, user)
+}
+
+// FindProjectSecretRefs returns the references to external secrets that a
+// project's variables hold.
+func (pc *DBProjectConnector) FindProjectSecretRefs(projectID string) (map[string]string, error) {
+	vars, err := model.FindOneProjectVars(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding variables for project '%s'", projectID)
+	}
+	if vars == nil || vars.SecretRefs == nil {
+		return map[string]string{}, nil
+	}
+
+	return vars.SecretRefs, nil
+}
+
+// UpdateProjectSecretRefs sets the given references to external secrets of
+// a project's variables, removes the references of the variables in remove,
+// and logs the change as made by user.
+func (pc *DBProjectConnector) UpdateProjectSecretRefs(projectID, user string, refs map[string]string, remove []string) error {
+	if err := (&model.ProjectVars{SecretRefs: refs}).ValidateSecretRefs(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	before, err := model.GetProjectSettings(projectID)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' not found", projectID),
+		}
+	}
+
+	vars, err := model.FindOneProjectVars(projectID)
+	if err != nil {
+		return errors.Wrapf(err, "problem finding variables for project '%s'", projectID)
+	}
+	if vars == nil {
+		vars = &model.ProjectVars{Id: projectID}
+	}
+	if vars.SecretRefs == nil {
+		vars.SecretRefs = map[string]string{}
+	}
+	for _, name := range remove {
+		delete(vars.SecretRefs, name)
+	}
+	for name, ref := range refs {
+		vars.SecretRefs[name] = ref
+		// variables backed by a secret store keep only their reference
+		delete(vars.Vars, name)
+	}
+	if _, err = vars.Upsert(); err != nil {
+		return errors.Wrapf(err, "problem updating variables for project '%s'", projectID)
+	}
+
+	after, err := model.GetProjectSettings(projectID)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(model.LogProjectModified(projectID, user, before, after),
+		"problem logging change to project '%s'", projectID)
+}
+
 // MockPatchConnector is a struct that implements the Patch related methods
 // from the Connector through interactions with he backing database.
 type MockProjectConnector struct {
 	CachedProjects []model.ProjectRef
 	CachedVars     []*model.ProjectVars
+	CachedEvents   map[string][]restModel.APIProjectEvent
+	Reverted       map[string]string
 }
 
 // FindProjects queries the cached projects slice for the matching projects.
@@ -55,3 +165,86 @@ func (pc *MockProjectConnector) FindProjects(key string, limit int, sortDir int,
 	}
 	return projects, nil
 }
+
+// GetProjectEventLog returns the cached events of a project made before the
+// given time, up to the limit. Assumes the cached events are sorted newest
+// first.
+func (pc *MockProjectConnector) GetProjectEventLog(projectID string, before time.Time, n int) ([]restModel.APIProjectEvent, error) {
+	out := []restModel.APIProjectEvent{}
+	for _, evt := range pc.CachedEvents[projectID] {
+		if !evt.Timestamp.Before(before) {
+			continue
+		}
+		out = append(out, evt)
+		if len(out) == n {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+// RevertProjectTo records the GUID of the event a project was reverted to.
+func (pc *MockProjectConnector) RevertProjectTo(projectID, guid, user string) error {
+	for _, evt := range pc.CachedEvents[projectID] {
+		if restModel.FromAPIString(evt.Guid) == guid {
+			if pc.Reverted == nil {
+				pc.Reverted = map[string]string{}
+			}
+			pc.Reverted[projectID] = guid
+			return nil
+		}
+	}
+
+	return gimlet.ErrorResponse{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("unable to find event '%s' for project '%s'", guid, projectID),
+	}
+}
+
+// FindProjectSecretRefs returns the secret references of the cached
+// variables of a project.
+func (pc *MockProjectConnector) FindProjectSecretRefs(projectID string) (map[string]string, error) {
+	for _, vars := range pc.CachedVars {
+		if vars.Id == projectID && vars.SecretRefs != nil {
+			return vars.SecretRefs, nil
+		}
+	}
+
+	return map[string]string{}, nil
+}
+
+// UpdateProjectSecretRefs updates the secret references of the cached
+// variables of a project.
+func (pc *MockProjectConnector) UpdateProjectSecretRefs(projectID, user string, refs map[string]string, remove []string) error {
+	if err := (&model.ProjectVars{SecretRefs: refs}).ValidateSecretRefs(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	var vars *model.ProjectVars
+	for _, cached := range pc.CachedVars {
+		if cached.Id == projectID {
+			vars = cached
+			break
+		}
+	}
+	if vars == nil {
+		vars = &model.ProjectVars{Id: projectID}
+		pc.CachedVars = append(pc.CachedVars, vars)
+	}
+	if vars.SecretRefs == nil {
+		vars.SecretRefs = map[string]string{}
+	}
+	for _, name := range remove {
+		delete(vars.SecretRefs, name)
+	}
+	for name, ref := range refs {
+		vars.SecretRefs[name] = ref
+		delete(vars.Vars, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package data
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+// DBRoleConnector is a struct that implements the project role related
+// methods from the Connector through interactions with the backing database.
+type DBRoleConnector struct{}
+
+// FindRoleAssignmentsByProject returns all of the roles assigned on a
+// project.
+func (rc *DBRoleConnector) FindRoleAssignmentsByProject(projectID string) ([]role.Assignment, error) {
+	if err := checkRoleProject(projectID); err != nil {
+		return nil, err
+	}
+	return role.FindByProject(projectID)
+}
+
+// FindRoleAssignmentsForUser returns the roles assigned on a project to a
+// user or to any of their groups.
+func (rc *DBRoleConnector) FindRoleAssignmentsForUser(projectID, user string, groups []string) ([]role.Assignment, error) {
+	return role.FindForUser(projectID, user, groups)
+}
+
+// AssignRole saves a role assignment.
+func (rc *DBRoleConnector) AssignRole(assignment role.Assignment) error {
+	if err := checkRoleProject(assignment.ProjectID); err != nil {
+		return err
+	}
+	if err := assignment.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return assignment.Upsert()
+}
+
+// RemoveRole removes a role assignment, if it exists.
+func (rc *DBRoleConnector) RemoveRole(assignment role.Assignment) error {
+	if err := assignment.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return assignment.Remove()
+}
+
+func checkRoleProject(projectID string) error {
+	projectRef, err := model.FindOneProjectRef(projectID)
+	if err != nil {
+		return errors.Wrapf(err, "problem finding project '%s'", projectID)
+	}
+	if projectRef == nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project with id '%s' not found", projectID),
+		}
+	}
+	return nil
+}
+
+// MockRoleConnector stores a cached set of role assignments that are queried
+// against by the implementations of the Connector interface's project role
+// related functions.
+type MockRoleConnector struct {
+	CachedRoleAssignments []role.Assignment
+}
+
+func (mc *MockRoleConnector) FindRoleAssignmentsByProject(projectID string) ([]role.Assignment, error) {
+	out := []role.Assignment{}
+	for _, assignment := range mc.CachedRoleAssignments {
+		if assignment.ProjectID == projectID {
+			out = append(out, assignment)
+		}
+	}
+	return out, nil
+}
+
+func (mc *MockRoleConnector) FindRoleAssignmentsForUser(projectID, user string, groups []string) ([]role.Assignment, error) {
+	out := []role.Assignment{}
+	for _, assignment := range mc.CachedRoleAssignments {
+		if assignment.ProjectID != projectID {
+			continue
+		}
+		if assignment.User == user {
+			out = append(out, assignment)
+			continue
+		}
+		for _, group := range groups {
+			if assignment.Group == group {
+				out = append(out, assignment)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (mc *MockRoleConnector) AssignRole(assignment role.Assignment) error {
+	if err := assignment.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	for _, existing := range mc.CachedRoleAssignments {
+		if existing == assignment {
+			return nil
+		}
+	}
+	mc.CachedRoleAssignments = append(mc.CachedRoleAssignments, assignment)
+	return nil
+}
+
+func (mc *MockRoleConnector) RemoveRole(assignment role.Assignment) error {
+	if err := assignment.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	for i, existing := range mc.CachedRoleAssignments {
+		if existing == assignment {
+			mc.CachedRoleAssignments = append(mc.CachedRoleAssignments[:i], mc.CachedRoleAssignments[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
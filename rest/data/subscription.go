@@ -3,6 +3,7 @@ package data
 import (
 	"net/http"
 
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/gimlet"
@@ -12,11 +13,43 @@ import (
 
 type DBSubscriptionConnector struct{}
 
-func (dc *DBSubscriptionConnector) SaveSubscriptions(subscriptions []event.Subscription) error {
+// SaveSubscriptions saves subscriptions on behalf of a user, and logs the
+// change to the settings of each project whose subscriptions were saved.
+func (dc *DBSubscriptionConnector) SaveSubscriptions(user string, subscriptions []event.Subscription) error {
+	projects := []string{}
+	before := map[string]*model.ProjectSettings{}
+	for _, subscription := range subscriptions {
+		if subscription.OwnerType != event.OwnerTypeProject {
+			continue
+		}
+		if _, ok := before[subscription.Owner]; ok {
+			continue
+		}
+		settings, err := model.GetProjectSettings(subscription.Owner)
+		if err != nil {
+			return errors.Wrapf(err, "problem getting settings of project '%s'", subscription.Owner)
+		}
+		before[subscription.Owner] = settings
+		projects = append(projects, subscription.Owner)
+	}
+
 	catcher := grip.NewSimpleCatcher()
 	for _, subscription := range subscriptions {
 		catcher.Add(subscription.Upsert())
 	}
+
+	for _, projectID := range projects {
+		if before[projectID] == nil {
+			continue
+		}
+		after, err := model.GetProjectSettings(projectID)
+		if err != nil {
+			catcher.Add(errors.Wrapf(err, "problem getting settings of project '%s'", projectID))
+			continue
+		}
+		catcher.Add(model.LogProjectModified(projectID, user, before[projectID], after))
+	}
+
 	return catcher.Resolve()
 }
 
@@ -57,7 +90,7 @@ func (mc *MockSubscriptionConnector) GetSubscriptions(user string, ownerType eve
 	return nil, errors.New("MockSubscriptionConnector unimplemented")
 }
 
-func (mc *MockSubscriptionConnector) SaveSubscriptions(subscriptions []event.Subscription) error {
+func (mc *MockSubscriptionConnector) SaveSubscriptions(user string, subscriptions []event.Subscription) error {
 	return errors.New("MockSubscriptionConnector unimplemented")
 }
 
@@ -2,10 +2,13 @@ package data
 
 import (
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/mgo.v2/bson"
 )
 
@@ -72,3 +75,35 @@ func TestGetSubscriptions(t *testing.T) {
 	assert.EqualError(err, "400 (Bad Request): no subscription owner provided")
 	assert.Len(apiSubs, 0)
 }
+
+func TestSaveProjectSubscriptionsLogsChange(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	require.NoError(db.ClearCollections(event.SubscriptionsCollection, event.AllLogCollection,
+		model.ProjectRefCollection, model.ProjectVarsCollection, model.ProjectAliasCollection))
+	require.NoError((&model.ProjectRef{Identifier: "mci"}).Insert())
+
+	c := &DBSubscriptionConnector{}
+	require.NoError(c.SaveSubscriptions("me", []event.Subscription{
+		{
+			ID:        bson.NewObjectId().Hex(),
+			Owner:     "mci",
+			OwnerType: event.OwnerTypeProject,
+			Type:      event.ResourceTypeVersion,
+			Trigger:   "outcome",
+			Selectors: []event.Selector{{Type: "project", Data: "mci"}},
+			Subscriber: event.Subscriber{
+				Type:   event.EmailSubscriberType,
+				Target: "a@domain.invalid",
+			},
+		},
+	}))
+
+	events, err := model.FindProjectChangeEvents("mci", time.Now().Add(time.Minute), 10)
+	require.NoError(err)
+	require.Len(events, 1)
+	assert.Equal("me", events[0].User)
+	assert.Empty(events[0].Before.Subscriptions)
+	assert.Len(events[0].After.Subscriptions, 1)
+}
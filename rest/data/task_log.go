@@ -0,0 +1,53 @@
+package data
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// DBTaskLogConnector is a struct that implements the task log related methods
+// from the Connector through interactions with the backing database and the
+// app server's stream of incoming task logs.
+type DBTaskLogConnector struct{}
+
+// FindTaskLogMessagesAfter returns the stored log messages of a task
+// execution logged after the given time, oldest first.
+func (tlc *DBTaskLogConnector) FindTaskLogMessagesAfter(taskId string, execution int, ts time.Time, msgTypes []string) ([]apimodels.LogMessage, error) {
+	return model.FindTaskLogMessagesAfter(taskId, execution, ts, msgTypes)
+}
+
+// SubscribeTaskLogs starts receiving the log messages of a task execution as
+// they are sent to this app server.
+func (tlc *DBTaskLogConnector) SubscribeTaskLogs(taskId string, execution int) *model.TaskLogSubscription {
+	return model.SubscribeTaskLogs(taskId, execution)
+}
+
+// MockTaskLogConnector stores a cached set of log messages that are queried
+// against by the implementations of the Connector interface's task log
+// related functions. Subscriptions use the in-memory stream, like the
+// database connector.
+type MockTaskLogConnector struct {
+	CachedLogMessages map[string][]apimodels.LogMessage
+}
+
+func (mtlc *MockTaskLogConnector) FindTaskLogMessagesAfter(taskId string, execution int, ts time.Time, msgTypes []string) ([]apimodels.LogMessage, error) {
+	msgs := []apimodels.LogMessage{}
+	for _, msg := range mtlc.CachedLogMessages[taskId] {
+		if !msg.Timestamp.After(ts) {
+			continue
+		}
+		if len(msgTypes) > 0 && !util.StringSliceContains(msgTypes, msg.Type) {
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+func (mtlc *MockTaskLogConnector) SubscribeTaskLogs(taskId string, execution int) *model.TaskLogSubscription {
+	return model.SubscribeTaskLogs(taskId, execution)
+}
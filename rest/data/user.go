@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/pkg/errors"
 )
@@ -35,6 +36,33 @@ func (u *DBUserConnector) DeletePublicKey(user *user.DBUser, keyName string) err
 	return user.DeletePublicKey(keyName)
 }
 
+func (u *DBUserConnector) AddAPIToken(dbUser *user.DBUser, name, scope string, ttl time.Duration) (string, error) {
+	token, err := dbUser.AddAPIToken(name, scope, ttl)
+	if err != nil {
+		return "", gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	return token, nil
+}
+
+func (u *DBUserConnector) RevokeAPIToken(dbUser *user.DBUser, name string) error {
+	for _, t := range dbUser.APITokens {
+		if t.Name == name {
+			return dbUser.RevokeAPIToken(name)
+		}
+	}
+	return gimlet.ErrorResponse{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("no API token named '%s'", name),
+	}
+}
+
+func (u *DBUserConnector) MarkAPITokenUsed(dbUser *user.DBUser, name string) error {
+	return dbUser.MarkAPITokenUsed(name, time.Now())
+}
+
 func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.UserSettings) error {
 	if strings.HasPrefix(settings.SlackUsername, "#") {
 		return gimlet.ErrorResponse{
@@ -46,13 +74,11 @@ func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.User
 	settings.Notifications.PatchFinishID = dbUser.Settings.Notifications.PatchFinishID
 	settings.Notifications.SpawnHostOutcomeID = dbUser.Settings.Notifications.SpawnHostOutcomeID
 	settings.Notifications.SpawnHostExpirationID = dbUser.Settings.Notifications.SpawnHostExpirationID
+	settings.Notifications.CommitQueueID = dbUser.Settings.Notifications.CommitQueueID
 
-	var patchSubscriber event.Subscriber
-	switch settings.Notifications.PatchFinish {
-	case user.PreferenceSlack:
-		patchSubscriber = event.NewSlackSubscriber(fmt.Sprintf("@%s", settings.SlackUsername))
-	case user.PreferenceEmail:
-		patchSubscriber = event.NewEmailSubscriber(dbUser.Email())
+	patchSubscriber, err := preferenceSubscriber(settings.Notifications.PatchFinish, dbUser, settings)
+	if err != nil {
+		return err
 	}
 	patchSubscription, err := event.CreateOrUpdateImplicitSubscription(event.ImplicitSubscriptionPatchOutcome,
 		dbUser.Settings.Notifications.PatchFinishID, patchSubscriber, dbUser.Id)
@@ -65,12 +91,9 @@ func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.User
 		settings.Notifications.PatchFinishID = ""
 	}
 
-	var buildBreakSubscriber event.Subscriber
-	switch settings.Notifications.BuildBreak {
-	case user.PreferenceSlack:
-		buildBreakSubscriber = event.NewSlackSubscriber(fmt.Sprintf("@%s", settings.SlackUsername))
-	case user.PreferenceEmail:
-		buildBreakSubscriber = event.NewEmailSubscriber(dbUser.Email())
+	buildBreakSubscriber, err := preferenceSubscriber(settings.Notifications.BuildBreak, dbUser, settings)
+	if err != nil {
+		return err
 	}
 	buildBreakSubscription, err := event.CreateOrUpdateImplicitSubscription(event.ImplicitSubscriptionBuildBreak,
 		dbUser.Settings.Notifications.BuildBreakID, buildBreakSubscriber, dbUser.Id)
@@ -83,12 +106,9 @@ func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.User
 		settings.Notifications.BuildBreakID = ""
 	}
 
-	var spawnhostSubscriber event.Subscriber
-	switch settings.Notifications.SpawnHostExpiration {
-	case user.PreferenceSlack:
-		spawnhostSubscriber = event.NewSlackSubscriber(fmt.Sprintf("@%s", settings.SlackUsername))
-	case user.PreferenceEmail:
-		spawnhostSubscriber = event.NewEmailSubscriber(dbUser.Email())
+	spawnhostSubscriber, err := preferenceSubscriber(settings.Notifications.SpawnHostExpiration, dbUser, settings)
+	if err != nil {
+		return err
 	}
 	spawnhostSubscription, err := event.CreateOrUpdateImplicitSubscription(event.ImplicitSubscriptionSpawnhostExpiration,
 		dbUser.Settings.Notifications.SpawnHostExpirationID, spawnhostSubscriber, dbUser.Id)
@@ -101,12 +121,9 @@ func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.User
 		settings.Notifications.SpawnHostExpirationID = ""
 	}
 
-	var spawnHostOutcomeSubscriber event.Subscriber
-	switch settings.Notifications.SpawnHostOutcome {
-	case user.PreferenceSlack:
-		spawnHostOutcomeSubscriber = event.NewSlackSubscriber(fmt.Sprintf("@%s", settings.SlackUsername))
-	case user.PreferenceEmail:
-		spawnHostOutcomeSubscriber = event.NewEmailSubscriber(dbUser.Email())
+	spawnHostOutcomeSubscriber, err := preferenceSubscriber(settings.Notifications.SpawnHostOutcome, dbUser, settings)
+	if err != nil {
+		return err
 	}
 	spawnHostOutcomeSubscription, err := event.CreateOrUpdateImplicitSubscription(event.ImplicitSubscriptionSpawnHostOutcome,
 		dbUser.Settings.Notifications.SpawnHostOutcomeID, spawnHostOutcomeSubscriber, dbUser.Id)
@@ -119,9 +136,51 @@ func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.User
 		settings.Notifications.SpawnHostOutcomeID = ""
 	}
 
+	commitQueueSubscriber, err := preferenceSubscriber(settings.Notifications.CommitQueue, dbUser, settings)
+	if err != nil {
+		return err
+	}
+	commitQueueSubscription, err := event.CreateOrUpdateImplicitSubscription(event.ImplicitSubscriptionCommitQueue,
+		dbUser.Settings.Notifications.CommitQueueID, commitQueueSubscriber, dbUser.Id)
+	if err != nil {
+		return errors.Wrap(err, "failed to create commit queue subscription")
+	}
+	if commitQueueSubscription != nil {
+		settings.Notifications.CommitQueueID = commitQueueSubscription.ID
+	} else {
+		settings.Notifications.CommitQueueID = ""
+	}
+
 	return model.SaveUserSettings(dbUser.Id, settings)
 }
 
+// preferenceSubscriber returns the subscriber for a user's notification
+// preference, which is empty if the user does not want the notification.
+func preferenceSubscriber(preference user.UserSubscriptionPreference, dbUser *user.DBUser, settings user.UserSettings) (event.Subscriber, error) {
+	var subscriber event.Subscriber
+	switch preference {
+	case user.PreferenceSlack:
+		subscriber = event.NewSlackSubscriber(fmt.Sprintf("@%s", settings.SlackUsername))
+	case user.PreferenceEmail:
+		subscriber = event.NewEmailSubscriber(dbUser.Email())
+	case user.PreferenceTeams:
+		subscriber = event.NewTeamsSubscriber(settings.TeamsWebhookURL)
+	case user.PreferenceMattermost:
+		subscriber = event.NewMattermostSubscriber(settings.MattermostWebhookURL)
+	default:
+		return subscriber, nil
+	}
+
+	if err := subscriber.Validate(); err != nil {
+		return subscriber, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("invalid %s notification preference: %s", preference, err.Error()),
+		}
+	}
+
+	return subscriber, nil
+}
+
 // MockUserConnector stores a cached set of users that are queried against by the
 // implementations of the UserConnector interface's functions.
 type MockUserConnector struct {
@@ -178,6 +237,68 @@ func (muc *MockUserConnector) DeletePublicKey(u *user.DBUser, keyName string) er
 	return nil
 }
 
+func (muc *MockUserConnector) AddAPIToken(dbUser *user.DBUser, name, scope string, ttl time.Duration) (string, error) {
+	u, ok := muc.CachedUsers[dbUser.Id]
+	if !ok {
+		return "", errors.Errorf("User '%s' doesn't exist", dbUser.Id)
+	}
+	if !util.StringSliceContains(user.ValidAPITokenScopes, scope) {
+		return "", gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("'%s' is not a valid API token scope", scope),
+		}
+	}
+	for _, t := range u.APITokens {
+		if t.Name == name {
+			return "", gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("User '%s' already has an API token named '%s'", u.Id, name),
+			}
+		}
+	}
+
+	token := user.APITokenPrefix + name
+	apiToken := user.APIToken{
+		Name:      name,
+		Hash:      user.HashAPIToken(token),
+		Scope:     scope,
+		CreatedAt: time.Now(),
+	}
+	if ttl > 0 {
+		apiToken.ExpiresAt = apiToken.CreatedAt.Add(ttl)
+	}
+	u.APITokens = append(u.APITokens, apiToken)
+
+	return token, nil
+}
+
+func (muc *MockUserConnector) RevokeAPIToken(dbUser *user.DBUser, name string) error {
+	u, ok := muc.CachedUsers[dbUser.Id]
+	if !ok {
+		return errors.Errorf("User '%s' doesn't exist", dbUser.Id)
+	}
+	for i, t := range u.APITokens {
+		if t.Name == name {
+			u.APITokens = append(u.APITokens[:i], u.APITokens[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("User '%s' has no API token named '%s'", u.Id, name)
+}
+
+func (muc *MockUserConnector) MarkAPITokenUsed(dbUser *user.DBUser, name string) error {
+	u, ok := muc.CachedUsers[dbUser.Id]
+	if !ok {
+		return errors.Errorf("User '%s' doesn't exist", dbUser.Id)
+	}
+	for i := range u.APITokens {
+		if u.APITokens[i].Name == name {
+			u.APITokens[i].LastUsedAt = time.Now()
+		}
+	}
+	return nil
+}
+
 func (muc *MockUserConnector) UpdateSettings(user *user.DBUser, settings user.UserSettings) error {
 	return errors.New("UpdateSettings not implemented for mock connector")
 }
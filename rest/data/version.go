@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
@@ -87,12 +88,30 @@ func (vc *DBVersionConnector) RestartVersion(versionId string, caller string) er
 	return model.RestartVersion(versionId, taskIds, true, caller)
 }
 
+// GetVersionGraph returns the dependency graph of the tasks of the version
+// with the given ID, using actual durations for finished tasks and expected
+// durations for the rest.
+func (vc *DBVersionConnector) GetVersionGraph(versionId string) (*task.DependencyGraph, error) {
+	tasks, err := task.Find(task.ByVersion(versionId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding tasks for version '%s'", versionId)
+	}
+	if len(tasks) == 0 {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("version with id %s not found", versionId),
+		}
+	}
+	return task.NewDependencyGraph(tasks, task.GraphDuration)
+}
+
 // Fetch versions until 'numVersionElements' elements are created, including
 // elements consisting of multiple versions rolled-up into one.
 // The skip value indicates how many versions back in time should be skipped
 // before starting to fetch versions, the project indicates which project the
-// returned versions should be a part of.
-func (vc *DBVersionConnector) GetVersionsAndVariants(skip, numVersionElements int, project *model.Project) (*restModel.VersionVariantData, error) {
+// returned versions should be a part of, and the requesters which versions
+// should be fetched.
+func (vc *DBVersionConnector) GetVersionsAndVariants(skip, numVersionElements int, project *model.Project, requesters []string) (*restModel.VersionVariantData, error) {
 	// the final array of versions to return
 	finalVersions := []restModel.APIVersions{}
 
@@ -115,7 +134,7 @@ func (vc *DBVersionConnector) GetVersionsAndVariants(skip, numVersionElements in
 
 		// fetch the versions and associated builds
 		versionsFromDB, buildsByVersion, err :=
-			model.FetchVersionsAndAssociatedBuilds(project, skip, numVersionElements)
+			model.FetchVersionsAndAssociatedBuilds(project, skip, numVersionElements, requesters)
 
 		if err != nil {
 			return nil, errors.Wrap(err,
@@ -373,6 +392,27 @@ func (mvc *MockVersionConnector) RestartVersion(versionId string, caller string)
 	return nil
 }
 
-func (mvc *MockVersionConnector) GetVersionsAndVariants(skip, numVersionElements int, project *model.Project) (*restModel.VersionVariantData, error) {
+// GetVersionGraph returns the dependency graph of the cached tasks of the
+// version, finding durations like the database connector does, but with the
+// tasks' cached expected durations.
+func (mvc *MockVersionConnector) GetVersionGraph(versionId string) (*task.DependencyGraph, error) {
+	tasks := []task.Task{}
+	for _, t := range mvc.CachedTasks {
+		if t.Version == versionId {
+			tasks = append(tasks, t)
+		}
+	}
+	if len(tasks) == 0 {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("version with id %s not found", versionId),
+		}
+	}
+	return task.NewDependencyGraph(tasks, task.GraphDurationFunc(func(t *task.Task) time.Duration {
+		return t.ExpectedDuration
+	}))
+}
+
+func (mvc *MockVersionConnector) GetVersionsAndVariants(skip, numVersionElements int, project *model.Project, requesters []string) (*restModel.VersionVariantData, error) {
 	return nil, nil
 }
@@ -372,7 +372,7 @@ func (s *VersionConnectorSuite) TestGetVersionsAndVariants() {
 	}
 	s.NoError(b22.Insert())
 
-	results, err := s.ctx.GetVersionsAndVariants(0, 10, &proj)
+	results, err := s.ctx.GetVersionsAndVariants(0, 10, &proj, evergreen.SystemVersionRequesters)
 	s.NoError(err)
 
 	bv1 := results.Rows["bv1"]
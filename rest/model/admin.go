@@ -29,6 +29,7 @@ func NewConfigModel() *APIAdminSettings {
 		Providers:         &APICloudProviders{},
 		RepoTracker:       &APIRepoTrackerConfig{},
 		Scheduler:         &APISchedulerConfig{},
+		Secrets:           &APISecretsConfig{},
 		ServiceFlags:      &APIServiceFlags{},
 		Slack:             &APISlackConfig{},
 		Splunk:            &APISplunkConnectionInfo{},
@@ -62,6 +63,7 @@ type APIAdminSettings struct {
 	Providers          *APICloudProviders                `json:"providers,omitempty"`
 	RepoTracker        *APIRepoTrackerConfig             `json:"repotracker,omitempty"`
 	Scheduler          *APISchedulerConfig               `json:"scheduler,omitempty"`
+	Secrets            *APISecretsConfig                 `json:"secrets,omitempty"`
 	ServiceFlags       *APIServiceFlags                  `json:"service_flags,omitempty"`
 	Slack              *APISlackConfig                   `json:"slack,omitempty"`
 	Splunk             *APISplunkConnectionInfo          `json:"splunk,omitempty"`
@@ -322,6 +324,8 @@ type APIAuthConfig struct {
 	Crowd  *APICrowdConfig      `json:"crowd"`
 	Naive  *APINaiveAuthConfig  `json:"naive"`
 	Github *APIGithubAuthConfig `json:"github"`
+	LDAP   *APILDAPConfig       `json:"ldap"`
+	OIDC   *APIOIDCConfig       `json:"oidc"`
 }
 
 func (a *APIAuthConfig) BuildFromService(h interface{}) error {
@@ -345,6 +349,18 @@ func (a *APIAuthConfig) BuildFromService(h interface{}) error {
 				return err
 			}
 		}
+		if v.LDAP != nil {
+			a.LDAP = &APILDAPConfig{}
+			if err := a.LDAP.BuildFromService(v.LDAP); err != nil {
+				return err
+			}
+		}
+		if v.OIDC != nil {
+			a.OIDC = &APIOIDCConfig{}
+			if err := a.OIDC.BuildFromService(v.OIDC); err != nil {
+				return err
+			}
+		}
 	default:
 		return errors.Errorf("%T is not a supported type", h)
 	}
@@ -355,6 +371,8 @@ func (a *APIAuthConfig) ToService() (interface{}, error) {
 	var crowd *evergreen.CrowdConfig
 	var naive *evergreen.NaiveAuthConfig
 	var github *evergreen.GithubAuthConfig
+	var ldap *evergreen.LDAPConfig
+	var oidc *evergreen.OIDCConfig
 	i, err := a.Crowd.ToService()
 	if err != nil {
 		return nil, err
@@ -376,10 +394,26 @@ func (a *APIAuthConfig) ToService() (interface{}, error) {
 	if i != nil {
 		github = i.(*evergreen.GithubAuthConfig)
 	}
+	i, err = a.LDAP.ToService()
+	if err != nil {
+		return nil, err
+	}
+	if i != nil {
+		ldap = i.(*evergreen.LDAPConfig)
+	}
+	i, err = a.OIDC.ToService()
+	if err != nil {
+		return nil, err
+	}
+	if i != nil {
+		oidc = i.(*evergreen.OIDCConfig)
+	}
 	return evergreen.AuthConfig{
 		Crowd:  crowd,
 		Naive:  naive,
 		Github: github,
+		LDAP:   ldap,
+		OIDC:   oidc,
 	}, nil
 }
 
@@ -529,6 +563,118 @@ func (a *APIGithubAuthConfig) ToService() (interface{}, error) {
 	return &config, nil
 }
 
+type APILDAPConfig struct {
+	URL                  APIString          `json:"url"`
+	BindDN               APIString          `json:"bind_dn"`
+	BindPassword         APIString          `json:"bind_password"`
+	UserBaseDN           APIString          `json:"user_base_dn"`
+	UserAttribute        APIString          `json:"user_attribute"`
+	GroupBaseDN          APIString          `json:"group_base_dn"`
+	GroupMemberAttribute APIString          `json:"group_member_attribute"`
+	GroupRoles           []APILDAPGroupRole `json:"group_roles"`
+	ExpireAfterMinutes   int                `json:"expire_after_minutes"`
+}
+
+type APILDAPGroupRole struct {
+	Group APIString `json:"group"`
+	Role  APIString `json:"role"`
+}
+
+func (a *APILDAPConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *evergreen.LDAPConfig:
+		if v == nil {
+			return nil
+		}
+		a.URL = ToAPIString(v.URL)
+		a.BindDN = ToAPIString(v.BindDN)
+		a.BindPassword = ToAPIString(v.BindPassword)
+		a.UserBaseDN = ToAPIString(v.UserBaseDN)
+		a.UserAttribute = ToAPIString(v.UserAttribute)
+		a.GroupBaseDN = ToAPIString(v.GroupBaseDN)
+		a.GroupMemberAttribute = ToAPIString(v.GroupMemberAttribute)
+		for _, groupRole := range v.GroupRoles {
+			a.GroupRoles = append(a.GroupRoles, APILDAPGroupRole{
+				Group: ToAPIString(groupRole.Group),
+				Role:  ToAPIString(groupRole.Role),
+			})
+		}
+		a.ExpireAfterMinutes = v.ExpireAfterMinutes
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APILDAPConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	config := evergreen.LDAPConfig{
+		URL:                  FromAPIString(a.URL),
+		BindDN:               FromAPIString(a.BindDN),
+		BindPassword:         FromAPIString(a.BindPassword),
+		UserBaseDN:           FromAPIString(a.UserBaseDN),
+		UserAttribute:        FromAPIString(a.UserAttribute),
+		GroupBaseDN:          FromAPIString(a.GroupBaseDN),
+		GroupMemberAttribute: FromAPIString(a.GroupMemberAttribute),
+		ExpireAfterMinutes:   a.ExpireAfterMinutes,
+	}
+	for _, groupRole := range a.GroupRoles {
+		config.GroupRoles = append(config.GroupRoles, evergreen.LDAPGroupRole{
+			Group: FromAPIString(groupRole.Group),
+			Role:  FromAPIString(groupRole.Role),
+		})
+	}
+	return &config, nil
+}
+
+type APIOIDCConfig struct {
+	Issuer             APIString   `json:"issuer"`
+	ClientID           APIString   `json:"client_id"`
+	ClientSecret       APIString   `json:"client_secret"`
+	Scopes             []APIString `json:"scopes"`
+	UsernameClaim      APIString   `json:"username_claim"`
+	ExpireAfterMinutes int         `json:"expire_after_minutes"`
+}
+
+func (a *APIOIDCConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *evergreen.OIDCConfig:
+		if v == nil {
+			return nil
+		}
+		a.Issuer = ToAPIString(v.Issuer)
+		a.ClientID = ToAPIString(v.ClientID)
+		a.ClientSecret = ToAPIString(v.ClientSecret)
+		for _, scope := range v.Scopes {
+			a.Scopes = append(a.Scopes, ToAPIString(scope))
+		}
+		a.UsernameClaim = ToAPIString(v.UsernameClaim)
+		a.ExpireAfterMinutes = v.ExpireAfterMinutes
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIOIDCConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	config := evergreen.OIDCConfig{
+		Issuer:             FromAPIString(a.Issuer),
+		ClientID:           FromAPIString(a.ClientID),
+		ClientSecret:       FromAPIString(a.ClientSecret),
+		UsernameClaim:      FromAPIString(a.UsernameClaim),
+		ExpireAfterMinutes: a.ExpireAfterMinutes,
+	}
+	for _, scope := range a.Scopes {
+		config.Scopes = append(config.Scopes, FromAPIString(scope))
+	}
+	return &config, nil
+}
+
 // APIBanner is a public structure representing the banner part of the admin settings
 type APIBanner struct {
 	Text  APIString `json:"banner"`
@@ -645,7 +791,8 @@ func (a *APILogBuffering) ToService() (interface{}, error) {
 type APINotifyConfig struct {
 	BufferTargetPerInterval int           `json:"buffer_target_per_interval"`
 	BufferIntervalSeconds   int           `json:"buffer_interval_seconds"`
-	SMTP                    APISMTPConfig `json:"smtp"`
+	SMTP                    APISMTPConfig    `json:"smtp"`
+	Webhook                 APIWebhookConfig `json:"webhook"`
 }
 
 func (a *APINotifyConfig) BuildFromService(h interface{}) error {
@@ -655,6 +802,10 @@ func (a *APINotifyConfig) BuildFromService(h interface{}) error {
 		if err := a.SMTP.BuildFromService(v.SMTP); err != nil {
 			return err
 		}
+		a.Webhook = APIWebhookConfig{}
+		if err := a.Webhook.BuildFromService(v.Webhook); err != nil {
+			return err
+		}
 		a.BufferTargetPerInterval = v.BufferTargetPerInterval
 		a.BufferIntervalSeconds = v.BufferIntervalSeconds
 	default:
@@ -668,19 +819,57 @@ func (a *APINotifyConfig) ToService() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	webhook, err := a.Webhook.ToService()
+	if err != nil {
+		return nil, err
+	}
 	return evergreen.NotifyConfig{
 		BufferTargetPerInterval: a.BufferTargetPerInterval,
 		BufferIntervalSeconds:   a.BufferIntervalSeconds,
-		SMTP: smtp.(evergreen.SMTPConfig),
+		SMTP:                    smtp.(evergreen.SMTPConfig),
+		Webhook:                 webhook.(evergreen.WebhookConfig),
+	}, nil
+}
+
+type APIWebhookConfig struct {
+	MaxAttempts                   int `json:"max_attempts"`
+	MinRetryDelaySeconds          int `json:"min_retry_delay_secs"`
+	MaxRetryDelaySeconds          int `json:"max_retry_delay_secs"`
+	CircuitBreakerThreshold       int `json:"circuit_breaker_threshold"`
+	CircuitBreakerCooldownSeconds int `json:"circuit_breaker_cooldown_secs"`
+}
+
+func (a *APIWebhookConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.WebhookConfig:
+		a.MaxAttempts = v.MaxAttempts
+		a.MinRetryDelaySeconds = v.MinRetryDelaySeconds
+		a.MaxRetryDelaySeconds = v.MaxRetryDelaySeconds
+		a.CircuitBreakerThreshold = v.CircuitBreakerThreshold
+		a.CircuitBreakerCooldownSeconds = v.CircuitBreakerCooldownSeconds
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIWebhookConfig) ToService() (interface{}, error) {
+	return evergreen.WebhookConfig{
+		MaxAttempts:                   a.MaxAttempts,
+		MinRetryDelaySeconds:          a.MinRetryDelaySeconds,
+		MaxRetryDelaySeconds:          a.MaxRetryDelaySeconds,
+		CircuitBreakerThreshold:       a.CircuitBreakerThreshold,
+		CircuitBreakerCooldownSeconds: a.CircuitBreakerCooldownSeconds,
 	}, nil
 }
 
 type APICloudProviders struct {
-	AWS       *APIAWSConfig       `json:"aws"`
-	Docker    *APIDockerConfig    `json:"docker"`
-	GCE       *APIGCEConfig       `json:"gce"`
-	OpenStack *APIOpenStackConfig `json:"openstack"`
-	VSphere   *APIVSphereConfig   `json:"vsphere"`
+	AWS        *APIAWSConfig        `json:"aws"`
+	Docker     *APIDockerConfig     `json:"docker"`
+	GCE        *APIGCEConfig        `json:"gce"`
+	Kubernetes *APIKubernetesConfig `json:"kubernetes"`
+	OpenStack  *APIOpenStackConfig  `json:"openstack"`
+	VSphere    *APIVSphereConfig    `json:"vsphere"`
 }
 
 func (a *APICloudProviders) BuildFromService(h interface{}) error {
@@ -689,6 +878,7 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		a.AWS = &APIAWSConfig{}
 		a.Docker = &APIDockerConfig{}
 		a.GCE = &APIGCEConfig{}
+		a.Kubernetes = &APIKubernetesConfig{}
 		a.OpenStack = &APIOpenStackConfig{}
 		a.VSphere = &APIVSphereConfig{}
 		if err := a.AWS.BuildFromService(v.AWS); err != nil {
@@ -700,6 +890,9 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		if err := a.GCE.BuildFromService(v.GCE); err != nil {
 			return err
 		}
+		if err := a.Kubernetes.BuildFromService(v.Kubernetes); err != nil {
+			return err
+		}
 		if err := a.OpenStack.BuildFromService(v.OpenStack); err != nil {
 			return err
 		}
@@ -725,6 +918,10 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	kubernetes, err := a.Kubernetes.ToService()
+	if err != nil {
+		return nil, err
+	}
 	openstack, err := a.OpenStack.ToService()
 	if err != nil {
 		return nil, err
@@ -734,11 +931,12 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 		return nil, err
 	}
 	return evergreen.CloudProviders{
-		AWS:       aws.(evergreen.AWSConfig),
-		Docker:    docker.(evergreen.DockerConfig),
-		GCE:       gce.(evergreen.GCEConfig),
-		OpenStack: openstack.(evergreen.OpenStackConfig),
-		VSphere:   vsphere.(evergreen.VSphereConfig),
+		AWS:        aws.(evergreen.AWSConfig),
+		Docker:     docker.(evergreen.DockerConfig),
+		GCE:        gce.(evergreen.GCEConfig),
+		Kubernetes: kubernetes.(evergreen.KubernetesConfig),
+		OpenStack:  openstack.(evergreen.OpenStackConfig),
+		VSphere:    vsphere.(evergreen.VSphereConfig),
 	}, nil
 }
 
@@ -920,6 +1118,35 @@ func (a *APIOpenStackConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APIKubernetesConfig struct {
+	APIServer          APIString `json:"api_server"`
+	Token              APIString `json:"token"`
+	CACert             APIString `json:"ca_cert"`
+	InsecureSkipVerify bool      `json:"insecure_skip_verify"`
+}
+
+func (a *APIKubernetesConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.KubernetesConfig:
+		a.APIServer = ToAPIString(v.APIServer)
+		a.Token = ToAPIString(v.Token)
+		a.CACert = ToAPIString(v.CACert)
+		a.InsecureSkipVerify = v.InsecureSkipVerify
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIKubernetesConfig) ToService() (interface{}, error) {
+	return evergreen.KubernetesConfig{
+		APIServer:          FromAPIString(a.APIServer),
+		Token:              FromAPIString(a.Token),
+		CACert:             FromAPIString(a.CACert),
+		InsecureSkipVerify: a.InsecureSkipVerify,
+	}, nil
+}
+
 type APIVSphereConfig struct {
 	Host     APIString `json:"host"`
 	Username APIString `json:"username"`
@@ -998,6 +1225,119 @@ func (a *APISchedulerConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APISecretsConfig struct {
+	HTTPKV  *APIHTTPKVSecretsConfig  `json:"http_kv"`
+	Keyring *APIKeyringSecretsConfig `json:"keyring"`
+}
+
+func (a *APISecretsConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.SecretsConfig:
+		if v.HTTPKV != nil {
+			a.HTTPKV = &APIHTTPKVSecretsConfig{}
+			if err := a.HTTPKV.BuildFromService(v.HTTPKV); err != nil {
+				return err
+			}
+		}
+		if v.Keyring != nil {
+			a.Keyring = &APIKeyringSecretsConfig{}
+			if err := a.Keyring.BuildFromService(v.Keyring); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APISecretsConfig) ToService() (interface{}, error) {
+	var httpKV *evergreen.HTTPKVSecretsConfig
+	var keyring *evergreen.KeyringSecretsConfig
+	i, err := a.HTTPKV.ToService()
+	if err != nil {
+		return nil, err
+	}
+	if i != nil {
+		httpKV = i.(*evergreen.HTTPKVSecretsConfig)
+	}
+	i, err = a.Keyring.ToService()
+	if err != nil {
+		return nil, err
+	}
+	if i != nil {
+		keyring = i.(*evergreen.KeyringSecretsConfig)
+	}
+	return evergreen.SecretsConfig{
+		HTTPKV:  httpKV,
+		Keyring: keyring,
+	}, nil
+}
+
+type APIHTTPKVSecretsConfig struct {
+	URL         APIString `json:"url"`
+	Token       APIString `json:"token"`
+	TokenHeader APIString `json:"token_header"`
+	PathPrefix  APIString `json:"path_prefix"`
+}
+
+func (a *APIHTTPKVSecretsConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *evergreen.HTTPKVSecretsConfig:
+		if v == nil {
+			return nil
+		}
+		a.URL = ToAPIString(v.URL)
+		a.Token = ToAPIString(v.Token)
+		a.TokenHeader = ToAPIString(v.TokenHeader)
+		a.PathPrefix = ToAPIString(v.PathPrefix)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIHTTPKVSecretsConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return &evergreen.HTTPKVSecretsConfig{
+		URL:         FromAPIString(a.URL),
+		Token:       FromAPIString(a.Token),
+		TokenHeader: FromAPIString(a.TokenHeader),
+		PathPrefix:  FromAPIString(a.PathPrefix),
+	}, nil
+}
+
+type APIKeyringSecretsConfig struct {
+	Path APIString `json:"path"`
+	Key  APIString `json:"key"`
+}
+
+func (a *APIKeyringSecretsConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *evergreen.KeyringSecretsConfig:
+		if v == nil {
+			return nil
+		}
+		a.Path = ToAPIString(v.Path)
+		a.Key = ToAPIString(v.Key)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIKeyringSecretsConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return &evergreen.KeyringSecretsConfig{
+		Path: FromAPIString(a.Path),
+		Key:  FromAPIString(a.Key),
+	}, nil
+}
+
 // APIServiceFlags is a public structure representing the admin service flags
 type APIServiceFlags struct {
 	TaskDispatchDisabled         bool `json:"task_dispatch_disabled"`
@@ -1012,14 +1352,18 @@ type APIServiceFlags struct {
 	CLIUpdatesDisabled           bool `json:"cli_updates_disabled"`
 	BackgroundStatsDisabled      bool `json:"background_stats_disabled"`
 	TaskLoggingDisabled          bool `json:"task_logging_disabled"`
+	FlakyTestDetectionDisabled   bool `json:"flaky_test_detection_disabled"`
+	CommitQueueDisabled          bool `json:"commit_queue_disabled"`
 
 	// Notifications Flags
-	EventProcessingDisabled      bool `json:"event_processing_disabled"`
-	JIRANotificationsDisabled    bool `json:"jira_notifications_disabled"`
-	SlackNotificationsDisabled   bool `json:"slack_notifications_disabled"`
-	EmailNotificationsDisabled   bool `json:"email_notifications_disabled"`
-	WebhookNotificationsDisabled bool `json:"webhook_notifications_disabled"`
-	GithubStatusAPIDisabled      bool `json:"github_status_api_disabled"`
+	EventProcessingDisabled         bool `json:"event_processing_disabled"`
+	JIRANotificationsDisabled       bool `json:"jira_notifications_disabled"`
+	SlackNotificationsDisabled      bool `json:"slack_notifications_disabled"`
+	TeamsNotificationsDisabled      bool `json:"teams_notifications_disabled"`
+	MattermostNotificationsDisabled bool `json:"mattermost_notifications_disabled"`
+	EmailNotificationsDisabled      bool `json:"email_notifications_disabled"`
+	WebhookNotificationsDisabled    bool `json:"webhook_notifications_disabled"`
+	GithubStatusAPIDisabled         bool `json:"github_status_api_disabled"`
 }
 
 type APISlackConfig struct {
@@ -1209,11 +1553,15 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 		as.EventProcessingDisabled = v.EventProcessingDisabled
 		as.JIRANotificationsDisabled = v.JIRANotificationsDisabled
 		as.SlackNotificationsDisabled = v.SlackNotificationsDisabled
+		as.TeamsNotificationsDisabled = v.TeamsNotificationsDisabled
+		as.MattermostNotificationsDisabled = v.MattermostNotificationsDisabled
 		as.EmailNotificationsDisabled = v.EmailNotificationsDisabled
 		as.WebhookNotificationsDisabled = v.WebhookNotificationsDisabled
 		as.GithubStatusAPIDisabled = v.GithubStatusAPIDisabled
 		as.BackgroundStatsDisabled = v.BackgroundStatsDisabled
 		as.TaskLoggingDisabled = v.TaskLoggingDisabled
+		as.FlakyTestDetectionDisabled = v.FlakyTestDetectionDisabled
+		as.CommitQueueDisabled = v.CommitQueueDisabled
 	default:
 		return errors.Errorf("%T is not a supported service flags type", h)
 	}
@@ -1223,24 +1571,28 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 // ToService returns a service model from an API model
 func (as *APIServiceFlags) ToService() (interface{}, error) {
 	return evergreen.ServiceFlags{
-		TaskDispatchDisabled:         as.TaskDispatchDisabled,
-		HostinitDisabled:             as.HostinitDisabled,
-		MonitorDisabled:              as.MonitorDisabled,
-		AlertsDisabled:               as.AlertsDisabled,
-		TaskrunnerDisabled:           as.TaskrunnerDisabled,
-		RepotrackerDisabled:          as.RepotrackerDisabled,
-		SchedulerDisabled:            as.SchedulerDisabled,
-		GithubPRTestingDisabled:      as.GithubPRTestingDisabled,
-		RepotrackerPushEventDisabled: as.RepotrackerPushEventDisabled,
-		CLIUpdatesDisabled:           as.CLIUpdatesDisabled,
-		EventProcessingDisabled:      as.EventProcessingDisabled,
-		JIRANotificationsDisabled:    as.JIRANotificationsDisabled,
-		SlackNotificationsDisabled:   as.SlackNotificationsDisabled,
-		EmailNotificationsDisabled:   as.EmailNotificationsDisabled,
-		WebhookNotificationsDisabled: as.WebhookNotificationsDisabled,
-		GithubStatusAPIDisabled:      as.GithubStatusAPIDisabled,
-		BackgroundStatsDisabled:      as.BackgroundStatsDisabled,
-		TaskLoggingDisabled:          as.TaskLoggingDisabled,
+		TaskDispatchDisabled:            as.TaskDispatchDisabled,
+		HostinitDisabled:                as.HostinitDisabled,
+		MonitorDisabled:                 as.MonitorDisabled,
+		AlertsDisabled:                  as.AlertsDisabled,
+		TaskrunnerDisabled:              as.TaskrunnerDisabled,
+		RepotrackerDisabled:             as.RepotrackerDisabled,
+		SchedulerDisabled:               as.SchedulerDisabled,
+		GithubPRTestingDisabled:         as.GithubPRTestingDisabled,
+		RepotrackerPushEventDisabled:    as.RepotrackerPushEventDisabled,
+		CLIUpdatesDisabled:              as.CLIUpdatesDisabled,
+		EventProcessingDisabled:         as.EventProcessingDisabled,
+		JIRANotificationsDisabled:       as.JIRANotificationsDisabled,
+		SlackNotificationsDisabled:      as.SlackNotificationsDisabled,
+		TeamsNotificationsDisabled:      as.TeamsNotificationsDisabled,
+		MattermostNotificationsDisabled: as.MattermostNotificationsDisabled,
+		EmailNotificationsDisabled:      as.EmailNotificationsDisabled,
+		WebhookNotificationsDisabled:    as.WebhookNotificationsDisabled,
+		GithubStatusAPIDisabled:         as.GithubStatusAPIDisabled,
+		BackgroundStatsDisabled:         as.BackgroundStatsDisabled,
+		TaskLoggingDisabled:             as.TaskLoggingDisabled,
+		FlakyTestDetectionDisabled:      as.FlakyTestDetectionDisabled,
+		CommitQueueDisabled:             as.CommitQueueDisabled,
 	}, nil
 }
 
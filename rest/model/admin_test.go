@@ -102,6 +102,8 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Notify.SMTP.From, FromAPIString(apiSettings.Notify.SMTP.From))
 	assert.EqualValues(testSettings.Notify.SMTP.Port, apiSettings.Notify.SMTP.Port)
 	assert.Equal(len(testSettings.Notify.SMTP.AdminEmail), len(apiSettings.Notify.SMTP.AdminEmail))
+	assert.Equal(testSettings.Notify.Webhook.MaxAttempts, apiSettings.Notify.Webhook.MaxAttempts)
+	assert.Equal(testSettings.Notify.Webhook.MinRetryDelaySeconds, apiSettings.Notify.Webhook.MinRetryDelaySeconds)
 	assert.EqualValues(testSettings.Providers.AWS.Id, FromAPIString(apiSettings.Providers.AWS.Id))
 	assert.EqualValues(testSettings.Providers.Docker.APIVersion, FromAPIString(apiSettings.Providers.Docker.APIVersion))
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, FromAPIString(apiSettings.Providers.GCE.ClientEmail))
@@ -140,6 +142,7 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Notify.SMTP.From, dbSettings.Notify.SMTP.From)
 	assert.EqualValues(testSettings.Notify.SMTP.Port, dbSettings.Notify.SMTP.Port)
 	assert.Equal(len(testSettings.Notify.SMTP.AdminEmail), len(dbSettings.Notify.SMTP.AdminEmail))
+	assert.Equal(testSettings.Notify.Webhook, dbSettings.Notify.Webhook)
 	assert.EqualValues(testSettings.Providers.AWS.Id, dbSettings.Providers.AWS.Id)
 	assert.EqualValues(testSettings.Providers.Docker.APIVersion, dbSettings.Providers.Docker.APIVersion)
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, dbSettings.Providers.GCE.ClientEmail)
@@ -12,8 +12,10 @@ import (
 )
 
 var (
-	commitOrigin = "commit"
-	patchOrigin  = "patch"
+	commitOrigin   = "commit"
+	patchOrigin    = "patch"
+	periodicOrigin = "periodic"
+	triggerOrigin  = "trigger"
 )
 
 // APIBuild is the model to be returned by the API whenever builds are fetched.
@@ -75,6 +77,10 @@ func (apiBuild *APIBuild) BuildFromService(h interface{}) error {
 		origin = commitOrigin
 	} else if evergreen.IsPatchRequester(v.Requester) {
 		origin = patchOrigin
+	} else if v.Requester == evergreen.PeriodicBuildRequester {
+		origin = periodicOrigin
+	} else if v.Requester == evergreen.TriggerRequester {
+		origin = triggerOrigin
 	}
 	apiBuild.Origin = ToAPIString(origin)
 	apiBuild.TaskCache = []APITaskCache{}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/pkg/errors"
+)
+
+// APICommitQueue is the model to be returned by the API whenever the commit
+// queue of a project is fetched.
+type APICommitQueue struct {
+	ProjectID APIString            `json:"project_id"`
+	Queue     []APICommitQueueItem `json:"queue"`
+}
+
+// APICommitQueueItem is a pull request in a commit queue.
+type APICommitQueueItem struct {
+	PRNumber     int       `json:"pr_number"`
+	HeadHash     APIString `json:"head_hash"`
+	Author       APIString `json:"author"`
+	GithubAuthor APIString `json:"github_author"`
+	Version      APIString `json:"version"`
+	EnqueueTime  APITime   `json:"enqueue_time"`
+}
+
+// BuildFromService converts from service level structs to an APICommitQueue.
+func (cq *APICommitQueue) BuildFromService(h interface{}) error {
+	var v *commitqueue.CommitQueue
+	switch t := h.(type) {
+	case commitqueue.CommitQueue:
+		v = &t
+	case *commitqueue.CommitQueue:
+		v = t
+	default:
+		return errors.Errorf("%T is not a supported commit queue type", h)
+	}
+
+	cq.ProjectID = ToAPIString(v.ProjectID)
+	cq.Queue = make([]APICommitQueueItem, 0, len(v.Queue))
+	for _, item := range v.Queue {
+		apiItem := APICommitQueueItem{}
+		if err := apiItem.BuildFromService(item); err != nil {
+			return errors.WithStack(err)
+		}
+		cq.Queue = append(cq.Queue, apiItem)
+	}
+
+	return nil
+}
+
+// ToService returns a service layer commit queue using the data from the
+// APICommitQueue.
+func (cq *APICommitQueue) ToService() (interface{}, error) {
+	queue := commitqueue.CommitQueue{
+		ProjectID: FromAPIString(cq.ProjectID),
+		Queue:     make([]commitqueue.CommitQueueItem, 0, len(cq.Queue)),
+	}
+	for _, apiItem := range cq.Queue {
+		item, err := apiItem.ToService()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		queue.Queue = append(queue.Queue, item.(commitqueue.CommitQueueItem))
+	}
+
+	return queue, nil
+}
+
+// BuildFromService converts from service level structs to an
+// APICommitQueueItem.
+func (item *APICommitQueueItem) BuildFromService(h interface{}) error {
+	var v *commitqueue.CommitQueueItem
+	switch t := h.(type) {
+	case commitqueue.CommitQueueItem:
+		v = &t
+	case *commitqueue.CommitQueueItem:
+		v = t
+	default:
+		return errors.Errorf("%T is not a supported commit queue item type", h)
+	}
+
+	item.PRNumber = v.PRNumber
+	item.HeadHash = ToAPIString(v.HeadHash)
+	item.Author = ToAPIString(v.Author)
+	item.GithubAuthor = ToAPIString(v.GithubAuthor)
+	item.Version = ToAPIString(v.Version)
+	item.EnqueueTime = NewTime(v.EnqueueTime)
+
+	return nil
+}
+
+// ToService returns a service layer commit queue item using the data from
+// the APICommitQueueItem.
+func (item *APICommitQueueItem) ToService() (interface{}, error) {
+	return commitqueue.CommitQueueItem{
+		PRNumber:     item.PRNumber,
+		HeadHash:     FromAPIString(item.HeadHash),
+		Author:       FromAPIString(item.Author),
+		GithubAuthor: FromAPIString(item.GithubAuthor),
+		Version:      FromAPIString(item.Version),
+		EnqueueTime:  time.Time(item.EnqueueTime),
+	}, nil
+}
+
+// APICommitQueuePosition is the position of a pull request in a commit queue,
+// returned when it is enqueued.
+type APICommitQueuePosition struct {
+	Position int `json:"position"`
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/pkg/errors"
+)
+
+// APIFlakyTest is the model to be returned by the API whenever flaky tests
+// are fetched.
+type APIFlakyTest struct {
+	Project              APIString `json:"project"`
+	BuildVariant         APIString `json:"build_variant"`
+	TaskName             APIString `json:"task_name"`
+	TestFile             APIString `json:"test_file"`
+	Score                float64   `json:"score"`
+	Runs                 int       `json:"runs"`
+	Failures             int       `json:"failures"`
+	Transitions          int       `json:"transitions"`
+	Restarts             int       `json:"restarts"`
+	Revisions            int       `json:"revisions"`
+	FlippedRevisions     int       `json:"flipped_revisions"`
+	LastFailingTask      APIString `json:"last_failing_task"`
+	LastFailingExecution int       `json:"last_failing_execution"`
+	LastFailingRevision  APIString `json:"last_failing_revision"`
+	WindowStart          APITime   `json:"window_start"`
+	UpdatedAt            APITime   `json:"updated_at"`
+}
+
+// BuildFromService converts from service level structs to an APIFlakyTest.
+func (ft *APIFlakyTest) BuildFromService(h interface{}) error {
+	var v *flakytest.FlakyTest
+	switch t := h.(type) {
+	case flakytest.FlakyTest:
+		v = &t
+	case *flakytest.FlakyTest:
+		v = t
+	default:
+		return errors.Errorf("%T is not a supported flaky test type", h)
+	}
+
+	ft.Project = ToAPIString(v.Project)
+	ft.BuildVariant = ToAPIString(v.BuildVariant)
+	ft.TaskName = ToAPIString(v.TaskName)
+	ft.TestFile = ToAPIString(v.TestFile)
+	ft.Score = v.Score
+	ft.Runs = v.Runs
+	ft.Failures = v.Failures
+	ft.Transitions = v.Transitions
+	ft.Restarts = v.Restarts
+	ft.Revisions = v.Revisions
+	ft.FlippedRevisions = v.FlippedRevisions
+	ft.LastFailingTask = ToAPIString(v.LastFailingTask)
+	ft.LastFailingExecution = v.LastFailingExecution
+	ft.LastFailingRevision = ToAPIString(v.LastFailingRevision)
+	ft.WindowStart = NewTime(v.WindowStart)
+	ft.UpdatedAt = NewTime(v.UpdatedAt)
+
+	return nil
+}
+
+// ToService returns a service layer flaky test using the data from the
+// APIFlakyTest.
+func (ft *APIFlakyTest) ToService() (interface{}, error) {
+	return flakytest.FlakyTest{
+		Project:              FromAPIString(ft.Project),
+		BuildVariant:         FromAPIString(ft.BuildVariant),
+		TaskName:             FromAPIString(ft.TaskName),
+		TestFile:             FromAPIString(ft.TestFile),
+		Score:                ft.Score,
+		Runs:                 ft.Runs,
+		Failures:             ft.Failures,
+		Transitions:          ft.Transitions,
+		Restarts:             ft.Restarts,
+		Revisions:            ft.Revisions,
+		FlippedRevisions:     ft.FlippedRevisions,
+		LastFailingTask:      FromAPIString(ft.LastFailingTask),
+		LastFailingExecution: ft.LastFailingExecution,
+		LastFailingRevision:  FromAPIString(ft.LastFailingRevision),
+		WindowStart:          time.Time(ft.WindowStart),
+		UpdatedAt:            time.Time(ft.UpdatedAt),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/pkg/errors"
+)
+
+// APIGenerateTasksDryRun is the model to be returned by the API for a dry run
+// of `generate.tasks`, listing the build variants and tasks that would be
+// added to the version of the task.
+type APIGenerateTasksDryRun struct {
+	TaskId        APIString             `json:"task_id"`
+	BuildVariants []APIGeneratedVariant `json:"build_variants"`
+}
+
+// APIGeneratedVariant is a build variant that `generate.tasks` would add
+// tasks to.
+type APIGeneratedVariant struct {
+	Name         APIString   `json:"name"`
+	New          bool        `json:"new"`
+	Tasks        []APIString `json:"tasks"`
+	DisplayTasks []APIString `json:"display_tasks"`
+}
+
+// BuildFromService converts from the service level generated variants by
+// loading the data into the appropriate fields of the APIGenerateTasksDryRun.
+func (apiDryRun *APIGenerateTasksDryRun) BuildFromService(h interface{}) error {
+	variants, ok := h.([]model.GeneratedVariant)
+	if !ok {
+		return errors.Errorf("incorrect type %T when converting generate.tasks dry run", h)
+	}
+
+	apiDryRun.BuildVariants = []APIGeneratedVariant{}
+	for _, v := range variants {
+		apiVariant := APIGeneratedVariant{
+			Name:         ToAPIString(v.Name),
+			New:          v.New,
+			Tasks:        []APIString{},
+			DisplayTasks: []APIString{},
+		}
+		for _, t := range v.Tasks {
+			apiVariant.Tasks = append(apiVariant.Tasks, ToAPIString(t))
+		}
+		for _, dt := range v.DisplayTasks {
+			apiVariant.DisplayTasks = append(apiVariant.DisplayTasks, ToAPIString(dt))
+		}
+		apiDryRun.BuildVariants = append(apiDryRun.BuildVariants, apiVariant)
+	}
+	return nil
+}
+
+// ToService is not implemented for APIGenerateTasksDryRun.
+func (apiDryRun *APIGenerateTasksDryRun) ToService() (interface{}, error) {
+	return nil, errors.Errorf("ToService() is not implemented for APIGenerateTasksDryRun")
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/evergreen-ci/evergreen/model/notification"
@@ -33,6 +34,8 @@ type apiNotificationStats struct {
 	EvergreenWebhook  int `json:"evergreen_webhook"`
 	Email             int `json:"email"`
 	Slack             int `json:"slack"`
+	Teams             int `json:"teams"`
+	Mattermost        int `json:"mattermost"`
 }
 
 func (n *apiNotificationStats) BuildFromService(h interface{}) error {
@@ -50,6 +53,8 @@ func (n *apiNotificationStats) BuildFromService(h interface{}) error {
 	n.EvergreenWebhook = data.EvergreenWebhook
 	n.Email = data.Email
 	n.Slack = data.Slack
+	n.Teams = data.Teams
+	n.Mattermost = data.Mattermost
 
 	return nil
 }
@@ -57,3 +62,41 @@ func (n *apiNotificationStats) BuildFromService(h interface{}) error {
 func (n *apiNotificationStats) ToService() (interface{}, error) {
 	return nil, errors.New("(*apiNotificationsStats) ToService not implemented")
 }
+
+// APIDeadLetter is a webhook notification that ran out of retries. The
+// webhook secret is not included.
+type APIDeadLetter struct {
+	NotificationID APIString   `json:"notification_id"`
+	URL            APIString   `json:"url"`
+	Body           APIString   `json:"body"`
+	Headers        http.Header `json:"headers"`
+	Attempts       int         `json:"attempts"`
+	Error          APIString   `json:"error"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+func (n *APIDeadLetter) BuildFromService(h interface{}) error {
+	var letter *notification.DeadLetter
+	switch v := h.(type) {
+	case notification.DeadLetter:
+		letter = &v
+	case *notification.DeadLetter:
+		letter = v
+	default:
+		return errors.Errorf("can't convert %T to APIDeadLetter", h)
+	}
+
+	n.NotificationID = ToAPIString(letter.ID)
+	n.URL = ToAPIString(letter.Payload.URL)
+	n.Body = ToAPIString(string(letter.Payload.Body))
+	n.Headers = letter.Payload.Headers
+	n.Attempts = letter.Attempts
+	n.Error = ToAPIString(letter.Error)
+	n.CreatedAt = letter.CreatedAt
+
+	return nil
+}
+
+func (n *APIDeadLetter) ToService() (interface{}, error) {
+	return nil, errors.New("(*APIDeadLetter) ToService not implemented")
+}
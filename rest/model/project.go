@@ -21,6 +21,7 @@ type APIProject struct {
 	AlertSettings      map[string][]alertConfig `json:"alert_settings"`
 	DeactivatePrevious bool                     `json:"deactivate_previous"`
 	Admins             []APIString              `json:"admins"`
+	DefaultRole        APIString                `json:"default_role"`
 	TracksPushEvents   bool                     `json:"tracks_push_events"`
 	PRTestingEnabled   bool                     `json:"pr_testing_enabled"`
 }
@@ -69,6 +70,7 @@ func (apiProject *APIProject) BuildFromService(p interface{}) error {
 		admins = append(admins, ToAPIString(a))
 	}
 	apiProject.Admins = admins
+	apiProject.DefaultRole = ToAPIString(v.GetDefaultRole())
 
 	return nil
 }
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/pkg/errors"
+)
+
+// APIProjectEvent is a logged change to the settings of a project. Private
+// variables and webhook secrets are redacted in both snapshots.
+type APIProjectEvent struct {
+	Timestamp time.Time          `json:"ts"`
+	User      APIString          `json:"user"`
+	EventType APIString          `json:"event_type"`
+	Before    APIProjectSettings `json:"before"`
+	After     APIProjectSettings `json:"after"`
+	Guid      APIString          `json:"guid"`
+}
+
+type APIProjectSettings struct {
+	ProjectRef    APIProject        `json:"proj_ref"`
+	Vars          APIProjectVars    `json:"vars"`
+	Aliases       []APIAlias        `json:"aliases"`
+	Subscriptions []APISubscription `json:"subscriptions"`
+}
+
+type APIProjectVars struct {
+	Vars        map[string]string `json:"vars"`
+	PrivateVars map[string]bool   `json:"private_vars"`
+	SecretRefs  map[string]string `json:"secret_refs"`
+}
+
+func (e *APIProjectEvent) BuildFromService(h interface{}) error {
+	v, ok := h.(model.ProjectChangeEvent)
+	if !ok {
+		return fmt.Errorf("%T is not the correct event type", h)
+	}
+
+	e.Timestamp = v.Timestamp
+	e.User = ToAPIString(v.User)
+	e.EventType = ToAPIString(v.EventType)
+	e.Guid = ToAPIString(v.GUID)
+	if err := e.Before.BuildFromService(v.Before); err != nil {
+		return errors.Wrap(err, "unable to convert 'before' settings")
+	}
+	if err := e.After.BuildFromService(v.After); err != nil {
+		return errors.Wrap(err, "unable to convert 'after' settings")
+	}
+
+	return nil
+}
+
+func (e *APIProjectEvent) ToService() (interface{}, error) {
+	return nil, errors.New("ToService not implemented for APIProjectEvent")
+}
+
+func (s *APIProjectSettings) BuildFromService(h interface{}) error {
+	v, ok := h.(model.ProjectSettings)
+	if !ok {
+		return fmt.Errorf("%T is not project settings", h)
+	}
+
+	if err := s.ProjectRef.BuildFromService(v.ProjectRef); err != nil {
+		return errors.Wrap(err, "unable to convert project ref")
+	}
+	s.Vars = APIProjectVars{
+		Vars:        v.Vars.Vars,
+		PrivateVars: v.Vars.PrivateVars,
+		SecretRefs:  v.Vars.SecretRefs,
+	}
+
+	s.Aliases = []APIAlias{}
+	for _, alias := range v.Aliases {
+		apiAlias := APIAlias{}
+		if err := apiAlias.BuildFromService(alias); err != nil {
+			return errors.Wrap(err, "unable to convert project alias")
+		}
+		s.Aliases = append(s.Aliases, apiAlias)
+	}
+
+	s.Subscriptions = []APISubscription{}
+	for _, sub := range v.Subscriptions {
+		apiSub := APISubscription{}
+		if err := apiSub.BuildFromService(sub); err != nil {
+			return errors.Wrap(err, "unable to convert project subscription")
+		}
+		s.Subscriptions = append(s.Subscriptions, apiSub)
+	}
+
+	return nil
+}
+
+func (s *APIProjectSettings) ToService() (interface{}, error) {
+	return nil, errors.New("ToService not implemented for APIProjectSettings")
+}
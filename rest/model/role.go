@@ -0,0 +1,81 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/pkg/errors"
+)
+
+// APIRole is a built in role and the permissions that it allows on a project.
+type APIRole struct {
+	Name        APIString   `json:"name"`
+	Description APIString   `json:"description"`
+	Permissions []APIString `json:"permissions"`
+}
+
+// BuildFromService converts from service level structs to an APIRole.
+func (r *APIRole) BuildFromService(h interface{}) error {
+	var v *role.Role
+	switch t := h.(type) {
+	case role.Role:
+		v = &t
+	case *role.Role:
+		v = t
+	default:
+		return errors.Errorf("%T is not a supported role type", h)
+	}
+
+	r.Name = ToAPIString(v.Name)
+	r.Description = ToAPIString(v.Description)
+	r.Permissions = make([]APIString, 0, len(v.Permissions))
+	for _, p := range v.Permissions {
+		r.Permissions = append(r.Permissions, ToAPIString(string(p)))
+	}
+
+	return nil
+}
+
+// ToService is not implemented for APIRole, since roles are built in.
+func (r *APIRole) ToService() (interface{}, error) {
+	return nil, errors.New("ToService() is not implemented for APIRole")
+}
+
+// APIRoleAssignment is a role on a project granted to either a user or a
+// group.
+type APIRoleAssignment struct {
+	ProjectID APIString `json:"project_id"`
+	Role      APIString `json:"role"`
+	User      APIString `json:"user"`
+	Group     APIString `json:"group"`
+}
+
+// BuildFromService converts from service level structs to an
+// APIRoleAssignment.
+func (a *APIRoleAssignment) BuildFromService(h interface{}) error {
+	var v *role.Assignment
+	switch t := h.(type) {
+	case role.Assignment:
+		v = &t
+	case *role.Assignment:
+		v = t
+	default:
+		return errors.Errorf("%T is not a supported role assignment type", h)
+	}
+
+	a.ProjectID = ToAPIString(v.ProjectID)
+	a.Role = ToAPIString(v.Role)
+	a.User = ToAPIString(v.User)
+	a.Group = ToAPIString(v.Group)
+
+	return nil
+}
+
+// ToService returns a service layer role assignment using the data from the
+// APIRoleAssignment.
+func (a *APIRoleAssignment) ToService() (interface{}, error) {
+	return role.Assignment{
+		ProjectID: FromAPIString(a.ProjectID),
+		Role:      FromAPIString(a.Role),
+		User:      FromAPIString(a.User),
+		Group:     FromAPIString(a.Group),
+	}, nil
+}
@@ -60,7 +60,7 @@ func (s *APISubscriber) BuildFromService(h interface{}) error {
 			target = sub
 
 		case event.JIRACommentSubscriberType, event.EmailSubscriberType,
-			event.SlackSubscriberType:
+			event.SlackSubscriberType, event.TeamsSubscriberType, event.MattermostSubscriberType:
 			target = v.Target
 
 		default:
@@ -124,7 +124,7 @@ func (s *APISubscriber) ToService() (interface{}, error) {
 		}
 
 	case event.JIRACommentSubscriberType, event.EmailSubscriberType,
-		event.SlackSubscriberType:
+		event.SlackSubscriberType, event.TeamsSubscriberType, event.MattermostSubscriberType:
 		target = s.Target
 
 	default:
@@ -12,15 +12,50 @@ type APISelector struct {
 }
 
 type APISubscription struct {
-	ID             APIString         `json:"id"`
-	ResourceType   APIString         `json:"resource_type"`
-	Trigger        APIString         `json:"trigger"`
-	Selectors      []APISelector     `json:"selectors"`
-	RegexSelectors []APISelector     `json:"regex_selectors"`
-	Subscriber     APISubscriber     `json:"subscriber"`
-	OwnerType      APIString         `json:"owner_type"`
-	Owner          APIString         `json:"owner"`
-	TriggerData    map[string]string `json:"trigger_data,omitempty"`
+	ID             APIString           `json:"id"`
+	ResourceType   APIString           `json:"resource_type"`
+	Trigger        APIString           `json:"trigger"`
+	Selectors      []APISelector       `json:"selectors"`
+	RegexSelectors []APISelector       `json:"regex_selectors"`
+	Subscriber     APISubscriber       `json:"subscriber"`
+	OwnerType      APIString           `json:"owner_type"`
+	Owner          APIString           `json:"owner"`
+	TriggerData    map[string]string   `json:"trigger_data,omitempty"`
+	Digest         *APIDigestOptions   `json:"digest,omitempty"`
+	Template       *APIMessageTemplate `json:"template,omitempty"`
+}
+
+// APIDigestOptions configure a subscription to deliver hourly or daily
+// digests, or a digest after every count events.
+type APIDigestOptions struct {
+	Frequency APIString `json:"frequency"`
+	Count     int       `json:"count,omitempty"`
+}
+
+// APIMessageTemplate is a Go text/template subject and body that replace the
+// text of the notifications of a subscription.
+type APIMessageTemplate struct {
+	Subject APIString `json:"subject"`
+	Body    APIString `json:"body"`
+}
+
+func (t *APIMessageTemplate) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case event.MessageTemplate:
+		t.Subject = ToAPIString(v.Subject)
+		t.Body = ToAPIString(v.Body)
+	default:
+		return errors.New("unrecognized type for APIMessageTemplate")
+	}
+
+	return nil
+}
+
+func (t *APIMessageTemplate) ToService() (interface{}, error) {
+	return event.MessageTemplate{
+		Subject: FromAPIString(t.Subject),
+		Body:    FromAPIString(t.Body),
+	}, nil
 }
 
 func (s *APISelector) BuildFromService(h interface{}) error {
@@ -51,6 +86,18 @@ func (s *APISubscription) BuildFromService(h interface{}) error {
 		s.Owner = ToAPIString(v.Owner)
 		s.OwnerType = ToAPIString(string(v.OwnerType))
 		s.TriggerData = v.TriggerData
+		if v.Digest != nil {
+			s.Digest = &APIDigestOptions{
+				Frequency: ToAPIString(v.Digest.Frequency),
+				Count:     v.Digest.Count,
+			}
+		}
+		if v.Template != nil {
+			s.Template = &APIMessageTemplate{}
+			if err := s.Template.BuildFromService(*v.Template); err != nil {
+				return err
+			}
+		}
 		err := s.Subscriber.BuildFromService(v.Subscriber)
 		if err != nil {
 			return err
@@ -91,6 +138,18 @@ func (s *APISubscription) ToService() (interface{}, error) {
 		RegexSelectors: []event.Selector{},
 		TriggerData:    s.TriggerData,
 	}
+	if s.Digest != nil {
+		out.Digest = &event.DigestOptions{
+			Frequency: FromAPIString(s.Digest.Frequency),
+			Count:     s.Digest.Count,
+		}
+	}
+	if s.Template != nil {
+		out.Template = &event.MessageTemplate{
+			Subject: FromAPIString(s.Template.Subject),
+			Body:    FromAPIString(s.Template.Body),
+		}
+	}
 	subscriberInterface, err := s.Subscriber.ToService()
 	if err != nil {
 		return nil, err
@@ -45,4 +45,18 @@ func TestSubscriptionModels(t *testing.T) {
 	origSubscription, err := apiSubscription.ToService()
 	assert.NoError(err)
 	assert.EqualValues(subscription, origSubscription)
+
+	subscription.Digest = &event.DigestOptions{
+		Frequency: event.DigestCount,
+		Count:     10,
+	}
+	subscription.Template = &event.MessageTemplate{
+		Subject: "{{ .Object }} {{ .Status }}",
+		Body:    "{{ .URL }}",
+	}
+	apiSubscription = APISubscription{}
+	assert.NoError(apiSubscription.BuildFromService(subscription))
+	origSubscription, err = apiSubscription.ToService()
+	assert.NoError(err)
+	assert.EqualValues(subscription, origSubscription)
 }
@@ -37,6 +37,8 @@ type APITask struct {
 	HostId             APIString        `json:"host_id"`
 	Restarts           int              `json:"restarts"`
 	Execution          int              `json:"execution"`
+	AutoRetries        int              `json:"auto_retries"`
+	RetryAfter         APITime          `json:"retry_after"`
 	Order              int              `json:"order"`
 	Status             APIString        `json:"status"`
 	Details            apiTaskEndDetail `json:"status_details"`
@@ -101,6 +103,8 @@ func (at *APITask) BuildFromService(t interface{}) error {
 			HostId:        ToAPIString(v.HostId),
 			Restarts:      v.Restarts,
 			Execution:     v.Execution,
+			AutoRetries:   v.AutoRetries,
+			RetryAfter:    NewTime(v.RetryAfter),
 			Order:         v.RevisionOrderNumber,
 			Details: apiTaskEndDetail{
 				Status:      ToAPIString(v.Details.Status),
@@ -161,6 +165,8 @@ func (ad *APITask) ToService() (interface{}, error) {
 		HostId:              FromAPIString(ad.HostId),
 		Restarts:            ad.Restarts,
 		Execution:           ad.Execution,
+		AutoRetries:         ad.AutoRetries,
+		RetryAfter:          time.Time(ad.RetryAfter),
 		RevisionOrderNumber: ad.Order,
 		Details: apimodels.TaskEndDetail{
 			Status:      FromAPIString(ad.Details.Status),
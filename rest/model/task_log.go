@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/pkg/errors"
+)
+
+// TaskLogStreamEndEvent is the server-sent event that ends a task log stream
+// because there are no more messages to send, as opposed to the stream
+// reaching its maximum duration, after which the client should reconnect.
+const TaskLogStreamEndEvent = "end"
+
+// APILogMessage is a single line of a task's task, agent or system log.
+type APILogMessage struct {
+	Type      APIString `json:"type"`
+	Severity  APIString `json:"severity"`
+	Message   APIString `json:"message"`
+	Timestamp APITime   `json:"timestamp"`
+	Version   int       `json:"version"`
+}
+
+// BuildFromService converts from a service level log message to an
+// APILogMessage.
+func (m *APILogMessage) BuildFromService(h interface{}) error {
+	var v *apimodels.LogMessage
+	switch t := h.(type) {
+	case apimodels.LogMessage:
+		v = &t
+	case *apimodels.LogMessage:
+		v = t
+	default:
+		return errors.Errorf("%T is not a supported log message type", h)
+	}
+
+	m.Type = ToAPIString(v.Type)
+	m.Severity = ToAPIString(v.Severity)
+	m.Message = ToAPIString(v.Message)
+	m.Timestamp = NewTime(v.Timestamp)
+	m.Version = v.Version
+
+	return nil
+}
+
+// ToService returns a service layer log message using the data from the
+// APILogMessage.
+func (m *APILogMessage) ToService() (interface{}, error) {
+	return apimodels.LogMessage{
+		Type:      FromAPIString(m.Type),
+		Severity:  FromAPIString(m.Severity),
+		Message:   FromAPIString(m.Message),
+		Timestamp: time.Time(m.Timestamp),
+		Version:   m.Version,
+	}, nil
+}
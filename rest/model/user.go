@@ -29,11 +29,45 @@ func (apiPubKey *APIPubKey) ToService() (interface{}, error) {
 	return nil, errors.Errorf("ToService() is not impelemented for APIPubKey")
 }
 
+// APIUserToken is a named, scoped API token of a user. The token itself is
+// only set when it is created.
+type APIUserToken struct {
+	Name       APIString `json:"name"`
+	Scope      APIString `json:"scope"`
+	Token      APIString `json:"token,omitempty"`
+	CreatedAt  APITime   `json:"created_at"`
+	ExpiresAt  APITime   `json:"expires_at"`
+	LastUsedAt APITime   `json:"last_used_at"`
+}
+
+// BuildFromService converts from service level structs to an APIUserToken.
+func (t *APIUserToken) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case user.APIToken:
+		t.Name = ToAPIString(v.Name)
+		t.Scope = ToAPIString(v.Scope)
+		t.CreatedAt = NewTime(v.CreatedAt)
+		t.ExpiresAt = NewTime(v.ExpiresAt)
+		t.LastUsedAt = NewTime(v.LastUsedAt)
+	default:
+		return errors.Errorf("incorrect type when converting API token type")
+	}
+	return nil
+}
+
+// ToService is not implemented for APIUserToken, since tokens can only be
+// created by the service.
+func (t *APIUserToken) ToService() (interface{}, error) {
+	return nil, errors.New("ToService() is not implemented for APIUserToken")
+}
+
 type APIUserSettings struct {
-	Timezone      APIString                   `json:"timezone"`
-	GithubUser    *APIGithubUser              `json:"github_user"`
-	SlackUsername APIString                   `json:"slack_username"`
-	Notifications *APINotificationPreferences `json:"notifications"`
+	Timezone             APIString                   `json:"timezone"`
+	GithubUser           *APIGithubUser              `json:"github_user"`
+	SlackUsername        APIString                   `json:"slack_username"`
+	TeamsWebhookURL      APIString                   `json:"teams_webhook_url"`
+	MattermostWebhookURL APIString                   `json:"mattermost_webhook_url"`
+	Notifications        *APINotificationPreferences `json:"notifications"`
 }
 
 func (s *APIUserSettings) BuildFromService(h interface{}) error {
@@ -41,6 +75,8 @@ func (s *APIUserSettings) BuildFromService(h interface{}) error {
 	case user.UserSettings:
 		s.Timezone = ToAPIString(v.Timezone)
 		s.SlackUsername = ToAPIString(v.SlackUsername)
+		s.TeamsWebhookURL = ToAPIString(v.TeamsWebhookURL)
+		s.MattermostWebhookURL = ToAPIString(v.MattermostWebhookURL)
 		s.GithubUser = &APIGithubUser{}
 		err := s.GithubUser.BuildFromService(v.GithubUser)
 		if err != nil {
@@ -75,10 +111,12 @@ func (s *APIUserSettings) ToService() (interface{}, error) {
 		return nil, errors.New("unable to convert NotificationPreferences")
 	}
 	return user.UserSettings{
-		Timezone:      FromAPIString(s.Timezone),
-		SlackUsername: FromAPIString(s.SlackUsername),
-		GithubUser:    githubUser,
-		Notifications: preferences,
+		Timezone:             FromAPIString(s.Timezone),
+		SlackUsername:        FromAPIString(s.SlackUsername),
+		TeamsWebhookURL:      FromAPIString(s.TeamsWebhookURL),
+		MattermostWebhookURL: FromAPIString(s.MattermostWebhookURL),
+		GithubUser:           githubUser,
+		Notifications:        preferences,
 	}, nil
 }
 
@@ -120,6 +158,8 @@ type APINotificationPreferences struct {
 	SpawnHostExpirationID APIString `json:"spawn_host_expiration_id,omitempty"`
 	SpawnHostOutcome      APIString `json:"spawn_host_outcome"`
 	SpawnHostOutcomeID    APIString `json:"spawn_host_outcome_id,omitempty"`
+	CommitQueue           APIString `json:"commit_queue"`
+	CommitQueueID         APIString `json:"commit_queue_id,omitempty"`
 }
 
 func (n *APINotificationPreferences) BuildFromService(h interface{}) error {
@@ -132,6 +172,7 @@ func (n *APINotificationPreferences) BuildFromService(h interface{}) error {
 		n.PatchFinish = ToAPIString(string(v.PatchFinish))
 		n.SpawnHostOutcome = ToAPIString(string(v.SpawnHostOutcome))
 		n.SpawnHostExpiration = ToAPIString(string(v.SpawnHostExpiration))
+		n.CommitQueue = ToAPIString(string(v.CommitQueue))
 		if v.BuildBreakID != "" {
 			n.BuildBreakID = ToAPIString(v.BuildBreakID)
 		}
@@ -144,6 +185,9 @@ func (n *APINotificationPreferences) BuildFromService(h interface{}) error {
 		if v.SpawnHostExpirationID != "" {
 			n.SpawnHostExpirationID = ToAPIString(v.SpawnHostExpirationID)
 		}
+		if v.CommitQueueID != "" {
+			n.CommitQueueID = ToAPIString(v.CommitQueueID)
+		}
 	default:
 		return errors.Errorf("incorrect type for APINotificationPreferences")
 	}
@@ -158,6 +202,7 @@ func (n *APINotificationPreferences) ToService() (interface{}, error) {
 	patchFinish := FromAPIString(n.PatchFinish)
 	spawnHostExpiration := FromAPIString(n.SpawnHostExpiration)
 	spawnHostOutcome := FromAPIString(n.SpawnHostOutcome)
+	commitQueue := FromAPIString(n.CommitQueue)
 	if !user.IsValidSubscriptionPreference(buildbreak) {
 		return nil, errors.New("Build break preference is not a valid type")
 	}
@@ -170,16 +215,21 @@ func (n *APINotificationPreferences) ToService() (interface{}, error) {
 	if !user.IsValidSubscriptionPreference(spawnHostOutcome) {
 		return nil, errors.New("Spawn Host Outcome preference is not a valid type")
 	}
+	if !user.IsValidSubscriptionPreference(commitQueue) {
+		return nil, errors.New("Commit Queue preference is not a valid type")
+	}
 	preferences := user.NotificationPreferences{
 		BuildBreak:          user.UserSubscriptionPreference(buildbreak),
 		PatchFinish:         user.UserSubscriptionPreference(patchFinish),
 		SpawnHostOutcome:    user.UserSubscriptionPreference(spawnHostOutcome),
 		SpawnHostExpiration: user.UserSubscriptionPreference(spawnHostExpiration),
+		CommitQueue:         user.UserSubscriptionPreference(commitQueue),
 	}
 	preferences.BuildBreakID = FromAPIString(n.BuildBreakID)
 	preferences.PatchFinishID = FromAPIString(n.PatchFinishID)
 	preferences.SpawnHostOutcomeID = FromAPIString(n.PatchFinishID)
 	preferences.SpawnHostExpirationID = FromAPIString(n.SpawnHostExpirationID)
+	preferences.CommitQueueID = FromAPIString(n.CommitQueueID)
 	return preferences, nil
 }
 
@@ -18,6 +18,7 @@ type APIVersion struct {
 	AuthorEmail   APIString     `json:"author_email"`
 	Message       APIString     `json:"message"`
 	Status        APIString     `json:"status"`
+	Requester     APIString     `json:"requester"`
 	Repo          APIString     `json:"repo"`
 	Branch        APIString     `json:"branch"`
 	BuildVariants []buildDetail `json:"build_variants_status"`
@@ -25,6 +26,11 @@ type APIVersion struct {
 	Errors   []APIString `json:"errors"`
 	Warnings []APIString `json:"warnings"`
 	Ignored  bool        `json:"ignored"`
+
+	Skipped []skippedDetail `json:"skipped"`
+
+	Upstream             *upstreamDetail `json:"upstream"`
+	DownstreamVersionIds []APIString     `json:"downstream_version_ids"`
 }
 
 type buildDetail struct {
@@ -32,6 +38,33 @@ type buildDetail struct {
 	BuildId      APIString `json:"build_id"`
 }
 
+// skippedDetail is a build variant, or a task in it, that was not created
+// because none of the version's changed files affect it.
+type skippedDetail struct {
+	BuildVariant APIString `json:"build_variant"`
+	Task         APIString `json:"task,omitempty"`
+	Reason       APIString `json:"reason"`
+}
+
+// upstreamDetail is the version, or build of a version, of another project
+// whose completion triggered the version.
+type upstreamDetail struct {
+	TriggerID    APIString          `json:"trigger_id"`
+	Project      APIString          `json:"project"`
+	VersionId    APIString          `json:"version_id"`
+	Revision     APIString          `json:"revision"`
+	BuildId      APIString          `json:"build_id,omitempty"`
+	BuildVariant APIString          `json:"build_variant,omitempty"`
+	Status       APIString          `json:"status"`
+	Artifacts    []upstreamArtifact `json:"artifacts"`
+}
+
+type upstreamArtifact struct {
+	Task APIString `json:"task"`
+	Name APIString `json:"name"`
+	Link APIString `json:"link"`
+}
+
 // BuildFromService converts from service level structs to an APIVersion.
 func (apiVersion *APIVersion) BuildFromService(h interface{}) error {
 	v, ok := h.(*version.Version)
@@ -48,6 +81,7 @@ func (apiVersion *APIVersion) BuildFromService(h interface{}) error {
 	apiVersion.AuthorEmail = ToAPIString(v.AuthorEmail)
 	apiVersion.Message = ToAPIString(v.Message)
 	apiVersion.Status = ToAPIString(v.Status)
+	apiVersion.Requester = ToAPIString(v.Requester)
 	apiVersion.Repo = ToAPIString(v.Repo)
 	apiVersion.Branch = ToAPIString(v.Branch)
 	apiVersion.Order = v.RevisionOrderNumber
@@ -61,6 +95,41 @@ func (apiVersion *APIVersion) BuildFromService(h interface{}) error {
 		apiVersion.BuildVariants = append(apiVersion.BuildVariants, bd)
 	}
 
+	for _, s := range v.Skipped {
+		sd := skippedDetail{
+			BuildVariant: ToAPIString(s.BuildVariant),
+			Reason:       ToAPIString(s.Reason),
+		}
+		if s.Task != "" {
+			sd.Task = ToAPIString(s.Task)
+		}
+		apiVersion.Skipped = append(apiVersion.Skipped, sd)
+	}
+
+	if v.Upstream != nil {
+		apiVersion.Upstream = &upstreamDetail{
+			TriggerID: ToAPIString(v.Upstream.TriggerID),
+			Project:   ToAPIString(v.Upstream.Project),
+			VersionId: ToAPIString(v.Upstream.VersionId),
+			Revision:  ToAPIString(v.Upstream.Revision),
+			Status:    ToAPIString(v.Upstream.Status),
+		}
+		if v.Upstream.BuildId != "" {
+			apiVersion.Upstream.BuildId = ToAPIString(v.Upstream.BuildId)
+			apiVersion.Upstream.BuildVariant = ToAPIString(v.Upstream.BuildVariant)
+		}
+		for _, a := range v.Upstream.Artifacts {
+			apiVersion.Upstream.Artifacts = append(apiVersion.Upstream.Artifacts, upstreamArtifact{
+				Task: ToAPIString(a.Task),
+				Name: ToAPIString(a.Name),
+				Link: ToAPIString(a.Link),
+			})
+		}
+	}
+	for _, id := range v.DownstreamVersionIds {
+		apiVersion.DownstreamVersionIds = append(apiVersion.DownstreamVersionIds, ToAPIString(id))
+	}
+
 	return nil
 }
 
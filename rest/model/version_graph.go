@@ -0,0 +1,86 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// APIVersionGraph is the model to be returned by the API whenever the task
+// dependency graph of a version is fetched.
+type APIVersionGraph struct {
+	VersionId    APIString             `json:"version_id"`
+	Length       APIDuration           `json:"length_ms"`
+	CriticalPath []APIString           `json:"critical_path"`
+	Tasks        []APIVersionGraphTask `json:"tasks"`
+}
+
+// APIVersionGraphTask is a task in a version's dependency graph. Start and
+// finish times are offsets from the start of the version.
+type APIVersionGraphTask struct {
+	TaskId           APIString       `json:"task_id"`
+	DisplayName      APIString       `json:"display_name"`
+	BuildVariant     APIString       `json:"build_variant"`
+	Status           APIString       `json:"status"`
+	DependsOn        []APIDependency `json:"depends_on"`
+	Duration         APIDuration     `json:"duration_ms"`
+	ExpectedDuration bool            `json:"expected_duration"`
+	EarliestStart    APIDuration     `json:"earliest_start_ms"`
+	EarliestFinish   APIDuration     `json:"earliest_finish_ms"`
+	LatestStart      APIDuration     `json:"latest_start_ms"`
+	LatestFinish     APIDuration     `json:"latest_finish_ms"`
+	Slack            APIDuration     `json:"slack_ms"`
+	Critical         bool            `json:"critical"`
+}
+
+// APIDependency is a dependency of a task on another task finishing with a
+// given status.
+type APIDependency struct {
+	TaskId APIString `json:"task_id"`
+	Status APIString `json:"status"`
+}
+
+// BuildFromService converts from a service level dependency graph by loading
+// the data into the appropriate fields of the APIVersionGraph.
+func (apiGraph *APIVersionGraph) BuildFromService(h interface{}) error {
+	g, ok := h.(*task.DependencyGraph)
+	if !ok {
+		return errors.Errorf("incorrect type %T when converting version graph", h)
+	}
+
+	apiGraph.Length = NewAPIDuration(g.Length)
+	apiGraph.CriticalPath = []APIString{}
+	for _, id := range g.CriticalPath {
+		apiGraph.CriticalPath = append(apiGraph.CriticalPath, ToAPIString(id))
+	}
+	apiGraph.Tasks = []APIVersionGraphTask{}
+	for _, node := range g.Nodes {
+		deps := []APIDependency{}
+		for _, dep := range node.DependsOn {
+			deps = append(deps, APIDependency{
+				TaskId: ToAPIString(dep.TaskId),
+				Status: ToAPIString(dep.Status),
+			})
+		}
+		apiGraph.Tasks = append(apiGraph.Tasks, APIVersionGraphTask{
+			TaskId:           ToAPIString(node.TaskID),
+			DisplayName:      ToAPIString(node.DisplayName),
+			BuildVariant:     ToAPIString(node.BuildVariant),
+			Status:           ToAPIString(node.Status),
+			DependsOn:        deps,
+			Duration:         NewAPIDuration(node.Duration),
+			ExpectedDuration: node.ExpectedDuration,
+			EarliestStart:    NewAPIDuration(node.EarliestStart),
+			EarliestFinish:   NewAPIDuration(node.EarliestFinish),
+			LatestStart:      NewAPIDuration(node.LatestStart),
+			LatestFinish:     NewAPIDuration(node.LatestFinish),
+			Slack:            NewAPIDuration(node.Slack),
+			Critical:         node.Critical,
+		})
+	}
+	return nil
+}
+
+// ToService is not implemented for APIVersionGraph.
+func (apiGraph *APIVersionGraph) ToService() (interface{}, error) {
+	return nil, errors.Errorf("ToService() is not implemented for APIVersionGraph")
+}
@@ -51,6 +51,21 @@ func TestVersionBuildFromService(t *testing.T) {
 		Repo:          repo,
 		Branch:        branch,
 		BuildVariants: buildVariants,
+		Skipped: []version.SkippedUnit{
+			{BuildVariant: "bv3", Reason: "skipped variant"},
+			{BuildVariant: bv1, Task: "lint", Reason: "skipped task"},
+		},
+		Upstream: &version.UpstreamVersion{
+			TriggerID: "lib-green",
+			Project:   "lib",
+			VersionId: "lib_abc",
+			Revision:  "abc",
+			Status:    "success",
+			Artifacts: []version.UpstreamArtifact{
+				{Task: "package", Name: "tarball", Link: "https://example.com/lib.tgz"},
+			},
+		},
+		DownstreamVersionIds: []string{"integration_lib_green_v1"},
 	}
 
 	apiVersion := &APIVersion{}
@@ -75,6 +90,23 @@ func TestVersionBuildFromService(t *testing.T) {
 	assert.Equal(bvs[0].BuildId, ToAPIString(bi1))
 	assert.Equal(bvs[1].BuildVariant, ToAPIString(bv2))
 	assert.Equal(bvs[1].BuildId, ToAPIString(bi2))
+
+	skipped := apiVersion.Skipped
+	assert.Len(skipped, 2)
+	assert.Equal(ToAPIString("bv3"), skipped[0].BuildVariant)
+	assert.Nil(skipped[0].Task)
+	assert.Equal(ToAPIString("skipped variant"), skipped[0].Reason)
+	assert.Equal(ToAPIString("lint"), skipped[1].Task)
+
+	upstream := apiVersion.Upstream
+	assert.NotNil(upstream)
+	assert.Equal(ToAPIString("lib-green"), upstream.TriggerID)
+	assert.Equal(ToAPIString("lib_abc"), upstream.VersionId)
+	assert.Equal(ToAPIString("abc"), upstream.Revision)
+	assert.Nil(upstream.BuildId)
+	assert.Len(upstream.Artifacts, 1)
+	assert.Equal(ToAPIString("https://example.com/lib.tgz"), upstream.Artifacts[0].Link)
+	assert.Equal([]APIString{ToAPIString("integration_lib_green_v1")}, apiVersion.DownstreamVersionIds)
 }
 
 func TestVersionToService(t *testing.T) {
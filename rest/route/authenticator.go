@@ -2,13 +2,21 @@ package route
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest/data"
-	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
 )
 
 // Authenticator is an interface which defines how requests can authenticate
@@ -55,17 +63,17 @@ func (s *SuperUserAuthenticator) Authenticate(ctx context.Context, sc data.Conne
 // available and that the user also be set.
 type ProjectAdminAuthenticator struct{}
 
-// ProjectAdminAuthenticator checks that the user is either a super user or is
-// part of the project context's project admins.
+// ProjectAdminAuthenticator checks that the user is either a super user or
+// has a role on the project context's project that allows editing it, such
+// as being one of the project's admins.
 func (p *ProjectAdminAuthenticator) Authenticate(ctx context.Context, sc data.Connector) error {
 	projCtx := MustHaveProjectContext(ctx)
-	u := gimlet.GetUser(ctx)
-	if u != nil {
-		// If either a superuser or admin, request is allowed to proceed.
-		if auth.IsSuperUser(sc.GetSuperUsers(), u) ||
-			util.StringSliceContains(projCtx.ProjectRef.Admins, u.Username()) {
-			return nil
-		}
+	ok, err := hasProjectPermission(sc, gimlet.GetUser(ctx), projCtx.ProjectRef, role.EditProject)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
 	}
 
 	return gimlet.ErrorResponse{
@@ -74,6 +82,61 @@ func (p *ProjectAdminAuthenticator) Authenticate(ctx context.Context, sc data.Co
 	}
 }
 
+// ProjectPermissionAuthenticator only allows users with a role on the project
+// context's project that allows the permission, and superusers. It requires
+// that the project context be prefetched.
+type ProjectPermissionAuthenticator struct {
+	Permission role.Permission
+}
+
+// Authenticate checks that the user has the authenticator's permission on
+// the project context's project.
+func (p *ProjectPermissionAuthenticator) Authenticate(ctx context.Context, sc data.Connector) error {
+	return checkProjectPermission(sc, gimlet.GetUser(ctx), GetProjectContext(ctx), p.Permission)
+}
+
+// checkProjectPermission returns an error response if the user does not have
+// the permission on the project of the project context.
+func checkProjectPermission(sc data.Connector, u gimlet.User, projCtx *model.Context, permission role.Permission) error {
+	if u == nil || projCtx == nil || projCtx.ProjectRef == nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    "Not found",
+		}
+	}
+
+	ok, err := hasProjectPermission(sc, u, projCtx.ProjectRef, permission)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusForbidden,
+			Message: fmt.Sprintf("user '%s' does not have the '%s' permission on project '%s'",
+				u.Username(), permission, projCtx.ProjectRef.Identifier),
+		}
+	}
+	return nil
+}
+
+// hasProjectPermission returns true if the user is a superuser or has a role
+// on the project that allows the permission.
+func hasProjectPermission(sc data.Connector, u gimlet.User, projectRef *model.ProjectRef, permission role.Permission) (bool, error) {
+	if u == nil || projectRef == nil {
+		return false, nil
+	}
+	if auth.IsSuperUser(sc.GetSuperUsers(), u) {
+		return true, nil
+	}
+
+	assignments, err := sc.FindRoleAssignmentsForUser(projectRef.Identifier, u.Username(), u.Roles())
+	if err != nil {
+		return false, errors.Wrapf(err, "problem finding roles of user '%s'", u.Username())
+	}
+
+	return role.AnyHas(auth.ProjectRoles(u, projectRef, assignments), permission), nil
+}
+
 // RequireUserAuthenticator requires that a user be attached to a request.
 type RequireUserAuthenticator struct{}
 
@@ -98,3 +161,117 @@ func validPriority(priority int64, user gimlet.User, sc data.Connector) bool {
 	}
 	return true
 }
+
+// apiTokenPathPrefixes are the paths of the API routes. API tokens are not
+// accepted anywhere else, so that they can't be used to load UI pages, such
+// as the settings page that shows the user's API key.
+var apiTokenPathPrefixes = []string{
+	"/api/",
+	"/rest/v2/",
+}
+
+// apiTokenScopePrefixes are the paths that an API token with each scope may
+// make requests that change things to. Every token may make read only
+// requests to any API path.
+var apiTokenScopePrefixes = map[string][]string{
+	user.APITokenScopeRead: {},
+	user.APITokenScopePatch: {
+		"/api/patches",
+		"/api/validate",
+		"/rest/v2/patches",
+		"/api/rest/v2/patches",
+	},
+	user.APITokenScopeHosts: {
+		"/api/spawn",
+		"/rest/v2/hosts",
+		"/api/rest/v2/hosts",
+	},
+}
+
+// apiTokenAllows returns true if an API token with the given scope may make
+// the request.
+func apiTokenAllows(scope string, r *http.Request) bool {
+	isAPI := false
+	for _, prefix := range apiTokenPathPrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			isAPI = true
+			break
+		}
+	}
+	if !isAPI {
+		return false
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	for _, prefix := range apiTokenScopePrefixes[scope] {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type apiTokenMiddleware struct {
+	sc data.Connector
+}
+
+// NewAPITokenMiddleware authenticates requests that carry one of a user's
+// API tokens in place of their API key, and refuses the ones that the
+// token's scope does not allow. Tokens are only accepted on API routes, and
+// the user attached to the request has no API key, so a token can never be
+// exchanged for the key. It must run before the gimlet user middleware,
+// which only knows about API keys. Other requests pass through unchanged.
+func NewAPITokenMiddleware(sc data.Connector) gimlet.Middleware {
+	return &apiTokenMiddleware{
+		sc: sc,
+	}
+}
+
+func (m *apiTokenMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	token := r.Header.Get(evergreen.APIKeyHeader)
+	if !user.IsAPIToken(token) {
+		next(rw, r)
+		return
+	}
+	// the user middleware would otherwise compare the token to the user's
+	// API key and refuse the request
+	r.Header.Del(evergreen.APIKeyHeader)
+
+	userID := r.Header.Get(evergreen.APIUserHeader)
+	u, err := m.sc.FindUserById(userID)
+	if err != nil {
+		gimlet.WriteResponse(rw, gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "problem finding user '%s'", userID)))
+		return
+	}
+	dbUser, ok := u.(*user.DBUser)
+	if !ok || dbUser == nil {
+		gimlet.WriteTextResponse(rw, http.StatusUnauthorized, "invalid API token")
+		return
+	}
+
+	apiToken := dbUser.GetAPIToken(token)
+	if apiToken == nil || apiToken.IsExpired(time.Now()) {
+		gimlet.WriteTextResponse(rw, http.StatusUnauthorized, "invalid API token")
+		return
+	}
+	if !apiTokenAllows(apiToken.Scope, r) {
+		gimlet.WriteTextResponse(rw, http.StatusForbidden,
+			fmt.Sprintf("API token '%s' with scope '%s' may not %s %s", apiToken.Name, apiToken.Scope, r.Method, r.URL.Path))
+		return
+	}
+
+	grip.Warning(message.WrapError(m.sc.MarkAPITokenUsed(dbUser, apiToken.Name), message.Fields{
+		"message": "problem recording use of API token",
+		"user":    dbUser.Id,
+		"token":   apiToken.Name,
+	}))
+
+	// the API key is not limited by the token's scope or expiration, so
+	// nothing serving the request may see it
+	tokenUser := *dbUser
+	tokenUser.APIKey = ""
+	next(rw, r.WithContext(gimlet.AttachUser(r.Context(), &tokenUser)))
+}
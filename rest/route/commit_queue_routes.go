@@ -0,0 +1,246 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	dbModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for the commit queue of a project
+//
+//    /commit_queue/{project_id}
+
+type commitQueueGetHandler struct {
+	projectID string
+	sc        data.Connector
+}
+
+func makeGetCommitQueueItems(sc data.Connector) gimlet.RouteHandler {
+	return &commitQueueGetHandler{
+		sc: sc,
+	}
+}
+
+func (h *commitQueueGetHandler) Factory() gimlet.RouteHandler {
+	return &commitQueueGetHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *commitQueueGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectID = gimlet.GetVars(r)["project_id"]
+	return nil
+}
+
+func (h *commitQueueGetHandler) Run(ctx context.Context) gimlet.Responder {
+	cq, err := h.sc.FindCommitQueueByID(h.projectID)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't get commit queue"))
+	}
+
+	apiCommitQueue := &model.APICommitQueue{}
+	if err = apiCommitQueue.BuildFromService(cq); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+	}
+
+	return gimlet.NewJSONResponse(apiCommitQueue)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for adding a pull request to the commit queue of a project
+//
+//    /commit_queue/{project_id}/{item}
+
+type commitQueueEnqueueItemHandler struct {
+	projectID string
+	prNumber  int
+	sc        data.Connector
+}
+
+func makeCommitQueueEnqueueItem(sc data.Connector) gimlet.RouteHandler {
+	return &commitQueueEnqueueItemHandler{
+		sc: sc,
+	}
+}
+
+func (h *commitQueueEnqueueItemHandler) Factory() gimlet.RouteHandler {
+	return &commitQueueEnqueueItemHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *commitQueueEnqueueItemHandler) Parse(ctx context.Context, r *http.Request) error {
+	vars := gimlet.GetVars(r)
+	h.projectID = vars["project_id"]
+
+	var err error
+	h.prNumber, err = parsePRNumber(vars["item"])
+	return err
+}
+
+func (h *commitQueueEnqueueItemHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+
+	projectRef, err := findCommitQueueProjectRef(h.sc, h.projectID)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	pr, err := h.sc.GetGitHubPR(ctx, projectRef.Owner, projectRef.Repo, h.prNumber)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't get pull request"))
+	}
+	base := pr.GetBase()
+	if !strings.EqualFold(base.GetRepo().GetOwner().GetLogin(), projectRef.Owner) ||
+		!strings.EqualFold(base.GetRepo().GetName(), projectRef.Repo) ||
+		base.GetRef() != projectRef.Branch {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: fmt.Sprintf("pull request #%d is not against '%s/%s' branch '%s'",
+				h.prNumber, projectRef.Owner, projectRef.Repo, projectRef.Branch),
+		})
+	}
+
+	isAdmin, err := hasProjectPermission(h.sc, u, projectRef, role.EditProject)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	if !isAdmin && !isPRAuthor(u, pr) {
+		return gimlet.MakeJSONErrorResponder(commitQueueForbidden(u, h.prNumber, h.projectID))
+	}
+
+	position, err := h.sc.EnqueueCommitQueueItem(h.projectID, commitqueue.CommitQueueItem{
+		PRNumber:     h.prNumber,
+		Author:       u.Username(),
+		GithubAuthor: pr.GetUser().GetLogin(),
+	})
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't enqueue item"))
+	}
+
+	return gimlet.NewJSONResponse(model.APICommitQueuePosition{Position: position})
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for removing a pull request from the commit queue of a project
+//
+//    /commit_queue/{project_id}/{item}
+
+type commitQueueDeleteItemHandler struct {
+	projectID string
+	prNumber  int
+	sc        data.Connector
+}
+
+func makeDeleteCommitQueueItems(sc data.Connector) gimlet.RouteHandler {
+	return &commitQueueDeleteItemHandler{
+		sc: sc,
+	}
+}
+
+func (h *commitQueueDeleteItemHandler) Factory() gimlet.RouteHandler {
+	return &commitQueueDeleteItemHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *commitQueueDeleteItemHandler) Parse(ctx context.Context, r *http.Request) error {
+	vars := gimlet.GetVars(r)
+	h.projectID = vars["project_id"]
+
+	var err error
+	h.prNumber, err = parsePRNumber(vars["item"])
+	return err
+}
+
+func (h *commitQueueDeleteItemHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+
+	projectRef, err := findCommitQueueProjectRef(h.sc, h.projectID)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	isAdmin, err := hasProjectPermission(h.sc, u, projectRef, role.EditProject)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	if !isAdmin {
+		pr, err := h.sc.GetGitHubPR(ctx, projectRef.Owner, projectRef.Repo, h.prNumber)
+		if err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't get pull request"))
+		}
+		if !isPRAuthor(u, pr) {
+			return gimlet.MakeJSONErrorResponder(commitQueueForbidden(u, h.prNumber, h.projectID))
+		}
+	}
+
+	found, err := h.sc.RemoveCommitQueueItem(h.projectID, h.prNumber, u.Username())
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't delete item"))
+	}
+	if !found {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("pull request #%d not found in the commit queue of '%s'", h.prNumber, h.projectID),
+		})
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
+
+func parsePRNumber(item string) (int, error) {
+	prNumber, err := strconv.Atoi(item)
+	if err != nil || prNumber <= 0 {
+		return 0, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("'%s' is not a valid pull request number", item),
+		}
+	}
+
+	return prNumber, nil
+}
+
+func findCommitQueueProjectRef(sc data.Connector, projectID string) (*dbModel.ProjectRef, error) {
+	projectRef, err := sc.FindProjectByBranch(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem finding project '%s'", projectID)
+	}
+	if projectRef == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project with id '%s' not found", projectID),
+		}
+	}
+
+	return projectRef, nil
+}
+
+// isPRAuthor returns true if the user's linked GitHub account opened the pull
+// request.
+func isPRAuthor(u *user.DBUser, pr *github.PullRequest) bool {
+	uid := u.Settings.GithubUser.UID
+	return uid != 0 && uid == pr.GetUser().GetID()
+}
+
+func commitQueueForbidden(u *user.DBUser, prNumber int, projectID string) error {
+	return gimlet.ErrorResponse{
+		StatusCode: http.StatusForbidden,
+		Message: fmt.Sprintf("user '%s' is neither an admin of '%s' nor the author of pull request #%d",
+			u.Username(), projectID, prNumber),
+	}
+}
@@ -0,0 +1,152 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	dbModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/google/go-github/github"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommitQueueSuite struct {
+	sc  *data.MockConnector
+	ctx context.Context
+	suite.Suite
+}
+
+func TestCommitQueueSuite(t *testing.T) {
+	suite.Run(t, new(CommitQueueSuite))
+}
+
+func (s *CommitQueueSuite) SetupTest() {
+	s.sc = &data.MockConnector{
+		MockCommitQueueConnector: data.MockCommitQueueConnector{
+			CachedCommitQueues: map[string][]commitqueue.CommitQueueItem{
+				"mci": {
+					{PRNumber: 1, Author: "octocat"},
+					{PRNumber: 2, Author: "octodog"},
+				},
+			},
+			CachedPRs: map[string]*github.PullRequest{
+				"evergreen-ci/evergreen#1": makeTestPR(1, 1234, "master"),
+				"evergreen-ci/evergreen#2": makeTestPR(2, 5678, "master"),
+				"evergreen-ci/evergreen#3": makeTestPR(3, 1234, "master"),
+				"evergreen-ci/evergreen#4": makeTestPR(4, 5678, "master"),
+				"evergreen-ci/evergreen#5": makeTestPR(5, 1234, "release"),
+			},
+		},
+		MockBuildConnector: data.MockBuildConnector{
+			CachedProjects: map[string]*dbModel.ProjectRef{
+				"mci": {Identifier: "mci", Owner: "evergreen-ci", Repo: "evergreen", Branch: "master"},
+			},
+		},
+	}
+	s.sc.SetSuperUsers([]string{"admin"})
+
+	u := &user.DBUser{Id: "user"}
+	u.Settings.GithubUser.UID = 1234
+	s.ctx = gimlet.AttachUser(context.Background(), u)
+}
+
+func makeTestPR(number, authorUID int, baseBranch string) *github.PullRequest {
+	return &github.PullRequest{
+		Number: github.Int(number),
+		User:   &github.User{ID: github.Int(authorUID), Login: github.String("octocat")},
+		Base: &github.PullRequestBranch{
+			Ref: github.String(baseBranch),
+			Repo: &github.Repository{
+				Name:  github.String("evergreen"),
+				Owner: &github.User{Login: github.String("evergreen-ci")},
+			},
+		},
+	}
+}
+
+func (s *CommitQueueSuite) TestGetCommitQueue() {
+	route := makeGetCommitQueueItems(s.sc).(*commitQueueGetHandler)
+	route.projectID = "mci"
+	response := route.Run(s.ctx)
+	s.Require().Equal(http.StatusOK, response.Status())
+	cq, ok := response.Data().(*model.APICommitQueue)
+	s.Require().True(ok)
+	s.Equal("mci", model.FromAPIString(cq.ProjectID))
+	s.Require().Len(cq.Queue, 2)
+	s.Equal(1, cq.Queue[0].PRNumber)
+	s.Equal("octodog", model.FromAPIString(cq.Queue[1].Author))
+
+	route.projectID = "nope"
+	response = route.Run(s.ctx)
+	s.Equal(http.StatusNotFound, response.Status())
+}
+
+func (s *CommitQueueSuite) TestParsePRNumber() {
+	prNumber, err := parsePRNumber("3")
+	s.NoError(err)
+	s.Equal(3, prNumber)
+
+	for _, item := range []string{"0", "-1", "abc", ""} {
+		_, err = parsePRNumber(item)
+		s.Error(err, item)
+	}
+}
+
+func (s *CommitQueueSuite) TestEnqueueItem() {
+	route := makeCommitQueueEnqueueItem(s.sc).(*commitQueueEnqueueItemHandler)
+	route.projectID = "mci"
+	route.prNumber = 3
+	response := route.Run(s.ctx)
+	s.Require().Equal(http.StatusOK, response.Status())
+	s.Equal(model.APICommitQueuePosition{Position: 2}, response.Data())
+	s.Equal("user", s.sc.CachedCommitQueues["mci"][2].Author)
+	s.Equal("octocat", s.sc.CachedCommitQueues["mci"][2].GithubAuthor)
+
+	response = route.Run(s.ctx)
+	s.Equal(http.StatusBadRequest, response.Status())
+
+	// only the author of a pull request may enqueue it
+	route.prNumber = 4
+	response = route.Run(s.ctx)
+	s.Equal(http.StatusForbidden, response.Status())
+	s.Len(s.sc.CachedCommitQueues["mci"], 3)
+
+	// unless they're an admin of the project
+	response = route.Run(gimlet.AttachUser(context.Background(), &user.DBUser{Id: "admin"}))
+	s.Equal(http.StatusOK, response.Status())
+	s.Len(s.sc.CachedCommitQueues["mci"], 4)
+
+	// pull requests must be against the project's branch
+	route.prNumber = 5
+	response = route.Run(s.ctx)
+	s.Equal(http.StatusBadRequest, response.Status())
+	s.Len(s.sc.CachedCommitQueues["mci"], 4)
+}
+
+func (s *CommitQueueSuite) TestDeleteItem() {
+	route := makeDeleteCommitQueueItems(s.sc).(*commitQueueDeleteItemHandler)
+	route.projectID = "mci"
+	route.prNumber = 1
+	response := route.Run(s.ctx)
+	s.Equal(http.StatusOK, response.Status())
+	s.Require().Len(s.sc.CachedCommitQueues["mci"], 1)
+	s.Equal(2, s.sc.CachedCommitQueues["mci"][0].PRNumber)
+
+	response = route.Run(s.ctx)
+	s.Equal(http.StatusNotFound, response.Status())
+
+	// only the author of a pull request, or an admin, may remove it
+	route.prNumber = 2
+	response = route.Run(s.ctx)
+	s.Equal(http.StatusForbidden, response.Status())
+	s.Len(s.sc.CachedCommitQueues["mci"], 1)
+
+	response = route.Run(gimlet.AttachUser(context.Background(), &user.DBUser{Id: "admin"}))
+	s.Equal(http.StatusOK, response.Status())
+	s.Empty(s.sc.CachedCommitQueues["mci"])
+}
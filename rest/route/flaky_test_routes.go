@@ -0,0 +1,92 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for the flaky tests of a project
+//
+//    /projects/{project_id}/flaky_tests
+
+type flakyTestsGetHandler struct {
+	projectId string
+	variant   string
+	minScore  float64
+	limit     int
+	sc        data.Connector
+}
+
+func makeFetchFlakyTests(sc data.Connector) gimlet.RouteHandler {
+	return &flakyTestsGetHandler{
+		sc: sc,
+	}
+}
+
+func (h *flakyTestsGetHandler) Factory() gimlet.RouteHandler {
+	return &flakyTestsGetHandler{
+		sc: h.sc,
+	}
+}
+
+// Parse fetches the project from the url, along with the optional 'variant',
+// 'min_score' and 'limit' query parameters.
+func (h *flakyTestsGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectId = gimlet.GetVars(r)["project_id"]
+
+	vals := r.URL.Query()
+	h.variant = vals.Get("variant")
+
+	if minScore := vals.Get("min_score"); minScore != "" {
+		var err error
+		h.minScore, err = strconv.ParseFloat(minScore, 64)
+		if err != nil || h.minScore < 0 || h.minScore > 1 {
+			return gimlet.ErrorResponse{
+				Message:    fmt.Sprintf("invalid min_score '%s', must be between 0 and 1", minScore),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+	}
+
+	var err error
+	h.limit, err = getLimit(vals)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (h *flakyTestsGetHandler) Run(ctx context.Context) gimlet.Responder {
+	tests, err := h.sc.FindFlakyTests(h.projectId, h.variant, h.minScore, h.limit)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Database error"))
+	}
+
+	resp := gimlet.NewResponseBuilder()
+	if err = resp.SetFormat(gimlet.JSON); err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+
+	for _, ft := range tests {
+		apiTest := &model.APIFlakyTest{}
+		if err = apiTest.BuildFromService(ft); err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+		}
+
+		if err = resp.AddData(apiTest); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+	}
+
+	return resp
+}
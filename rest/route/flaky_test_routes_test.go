@@ -0,0 +1,78 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type FlakyTestsRouteSuite struct {
+	sc *data.MockConnector
+	suite.Suite
+}
+
+func TestFlakyTestsRouteSuite(t *testing.T) {
+	suite.Run(t, new(FlakyTestsRouteSuite))
+}
+
+func (s *FlakyTestsRouteSuite) SetupTest() {
+	s.sc = &data.MockConnector{
+		MockFlakyTestConnector: data.MockFlakyTestConnector{
+			CachedFlakyTests: []flakytest.FlakyTest{
+				{Project: "proj", BuildVariant: "bv1", TaskName: "unit", TestFile: "a", Score: 0.2},
+				{Project: "proj", BuildVariant: "bv2", TaskName: "unit", TestFile: "b", Score: 0.9},
+				{Project: "proj", BuildVariant: "bv1", TaskName: "unit", TestFile: "c", Score: 0.5},
+				{Project: "other", BuildVariant: "bv1", TaskName: "unit", TestFile: "d", Score: 1},
+			},
+		},
+	}
+}
+
+func (s *FlakyTestsRouteSuite) makeRequest(query string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, "/projects/proj/flaky_tests?"+query, nil)
+}
+
+func (s *FlakyTestsRouteSuite) TestParse() {
+	rh := makeFetchFlakyTests(s.sc).Factory().(*flakyTestsGetHandler)
+	r, err := s.makeRequest("variant=bv1&min_score=0.3&limit=5")
+	s.Require().NoError(err)
+	s.NoError(rh.Parse(context.Background(), r))
+	s.Equal("bv1", rh.variant)
+	s.Equal(0.3, rh.minScore)
+	s.Equal(5, rh.limit)
+
+	for _, query := range []string{"min_score=foo", "min_score=2", "min_score=-1", "limit=foo"} {
+		rh = makeFetchFlakyTests(s.sc).Factory().(*flakyTestsGetHandler)
+		r, err = s.makeRequest(query)
+		s.Require().NoError(err)
+		s.Error(rh.Parse(context.Background(), r), query)
+	}
+}
+
+func (s *FlakyTestsRouteSuite) TestRun() {
+	rh := makeFetchFlakyTests(s.sc).Factory().(*flakyTestsGetHandler)
+	rh.projectId = "proj"
+	rh.limit = 2
+
+	resp := rh.Run(context.Background())
+	s.Require().Equal(http.StatusOK, resp.Status())
+	tests, ok := resp.Data().([]interface{})
+	s.Require().True(ok)
+	s.Require().Len(tests, 2)
+	s.Equal("b", model.FromAPIString(tests[0].(*model.APIFlakyTest).TestFile))
+	s.Equal("c", model.FromAPIString(tests[1].(*model.APIFlakyTest).TestFile))
+
+	rh.variant = "bv1"
+	rh.minScore = 0.3
+	resp = rh.Run(context.Background())
+	s.Require().Equal(http.StatusOK, resp.Status())
+	tests, ok = resp.Data().([]interface{})
+	s.Require().True(ok)
+	s.Require().Len(tests, 1)
+	s.Equal("c", model.FromAPIString(tests[0].(*model.APIFlakyTest).TestFile))
+}
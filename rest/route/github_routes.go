@@ -3,6 +3,7 @@ package route
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/rest/data"
@@ -18,6 +19,11 @@ const (
 	githubActionOpened      = "opened"
 	githubActionSynchronize = "synchronize"
 	githubActionReopened    = "reopened"
+	githubActionCreated     = "created"
+
+	// commitQueueCommentTrigger is the pull request comment that adds a
+	// pull request to the commit queue of its project.
+	commitQueueCommentTrigger = "evergreen merge"
 )
 
 type githubHookApi struct {
@@ -193,7 +199,57 @@ func (gh *githubHookApi) Execute(ctx context.Context, sc data.Connector) (Respon
 
 	case *github.PushEvent:
 		return ResponseData{}, sc.TriggerRepotracker(gh.queue, gh.msgID, event)
+
+	case *github.IssueCommentEvent:
+		if !isCommitQueueComment(event) {
+			return ResponseData{}, nil
+		}
+
+		grip.Info(message.Fields{
+			"source":    "github hook",
+			"msg_id":    gh.msgID,
+			"event":     gh.eventType,
+			"action":    event.GetAction(),
+			"message":   "commit queue comment received; enqueueing pull request",
+			"repo":      event.GetRepo().GetFullName(),
+			"pr_number": event.GetIssue().GetNumber(),
+			"commenter": event.GetSender().GetLogin(),
+		})
+
+		position, err := sc.EnqueuePRFromGithubComment(ctx, event)
+		grip.Error(message.WrapError(err, message.Fields{
+			"source":    "github hook",
+			"msg_id":    gh.msgID,
+			"event":     gh.eventType,
+			"repo":      event.GetRepo().GetFullName(),
+			"pr_number": event.GetIssue().GetNumber(),
+			"message":   "failed to enqueue pull request",
+		}))
+		grip.InfoWhen(err == nil, message.Fields{
+			"source":    "github hook",
+			"msg_id":    gh.msgID,
+			"event":     gh.eventType,
+			"repo":      event.GetRepo().GetFullName(),
+			"pr_number": event.GetIssue().GetNumber(),
+			"position":  position,
+			"message":   "enqueued pull request",
+		})
+
+		return ResponseData{}, err
 	}
 
 	return ResponseData{}, nil
 }
+
+// isCommitQueueComment returns true if a comment was newly left on a pull
+// request and asks for it to be added to the commit queue.
+func isCommitQueueComment(event *github.IssueCommentEvent) bool {
+	if event.GetAction() != githubActionCreated {
+		return false
+	}
+	if event.Issue == nil || event.Issue.PullRequestLinks == nil {
+		return false
+	}
+
+	return strings.TrimSpace(event.GetComment().GetBody()) == commitQueueCommentTrigger
+}
@@ -183,3 +183,42 @@ func (s *GithubWebhookRouteSuite) TestPushEventTriggersRepoTracker() {
 	s.NoError(err)
 	s.Empty(resp.Result)
 }
+
+func (s *GithubWebhookRouteSuite) TestCommitQueueCommentEnqueuesPR() {
+	event := &github.IssueCommentEvent{
+		Action: github.String("created"),
+		Issue: &github.Issue{
+			Number:           github.Int(1),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+		Comment: &github.IssueComment{Body: github.String("evergreen merge\n")},
+		Repo: &github.Repository{
+			Name:     github.String("evergreen"),
+			FullName: github.String("evergreen-ci/evergreen"),
+			Owner:    &github.User{Login: github.String("evergreen-ci")},
+		},
+		Sender: &github.User{Login: github.String("octocat"), ID: github.Int(1234)},
+	}
+	s.h.event = event
+	s.h.msgID = "1"
+
+	ctx := context.Background()
+	_, err := s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockCommitQueueConnector.CachedCommitQueues["evergreen-ci/evergreen"], 1)
+
+	// a repeated comment doesn't enqueue the pull request twice
+	_, err = s.h.Execute(ctx, s.sc)
+	s.Error(err)
+
+	// other comments and comments on issues are ignored
+	event.Issue.Number = github.Int(2)
+	event.Comment.Body = github.String("looks good")
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	event.Comment.Body = github.String("evergreen merge")
+	event.Issue.PullRequestLinks = nil
+	_, err = s.h.Execute(ctx, s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockCommitQueueConnector.CachedCommitQueues["evergreen-ci/evergreen"], 1)
+}
@@ -3,9 +3,12 @@ package route
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
 )
 
 func makeFetchNotifcationStatusRoute(sc data.Connector) gimlet.RouteHandler {
@@ -28,3 +31,100 @@ func (gh *notificationsStatusHandler) Run(ctx context.Context) gimlet.Responder
 
 	return gimlet.NewJSONResponse(stats)
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for listing the webhook notifications that ran out of retries
+//
+//    /admin/notifications/dead_letter
+
+const defaultDeadLetterLimit = 100
+
+type deadLettersGetHandler struct {
+	limit int
+	sc    data.Connector
+}
+
+func makeFetchDeadLetters(sc data.Connector) gimlet.RouteHandler {
+	return &deadLettersGetHandler{sc: sc}
+}
+
+func (h *deadLettersGetHandler) Factory() gimlet.RouteHandler {
+	return &deadLettersGetHandler{sc: h.sc}
+}
+
+func (h *deadLettersGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.limit = defaultDeadLetterLimit
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		h.limit, err = strconv.Atoi(limit)
+		if err != nil || h.limit < 0 {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Invalid limit",
+			}
+		}
+	}
+	return nil
+}
+
+func (h *deadLettersGetHandler) Run(ctx context.Context) gimlet.Responder {
+	letters, err := h.sc.GetDeadLetters(h.limit)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Database error"))
+	}
+
+	resp := gimlet.NewResponseBuilder()
+	if err = resp.SetFormat(gimlet.JSON); err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	for i := range letters {
+		letter := &model.APIDeadLetter{}
+		if err = letter.BuildFromService(&letters[i]); err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+		}
+		if err = resp.AddData(letter); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+	}
+
+	return resp
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for sending a dead-lettered webhook notification again
+//
+//    /admin/notifications/dead_letter/{notification_id}/replay
+
+type deadLetterReplayHandler struct {
+	id string
+	sc data.Connector
+}
+
+func makeReplayDeadLetter(sc data.Connector) gimlet.RouteHandler {
+	return &deadLetterReplayHandler{sc: sc}
+}
+
+func (h *deadLetterReplayHandler) Factory() gimlet.RouteHandler {
+	return &deadLetterReplayHandler{sc: h.sc}
+}
+
+func (h *deadLetterReplayHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.id = gimlet.GetVars(r)["notification_id"]
+	if h.id == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "notification ID is required",
+		}
+	}
+	return nil
+}
+
+func (h *deadLetterReplayHandler) Run(ctx context.Context) gimlet.Responder {
+	if err := h.sc.ReplayDeadLetter(h.id); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrapf(err, "can't replay notification '%s'", h.id))
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
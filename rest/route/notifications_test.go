@@ -12,6 +12,9 @@ import (
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -125,3 +128,57 @@ func (s *notificationSuite) TestStatsCollector() {
 	s.Equal(1, stats.PendingNotificationsByType.Slack)
 	s.Equal(1, stats.PendingNotificationsByType.GithubPullRequest)
 }
+
+func TestDeadLetterRoutes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	sc := &data.MockConnector{}
+	sc.MockNotificationConnector.CachedDeadLetters = []notification.DeadLetter{
+		{
+			ID: "n1",
+			Payload: util.EvergreenWebhook{
+				NotificationID: "n1",
+				URL:            "https://example.com/hook",
+				Secret:         []byte("secret"),
+				Body:           []byte(`{"status": "failed"}`),
+			},
+			Attempts: 5,
+			Error:    "evergreen-webhook response status was 500 Internal Server Error",
+		},
+		{ID: "n2"},
+	}
+
+	get := makeFetchDeadLetters(sc)
+	req, err := http.NewRequest(http.MethodGet, "/admin/notifications/dead_letter?limit=1", nil)
+	require.NoError(err)
+	require.NoError(get.Parse(ctx, req))
+	resp := get.Run(ctx)
+	require.Equal(http.StatusOK, resp.Status())
+	letters, ok := resp.Data().([]interface{})
+	require.True(ok)
+	require.Len(letters, 1)
+	letter, ok := letters[0].(*model.APIDeadLetter)
+	require.True(ok)
+	assert.Equal("n1", model.FromAPIString(letter.NotificationID))
+	assert.Equal("https://example.com/hook", model.FromAPIString(letter.URL))
+	assert.Equal(`{"status": "failed"}`, model.FromAPIString(letter.Body))
+	assert.Equal(5, letter.Attempts)
+
+	req, err = http.NewRequest(http.MethodGet, "/admin/notifications/dead_letter?limit=many", nil)
+	require.NoError(err)
+	assert.Error(get.Factory().Parse(ctx, req))
+
+	replay := makeReplayDeadLetter(sc).Factory().(*deadLetterReplayHandler)
+	req, err = http.NewRequest(http.MethodPost, "/admin/notifications/dead_letter//replay", nil)
+	require.NoError(err)
+	assert.Error(replay.Parse(ctx, req))
+	replay.id = "n1"
+	assert.Equal(http.StatusOK, replay.Run(ctx).Status())
+	assert.Equal([]string{"n1"}, sc.MockNotificationConnector.Replayed)
+	assert.Len(sc.MockNotificationConnector.CachedDeadLetters, 1)
+
+	// a notification can't be replayed twice
+	assert.Equal(http.StatusNotFound, replay.Run(ctx).Status())
+}
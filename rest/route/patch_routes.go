@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/util"
@@ -351,9 +352,10 @@ func getPatchAbortManager(route string, version int) *RouteManager {
 		Version: version,
 		Methods: []MethodHandler{
 			{
-				MethodType:     http.MethodPost,
-				Authenticator:  &RequireUserAuthenticator{},
-				RequestHandler: p.Handler(),
+				MethodType:        http.MethodPost,
+				PrefetchFunctions: []PrefetchFunc{prefetchProjectContext},
+				Authenticator:     &ProjectPermissionAuthenticator{Permission: role.TaskControl},
+				RequestHandler:    p.Handler(),
 			},
 		},
 	}
@@ -409,9 +411,10 @@ func getPatchRestartManager(route string, version int) *RouteManager {
 		Version: version,
 		Methods: []MethodHandler{
 			{
-				MethodType:     http.MethodPost,
-				Authenticator:  &RequireUserAuthenticator{},
-				RequestHandler: p.Handler(),
+				MethodType:        http.MethodPost,
+				PrefetchFunctions: []PrefetchFunc{prefetchProjectContext},
+				Authenticator:     &ProjectPermissionAuthenticator{Permission: role.TaskControl},
+				RequestHandler:    p.Handler(),
 			},
 		},
 	}
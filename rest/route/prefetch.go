@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/gimlet"
@@ -75,6 +76,52 @@ func NewProjectContextMiddleware(sc data.Connector) gimlet.Middleware {
 	}
 }
 
+// prefetchProjectContext attaches the project context of the task, build,
+// version, patch or project in the request's URL to the request context.
+func prefetchProjectContext(ctx context.Context, sc data.Connector, r *http.Request) (context.Context, error) {
+	vars := gimlet.GetVars(r)
+	opCtx, err := sc.FetchContext(vars["task_id"], vars["build_id"], vars["version_id"], vars["patch_id"], vars["project_id"])
+	if err != nil {
+		return ctx, err
+	}
+
+	return context.WithValue(ctx, RequestContext, &opCtx), nil
+}
+
+type projectPermissionMiddleware struct {
+	sc         data.Connector
+	permission role.Permission
+}
+
+func (m *projectPermissionMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	ctx := r.Context()
+	if GetProjectContext(ctx) == nil {
+		var err error
+		if ctx, err = prefetchProjectContext(ctx, m.sc, r); err != nil {
+			gimlet.WriteResponse(rw, gimlet.MakeJSONErrorResponder(err))
+			return
+		}
+		r = r.WithContext(ctx)
+	}
+
+	if err := checkProjectPermission(m.sc, gimlet.GetUser(ctx), GetProjectContext(ctx), m.permission); err != nil {
+		gimlet.WriteResponse(rw, gimlet.MakeJSONInternalErrorResponder(err))
+		return
+	}
+
+	next(rw, r)
+}
+
+// NewProjectPermissionMiddleware only allows users with a role that allows
+// the permission on the project of the task, build, version, patch or project
+// in the request's URL, and superusers, to proceed.
+func NewProjectPermissionMiddleware(sc data.Connector, permission role.Permission) gimlet.Middleware {
+	return &projectPermissionMiddleware{
+		sc:         sc,
+		permission: permission,
+	}
+}
+
 // GetProjectContext returns the project context associated with a
 // given request.
 func GetProjectContext(ctx context.Context) *model.Context {
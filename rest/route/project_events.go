@@ -0,0 +1,139 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/projects/{project_id}/events
+
+func makeFetchProjectEvents(sc data.Connector) gimlet.RouteHandler {
+	return &projectEventsGet{sc: sc}
+}
+
+type projectEventsGet struct {
+	Timestamp time.Time
+	Limit     int
+	projectID string
+
+	sc data.Connector
+}
+
+func (h *projectEventsGet) Factory() gimlet.RouteHandler {
+	return &projectEventsGet{
+		Timestamp: time.Now(),
+		Limit:     10,
+		sc:        h.sc,
+	}
+}
+
+func (h *projectEventsGet) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	h.projectID = gimlet.GetVars(r)["project_id"]
+	vals := r.URL.Query()
+
+	k, ok := vals["ts"]
+	if ok && len(k) > 0 {
+		h.Timestamp, err = time.Parse(time.RFC3339, k[0])
+		if err != nil {
+			return errors.Wrap(err, "problem parsing time as RFC-3339")
+		}
+	}
+
+	h.Limit, err = getLimit(vals)
+	return errors.WithStack(err)
+}
+
+func (h *projectEventsGet) Run(ctx context.Context) gimlet.Responder {
+	resp := gimlet.NewResponseBuilder()
+
+	events, err := h.sc.GetProjectEventLog(h.projectID, h.Timestamp, h.Limit+1)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "database error"))
+	}
+
+	lastIndex := len(events)
+	if len(events) > h.Limit {
+		lastIndex = h.Limit
+		err = resp.SetPages(&gimlet.ResponsePages{
+			Next: &gimlet.Page{
+				BaseURL:         h.sc.GetURL(),
+				KeyQueryParam:   "ts",
+				LimitQueryParam: "limit",
+				Relation:        "next",
+				Key:             events[h.Limit-1].Timestamp.Format(time.RFC3339Nano),
+				Limit:           h.Limit,
+			},
+		})
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err,
+				"problem paginating response"))
+		}
+	}
+	events = events[:lastIndex]
+	catcher := grip.NewBasicCatcher()
+	for i := range events {
+		catcher.Add(resp.AddData(model.Model(&events[i])))
+	}
+
+	if catcher.HasErrors() {
+		return gimlet.MakeJSONInternalErrorResponder(catcher.Resolve())
+	}
+
+	if err = resp.SetStatus(http.StatusOK); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	return resp
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/projects/{project_id}/revert
+
+func makeRevertProject(sc data.Connector) gimlet.RouteHandler {
+	return &projectRevertHandler{sc: sc}
+}
+
+type projectRevertHandler struct {
+	GUID      string `json:"guid"`
+	projectID string
+
+	sc data.Connector
+}
+
+func (h *projectRevertHandler) Factory() gimlet.RouteHandler {
+	return &projectRevertHandler{sc: h.sc}
+}
+
+func (h *projectRevertHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectID = gimlet.GetVars(r)["project_id"]
+	if err := gimlet.GetJSON(r.Body, h); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if h.GUID == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "GUID to revert to must be specified",
+		}
+	}
+	return nil
+}
+
+func (h *projectRevertHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	if err := h.sc.RevertProjectTo(h.projectID, h.GUID, u.Username()); err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	return gimlet.NewJSONResponse(struct{}{})
+}
@@ -0,0 +1,76 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectEventRoutes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Now()
+	sc := &data.MockConnector{
+		MockProjectConnector: data.MockProjectConnector{
+			CachedEvents: map[string][]model.APIProjectEvent{
+				"mci": {
+					{Timestamp: now.Add(-time.Minute), Guid: model.ToAPIString("third"), User: model.ToAPIString("me")},
+					{Timestamp: now.Add(-2 * time.Minute), Guid: model.ToAPIString("second"), User: model.ToAPIString("me")},
+					{Timestamp: now.Add(-3 * time.Minute), Guid: model.ToAPIString("first"), User: model.ToAPIString("you")},
+				},
+			},
+		},
+	}
+	sc.SetURL("https://evergreen.example.net")
+	ctx := gimlet.AttachUser(context.Background(), &user.DBUser{Id: "admin"})
+
+	get := makeFetchProjectEvents(sc).Factory().(*projectEventsGet)
+	get.projectID = "mci"
+	get.Limit = 2
+	resp := get.Run(ctx)
+	require.Equal(http.StatusOK, resp.Status())
+	events, ok := resp.Data().([]interface{})
+	require.True(ok)
+	require.Len(events, 2)
+	assert.Equal("third", model.FromAPIString(events[0].(*model.APIProjectEvent).Guid))
+	assert.Equal("second", model.FromAPIString(events[1].(*model.APIProjectEvent).Guid))
+	require.NotNil(resp.Pages())
+	assert.Equal(now.Add(-2*time.Minute).Format(time.RFC3339Nano), resp.Pages().Next.Key)
+
+	get = makeFetchProjectEvents(sc).Factory().(*projectEventsGet)
+	get.projectID = "mci"
+	get.Timestamp = now.Add(-2 * time.Minute)
+	resp = get.Run(ctx)
+	require.Equal(http.StatusOK, resp.Status())
+	events, ok = resp.Data().([]interface{})
+	require.True(ok)
+	require.Len(events, 1)
+	assert.Equal("first", model.FromAPIString(events[0].(*model.APIProjectEvent).Guid))
+
+	revert := makeRevertProject(sc).(*projectRevertHandler)
+	req, err := http.NewRequest(http.MethodPost, "/projects/mci/revert", bytes.NewBuffer([]byte(`{}`)))
+	require.NoError(err)
+	assert.Error(revert.Parse(ctx, req))
+
+	req, err = http.NewRequest(http.MethodPost, "/projects/mci/revert", bytes.NewBuffer([]byte(`{"guid": "second"}`)))
+	require.NoError(err)
+	require.NoError(revert.Parse(ctx, req))
+	revert.projectID = "mci"
+	resp = revert.Run(ctx)
+	assert.Equal(http.StatusOK, resp.Status())
+	assert.Equal("second", sc.MockProjectConnector.Reverted["mci"])
+
+	revert.GUID = "nonexistent"
+	resp = revert.Run(ctx)
+	assert.Equal(http.StatusNotFound, resp.Status())
+}
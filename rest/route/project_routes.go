@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/evergreen-ci/evergreen"
 	dbModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/pkg/errors"
 )
@@ -108,9 +110,10 @@ func (p *projectGetHandler) Run(ctx context.Context) gimlet.Responder {
 }
 
 type versionsGetHandler struct {
-	project string
-	limit   int
-	offset  int
+	project    string
+	limit      int
+	offset     int
+	requesters []string
 }
 
 func getRecentVersionsManager(route string, version int) *RouteManager {
@@ -162,6 +165,18 @@ func (h *versionsGetHandler) ParseAndValidate(ctx context.Context, r *http.Reque
 		h.offset = 0
 	}
 
+	h.requesters = evergreen.SystemVersionRequesters
+	requester := query.Get("requester")
+	if requester != "" {
+		if !util.StringSliceContains(evergreen.SystemVersionRequesters, requester) {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Invalid requester",
+			}
+		}
+		h.requesters = []string{requester}
+	}
+
 	return nil
 }
 
@@ -180,7 +195,7 @@ func (h *versionsGetHandler) Execute(ctx context.Context, sc data.Connector) (Re
 			Message:    "Project not found",
 		}
 	}
-	versions, err := sc.GetVersionsAndVariants(h.offset, h.limit, proj)
+	versions, err := sc.GetVersionsAndVariants(h.offset, h.limit, proj, h.requesters)
 	if err != nil {
 		return ResponseData{}, errors.Wrap(err, "Error retrieving versions")
 	}
@@ -0,0 +1,94 @@
+package route
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/projects/{project_id}/secret_refs
+
+func makeFetchProjectSecretRefs(sc data.Connector) gimlet.RouteHandler {
+	return &projectSecretRefsGetHandler{sc: sc}
+}
+
+type projectSecretRefsGetHandler struct {
+	projectID string
+
+	sc data.Connector
+}
+
+func (h *projectSecretRefsGetHandler) Factory() gimlet.RouteHandler {
+	return &projectSecretRefsGetHandler{sc: h.sc}
+}
+
+func (h *projectSecretRefsGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectID = gimlet.GetVars(r)["project_id"]
+	return nil
+}
+
+func (h *projectSecretRefsGetHandler) Run(ctx context.Context) gimlet.Responder {
+	refs, err := h.sc.FindProjectSecretRefs(h.projectID)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "database error"))
+	}
+
+	return gimlet.NewJSONResponse(refs)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// PATCH /rest/v2/projects/{project_id}/secret_refs
+
+func makePatchProjectSecretRefs(sc data.Connector) gimlet.RouteHandler {
+	return &projectSecretRefsPatchHandler{sc: sc}
+}
+
+// projectSecretRefsPatchHandler sets the references to external secrets of
+// a project's variables, i.e. {"secret_refs": {"token": "keyring:token"}},
+// and removes the references of the variables listed in "delete".
+type projectSecretRefsPatchHandler struct {
+	SecretRefs map[string]string `json:"secret_refs"`
+	Delete     []string          `json:"delete"`
+	projectID  string
+
+	sc data.Connector
+}
+
+func (h *projectSecretRefsPatchHandler) Factory() gimlet.RouteHandler {
+	return &projectSecretRefsPatchHandler{sc: h.sc}
+}
+
+func (h *projectSecretRefsPatchHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectID = gimlet.GetVars(r)["project_id"]
+	if err := gimlet.GetJSON(r.Body, h); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if len(h.SecretRefs) == 0 && len(h.Delete) == 0 {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify secret references to set or delete",
+		}
+	}
+	return nil
+}
+
+func (h *projectSecretRefsPatchHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	if err := h.sc.UpdateProjectSecretRefs(h.projectID, u.Username(), h.SecretRefs, h.Delete); err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+
+	refs, err := h.sc.FindProjectSecretRefs(h.projectID)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "database error"))
+	}
+
+	return gimlet.NewJSONResponse(refs)
+}
@@ -0,0 +1,65 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectSecretRefsRoutes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sc := &data.MockConnector{
+		MockProjectConnector: data.MockProjectConnector{
+			CachedVars: []*model.ProjectVars{
+				{
+					Id:         "mci",
+					Vars:       map[string]string{"a": "1", "token": "plain"},
+					SecretRefs: map[string]string{"old": "keyring:old"},
+				},
+			},
+		},
+	}
+	ctx := gimlet.AttachUser(context.Background(), &user.DBUser{Id: "admin"})
+
+	get := makeFetchProjectSecretRefs(sc).Factory().(*projectSecretRefsGetHandler)
+	get.projectID = "mci"
+	resp := get.Run(ctx)
+	require.Equal(http.StatusOK, resp.Status())
+	assert.Equal(map[string]string{"old": "keyring:old"}, resp.Data())
+
+	patch := makePatchProjectSecretRefs(sc).Factory().(*projectSecretRefsPatchHandler)
+	req, err := http.NewRequest(http.MethodPatch, "/projects/mci/secret_refs",
+		bytes.NewBufferString(`{"secret_refs": {"token": "http_kv:deploy#token"}, "delete": ["old"]}`))
+	require.NoError(err)
+	require.NoError(patch.Parse(ctx, req))
+	patch.projectID = "mci"
+	resp = patch.Run(ctx)
+	require.Equal(http.StatusOK, resp.Status())
+	assert.Equal(map[string]string{"token": "http_kv:deploy#token"}, resp.Data())
+	assert.Equal(map[string]string{"a": "1"}, sc.MockProjectConnector.CachedVars[0].Vars)
+
+	// references can't leave the project's own secrets
+	for _, ref := range []string{"keyring:../other/token", "http_kv:/secret/data/other#token", "keyring:a/../../b"} {
+		patch = makePatchProjectSecretRefs(sc).Factory().(*projectSecretRefsPatchHandler)
+		patch.projectID = "mci"
+		patch.SecretRefs = map[string]string{"bad": ref}
+		resp = patch.Run(ctx)
+		assert.Equal(http.StatusBadRequest, resp.Status(), ref)
+	}
+	assert.NotContains(sc.MockProjectConnector.CachedVars[0].SecretRefs, "bad")
+
+	patch = makePatchProjectSecretRefs(sc).Factory().(*projectSecretRefsPatchHandler)
+	req, err = http.NewRequest(http.MethodPatch, "/projects/mci/secret_refs", bytes.NewBufferString(`{}`))
+	require.NoError(err)
+	assert.Error(patch.Parse(ctx, req))
+}
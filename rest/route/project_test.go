@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen"
 	serviceModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
@@ -112,4 +113,15 @@ func (s *ProjectGetSuite) TestGetRecentVersions() {
 	request, err = http.NewRequest("GET", "/projects/projectA/recent_versions?offset=idk", bytes.NewReader(nil))
 	s.NoError(err)
 	s.EqualError(getVersions.ParseAndValidate(ctx, request), "400 (Bad Request): Invalid offset")
+
+	// only periodic builds
+	request, err = http.NewRequest("GET", "/projects/projectA/recent_versions?requester=periodic_build_request", bytes.NewReader(nil))
+	s.NoError(err)
+	s.NoError(getVersions.ParseAndValidate(ctx, request))
+	s.Equal([]string{evergreen.PeriodicBuildRequester}, getVersions.RequestHandler.(*versionsGetHandler).requesters)
+
+	// patches are not listed
+	request, err = http.NewRequest("GET", "/projects/projectA/recent_versions?requester=patch_request", bytes.NewReader(nil))
+	s.NoError(err)
+	s.EqualError(getVersions.ParseAndValidate(ctx, request), "400 (Bad Request): Invalid requester")
 }
@@ -0,0 +1,201 @@
+package route
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for the built in roles
+//
+//    /roles
+
+type rolesGetHandler struct{}
+
+func makeFetchRoles() gimlet.RouteHandler {
+	return &rolesGetHandler{}
+}
+
+func (h *rolesGetHandler) Factory() gimlet.RouteHandler {
+	return &rolesGetHandler{}
+}
+
+func (h *rolesGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *rolesGetHandler) Run(ctx context.Context) gimlet.Responder {
+	resp := gimlet.NewResponseBuilder()
+	if err := resp.SetFormat(gimlet.JSON); err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+
+	for _, r := range role.All() {
+		apiRole := &model.APIRole{}
+		if err := apiRole.BuildFromService(r); err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+		}
+		if err := resp.AddData(apiRole); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+	}
+
+	return resp
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for the roles assigned on a project
+//
+//    /projects/{project_id}/roles
+
+type projectRolesGetHandler struct {
+	projectID string
+	sc        data.Connector
+}
+
+func makeFetchProjectRoles(sc data.Connector) gimlet.RouteHandler {
+	return &projectRolesGetHandler{
+		sc: sc,
+	}
+}
+
+func (h *projectRolesGetHandler) Factory() gimlet.RouteHandler {
+	return &projectRolesGetHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *projectRolesGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectID = gimlet.GetVars(r)["project_id"]
+	return nil
+}
+
+func (h *projectRolesGetHandler) Run(ctx context.Context) gimlet.Responder {
+	assignments, err := h.sc.FindRoleAssignmentsByProject(h.projectID)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't get project roles"))
+	}
+
+	resp := gimlet.NewResponseBuilder()
+	if err = resp.SetFormat(gimlet.JSON); err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	for _, assignment := range assignments {
+		apiAssignment := &model.APIRoleAssignment{}
+		if err = apiAssignment.BuildFromService(assignment); err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+		}
+		if err = resp.AddData(apiAssignment); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+	}
+
+	return resp
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for granting a role on a project
+//
+//    /projects/{project_id}/roles
+
+type projectRolesPostHandler struct {
+	assignment role.Assignment
+	sc         data.Connector
+}
+
+func makeAssignProjectRole(sc data.Connector) gimlet.RouteHandler {
+	return &projectRolesPostHandler{
+		sc: sc,
+	}
+}
+
+func (h *projectRolesPostHandler) Factory() gimlet.RouteHandler {
+	return &projectRolesPostHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *projectRolesPostHandler) Parse(ctx context.Context, r *http.Request) error {
+	apiAssignment := model.APIRoleAssignment{}
+	if err := util.ReadJSONInto(r.Body, &apiAssignment); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "problem parsing role assignment").Error(),
+		}
+	}
+	apiAssignment.ProjectID = model.ToAPIString(gimlet.GetVars(r)["project_id"])
+
+	i, err := apiAssignment.ToService()
+	if err != nil {
+		return errors.Wrap(err, "problem converting role assignment")
+	}
+	h.assignment = i.(role.Assignment)
+
+	return nil
+}
+
+func (h *projectRolesPostHandler) Run(ctx context.Context) gimlet.Responder {
+	if err := h.sc.AssignRole(h.assignment); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't assign role"))
+	}
+
+	apiAssignment := &model.APIRoleAssignment{}
+	if err := apiAssignment.BuildFromService(h.assignment); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+	}
+
+	return gimlet.NewJSONResponse(apiAssignment)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for revoking a role on a project
+//
+//    /projects/{project_id}/roles?role={role}&user={user}&group={group}
+
+type projectRolesDeleteHandler struct {
+	assignment role.Assignment
+	sc         data.Connector
+}
+
+func makeRemoveProjectRole(sc data.Connector) gimlet.RouteHandler {
+	return &projectRolesDeleteHandler{
+		sc: sc,
+	}
+}
+
+func (h *projectRolesDeleteHandler) Factory() gimlet.RouteHandler {
+	return &projectRolesDeleteHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *projectRolesDeleteHandler) Parse(ctx context.Context, r *http.Request) error {
+	vals := r.URL.Query()
+	h.assignment = role.Assignment{
+		ProjectID: gimlet.GetVars(r)["project_id"],
+		Role:      vals.Get("role"),
+		User:      vals.Get("user"),
+		Group:     vals.Get("group"),
+	}
+
+	return nil
+}
+
+func (h *projectRolesDeleteHandler) Run(ctx context.Context) gimlet.Responder {
+	if err := h.sc.RemoveRole(h.assignment); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't remove role"))
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
@@ -0,0 +1,159 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleRoutesSuite struct {
+	sc  *data.MockConnector
+	ctx context.Context
+	suite.Suite
+}
+
+func TestRoleRoutesSuite(t *testing.T) {
+	suite.Run(t, new(RoleRoutesSuite))
+}
+
+func (s *RoleRoutesSuite) SetupTest() {
+	s.sc = &data.MockConnector{
+		MockRoleConnector: data.MockRoleConnector{
+			CachedRoleAssignments: []role.Assignment{
+				{ProjectID: "mci", Role: role.Admin, User: "octocat"},
+				{ProjectID: "mci", Role: role.Patcher, Group: "developers"},
+				{ProjectID: "other", Role: role.Admin, User: "octodog"},
+			},
+		},
+		MockContextConnector: data.MockContextConnector{
+			CachedContext: serviceModel.Context{
+				ProjectRef: &serviceModel.ProjectRef{Identifier: "mci", DefaultRole: role.Viewer},
+			},
+		},
+	}
+	s.sc.SetSuperUsers([]string{"root"})
+	s.ctx = gimlet.AttachUser(context.Background(), &user.DBUser{Id: "user"})
+}
+
+func (s *RoleRoutesSuite) TestGetRoles() {
+	route := makeFetchRoles()
+	response := route.Run(s.ctx)
+	s.Require().Equal(http.StatusOK, response.Status())
+	roles, ok := response.Data().([]interface{})
+	s.Require().True(ok)
+	s.Require().Len(roles, len(role.All()))
+	s.Equal(role.Viewer, model.FromAPIString(roles[0].(*model.APIRole).Name))
+	s.Len(roles[3].(*model.APIRole).Permissions, 5)
+}
+
+func (s *RoleRoutesSuite) TestGetProjectRoles() {
+	route := makeFetchProjectRoles(s.sc).(*projectRolesGetHandler)
+	route.projectID = "mci"
+	response := route.Run(s.ctx)
+	s.Require().Equal(http.StatusOK, response.Status())
+	assignments, ok := response.Data().([]interface{})
+	s.Require().True(ok)
+	s.Require().Len(assignments, 2)
+	s.Equal("octocat", model.FromAPIString(assignments[0].(*model.APIRoleAssignment).User))
+	s.Equal("developers", model.FromAPIString(assignments[1].(*model.APIRoleAssignment).Group))
+}
+
+func (s *RoleRoutesSuite) TestAssignAndRemoveRole() {
+	body := []byte(`{"role": "scheduler", "user": "octodog"}`)
+	req, err := http.NewRequest(http.MethodPost, "/projects/mci/roles", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+
+	post := makeAssignProjectRole(s.sc).(*projectRolesPostHandler)
+	s.Require().NoError(post.Parse(s.ctx, req))
+	s.Equal(role.Scheduler, post.assignment.Role)
+	s.Equal("octodog", post.assignment.User)
+	post.assignment.ProjectID = "mci"
+	response := post.Run(s.ctx)
+	s.Require().Equal(http.StatusOK, response.Status())
+	s.Require().Len(s.sc.CachedRoleAssignments, 4)
+	s.Equal(role.Assignment{ProjectID: "mci", Role: role.Scheduler, User: "octodog"}, s.sc.CachedRoleAssignments[3])
+
+	req, err = http.NewRequest(http.MethodDelete, "/projects/mci/roles?role=scheduler&user=octodog", nil)
+	s.Require().NoError(err)
+
+	del := makeRemoveProjectRole(s.sc).(*projectRolesDeleteHandler)
+	s.Require().NoError(del.Parse(s.ctx, req))
+	s.Equal(role.Scheduler, del.assignment.Role)
+	s.Equal("octodog", del.assignment.User)
+	del.assignment.ProjectID = "mci"
+	response = del.Run(s.ctx)
+	s.Require().Equal(http.StatusOK, response.Status())
+	s.Len(s.sc.CachedRoleAssignments, 3)
+}
+
+func (s *RoleRoutesSuite) TestAssignInvalidRole() {
+	for _, body := range []string{
+		`{"role": "owner", "user": "octodog"}`,
+		`{"role": "admin"}`,
+		`{"role": "admin", "user": "octodog", "group": "developers"}`,
+	} {
+		req, err := http.NewRequest(http.MethodPost, "/projects/mci/roles", bytes.NewBufferString(body))
+		s.Require().NoError(err)
+
+		route := makeAssignProjectRole(s.sc).(*projectRolesPostHandler)
+		s.Require().NoError(route.Parse(s.ctx, req))
+		route.assignment.ProjectID = "mci"
+		response := route.Run(s.ctx)
+		s.Equal(http.StatusBadRequest, response.Status(), body)
+	}
+	s.Len(s.sc.CachedRoleAssignments, 3)
+}
+
+func (s *RoleRoutesSuite) TestProjectPermissionMiddleware() {
+	for _, test := range []struct {
+		user       string
+		groups     []string
+		permission role.Permission
+		status     int
+	}{
+		{user: "octocat", permission: role.EditProject, status: http.StatusOK},
+		{user: "octodog", permission: role.TaskControl, status: http.StatusForbidden},
+		{user: "user", groups: []string{"developers"}, permission: role.SubmitPatches, status: http.StatusOK},
+		{user: "user", groups: []string{"developers"}, permission: role.TaskControl, status: http.StatusForbidden},
+		{user: "root", permission: role.EditProject, status: http.StatusOK},
+		{user: "octodog", permission: role.ViewProject, status: http.StatusOK},
+		{permission: role.ViewProject, status: http.StatusNotFound},
+		{permission: role.TaskControl, status: http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(http.MethodPost, "/tasks/t1/restart", nil)
+		s.Require().NoError(err)
+		if test.user != "" {
+			req = req.WithContext(gimlet.AttachUser(req.Context(), &user.DBUser{Id: test.user, SystemRoles: test.groups}))
+		}
+
+		rw := httptest.NewRecorder()
+		NewProjectPermissionMiddleware(s.sc, test.permission).ServeHTTP(rw, req, func(rw http.ResponseWriter, r *http.Request) {
+			s.NotNil(GetProjectContext(r.Context()))
+			rw.WriteHeader(http.StatusOK)
+		})
+		s.Equal(test.status, rw.Code, "%s %s", test.user, test.permission)
+	}
+}
+
+func (s *RoleRoutesSuite) TestProjectPermissionAuthenticator() {
+	author := &ProjectPermissionAuthenticator{Permission: role.TaskControl}
+	opCtx := s.sc.CachedContext
+	ctx := context.WithValue(s.ctx, RequestContext, &opCtx)
+
+	err := author.Authenticate(ctx, s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusForbidden, err.(gimlet.ErrorResponse).StatusCode)
+
+	opCtx.ProjectRef.DefaultRole = ""
+	s.NoError(author.Authenticate(ctx, s.sc))
+}
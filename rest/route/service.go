@@ -1,6 +1,7 @@
 package route
 
 import (
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/mongodb/amboy"
@@ -42,6 +43,9 @@ func AttachHandler(app *gimlet.APIApp, queue amboy.Queue, URL string, superUsers
 	superUser := gimlet.NewRestrictAccessToUsers(sc.GetSuperUsers())
 	checkUser := gimlet.NewRequireAuthHandler()
 	addProject := NewProjectContextMiddleware(sc)
+	viewProject := NewProjectPermissionMiddleware(sc, role.ViewProject)
+	taskControl := NewProjectPermissionMiddleware(sc, role.TaskControl)
+	editProject := NewProjectPermissionMiddleware(sc, role.EditProject)
 
 	// Routes
 	app.AddRoute("/").Version(2).Get().RouteHandler(makePlaceHolderManger(sc))
@@ -49,6 +53,8 @@ func AttachHandler(app *gimlet.APIApp, queue amboy.Queue, URL string, superUsers
 	app.AddRoute("/admin/banner").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchAdminBanner(sc))
 	app.AddRoute("/admin/banner").Version(2).Post().Wrap(superUser).RouteHandler(makeSetAdminBanner(sc))
 	app.AddRoute("/admin/events").Version(2).Get().Wrap(superUser).RouteHandler(makeFetchAdminEvents(sc))
+	app.AddRoute("/admin/notifications/dead_letter").Version(2).Get().Wrap(superUser).RouteHandler(makeFetchDeadLetters(sc))
+	app.AddRoute("/admin/notifications/dead_letter/{notification_id}/replay").Version(2).Post().Wrap(superUser).RouteHandler(makeReplayDeadLetter(sc))
 	app.AddRoute("/admin/restart").Version(2).Post().Wrap(superUser).RouteHandler(makeRestartRoute(sc, queue))
 	app.AddRoute("/admin/revert").Version(2).Post().Wrap(superUser).RouteHandler(makeRevertRouteManager(sc))
 	app.AddRoute("/admin/service_flags").Version(2).Post().Wrap(superUser).RouteHandler(makeSetServiceFlagsRouteManager(sc))
@@ -57,10 +63,13 @@ func AttachHandler(app *gimlet.APIApp, queue amboy.Queue, URL string, superUsers
 	app.AddRoute("/admin/task_queue").Version(2).Delete().Wrap(superUser).RouteHandler(makeClearTaskQueueHandler(sc))
 	app.AddRoute("/alias/{name}").Version(2).Get().RouteHandler(makeFetchAliases(sc))
 	app.AddRoute("/builds/{build_id}").Version(2).Get().RouteHandler(makeGetBuildByID(sc))
-	app.AddRoute("/builds/{build_id}").Version(2).Patch().Wrap(checkUser).RouteHandler(makeChangeStatusForBuild(sc))
-	app.AddRoute("/builds/{build_id}/abort").Version(2).Post().Wrap(checkUser).RouteHandler(makeAbortBuild(sc))
-	app.AddRoute("/builds/{build_id}/restart").Version(2).Post().Wrap(checkUser).RouteHandler(makeRestartBuild(sc))
+	app.AddRoute("/builds/{build_id}").Version(2).Patch().Wrap(checkUser, taskControl).RouteHandler(makeChangeStatusForBuild(sc))
+	app.AddRoute("/builds/{build_id}/abort").Version(2).Post().Wrap(checkUser, taskControl).RouteHandler(makeAbortBuild(sc))
+	app.AddRoute("/builds/{build_id}/restart").Version(2).Post().Wrap(checkUser, taskControl).RouteHandler(makeRestartBuild(sc))
 	app.AddRoute("/builds/{build_id}/tasks").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchTasksByBuild(sc))
+	app.AddRoute("/commit_queue/{project_id}").Version(2).Get().Wrap(checkUser).RouteHandler(makeGetCommitQueueItems(sc))
+	app.AddRoute("/commit_queue/{project_id}/{item}").Version(2).Put().Wrap(checkUser).RouteHandler(makeCommitQueueEnqueueItem(sc))
+	app.AddRoute("/commit_queue/{project_id}/{item}").Version(2).Delete().Wrap(checkUser).RouteHandler(makeDeleteCommitQueueItems(sc))
 	app.AddRoute("/cost/distro/{distro_id}").Version(2).Get().Wrap(checkUser).RouteHandler(makeCostByDistroHandler(sc))
 	app.AddRoute("/cost/project/{project_id}/tasks").Version(2).Get().Wrap(checkUser).RouteHandler(makeTaskCostByProjectRoute(sc))
 	app.AddRoute("/cost/version/{version_id}").Version(2).Get().Wrap(checkUser).RouteHandler(makeCostByVersionHandler(sc))
@@ -70,24 +79,40 @@ func AttachHandler(app *gimlet.APIApp, queue amboy.Queue, URL string, superUsers
 	app.AddRoute("/hosts/{task_id}/create").Version(2).Post().RouteHandler(makeHostCreateRouteManager(sc))
 	app.AddRoute("/hosts/{task_id}/list").Version(2).Get().RouteHandler(makeHostListRouteManager(sc))
 	app.AddRoute("/patches/{patch_id}").Version(2).Get().RouteHandler(makeFetchPatchByID(sc))
-	app.AddRoute("/patches/{patch_id}").Version(2).Patch().Wrap(checkUser).RouteHandler(makeChangePatchStatus(sc))
+	app.AddRoute("/patches/{patch_id}").Version(2).Patch().Wrap(checkUser, taskControl).RouteHandler(makeChangePatchStatus(sc))
 	app.AddRoute("/projects").Version(2).Get().RouteHandler(makeFetchProjectsRoute(sc))
 	app.AddRoute("/projects/{project_id}/revisions/{commit_hash}/tasks").Version(2).Get().Wrap(checkUser).RouteHandler(makeTasksByProjectAndCommitHandler(sc))
+	app.AddRoute("/projects/{project_id}/events").Version(2).Get().Wrap(checkUser, editProject).RouteHandler(makeFetchProjectEvents(sc))
+	app.AddRoute("/projects/{project_id}/flaky_tests").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchFlakyTests(sc))
 	app.AddRoute("/projects/{project_id}/patches").Version(2).Get().Wrap(checkUser).RouteHandler(makePatchesByProjectRoute(sc))
+	app.AddRoute("/projects/{project_id}/roles").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchProjectRoles(sc))
+	app.AddRoute("/projects/{project_id}/roles").Version(2).Post().Wrap(checkUser, editProject).RouteHandler(makeAssignProjectRole(sc))
+	app.AddRoute("/projects/{project_id}/roles").Version(2).Delete().Wrap(checkUser, editProject).RouteHandler(makeRemoveProjectRole(sc))
+	app.AddRoute("/projects/{project_id}/revert").Version(2).Post().Wrap(checkUser, editProject).RouteHandler(makeRevertProject(sc))
+	app.AddRoute("/projects/{project_id}/secret_refs").Version(2).Get().Wrap(checkUser, editProject).RouteHandler(makeFetchProjectSecretRefs(sc))
+	app.AddRoute("/projects/{project_id}/secret_refs").Version(2).Patch().Wrap(checkUser, editProject).RouteHandler(makePatchProjectSecretRefs(sc))
+	app.AddRoute("/roles").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchRoles())
 	app.AddRoute("/status/cli_version").Version(2).Get().RouteHandler(makeFetchCLIVersionRoute(sc))
 	app.AddRoute("/status/hosts/distros").Version(2).Get().Wrap(checkUser).RouteHandler(makeHostStatusByDistroRoute(sc))
 	app.AddRoute("/status/notifications").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchNotifcationStatusRoute(sc))
 	app.AddRoute("/status/recent_tasks").Version(2).Get().RouteHandler(makeRecentTaskStatusHandler(sc))
+	app.AddRoute("/subscriptions/preview").Version(2).Post().Wrap(checkUser).RouteHandler(makeSubscriptionTemplatePreview())
 	app.AddRoute("/tasks/{task_id}").Version(2).Get().Wrap(checkUser).RouteHandler(makeGetTaskRoute(sc))
-	app.AddRoute("/tasks/{task_id}").Version(2).Patch().Wrap(checkUser).RouteHandler(makeModifyTaskRoute(sc))
-	app.AddRoute("/tasks/{task_id}/abort").Version(2).Post().Wrap(checkUser).RouteHandler(makeTaskAbortHandler(sc))
+	app.AddRoute("/tasks/{task_id}").Version(2).Patch().Wrap(checkUser, taskControl).RouteHandler(makeModifyTaskRoute(sc))
+	app.AddRoute("/tasks/{task_id}/abort").Version(2).Post().Wrap(checkUser, taskControl).RouteHandler(makeTaskAbortHandler(sc))
 	app.AddRoute("/tasks/{task_id}/generate").Version(2).Post().RouteHandler(makeGenerateTasksHandler(sc))
+	app.AddRoute("/tasks/{task_id}/generate/dry_run").Version(2).Post().Wrap(checkUser, viewProject).RouteHandler(makeGenerateTasksDryRunHandler(sc))
+	app.AddRoute("/tasks/{task_id}/logs/stream").Version(2).Get().Wrap(checkUser).Handler(makeTaskLogStreamHandler(sc))
 	app.AddRoute("/tasks/{task_id}/metrics/process").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchTaskProcessMetrics(sc))
 	app.AddRoute("/tasks/{task_id}/metrics/system").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchTaskSystmMetrics(sc))
-	app.AddRoute("/tasks/{task_id}/restart").Version(2).Post().Wrap(checkUser).RouteHandler(makeTaskRestartHandler(sc))
+	app.AddRoute("/tasks/{task_id}/restart").Version(2).Post().Wrap(checkUser, taskControl).RouteHandler(makeTaskRestartHandler(sc))
 	app.AddRoute("/tasks/{task_id}/tests").Version(2).Get().Wrap(addProject).RouteHandler(makeFetchTestsForTask(sc))
+	app.AddRoute("/tokens").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchAPITokens())
+	app.AddRoute("/tokens").Version(2).Post().Wrap(checkUser).RouteHandler(makeCreateAPIToken(sc))
+	app.AddRoute("/tokens/{token_name}").Version(2).Delete().Wrap(checkUser).RouteHandler(makeRevokeAPIToken(sc))
 	app.AddRoute("/users/{user_id}/hosts").Version(2).Get().Wrap(checkUser).RouteHandler(makeFetchHosts(sc))
 	app.AddRoute("/users/{user_id}/patches").Version(2).Get().Wrap(checkUser).RouteHandler(makeUserPatchHandler(sc))
 	app.AddRoute("/versions/{version_id}").Version(2).Get().RouteHandler(makeGetVersionByID(sc))
 	app.AddRoute("/versions/{version_id}/builds").Version(2).Get().RouteHandler(makeGetVersionBuilds(sc))
+	app.AddRoute("/versions/{version_id}/graph").Version(2).Get().RouteHandler(makeGetVersionGraph(sc))
 }
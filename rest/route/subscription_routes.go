@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
 )
 
 func getSubscriptionRouteManager(route string, version int) *RouteManager {
@@ -143,7 +144,8 @@ func validateSelectors(subscriber event.Subscriber, selectors []event.Selector)
 }
 
 func (s *subscriptionPostHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
-	err := sc.SaveSubscriptions(s.dbSubscriptions)
+	u := MustHaveUser(ctx)
+	err := sc.SaveSubscriptions(u.Username(), s.dbSubscriptions)
 	if err != nil {
 		return ResponseData{}, err
 	}
@@ -251,3 +253,77 @@ func (s *subscriptionDeleteHandler) Execute(_ context.Context, sc data.Connector
 
 	return ResponseData{}, nil
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for previewing the message template of a subscription
+//
+//    /subscriptions/preview
+
+type subscriptionTemplatePreviewHandler struct {
+	ResourceType   string                   `json:"resource_type"`
+	Trigger        string                   `json:"trigger"`
+	SubscriberType string                   `json:"subscriber_type"`
+	Template       model.APIMessageTemplate `json:"template"`
+}
+
+func makeSubscriptionTemplatePreview() gimlet.RouteHandler {
+	return &subscriptionTemplatePreviewHandler{}
+}
+
+func (h *subscriptionTemplatePreviewHandler) Factory() gimlet.RouteHandler {
+	return &subscriptionTemplatePreviewHandler{}
+}
+
+func (h *subscriptionTemplatePreviewHandler) Parse(ctx context.Context, r *http.Request) error {
+	if err := util.ReadJSONInto(r.Body, h); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "problem parsing message template preview").Error(),
+		}
+	}
+	if h.ResourceType == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "a resource type is required to preview a message template",
+		}
+	}
+	return nil
+}
+
+func (h *subscriptionTemplatePreviewHandler) Run(ctx context.Context) gimlet.Responder {
+	tmpl := event.MessageTemplate{
+		Subject: model.FromAPIString(h.Template.Subject),
+		Body:    model.FromAPIString(h.Template.Body),
+	}
+	// the subscriber type is optional, since a template can be previewed
+	// before choosing where its notifications go
+	if h.SubscriberType != "" {
+		if err := tmpl.Validate(h.ResourceType, h.Trigger, h.SubscriberType); err != nil {
+			return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "invalid message template").Error(),
+			})
+		}
+	}
+
+	data, err := event.SampleMessageTemplateData(h.ResourceType, h.Trigger)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+	subject, body, err := tmpl.Render(data)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "invalid message template").Error(),
+		})
+	}
+
+	return gimlet.NewJSONResponse(&model.APIMessageTemplate{
+		Subject: model.ToAPIString(subject),
+		Body:    model.ToAPIString(body),
+	})
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -517,3 +519,45 @@ func (s *SubscriptionRouteSuite) TestAcceptSubscriptionWithOnlyRegexSelectors()
 	s.NoError(err)
 	s.NoError(s.postHandler.RequestHandler.ParseAndValidate(ctx, request))
 }
+
+func TestSubscriptionTemplatePreview(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	preview := func(body string) gimlet.Responder {
+		h := makeSubscriptionTemplatePreview()
+		req, err := http.NewRequest(http.MethodPost, "/subscriptions/preview", bytes.NewBufferString(body))
+		require.NoError(err)
+		if err = h.Parse(ctx, req); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+		return h.Run(ctx)
+	}
+
+	resp := preview(`{"resource_type": "VERSION", "trigger": "outcome", "subscriber_type": "email",
+		"template": {"subject": "{{ .Object }} {{ .Status }}", "body": "{{ .Version.Author }} in {{ .Project }}"}}`)
+	require.Equal(http.StatusOK, resp.Status())
+	rendered, ok := resp.Data().(*model.APIMessageTemplate)
+	require.True(ok)
+	assert.Equal("version failed", model.FromAPIString(rendered.Subject))
+	assert.Equal("octocat in sample-project", model.FromAPIString(rendered.Body))
+
+	// the subscriber type is optional
+	resp = preview(`{"resource_type": "HOST", "trigger": "expiration", "template": {"body": "{{ .Host.Distro }}"}}`)
+	require.Equal(http.StatusOK, resp.Status())
+	rendered, ok = resp.Data().(*model.APIMessageTemplate)
+	require.True(ok)
+	assert.Equal("ubuntu", model.FromAPIString(rendered.Body))
+
+	for _, body := range []string{
+		`not json`,
+		`{"template": {"body": "{{ .ID }}"}}`,
+		`{"resource_type": "ADMIN", "template": {"body": "{{ .ID }}"}}`,
+		`{"resource_type": "TASK", "template": {"body": "{{ .ID "}}`,
+		`{"resource_type": "TASK", "template": {"body": "{{ .Build.ID }}"}}`,
+		`{"resource_type": "TASK", "subscriber_type": "evergreen-webhook", "template": {"body": "{{ .ID }}"}}`,
+	} {
+		assert.Equal(http.StatusBadRequest, preview(body).Status(), body)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	dbModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/mongodb/grip"
@@ -74,3 +75,60 @@ func (h *generateHandler) Run(ctx context.Context) gimlet.Responder {
 
 	return gimlet.NewJSONResponse(struct{}{})
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/tasks/{task_id}/generate/dry_run
+
+func makeGenerateTasksDryRunHandler(sc data.Connector) gimlet.RouteHandler {
+	return &generateDryRunHandler{
+		sc: sc,
+	}
+}
+
+type generateDryRunHandler struct {
+	files  []json.RawMessage
+	taskID string
+	sc     data.Connector
+}
+
+func (h *generateDryRunHandler) Factory() gimlet.RouteHandler {
+	return &generateDryRunHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *generateDryRunHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	if h.files, err = parseJson(r); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("error reading JSON from body (%s)", err),
+		}
+	}
+	h.taskID = gimlet.GetVars(r)["task_id"]
+	if h.taskID == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a task",
+		}
+	}
+	return nil
+}
+
+// Run validates the generated files against the project of the task's
+// version and returns the build variants and tasks they would add, without
+// changing the version.
+func (h *generateDryRunHandler) Run(ctx context.Context) gimlet.Responder {
+	variants, err := h.sc.GenerateTasksDryRun(h.taskID, h.files)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+
+	dryRun := &model.APIGenerateTasksDryRun{}
+	if err = dryRun.BuildFromService(variants); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+	dryRun.TaskId = model.ToAPIString(h.taskID)
+	return gimlet.NewJSONResponse(dryRun)
+}
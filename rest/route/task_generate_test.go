@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type Thing struct {
@@ -50,3 +52,29 @@ func TestGenerateExecute(t *testing.T) {
 	assert.Equal(t, r.Data(), struct{}{})
 	assert.Equal(t, r.Status(), http.StatusOK)
 }
+
+func TestGenerateDryRunExecute(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	h := &generateDryRunHandler{
+		taskID: "generator",
+		files: []json.RawMessage{
+			json.RawMessage(`{"tasks": [{"name": "lint"}], "buildvariants": [{"name": "linux", "tasks": [{"name": "lint"}]}]}`),
+		},
+		sc: &data.MockConnector{},
+	}
+	r := h.Run(context.Background())
+	require.Equal(http.StatusOK, r.Status())
+	dryRun, ok := r.Data().(*model.APIGenerateTasksDryRun)
+	require.True(ok)
+	assert.Equal("generator", model.FromAPIString(dryRun.TaskId))
+	require.Len(dryRun.BuildVariants, 1)
+	assert.Equal("linux", model.FromAPIString(dryRun.BuildVariants[0].Name))
+	assert.True(dryRun.BuildVariants[0].New)
+	assert.Equal([]model.APIString{model.ToAPIString("lint")}, dryRun.BuildVariants[0].Tasks)
+
+	h.files = []json.RawMessage{json.RawMessage(`{"tasks": "lint"}`)}
+	r = h.Run(context.Background())
+	assert.Equal(http.StatusBadRequest, r.Status())
+}
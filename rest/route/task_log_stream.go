@@ -0,0 +1,281 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// taskLogStreamMaxDuration is how long a log stream is kept open before
+	// the client has to reconnect, which keeps it within the app server's
+	// write timeout.
+	taskLogStreamMaxDuration = 45 * time.Second
+
+	// taskLogStreamCheckInterval is how often a log stream checks whether
+	// the task has finished, and sends a comment to keep the connection
+	// alive.
+	taskLogStreamCheckInterval = 5 * time.Second
+)
+
+var taskLogStreamTypes = map[string]string{
+	"task":   apimodels.TaskLogPrefix,
+	"agent":  apimodels.AgentLogPrefix,
+	"system": apimodels.SystemLogPrefix,
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for streaming the logs of a task as server-sent events
+//
+//    /tasks/{task_id}/logs/stream
+
+type taskLogStreamHandler struct {
+	sc            data.Connector
+	maxDuration   time.Duration
+	checkInterval time.Duration
+}
+
+func makeTaskLogStreamHandler(sc data.Connector) http.HandlerFunc {
+	h := &taskLogStreamHandler{
+		sc:            sc,
+		maxDuration:   taskLogStreamMaxDuration,
+		checkInterval: taskLogStreamCheckInterval,
+	}
+	return h.ServeHTTP
+}
+
+type taskLogStreamRequest struct {
+	taskID    string
+	execution int
+	types     []string
+	since     time.Time
+	follow    bool
+}
+
+// parse reads the optional 'type' (a comma separated list of task, agent,
+// system or all, defaulting to all), 'execution' (defaulting to the latest),
+// 'since' (an RFC3339 time; only later messages are sent) and 'follow'
+// (defaulting to true; if false, only the stored messages are sent)
+// parameters.
+func (h *taskLogStreamHandler) parse(r *http.Request, t *task.Task) (*taskLogStreamRequest, error) {
+	req := &taskLogStreamRequest{
+		taskID:    t.Id,
+		execution: t.Execution,
+		follow:    true,
+	}
+	vals := r.URL.Query()
+
+	allTypes := false
+	for _, logType := range strings.Split(vals.Get("type"), ",") {
+		logType = strings.TrimSpace(logType)
+		if logType == "" || logType == "all" {
+			allTypes = true
+			continue
+		}
+		prefix, ok := taskLogStreamTypes[logType]
+		if !ok {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("invalid log type '%s'", logType),
+			}
+		}
+		req.types = append(req.types, prefix)
+	}
+	if allTypes {
+		req.types = nil
+	}
+
+	if execution := vals.Get("execution"); execution != "" {
+		var err error
+		req.execution, err = strconv.Atoi(execution)
+		if err != nil || req.execution < 0 || req.execution > t.Execution {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("invalid execution '%s'", execution),
+			}
+		}
+	}
+
+	if since := vals.Get("since"); since != "" {
+		var err error
+		req.since, err = time.Parse(time.RFC3339Nano, since)
+		if err != nil {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("invalid time '%s'", since),
+			}
+		}
+	}
+
+	if follow := vals.Get("follow"); follow != "" {
+		var err error
+		req.follow, err = strconv.ParseBool(follow)
+		if err != nil {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("invalid value '%s' for follow", follow),
+			}
+		}
+	}
+
+	return req, nil
+}
+
+// ServeHTTP sends the stored log messages of the task, then the messages the
+// agent sends while the task runs, until the task finishes or the stream
+// reaches its maximum duration. Each message is an event whose id is its
+// timestamp, to pass as 'since' when reconnecting.
+func (h *taskLogStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	taskID := gimlet.GetVars(r)["task_id"]
+	t, err := h.sc.FindTaskById(taskID)
+	if err != nil {
+		writeTaskLogStreamError(w, errors.Wrapf(err, "problem finding task '%s'", taskID))
+		return
+	}
+	if t == nil {
+		writeTaskLogStreamError(w, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", taskID),
+		})
+		return
+	}
+
+	req, err := h.parse(r, t)
+	if err != nil {
+		writeTaskLogStreamError(w, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeTaskLogStreamError(w, errors.New("streaming is not supported"))
+		return
+	}
+
+	// subscribe before reading the stored logs so that no message is missed
+	sub := h.sc.SubscribeTaskLogs(req.taskID, req.execution)
+	defer sub.Close()
+
+	stored, err := h.sc.FindTaskLogMessagesAfter(req.taskID, req.execution, req.since, req.types)
+	if err != nil {
+		writeTaskLogStreamError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	last := req.since
+	for _, msg := range stored {
+		if err = writeTaskLogStreamMessage(w, msg); err != nil {
+			return
+		}
+		last = msg.Timestamp
+	}
+	flusher.Flush()
+
+	if !req.follow || req.execution < t.Execution || t.IsFinished() {
+		writeTaskLogStreamEnd(w)
+		flusher.Flush()
+		return
+	}
+
+	deadline := time.NewTimer(h.maxDuration)
+	defer deadline.Stop()
+	ticker := time.NewTicker(h.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-deadline.C:
+			return
+		case msg, ok := <-sub.Messages:
+			if !ok {
+				// the stream fell behind, so the client has to
+				// reconnect and catch up from the stored logs
+				return
+			}
+			if !msg.Timestamp.After(last) {
+				continue
+			}
+			if len(req.types) > 0 && !util.StringSliceContains(req.types, msg.Type) {
+				continue
+			}
+			if err = writeTaskLogStreamMessage(w, msg); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if h.taskFinished(req) {
+				writeTaskLogStreamEnd(w)
+				flusher.Flush()
+				return
+			}
+			if _, err = fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (h *taskLogStreamHandler) taskFinished(req *taskLogStreamRequest) bool {
+	t, err := h.sc.FindTaskById(req.taskID)
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"message": "problem checking task status for log stream",
+			"task_id": req.taskID,
+		}))
+		return false
+	}
+
+	return t == nil || req.execution < t.Execution || t.IsFinished()
+}
+
+func writeTaskLogStreamMessage(w http.ResponseWriter, msg apimodels.LogMessage) error {
+	apiMsg := &model.APILogMessage{}
+	if err := apiMsg.BuildFromService(msg); err != nil {
+		return errors.WithStack(err)
+	}
+	data, err := json.Marshal(apiMsg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\ndata: %s\n\n", msg.Timestamp.Format(time.RFC3339Nano), data)
+	return errors.WithStack(err)
+}
+
+func writeTaskLogStreamEnd(w http.ResponseWriter) {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: {}\n\n", model.TaskLogStreamEndEvent)
+	grip.Debug(err)
+}
+
+func writeTaskLogStreamError(w http.ResponseWriter, err error) {
+	if resp, ok := errors.Cause(err).(gimlet.ErrorResponse); ok {
+		gimlet.WriteJSONResponse(w, resp.StatusCode, resp)
+		return
+	}
+	gimlet.WriteJSONInternalError(w, gimlet.ErrorResponse{
+		StatusCode: http.StatusInternalServerError,
+		Message:    err.Error(),
+	})
+}
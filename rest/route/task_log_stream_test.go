@@ -0,0 +1,255 @@
+package route
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type taskLogStreamEvent struct {
+	id   string
+	name string
+	data string
+}
+
+func startTaskLogStreamServer(t *testing.T, sc data.Connector) *httptest.Server {
+	h := &taskLogStreamHandler{
+		sc:            sc,
+		maxDuration:   time.Second,
+		checkInterval: 50 * time.Millisecond,
+	}
+
+	app := gimlet.NewApp()
+	app.SetPrefix(evergreen.RestRoutePrefix)
+	app.AddRoute("/tasks/{task_id}/logs/stream").Version(2).Get().Handler(h.ServeHTTP)
+	router, err := app.Handler()
+	require.NoError(t, err)
+
+	return httptest.NewServer(router)
+}
+
+// readTaskLogStreamEvents reads events from a stream until it closes,
+// sending each on the returned channel.
+func readTaskLogStreamEvents(t *testing.T, server *httptest.Server, taskID, query string) (<-chan taskLogStreamEvent, *http.Response) {
+	resp, err := http.Get(fmt.Sprintf("%s/rest/v2/tasks/%s/logs/stream?%s", server.URL, taskID, query))
+	require.NoError(t, err)
+
+	events := make(chan taskLogStreamEvent, 100)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		event := taskLogStreamEvent{}
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if event.data != "" {
+					events <- event
+				}
+				event = taskLogStreamEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	return events, resp
+}
+
+func nextTaskLogStreamEvent(t *testing.T, events <-chan taskLogStreamEvent) (taskLogStreamEvent, bool) {
+	select {
+	case event, ok := <-events:
+		return event, ok
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for log stream event")
+	}
+	return taskLogStreamEvent{}, false
+}
+
+func requireTaskLogStreamMessage(t *testing.T, events <-chan taskLogStreamEvent, expected string) {
+	event, ok := nextTaskLogStreamEvent(t, events)
+	require.True(t, ok)
+	require.Empty(t, event.name)
+
+	msg := model.APILogMessage{}
+	require.NoError(t, json.Unmarshal([]byte(event.data), &msg))
+	assert.Equal(t, expected, model.FromAPIString(msg.Message))
+	assert.Equal(t, time.Time(msg.Timestamp).Format(time.RFC3339Nano), event.id)
+}
+
+// taskStatusConnector lets a test change the status of a task while a
+// stream handler is reading it.
+type taskStatusConnector struct {
+	*data.MockConnector
+	mu     sync.Mutex
+	status string
+}
+
+func (c *taskStatusConnector) setStatus(status string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status = status
+}
+
+func (c *taskStatusConnector) FindTaskById(taskId string) (*task.Task, error) {
+	t, err := c.MockConnector.FindTaskById(taskId)
+	if t != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		t.Status = c.status
+	}
+	return t, err
+}
+
+func TestTaskLogStreamSendsStoredMessagesOfFinishedTask(t *testing.T) {
+	now := time.Now().Round(time.Millisecond)
+	sc := &data.MockConnector{
+		MockTaskConnector: data.MockTaskConnector{
+			CachedTasks: []task.Task{{Id: "t1", Status: evergreen.TaskSucceeded}},
+		},
+		MockTaskLogConnector: data.MockTaskLogConnector{
+			CachedLogMessages: map[string][]apimodels.LogMessage{
+				"t1": {
+					{Type: apimodels.TaskLogPrefix, Message: "one", Timestamp: now.Add(-3 * time.Second)},
+					{Type: apimodels.AgentLogPrefix, Message: "two", Timestamp: now.Add(-2 * time.Second)},
+					{Type: apimodels.TaskLogPrefix, Message: "three", Timestamp: now.Add(-time.Second)},
+				},
+			},
+		},
+	}
+	server := startTaskLogStreamServer(t, sc)
+	defer server.Close()
+
+	events, resp := readTaskLogStreamEvents(t, server, "t1", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	requireTaskLogStreamMessage(t, events, "one")
+	requireTaskLogStreamMessage(t, events, "two")
+	requireTaskLogStreamMessage(t, events, "three")
+	event, ok := nextTaskLogStreamEvent(t, events)
+	require.True(t, ok)
+	assert.Equal(t, model.TaskLogStreamEndEvent, event.name)
+
+	// filtering by type and time
+	since := now.Add(-3 * time.Second).Format(time.RFC3339Nano)
+	events, _ = readTaskLogStreamEvents(t, server, "t1", "type=task&since="+since)
+	requireTaskLogStreamMessage(t, events, "three")
+	event, ok = nextTaskLogStreamEvent(t, events)
+	require.True(t, ok)
+	assert.Equal(t, model.TaskLogStreamEndEvent, event.name)
+}
+
+func TestTaskLogStreamFollowsRunningTask(t *testing.T) {
+	now := time.Now().Round(time.Millisecond)
+	sc := &taskStatusConnector{
+		MockConnector: &data.MockConnector{
+			MockTaskConnector: data.MockTaskConnector{
+				CachedTasks: []task.Task{{Id: "t2"}},
+			},
+			MockTaskLogConnector: data.MockTaskLogConnector{
+				CachedLogMessages: map[string][]apimodels.LogMessage{
+					"t2": {{Type: apimodels.TaskLogPrefix, Message: "stored", Timestamp: now}},
+				},
+			},
+		},
+		status: evergreen.TaskStarted,
+	}
+	server := startTaskLogStreamServer(t, sc)
+	defer server.Close()
+
+	events, _ := readTaskLogStreamEvents(t, server, "t2", "type=task")
+	requireTaskLogStreamMessage(t, events, "stored")
+
+	serviceModel.PublishTaskLog(&serviceModel.TaskLog{
+		TaskId: "t2",
+		Messages: []apimodels.LogMessage{
+			// already sent from the stored logs
+			{Type: apimodels.TaskLogPrefix, Message: "stored", Timestamp: now},
+			// filtered out by type
+			{Type: apimodels.SystemLogPrefix, Message: "system", Timestamp: now.Add(time.Millisecond)},
+			{Type: apimodels.TaskLogPrefix, Message: "live", Timestamp: now.Add(2 * time.Millisecond)},
+		},
+	})
+	requireTaskLogStreamMessage(t, events, "live")
+
+	sc.setStatus(evergreen.TaskFailed)
+	event, ok := nextTaskLogStreamEvent(t, events)
+	require.True(t, ok)
+	assert.Equal(t, model.TaskLogStreamEndEvent, event.name)
+	_, ok = nextTaskLogStreamEvent(t, events)
+	assert.False(t, ok)
+}
+
+func TestTaskLogStreamClosesAtMaxDuration(t *testing.T) {
+	sc := &data.MockConnector{
+		MockTaskConnector: data.MockTaskConnector{
+			CachedTasks: []task.Task{{Id: "t3", Status: evergreen.TaskStarted}},
+		},
+	}
+	server := startTaskLogStreamServer(t, sc)
+	defer server.Close()
+
+	events, _ := readTaskLogStreamEvents(t, server, "t3", "")
+	_, ok := nextTaskLogStreamEvent(t, events)
+	assert.False(t, ok, "stream should close without an end event")
+
+	// only the stored messages are sent when not following
+	events, _ = readTaskLogStreamEvents(t, server, "t3", "follow=false")
+	event, ok := nextTaskLogStreamEvent(t, events)
+	require.True(t, ok)
+	assert.Equal(t, model.TaskLogStreamEndEvent, event.name)
+}
+
+func TestTaskLogStreamErrors(t *testing.T) {
+	sc := &data.MockConnector{
+		MockTaskConnector: data.MockTaskConnector{
+			CachedTasks: []task.Task{{Id: "t4", Status: evergreen.TaskStarted}},
+		},
+	}
+	server := startTaskLogStreamServer(t, sc)
+	defer server.Close()
+
+	for query, status := range map[string]int{
+		"type=bogus":     http.StatusBadRequest,
+		"execution=1":    http.StatusBadRequest,
+		"since=tomorrow": http.StatusBadRequest,
+		"follow=maybe":   http.StatusBadRequest,
+	} {
+		resp, err := http.Get(fmt.Sprintf("%s/rest/v2/tasks/t4/logs/stream?%s", server.URL, query))
+		require.NoError(t, err)
+		assert.Equal(t, status, resp.StatusCode, query)
+		assert.NoError(t, resp.Body.Close())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/rest/v2/tasks/missing/logs/stream", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.NoError(t, resp.Body.Close())
+}
@@ -0,0 +1,165 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for the API tokens of the current user
+//
+//    /tokens
+
+type tokensGetHandler struct{}
+
+func makeFetchAPITokens() gimlet.RouteHandler {
+	return &tokensGetHandler{}
+}
+
+func (h *tokensGetHandler) Factory() gimlet.RouteHandler {
+	return &tokensGetHandler{}
+}
+
+func (h *tokensGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *tokensGetHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+
+	resp := gimlet.NewResponseBuilder()
+	if err := resp.SetFormat(gimlet.JSON); err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	for _, t := range u.APITokens {
+		apiToken := &model.APIUserToken{}
+		if err := apiToken.BuildFromService(t); err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+		}
+		if err := resp.AddData(apiToken); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+	}
+
+	return resp
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for creating an API token for the current user
+//
+//    /tokens
+
+type tokenPostHandler struct {
+	Name           string `json:"name"`
+	Scope          string `json:"scope"`
+	ExpiresInHours int    `json:"expires_in_hours"`
+
+	sc data.Connector
+}
+
+func makeCreateAPIToken(sc data.Connector) gimlet.RouteHandler {
+	return &tokenPostHandler{
+		sc: sc,
+	}
+}
+
+func (h *tokenPostHandler) Factory() gimlet.RouteHandler {
+	return &tokenPostHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *tokenPostHandler) Parse(ctx context.Context, r *http.Request) error {
+	if err := util.ReadJSONInto(r.Body, h); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "problem parsing API token").Error(),
+		}
+	}
+	if h.Name == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "API token must have a name",
+		}
+	}
+	if h.ExpiresInHours < 0 {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "API token expiry cannot be in the past",
+		}
+	}
+	return nil
+}
+
+func (h *tokenPostHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+
+	token, err := h.sc.AddAPIToken(u, h.Name, h.Scope, time.Duration(h.ExpiresInHours)*time.Hour)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't create API token"))
+	}
+
+	for _, t := range u.APITokens {
+		if t.Name != h.Name {
+			continue
+		}
+		apiToken := &model.APIUserToken{}
+		if err = apiToken.BuildFromService(t); err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Model error"))
+		}
+		apiToken.Token = model.ToAPIString(token)
+		return gimlet.NewJSONResponse(apiToken)
+	}
+
+	return gimlet.MakeJSONInternalErrorResponder(errors.Errorf("API token '%s' was not saved", h.Name))
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for revoking an API token of the current user
+//
+//    /tokens/{token_name}
+
+type tokenDeleteHandler struct {
+	name string
+	sc   data.Connector
+}
+
+func makeRevokeAPIToken(sc data.Connector) gimlet.RouteHandler {
+	return &tokenDeleteHandler{
+		sc: sc,
+	}
+}
+
+func (h *tokenDeleteHandler) Factory() gimlet.RouteHandler {
+	return &tokenDeleteHandler{
+		sc: h.sc,
+	}
+}
+
+func (h *tokenDeleteHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.name = gimlet.GetVars(r)["token_name"]
+	return nil
+}
+
+func (h *tokenDeleteHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+
+	if err := h.sc.RevokeAPIToken(u, h.name); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "can't revoke API token"))
+	}
+
+	return gimlet.NewJSONResponse(struct {
+		Message string `json:"message"`
+	}{fmt.Sprintf("revoked API token '%s'", h.name)})
+}
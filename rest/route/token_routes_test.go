@@ -0,0 +1,210 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenRoutesSuite struct {
+	sc  *data.MockConnector
+	ctx context.Context
+	suite.Suite
+}
+
+func TestTokenRoutesSuite(t *testing.T) {
+	suite.Run(t, new(TokenRoutesSuite))
+}
+
+func (s *TokenRoutesSuite) SetupTest() {
+	s.sc = &data.MockConnector{MockUserConnector: data.MockUserConnector{
+		CachedUsers: map[string]*user.DBUser{
+			"user0": {
+				Id:     "user0",
+				APIKey: "apikey0",
+			},
+		},
+	}}
+	s.ctx = gimlet.AttachUser(context.Background(), s.sc.MockUserConnector.CachedUsers["user0"])
+}
+
+func (s *TokenRoutesSuite) createToken(name, scope string, hours int) *model.APIUserToken {
+	body, err := json.Marshal(map[string]interface{}{
+		"name":             name,
+		"scope":            scope,
+		"expires_in_hours": hours,
+	})
+	s.Require().NoError(err)
+	req, err := http.NewRequest(http.MethodPost, "/tokens", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+
+	h := makeCreateAPIToken(s.sc).Factory()
+	s.Require().NoError(h.Parse(s.ctx, req))
+	resp := h.Run(s.ctx)
+	s.Require().Equal(http.StatusOK, resp.Status())
+
+	token, ok := resp.Data().(*model.APIUserToken)
+	s.Require().True(ok)
+	return token
+}
+
+func (s *TokenRoutesSuite) TestCreateAndListTokens() {
+	token := s.createToken("ci", user.APITokenScopePatch, 24)
+	s.Equal("ci", model.FromAPIString(token.Name))
+	s.Equal(user.APITokenScopePatch, model.FromAPIString(token.Scope))
+	s.True(user.IsAPIToken(model.FromAPIString(token.Token)))
+	s.False(time.Time(token.ExpiresAt).IsZero())
+
+	resp := makeFetchAPITokens().Factory().Run(s.ctx)
+	s.Equal(http.StatusOK, resp.Status())
+	tokens, ok := resp.Data().([]interface{})
+	s.Require().True(ok)
+	s.Require().Len(tokens, 1)
+	listed := tokens[0].(*model.APIUserToken)
+	s.Equal("ci", model.FromAPIString(listed.Name))
+	s.Nil(listed.Token)
+}
+
+func (s *TokenRoutesSuite) TestCreateTokenRejectsInvalidInput() {
+	for _, body := range []string{
+		`{"scope": "read"}`,
+		`{"name": "ci", "expires_in_hours": -1}`,
+		`not json`,
+	} {
+		req, err := http.NewRequest(http.MethodPost, "/tokens", bytes.NewBufferString(body))
+		s.Require().NoError(err)
+		s.Error(makeCreateAPIToken(s.sc).Factory().Parse(s.ctx, req), body)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/tokens", bytes.NewBufferString(`{"name": "ci", "scope": "admin"}`))
+	s.Require().NoError(err)
+	h := makeCreateAPIToken(s.sc).Factory()
+	s.Require().NoError(h.Parse(s.ctx, req))
+	s.Equal(http.StatusBadRequest, h.Run(s.ctx).Status())
+}
+
+func (s *TokenRoutesSuite) TestRevokeTokenLeavesOthers() {
+	s.createToken("ci", user.APITokenScopePatch, 0)
+	hosts := s.createToken("hosts", user.APITokenScopeHosts, 0)
+
+	h := makeRevokeAPIToken(s.sc).Factory().(*tokenDeleteHandler)
+	h.name = "ci"
+	s.Equal(http.StatusOK, h.Run(s.ctx).Status())
+	s.NotEqual(http.StatusOK, h.Run(s.ctx).Status())
+
+	u := s.sc.MockUserConnector.CachedUsers["user0"]
+	s.Len(u.APITokens, 1)
+	s.NotNil(u.GetAPIToken(model.FromAPIString(hosts.Token)))
+}
+
+func (s *TokenRoutesSuite) serveWithToken(method, path, token string) (*httptest.ResponseRecorder, gimlet.User) {
+	req, err := http.NewRequest(method, path, nil)
+	s.Require().NoError(err)
+	req.Header.Set(evergreen.APIUserHeader, "user0")
+	req.Header.Set(evergreen.APIKeyHeader, token)
+
+	var attached gimlet.User
+	rw := httptest.NewRecorder()
+	NewAPITokenMiddleware(s.sc).ServeHTTP(rw, req, func(rw http.ResponseWriter, r *http.Request) {
+		s.Empty(r.Header.Get(evergreen.APIKeyHeader))
+		attached = gimlet.GetUser(r.Context())
+	})
+	return rw, attached
+}
+
+func (s *TokenRoutesSuite) TestMiddlewareAuthenticatesToken() {
+	token := model.FromAPIString(s.createToken("ci", user.APITokenScopePatch, 0).Token)
+
+	rw, u := s.serveWithToken(http.MethodGet, "/rest/v2/tasks/t1", token)
+	s.Equal(http.StatusOK, rw.Code)
+	s.Require().NotNil(u)
+	s.Equal("user0", u.Username())
+	s.Empty(u.GetAPIKey())
+	s.False(s.sc.MockUserConnector.CachedUsers["user0"].APITokens[0].LastUsedAt.IsZero())
+
+	rw, u = s.serveWithToken(http.MethodPut, "/api/patches/", token)
+	s.Equal(http.StatusOK, rw.Code)
+	s.NotNil(u)
+}
+
+func (s *TokenRoutesSuite) TestMiddlewareEnforcesScope() {
+	token := model.FromAPIString(s.createToken("readonly", user.APITokenScopeRead, 0).Token)
+
+	rw, u := s.serveWithToken(http.MethodPut, "/api/patches/", token)
+	s.Equal(http.StatusForbidden, rw.Code)
+	s.Nil(u)
+
+	token = model.FromAPIString(s.createToken("hosts", user.APITokenScopeHosts, 0).Token)
+	rw, u = s.serveWithToken(http.MethodPost, "/rest/v2/hosts", token)
+	s.Equal(http.StatusOK, rw.Code)
+	s.NotNil(u)
+	rw, _ = s.serveWithToken(http.MethodPost, "/rest/v2/patches/p1/abort", token)
+	s.Equal(http.StatusForbidden, rw.Code)
+	rw, _ = s.serveWithToken(http.MethodPost, "/rest/v2/tokens", token)
+	s.Equal(http.StatusForbidden, rw.Code)
+}
+
+func (s *TokenRoutesSuite) TestMiddlewareRejectsUIRoutes() {
+	token := model.FromAPIString(s.createToken("readonly", user.APITokenScopeRead, 0).Token)
+
+	for _, path := range []string{"/settings", "/spawn", "/patch/p1"} {
+		rw, u := s.serveWithToken(http.MethodGet, path, token)
+		s.Equal(http.StatusForbidden, rw.Code, path)
+		s.Nil(u, path)
+		s.NotContains(rw.Body.String(), "apikey0", path)
+	}
+
+	token = model.FromAPIString(s.createToken("hosts", user.APITokenScopeHosts, 0).Token)
+	rw, u := s.serveWithToken(http.MethodPut, "/spawn", token)
+	s.Equal(http.StatusForbidden, rw.Code)
+	s.Nil(u)
+
+	// the user attached on API routes has no API key to return
+	rw, u = s.serveWithToken(http.MethodGet, "/rest/v2/users/user0", token)
+	s.Equal(http.StatusOK, rw.Code)
+	s.Require().NotNil(u)
+	s.Empty(u.GetAPIKey())
+	s.Equal("apikey0", s.sc.MockUserConnector.CachedUsers["user0"].APIKey)
+}
+
+func (s *TokenRoutesSuite) TestMiddlewareRejectsInvalidTokens() {
+	rw, u := s.serveWithToken(http.MethodGet, "/rest/v2/tasks/t1", user.APITokenPrefix+"missing")
+	s.Equal(http.StatusUnauthorized, rw.Code)
+	s.Nil(u)
+
+	s.sc.MockUserConnector.CachedUsers["user0"].APITokens = append(s.sc.MockUserConnector.CachedUsers["user0"].APITokens, user.APIToken{
+		Name:      "old",
+		Hash:      user.HashAPIToken(user.APITokenPrefix + "old"),
+		Scope:     user.APITokenScopeRead,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	rw, u = s.serveWithToken(http.MethodGet, "/rest/v2/tasks/t1", user.APITokenPrefix+"old")
+	s.Equal(http.StatusUnauthorized, rw.Code)
+	s.Nil(u)
+}
+
+func (s *TokenRoutesSuite) TestMiddlewareIgnoresAPIKeys() {
+	req, err := http.NewRequest(http.MethodPost, "/rest/v2/hosts", nil)
+	s.Require().NoError(err)
+	req.Header.Set(evergreen.APIUserHeader, "user0")
+	req.Header.Set(evergreen.APIKeyHeader, "apikey0")
+
+	called := false
+	NewAPITokenMiddleware(s.sc).ServeHTTP(httptest.NewRecorder(), req, func(rw http.ResponseWriter, r *http.Request) {
+		called = true
+		s.Equal("apikey0", r.Header.Get(evergreen.APIKeyHeader))
+		s.Nil(gimlet.GetUser(r.Context()))
+	})
+	s.True(called)
+}
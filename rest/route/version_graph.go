@@ -0,0 +1,80 @@
+package route
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/versions/{version_id}/graph
+
+const (
+	versionGraphFormatJSON = "json"
+	versionGraphFormatDOT  = "dot"
+)
+
+type versionGraphHandler struct {
+	versionId string
+	format    string
+	sc        data.Connector
+}
+
+func makeGetVersionGraph(sc data.Connector) gimlet.RouteHandler {
+	return &versionGraphHandler{
+		sc: sc,
+	}
+}
+
+func (h *versionGraphHandler) Factory() gimlet.RouteHandler {
+	return &versionGraphHandler{
+		sc: h.sc,
+	}
+}
+
+// Parse fetches the versionId from the request and the format to return the
+// graph in, which is either json (the default) or dot.
+func (h *versionGraphHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.versionId = gimlet.GetVars(r)["version_id"]
+	if h.versionId == "" {
+		return errors.New("request data incomplete")
+	}
+
+	h.format = r.URL.Query().Get("format")
+	if h.format == "" {
+		h.format = versionGraphFormatJSON
+	}
+	if h.format != versionGraphFormatJSON && h.format != versionGraphFormatDOT {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "format must be 'json' or 'dot'",
+		}
+	}
+
+	return nil
+}
+
+// Run returns the dependency graph of the version's tasks, with the critical
+// path and the slack of each task.
+func (h *versionGraphHandler) Run(ctx context.Context) gimlet.Responder {
+	graph, err := h.sc.GetVersionGraph(h.versionId)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "Database error"))
+	}
+
+	if h.format == versionGraphFormatDOT {
+		return gimlet.NewTextResponse(graph.DOT())
+	}
+
+	graphModel := &model.APIVersionGraph{}
+	if err = graphModel.BuildFromService(graph); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "API model error"))
+	}
+	graphModel.VersionId = model.ToAPIString(h.versionId)
+	return gimlet.NewJSONResponse(graphModel)
+}
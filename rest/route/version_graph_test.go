@@ -0,0 +1,70 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionGraphHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sc := &data.MockConnector{
+		MockVersionConnector: data.MockVersionConnector{
+			CachedTasks: []task.Task{
+				{Id: "compile", DisplayName: "compile", BuildVariant: "linux", Version: "v1",
+					Status: evergreen.TaskSucceeded, TimeTaken: 10 * time.Minute},
+				{Id: "test", DisplayName: "test", BuildVariant: "linux", Version: "v1",
+					Status: evergreen.TaskUndispatched, ExpectedDuration: 20 * time.Minute,
+					DependsOn: []task.Dependency{{TaskId: "compile", Status: evergreen.TaskSucceeded}}},
+				{Id: "lint", DisplayName: "lint", BuildVariant: "linux", Version: "v1",
+					Status: evergreen.TaskSucceeded, TimeTaken: 5 * time.Minute},
+			},
+		},
+	}
+
+	handler := &versionGraphHandler{versionId: "v1", format: versionGraphFormatJSON, sc: sc}
+	resp := handler.Run(context.Background())
+	require.Equal(http.StatusOK, resp.Status())
+	graph, ok := resp.Data().(*model.APIVersionGraph)
+	require.True(ok)
+	assert.Equal("v1", model.FromAPIString(graph.VersionId))
+	assert.Equal(30*time.Minute, graph.Length.ToDuration())
+	assert.Equal([]model.APIString{model.ToAPIString("compile"), model.ToAPIString("test")}, graph.CriticalPath)
+	require.Len(graph.Tasks, 3)
+	for _, tsk := range graph.Tasks {
+		switch model.FromAPIString(tsk.TaskId) {
+		case "test":
+			assert.True(tsk.Critical)
+			assert.True(tsk.ExpectedDuration)
+			assert.Equal(10*time.Minute, tsk.EarliestStart.ToDuration())
+			require.Len(tsk.DependsOn, 1)
+			assert.Equal("compile", model.FromAPIString(tsk.DependsOn[0].TaskId))
+		case "lint":
+			assert.False(tsk.Critical)
+			assert.Equal(25*time.Minute, tsk.Slack.ToDuration())
+		}
+	}
+
+	handler = &versionGraphHandler{versionId: "v1", format: versionGraphFormatDOT, sc: sc}
+	resp = handler.Run(context.Background())
+	require.Equal(http.StatusOK, resp.Status())
+	dot, ok := resp.Data().(string)
+	require.True(ok)
+	assert.True(strings.HasPrefix(dot, "digraph version {"))
+	assert.Contains(dot, `"compile" -> "test" [color=red, penwidth=2];`)
+
+	handler = &versionGraphHandler{versionId: "v2", format: versionGraphFormatJSON, sc: sc}
+	resp = handler.Run(context.Background())
+	assert.Equal(http.StatusNotFound, resp.Status())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/gimlet"
@@ -130,9 +131,10 @@ func getAbortVersionRouteManager(route string, version int) *RouteManager {
 		Route: route,
 		Methods: []MethodHandler{
 			{
-				Authenticator:  &RequireUserAuthenticator{},
-				RequestHandler: &versionAbortHandler{},
-				MethodType:     http.MethodPost,
+				PrefetchFunctions: []PrefetchFunc{prefetchProjectContext},
+				Authenticator:     &ProjectPermissionAuthenticator{Permission: role.TaskControl},
+				RequestHandler:    &versionAbortHandler{},
+				MethodType:        http.MethodPost,
 			},
 		},
 		Version: version,
@@ -194,9 +196,10 @@ func getRestartVersionRouteManager(route string, version int) *RouteManager {
 		Route: route,
 		Methods: []MethodHandler{
 			{
-				Authenticator:  &RequireUserAuthenticator{},
-				RequestHandler: &versionRestartHandler{},
-				MethodType:     http.MethodPost,
+				PrefetchFunctions: []PrefetchFunc{prefetchProjectContext},
+				Authenticator:     &ProjectPermissionAuthenticator{Permission: role.TaskControl},
+				RequestHandler:    &versionRestartHandler{},
+				MethodType:        http.MethodPost,
 			},
 		},
 		Version: version,
@@ -0,0 +1,337 @@
+package scheduler
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// CostBudgetHostAllocator requests as many hosts as the utilization based
+// allocator would, but no more than fit within the hourly budget of the
+// distro and of each project with tasks in the queue. The cost of a host is
+// estimated from the distro's running hosts; if no estimate is available and
+// the distro has no hosts, one host is started so that an estimate can be
+// made on a later run.
+func CostBudgetHostAllocator(ctx context.Context, hostAllocatorData HostAllocatorData) (int, error) {
+	demand, err := UtilizationBasedHostAllocator(ctx, hostAllocatorData)
+	if err != nil {
+		return 0, errors.Wrap(err, "problem calculating host demand")
+	}
+
+	allowed, budgetEvents := applyHostBudgets(hostAllocatorData, demand)
+	for _, info := range budgetEvents {
+		if info.HostHourlyCost <= 0 {
+			event.LogSchedulerBudgetCostUnknownEvent(hostAllocatorData.distro.Id, info)
+			continue
+		}
+		event.LogSchedulerBudgetExceededEvent(hostAllocatorData.distro.Id, info)
+	}
+
+	grip.Info(message.Fields{
+		"runner":           RunnerName,
+		"distro":           hostAllocatorData.distro.Id,
+		"message":          "applied hourly budgets to requested hosts",
+		"host_hourly_cost": hostAllocatorData.hostHourlyCost,
+		"distro_budget":    hostAllocatorData.distro.HourlyBudget,
+		"hosts_requested":  demand,
+		"hosts_allowed":    allowed,
+		"budgets_exceeded": len(budgetEvents),
+	})
+
+	return allowed, nil
+}
+
+// applyHostBudgets reduces the number of requested new hosts so that the
+// distro's and projects' hourly budgets are not exceeded, and returns the
+// number of hosts allowed with a description of each budget that was hit.
+//
+// Each project is attributed a share of the requested hosts in proportion to
+// the expected duration of its tasks in the queue. Project budgets count the
+// project's hosts in the distro being planned, and its spend in other distros.
+//
+// If the cost of the distro's hosts is unknown, one host is allowed only if
+// the distro has no running or starting hosts, and this is reported as a
+// budget event with no host cost.
+func applyHostBudgets(data HostAllocatorData, demand int) (int, []event.BudgetInfo) {
+	if demand <= 0 {
+		return 0, nil
+	}
+	if data.distro.HourlyBudget <= 0 && len(data.projectBudgets) == 0 {
+		return demand, nil
+	}
+
+	cost := data.hostHourlyCost
+	if cost <= 0 {
+		allowed := unknownCostHostsAllowed(data)
+		grip.Info(message.Fields{
+			"runner":          RunnerName,
+			"distro":          data.distro.Id,
+			"message":         "no host cost estimate available, only starting a host if the distro has none",
+			"hosts_existing":  len(data.existingHosts),
+			"hosts_requested": demand,
+			"hosts_allowed":   allowed,
+		})
+		return allowed, []event.BudgetInfo{{
+			HourlyBudget:   data.distro.HourlyBudget,
+			HostsRequested: demand,
+			HostsAllowed:   allowed,
+			TasksWaiting:   countTasksBeyondCapacity(data.taskQueueItems, 0, allowed),
+		}}
+	}
+
+	budgetEvents := []event.BudgetInfo{}
+	allowed := demand
+	perHostDuration := calcScheduledTasksDuration(data.taskQueueItems) / time.Duration(demand)
+
+	// project budgets
+	projectQueues := map[string][]model.TaskQueueItem{}
+	for _, item := range data.taskQueueItems {
+		if _, ok := data.projectBudgets[item.Project]; ok {
+			projectQueues[item.Project] = append(projectQueues[item.Project], item)
+		}
+	}
+	projects := make([]string, 0, len(projectQueues))
+	for project := range projectQueues {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	for _, project := range projects {
+		queue := projectQueues[project]
+		budget := data.projectBudgets[project]
+		projectDemand := projectHostDemand(data.taskQueueItems, queue, demand)
+
+		running := 0
+		for _, h := range data.existingHosts {
+			if h.RunningTask != "" && h.RunningTaskProject == project {
+				running++
+			}
+		}
+		affordable := int(math.Floor((budget-data.projectSpend[project])/cost)) - running
+		if affordable < 0 {
+			affordable = 0
+		}
+		if projectDemand <= affordable {
+			continue
+		}
+
+		allowed -= projectDemand - affordable
+		budgetEvents = append(budgetEvents, event.BudgetInfo{
+			Project:        project,
+			HourlyBudget:   budget,
+			HostHourlyCost: cost,
+			HostsRequested: projectDemand,
+			HostsAllowed:   affordable,
+			TasksWaiting:   countTasksBeyondCapacity(queue, perHostDuration, affordable),
+		})
+	}
+	if allowed < 0 {
+		allowed = 0
+	}
+
+	// distro budget
+	if budget := data.distro.HourlyBudget; budget > 0 {
+		affordable := int(math.Floor(budget/cost)) - len(data.existingHosts)
+		if affordable < 0 {
+			affordable = 0
+		}
+		if allowed > affordable {
+			budgetEvents = append(budgetEvents, event.BudgetInfo{
+				HourlyBudget:   budget,
+				HostHourlyCost: cost,
+				HostsRequested: demand,
+				HostsAllowed:   affordable,
+				TasksWaiting:   countTasksBeyondCapacity(data.taskQueueItems, perHostDuration, affordable),
+			})
+			allowed = affordable
+		}
+	}
+
+	return allowed, budgetEvents
+}
+
+// projectHostDemand returns the share of the requested hosts attributed to
+// the tasks in projectQueue, in proportion to their expected duration. If
+// the queue has no expected durations, task counts are used instead.
+func projectHostDemand(queue, projectQueue []model.TaskQueueItem, demand int) int {
+	total := calcScheduledTasksDuration(queue)
+	if total <= 0 {
+		return int(math.Ceil(float64(demand*len(projectQueue)) / float64(len(queue))))
+	}
+	share := float64(calcScheduledTasksDuration(projectQueue)) / float64(total)
+	return int(math.Ceil(share * float64(demand)))
+}
+
+// countTasksBeyondCapacity returns how many tasks at the end of the queue
+// cannot be run by the given number of hosts, each of which is expected to
+// run perHostDuration worth of tasks.
+func countTasksBeyondCapacity(queue []model.TaskQueueItem, perHostDuration time.Duration, hosts int) int {
+	if perHostDuration <= 0 {
+		waiting := len(queue) - hosts
+		if waiting < 0 {
+			return 0
+		}
+		return waiting
+	}
+
+	capacity := perHostDuration * time.Duration(hosts)
+	var scheduled time.Duration
+	for i, item := range queue {
+		scheduled += item.ExpectedDuration
+		if scheduled > capacity {
+			return len(queue) - i
+		}
+	}
+	return 0
+}
+
+// capHostsToDistroBudget limits the number of new hosts to spawn for the
+// distro's pool target to what fits in the distro's hourly budget. It never
+// returns fewer hosts than the allocator allowed.
+func capHostsToDistroBudget(data HostAllocatorData, allowed, newHosts int) int {
+	budget := data.distro.HourlyBudget
+	if budget <= 0 || newHosts <= allowed {
+		return newHosts
+	}
+
+	affordable := unknownCostHostsAllowed(data)
+	if data.hostHourlyCost > 0 {
+		affordable = int(math.Floor(budget/data.hostHourlyCost)) - len(data.existingHosts)
+	}
+	if affordable < allowed {
+		affordable = allowed
+	}
+	if newHosts > affordable {
+		grip.Info(message.Fields{
+			"runner":        RunnerName,
+			"distro":        data.distro.Id,
+			"message":       "distro budget limited hosts started for its pool target",
+			"hosts_pool":    newHosts,
+			"hosts_allowed": affordable,
+		})
+		return affordable
+	}
+	return newHosts
+}
+
+// unknownCostHostsAllowed returns the number of new hosts allowed when the
+// cost of the distro's hosts is unknown: a single host to estimate the cost
+// from if the distro has no running or starting hosts, and none otherwise,
+// since the distro's existing hosts will provide an estimate once they run.
+func unknownCostHostsAllowed(data HostAllocatorData) int {
+	if len(data.existingHosts) > 0 {
+		return 0
+	}
+	return 1
+}
+
+// estimateProjectSpend returns the estimated hourly cost of the hosts outside
+// the given distro that are running tasks of each of the projects. Hosts in
+// distros whose cost can't be estimated are assumed to cost defaultCost.
+func estimateProjectSpend(ctx context.Context, distroID string, projects []string, defaultCost float64, s *evergreen.Settings) (map[string]float64, error) {
+	spend := map[string]float64{}
+	if len(projects) == 0 {
+		return spend, nil
+	}
+
+	hosts, err := host.Find(host.ByRunningTaskProjects(projects))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem finding hosts running budgeted projects")
+	}
+
+	distroHosts := map[string][]host.Host{}
+	distros := map[string]distro.Distro{}
+	for _, h := range hosts {
+		if h.Distro.Id == distroID {
+			continue
+		}
+		distroHosts[h.Distro.Id] = append(distroHosts[h.Distro.Id], h)
+		distros[h.Distro.Id] = h.Distro
+	}
+
+	for id, hosts := range distroHosts {
+		cost := estimateHostHourlyCost(ctx, distros[id], hosts, s)
+		if cost <= 0 {
+			cost = defaultCost
+		}
+		for _, h := range hosts {
+			spend[h.RunningTaskProject] += cost
+		}
+	}
+
+	return spend, nil
+}
+
+// maxHostsForCostEstimate bounds the number of provider cost lookups made
+// when estimating the hourly cost of a distro's hosts.
+const maxHostsForCostEstimate = 3
+
+// estimateHostHourlyCost averages the cost over the last hour of up to
+// maxHostsForCostEstimate of the distro's existing hosts. It returns zero if
+// the provider cannot calculate costs or there are no hosts to measure.
+func estimateHostHourlyCost(ctx context.Context, d distro.Distro, hosts []host.Host, s *evergreen.Settings) float64 {
+	if len(hosts) == 0 {
+		return 0
+	}
+
+	manager, err := cloud.GetManager(ctx, d.Provider, s)
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"runner":  RunnerName,
+			"distro":  d.Id,
+			"message": "could not get cloud manager to estimate host cost",
+		}))
+		return 0
+	}
+	calc, ok := manager.(cloud.CostCalculator)
+	if !ok {
+		return 0
+	}
+
+	now := time.Now()
+	var total float64
+	measured := 0
+	for i := range hosts {
+		if measured >= maxHostsForCostEstimate {
+			break
+		}
+		start := now.Add(-time.Hour)
+		if hosts[i].CreationTime.After(start) {
+			start = hosts[i].CreationTime
+		}
+		span := now.Sub(start)
+		if span < time.Minute {
+			continue
+		}
+
+		cost, err := calc.CostForDuration(ctx, &hosts[i], start, now, s)
+		if err != nil {
+			grip.Warning(message.WrapError(err, message.Fields{
+				"runner":  RunnerName,
+				"distro":  d.Id,
+				"host":    hosts[i].Id,
+				"message": "could not calculate host cost",
+			}))
+			continue
+		}
+
+		total += cost * float64(time.Hour) / float64(span)
+		measured++
+	}
+
+	if measured == 0 {
+		return 0
+	}
+	return total / float64(measured)
+}
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeBudgetQueue(project string, n int, duration time.Duration) []model.TaskQueueItem {
+	queue := make([]model.TaskQueueItem, n)
+	for i := range queue {
+		queue[i] = model.TaskQueueItem{Project: project, ExpectedDuration: duration}
+	}
+	return queue
+}
+
+func TestApplyHostBudgetsWithoutBudgets(t *testing.T) {
+	assert := assert.New(t)
+
+	data := HostAllocatorData{
+		distro:         distro.Distro{Id: "d"},
+		taskQueueItems: makeBudgetQueue("p", 10, 30*time.Minute),
+		hostHourlyCost: 1,
+	}
+	allowed, events := applyHostBudgets(data, 10)
+	assert.Equal(10, allowed)
+	assert.Empty(events)
+
+	allowed, events = applyHostBudgets(data, 0)
+	assert.Equal(0, allowed)
+	assert.Empty(events)
+}
+
+func TestApplyHostBudgetsWithoutCostEstimate(t *testing.T) {
+	assert := assert.New(t)
+
+	data := HostAllocatorData{
+		distro:         distro.Distro{Id: "d", HourlyBudget: 5},
+		taskQueueItems: makeBudgetQueue("p", 10, 30*time.Minute),
+	}
+	allowed, events := applyHostBudgets(data, 10)
+	assert.Equal(1, allowed)
+	if assert.Len(events, 1) {
+		assert.Zero(events[0].HostHourlyCost)
+		assert.Equal(5.0, events[0].HourlyBudget)
+		assert.Equal(10, events[0].HostsRequested)
+		assert.Equal(1, events[0].HostsAllowed)
+		assert.Equal(9, events[0].TasksWaiting)
+	}
+
+	// once the distro has a host, no more are started until its cost is
+	// known
+	data.existingHosts = []host.Host{{Id: "h1", Status: evergreen.HostStarting}}
+	allowed, events = applyHostBudgets(data, 10)
+	assert.Equal(0, allowed)
+	if assert.Len(events, 1) {
+		assert.Zero(events[0].HostHourlyCost)
+		assert.Equal(0, events[0].HostsAllowed)
+		assert.Equal(10, events[0].TasksWaiting)
+	}
+}
+
+func TestApplyHostBudgetsDistroCap(t *testing.T) {
+	assert := assert.New(t)
+
+	data := HostAllocatorData{
+		distro:         distro.Distro{Id: "d", HourlyBudget: 10},
+		taskQueueItems: makeBudgetQueue("p", 10, 30*time.Minute),
+		existingHosts:  []host.Host{{Id: "h1", RunningTask: "t1"}, {Id: "h2"}},
+		hostHourlyCost: 2,
+	}
+
+	// 10/2 = 5 hosts affordable, 2 already running
+	allowed, events := applyHostBudgets(data, 10)
+	assert.Equal(3, allowed)
+	if assert.Len(events, 1) {
+		assert.Equal("", events[0].Project)
+		assert.Equal(10.0, events[0].HourlyBudget)
+		assert.Equal(2.0, events[0].HostHourlyCost)
+		assert.Equal(10, events[0].HostsRequested)
+		assert.Equal(3, events[0].HostsAllowed)
+		assert.Equal(7, events[0].TasksWaiting)
+	}
+
+	// under the cap, nothing changes
+	allowed, events = applyHostBudgets(data, 2)
+	assert.Equal(2, allowed)
+	assert.Empty(events)
+
+	// already over the cap
+	data.hostHourlyCost = 20
+	allowed, events = applyHostBudgets(data, 4)
+	assert.Equal(0, allowed)
+	if assert.Len(events, 1) {
+		assert.Equal(10, events[0].TasksWaiting)
+	}
+}
+
+func TestApplyHostBudgetsProjectCap(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := append(makeBudgetQueue("capped", 6, 30*time.Minute), makeBudgetQueue("free", 6, 30*time.Minute)...)
+	data := HostAllocatorData{
+		distro:         distro.Distro{Id: "d"},
+		taskQueueItems: queue,
+		existingHosts: []host.Host{
+			{Id: "h1", RunningTask: "t1", RunningTaskProject: "capped"},
+			{Id: "h2", RunningTask: "t2", RunningTaskProject: "free"},
+		},
+		hostHourlyCost: 1,
+		projectBudgets: map[string]float64{"capped": 3, "unused": 1},
+	}
+
+	// "capped" gets half of the 6 requested hosts, but can only afford 2
+	allowed, events := applyHostBudgets(data, 6)
+	assert.Equal(5, allowed)
+	if assert.Len(events, 1) {
+		assert.Equal("capped", events[0].Project)
+		assert.Equal(3, events[0].HostsRequested)
+		assert.Equal(2, events[0].HostsAllowed)
+		assert.Equal(2, events[0].TasksWaiting)
+	}
+
+	// both project and distro caps apply
+	data.distro.HourlyBudget = 5
+	allowed, events = applyHostBudgets(data, 6)
+	assert.Equal(3, allowed)
+	assert.Len(events, 2)
+}
+
+func TestApplyHostBudgetsProjectSpendInOtherDistros(t *testing.T) {
+	assert := assert.New(t)
+
+	data := HostAllocatorData{
+		distro:         distro.Distro{Id: "d"},
+		taskQueueItems: makeBudgetQueue("capped", 6, 30*time.Minute),
+		existingHosts:  []host.Host{{Id: "h1", RunningTask: "t1", RunningTaskProject: "capped"}},
+		hostHourlyCost: 1,
+		projectBudgets: map[string]float64{"capped": 5},
+		projectSpend:   map[string]float64{"capped": 2.5},
+	}
+
+	// 5 - 2.5 spent elsewhere affords 2 hosts, 1 already running
+	allowed, events := applyHostBudgets(data, 6)
+	assert.Equal(1, allowed)
+	if assert.Len(events, 1) {
+		assert.Equal("capped", events[0].Project)
+		assert.Equal(1, events[0].HostsAllowed)
+	}
+
+	// spending the whole budget elsewhere allows no new hosts
+	data.projectSpend["capped"] = 6
+	allowed, _ = applyHostBudgets(data, 6)
+	assert.Equal(0, allowed)
+}
+
+func TestCapHostsToDistroBudget(t *testing.T) {
+	assert := assert.New(t)
+
+	data := HostAllocatorData{
+		distro:         distro.Distro{Id: "d", HourlyBudget: 10},
+		existingHosts:  []host.Host{{Id: "h1"}, {Id: "h2"}},
+		hostHourlyCost: 2,
+	}
+
+	// 10/2 = 5 hosts affordable, 2 already running
+	assert.Equal(3, capHostsToDistroBudget(data, 1, 5))
+	assert.Equal(2, capHostsToDistroBudget(data, 1, 2))
+	// never fewer than the allocator allowed
+	assert.Equal(4, capHostsToDistroBudget(data, 4, 6))
+
+	// without a cost estimate, no hosts beyond the existing ones
+	data.hostHourlyCost = 0
+	assert.Equal(0, capHostsToDistroBudget(data, 0, 3))
+	assert.Equal(1, capHostsToDistroBudget(data, 1, 3))
+	// and one to estimate the cost from if there are none
+	data.existingHosts = nil
+	assert.Equal(1, capHostsToDistroBudget(data, 0, 3))
+	data.existingHosts = []host.Host{{Id: "h1"}, {Id: "h2"}}
+
+	// without a distro budget, nothing changes
+	data.distro.HourlyBudget = 0
+	assert.Equal(3, capHostsToDistroBudget(data, 0, 3))
+}
+
+func TestCountTasksBeyondCapacity(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := makeBudgetQueue("p", 4, time.Hour)
+	assert.Equal(0, countTasksBeyondCapacity(queue, 2*time.Hour, 2))
+	assert.Equal(2, countTasksBeyondCapacity(queue, 2*time.Hour, 1))
+	assert.Equal(4, countTasksBeyondCapacity(queue, 2*time.Hour, 0))
+	assert.Equal(1, countTasksBeyondCapacity(queue, 0, 3))
+	assert.Equal(0, countTasksBeyondCapacity(queue, 0, 5))
+}
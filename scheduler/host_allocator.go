@@ -28,6 +28,12 @@ type HostAllocatorData struct {
 	freeHostFraction float64
 	usesContainers   bool
 	containerPool    *evergreen.ContainerPool
+	// hostHourlyCost, projectBudgets and projectSpend are only populated
+	// for the cost_budget allocator. projectSpend is the estimated hourly
+	// cost of the hosts in other distros running each project's tasks.
+	hostHourlyCost float64
+	projectBudgets map[string]float64
+	projectSpend   map[string]float64
 }
 
 func GetHostAllocator(name string) HostAllocator {
@@ -38,6 +44,8 @@ func GetHostAllocator(name string) HostAllocator {
 		return DurationBasedHostAllocator
 	case "utilization":
 		return UtilizationBasedHostAllocator
+	case evergreen.HostAllocatorCostBudget:
+		return CostBudgetHostAllocator
 	default:
 		return UtilizationBasedHostAllocator
 	}
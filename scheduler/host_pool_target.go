@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+)
+
+// numHostsForPoolTarget returns the number of new hosts to spawn for a
+// distro, given the number the host allocator asked for, so that the distro
+// has at least its minimum number of hosts, and its warm standby number of
+// hosts free once the queued tasks are dispatched. It never returns fewer
+// hosts than the allocator asked for, nor adds hosts beyond the pool size.
+func numHostsForPoolTarget(d distro.Distro, existingHosts []host.Host, queueLength, newHosts int, now time.Time) int {
+	if !d.IsEphemeral() {
+		return newHosts
+	}
+
+	target := d.HostPoolTarget(now)
+	if target.MinHosts == 0 && target.WarmStandby == 0 {
+		return newHosts
+	}
+
+	freeHosts := 0
+	for _, h := range existingHosts {
+		if h.RunningTask == "" {
+			freeHosts++
+		}
+	}
+
+	// hosts left free after the queue is dispatched
+	standbyHosts := freeHosts + newHosts - queueLength
+	if standbyHosts < 0 {
+		standbyHosts = 0
+	}
+
+	extraHosts := 0
+	if missing := target.WarmStandby - standbyHosts; missing > extraHosts {
+		extraHosts = missing
+	}
+	if missing := target.MinHosts - (len(existingHosts) + newHosts); missing > extraHosts {
+		extraHosts = missing
+	}
+	if extraHosts == 0 {
+		return newHosts
+	}
+
+	total := newHosts + extraHosts
+	if d.PoolSize > 0 && len(existingHosts)+total > d.PoolSize {
+		total = d.PoolSize - len(existingHosts)
+		if total < newHosts {
+			total = newHosts
+		}
+	}
+
+	return total
+}
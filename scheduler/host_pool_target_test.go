@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+)
+
+func makePoolTargetHosts(running, free int) []host.Host {
+	hosts := []host.Host{}
+	for i := 0; i < running; i++ {
+		hosts = append(hosts, host.Host{RunningTask: "task"})
+	}
+	for i := 0; i < free; i++ {
+		hosts = append(hosts, host.Host{})
+	}
+	return hosts
+}
+
+func TestNumHostsForPoolTarget(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	d := distro.Distro{Id: "d", Provider: evergreen.ProviderNameEc2OnDemand, PoolSize: 10}
+
+	// without targets, the allocator decides
+	assert.Equal(0, numHostsForPoolTarget(d, nil, 0, 0, now))
+	assert.Equal(3, numHostsForPoolTarget(d, nil, 3, 3, now))
+
+	// the minimum is kept without tasks
+	d.MinHosts = 4
+	assert.Equal(4, numHostsForPoolTarget(d, nil, 0, 0, now))
+	assert.Equal(1, numHostsForPoolTarget(d, makePoolTargetHosts(3, 0), 0, 0, now))
+	assert.Equal(0, numHostsForPoolTarget(d, makePoolTargetHosts(2, 2), 0, 0, now))
+	assert.Equal(5, numHostsForPoolTarget(d, nil, 5, 5, now))
+
+	// warm standby hosts are kept free beyond the queue
+	d.MinHosts = 0
+	d.WarmStandby = 2
+	assert.Equal(2, numHostsForPoolTarget(d, makePoolTargetHosts(3, 0), 0, 0, now))
+	assert.Equal(1, numHostsForPoolTarget(d, makePoolTargetHosts(3, 1), 0, 0, now))
+	assert.Equal(0, numHostsForPoolTarget(d, makePoolTargetHosts(3, 2), 0, 0, now))
+	assert.Equal(2, numHostsForPoolTarget(d, makePoolTargetHosts(3, 2), 2, 0, now))
+	assert.Equal(4, numHostsForPoolTarget(d, makePoolTargetHosts(0, 0), 2, 2, now))
+
+	// the pool size limits the extra hosts, but not the allocator's
+	assert.Equal(1, numHostsForPoolTarget(d, makePoolTargetHosts(9, 0), 0, 0, now))
+	assert.Equal(0, numHostsForPoolTarget(d, makePoolTargetHosts(10, 0), 0, 0, now))
+	d.PoolSize = 2
+	assert.Equal(3, numHostsForPoolTarget(d, nil, 3, 3, now))
+
+	// static distros cannot spawn hosts
+	d.Provider = evergreen.ProviderNameStatic
+	d.MinHosts = 4
+	assert.Equal(0, numHostsForPoolTarget(d, nil, 0, 0, now))
+}
@@ -91,11 +91,32 @@ func PlanDistro(ctx context.Context, conf Configuration, s *evergreen.Settings)
 		allocatorArgs.containerPool = pool
 	}
 
+	if conf.HostAllocator == evergreen.HostAllocatorCostBudget {
+		allocatorArgs.hostHourlyCost = estimateHostHourlyCost(ctx, distroSpec, distroHosts, s)
+		allocatorArgs.projectBudgets, err = model.FindProjectHourlyBudgets()
+		if err != nil {
+			return errors.Wrap(err, "problem finding project budgets")
+		}
+		projects := make([]string, 0, len(allocatorArgs.projectBudgets))
+		for project := range allocatorArgs.projectBudgets {
+			projects = append(projects, project)
+		}
+		allocatorArgs.projectSpend, err = estimateProjectSpend(ctx, distroSpec.Id, projects, allocatorArgs.hostHourlyCost, s)
+		if err != nil {
+			return errors.Wrap(err, "problem estimating project spend")
+		}
+	}
+
 	allocator := GetHostAllocator(conf.HostAllocator)
 	newHosts, err := allocator(ctx, allocatorArgs)
 	if err != nil {
 		return errors.Wrap(err, "problem finding distro")
 	}
+	poolHosts := numHostsForPoolTarget(distroSpec, distroHosts, len(res.taskQueueItem), newHosts, startAt)
+	if conf.HostAllocator == evergreen.HostAllocatorCostBudget {
+		poolHosts = capHostsToDistroBudget(allocatorArgs, newHosts, poolHosts)
+	}
+	newHosts = poolHosts
 
 	hostsSpawned, err := spawnHosts(ctx, distroSpec, newHosts, pool)
 	if err != nil {
@@ -120,6 +141,7 @@ func PlanDistro(ctx context.Context, conf Configuration, s *evergreen.Settings)
 		"distro":                 conf.DistroID,
 		"provider":               distroSpec.Provider,
 		"max_hosts":              distroSpec.PoolSize,
+		"pool_target":            distroSpec.HostPoolTarget(startAt),
 		"new_hosts":              hostsSpawned,
 		"num_hosts":              len(hostsSpawned),
 		"queue":                  res.schedulerEvent,
@@ -138,3 +138,7 @@ db.users.ensureIndex({ "settings.github_user.uid": 1 }, { unique: true })
 
 //======notifications======//
 db.notifications.ensureIndex({ "sent_at": 1 })
+db.notifications.ensureIndex({ "next_attempt": 1 }, { sparse: true })
+
+//======notifications_dead_letter======//
+db.notifications_dead_letter.ensureIndex({ "created_at": -1 })
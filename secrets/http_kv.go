@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// defaultHTTPKVField is the field read from a secret when the reference does
+// not name one.
+const defaultHTTPKVField = "value"
+
+// httpKVProvider reads secrets from a Vault-style key/value HTTP API. Paths
+// have the form "<secret path>#<field>", and are requested from
+// "<url>/v1/<path prefix>/<secret path>". Both the KV version 1 response shape,
+// {"data": {...}}, and the version 2 shape, {"data": {"data": {...}}}, are
+// supported.
+type httpKVProvider struct {
+	url         string
+	token       string
+	tokenHeader string
+	pathPrefix  string
+}
+
+// NewHTTPKVProvider returns a SecretProvider backed by an HTTP KV store.
+func NewHTTPKVProvider(conf *evergreen.HTTPKVSecretsConfig) SecretProvider {
+	header := conf.TokenHeader
+	if header == "" {
+		header = "X-Vault-Token"
+	}
+	prefix := strings.Trim(conf.PathPrefix, "/")
+	if prefix == "" {
+		prefix = evergreen.DefaultHTTPKVPathPrefix
+	}
+	return &httpKVProvider{
+		url:         strings.TrimSuffix(conf.URL, "/"),
+		token:       conf.Token,
+		tokenHeader: header,
+		pathPrefix:  prefix,
+	}
+}
+
+func (p *httpKVProvider) GetSecret(ctx context.Context, path string) (string, error) {
+	secretPath, field := path, defaultHTTPKVField
+	if idx := strings.LastIndex(path, "#"); idx >= 0 {
+		secretPath, field = path[:idx], path[idx+1:]
+	}
+	if field == "" || validateRelativePath(secretPath) != nil {
+		return "", errors.Errorf("invalid HTTP KV secret path '%s'", path)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s/%s", p.url, p.pathPrefix, secretPath), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "problem creating request")
+	}
+	req = req.WithContext(ctx)
+	if p.token != "" {
+		req.Header.Set(p.tokenHeader, p.token)
+	}
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "problem requesting secret")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "problem reading response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("secret store returned status %d for '%s'", resp.StatusCode, secretPath)
+	}
+
+	out := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err = json.Unmarshal(body, &out); err != nil {
+		return "", errors.Wrap(err, "problem parsing response")
+	}
+
+	data := out.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", errors.Errorf("secret '%s' has no field '%s'", secretPath, field)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", errors.Errorf("field '%s' of secret '%s' is not a string", field, secretPath)
+	}
+
+	return str, nil
+}
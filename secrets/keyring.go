@@ -0,0 +1,139 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+// keyringProvider reads secrets from a local JSON file that maps names to
+// base64-encoded AES-GCM ciphertexts, each prefixed with its nonce. The file
+// is read on every lookup so that it can be updated without a restart.
+type keyringProvider struct {
+	path string
+	aead cipher.AEAD
+}
+
+// NewKeyringProvider returns a SecretProvider backed by an encrypted keyring
+// file.
+func NewKeyringProvider(conf *evergreen.KeyringSecretsConfig) (SecretProvider, error) {
+	if conf.Path == "" {
+		return nil, errors.New("keyring path must not be empty")
+	}
+
+	aead, err := newKeyringCipher(conf.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &keyringProvider{path: conf.Path, aead: aead}, nil
+}
+
+func (p *keyringProvider) GetSecret(_ context.Context, name string) (string, error) {
+	if err := validateRelativePath(name); err != nil {
+		return "", errors.Wrap(err, "invalid keyring secret name")
+	}
+
+	entries, err := readKeyring(p.path)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	encoded, ok := entries[name]
+	if !ok {
+		return "", errors.Errorf("keyring has no secret named '%s'", name)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errors.Wrapf(err, "secret '%s' is not valid base64", name)
+	}
+
+	nonceSize := p.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return "", errors.Errorf("secret '%s' is too short", name)
+	}
+
+	plaintext, err := p.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], []byte(name))
+	if err != nil {
+		return "", errors.Wrapf(err, "problem decrypting secret '%s'", name)
+	}
+
+	return string(plaintext), nil
+}
+
+// SetKeyringSecret encrypts value with the hex-encoded key and stores it in
+// the keyring file at path under name, creating the file if necessary.
+func SetKeyringSecret(path, key, name, value string) error {
+	aead, err := newKeyringCipher(key)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	entries, err := readKeyring(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return errors.Wrap(err, "problem generating nonce")
+	}
+	ciphertext := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	entries[name] = base64.StdEncoding.EncodeToString(ciphertext)
+
+	out, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "problem encoding keyring")
+	}
+
+	return errors.Wrapf(ioutil.WriteFile(path, out, 0600), "problem writing keyring '%s'", path)
+}
+
+func newKeyringCipher(key string) (cipher.AEAD, error) {
+	rawKey, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "keyring key must be hex-encoded")
+	}
+
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid keyring key")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem creating GCM cipher")
+	}
+
+	return aead, nil
+}
+
+// readKeyring returns the entries of the keyring file at path, or an empty
+// keyring if the file does not exist.
+func readKeyring(path string) (map[string]string, error) {
+	entries := map[string]string{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, errors.Wrapf(err, "problem reading keyring '%s'", path)
+	}
+
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrapf(err, "problem parsing keyring '%s'", path)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,129 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProviderHTTPKV is the reference prefix for secrets read from a
+	// Vault-style key/value HTTP API.
+	ProviderHTTPKV = "http_kv"
+	// ProviderKeyring is the reference prefix for secrets read from the
+	// local encrypted keyring file.
+	ProviderKeyring = "keyring"
+)
+
+// SecretProvider is an external store that holds secret values, so that
+// project variables only need to store a reference to them.
+type SecretProvider interface {
+	// GetSecret returns the value stored at path.
+	GetSecret(context.Context, string) (string, error)
+}
+
+// Reference identifies a secret by the provider that holds it and the
+// provider-specific path within that provider. References are written as
+// "<provider>:<path>", for example "http_kv:deploy#api_key" or
+// "keyring:api_key". Paths are relative to the project's own secrets, so a
+// project can't reference another project's secrets.
+type Reference struct {
+	Provider string
+	Path     string
+}
+
+func (r Reference) String() string { return r.Provider + ":" + r.Path }
+
+// ParseReference parses and validates a reference string.
+func ParseReference(ref string) (Reference, error) {
+	parts := strings.SplitN(ref, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return Reference{}, errors.Errorf("secret reference '%s' must have the form <provider>:<path>", ref)
+	}
+
+	secretPath := parts[1]
+	switch parts[0] {
+	case ProviderHTTPKV:
+		if idx := strings.LastIndex(secretPath, "#"); idx >= 0 {
+			secretPath = secretPath[:idx]
+		}
+	case ProviderKeyring:
+	default:
+		return Reference{}, errors.Errorf("secret reference '%s' has unknown provider '%s'", ref, parts[0])
+	}
+	if err := validateRelativePath(secretPath); err != nil {
+		return Reference{}, errors.Wrapf(err, "invalid secret reference '%s'", ref)
+	}
+
+	return Reference{Provider: parts[0], Path: parts[1]}, nil
+}
+
+// validateRelativePath checks that path is a relative path that can't leave
+// the directory it is resolved in.
+func validateRelativePath(path string) error {
+	if path == "" {
+		return errors.New("path must not be empty")
+	}
+	if strings.HasPrefix(path, "/") || strings.Contains(path, "\\") {
+		return errors.Errorf("path '%s' must be relative", path)
+	}
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "", ".", "..":
+			return errors.Errorf("path '%s' must not have empty, '.' or '..' segments", path)
+		}
+	}
+	return nil
+}
+
+// projectPath returns the path of a project's secret in a provider.
+func projectPath(projectID, path string) (string, error) {
+	if err := validateRelativePath(projectID); err != nil || strings.ContainsAny(projectID, "/#") {
+		return "", errors.Errorf("invalid project '%s' for secret", projectID)
+	}
+	return projectID + "/" + path, nil
+}
+
+// LoadSecretProviders creates a SecretProvider for each store configured in
+// the admin settings, keyed by its reference prefix.
+func LoadSecretProviders(conf evergreen.SecretsConfig) (map[string]SecretProvider, error) {
+	providers := map[string]SecretProvider{}
+	if conf.HTTPKV != nil {
+		providers[ProviderHTTPKV] = NewHTTPKVProvider(conf.HTTPKV)
+	}
+	if conf.Keyring != nil {
+		provider, err := NewKeyringProvider(conf.Keyring)
+		if err != nil {
+			return nil, errors.Wrap(err, "problem loading keyring secrets provider")
+		}
+		providers[ProviderKeyring] = provider
+	}
+	return providers, nil
+}
+
+// Resolve looks up the value of a project's reference string using the
+// matching provider. The reference is resolved among the project's own
+// secrets, which each provider stores under "<project>/".
+func Resolve(ctx context.Context, providers map[string]SecretProvider, projectID, ref string) (string, error) {
+	reference, err := ParseReference(ref)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	path, err := projectPath(projectID, reference.Path)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	provider, ok := providers[reference.Provider]
+	if !ok {
+		return "", errors.Errorf("secret provider '%s' is not configured", reference.Provider)
+	}
+
+	value, err := provider.GetSecret(ctx, path)
+	if err != nil {
+		return "", errors.Wrapf(err, "problem resolving secret '%s'", reference)
+	}
+	return value, nil
+}
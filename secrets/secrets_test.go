@@ -0,0 +1,176 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyringKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestParseReference(t *testing.T) {
+	assert := assert.New(t)
+
+	ref, err := ParseReference("http_kv:deploy/aws#api_key")
+	assert.NoError(err)
+	assert.Equal(ProviderHTTPKV, ref.Provider)
+	assert.Equal("deploy/aws#api_key", ref.Path)
+	assert.Equal("http_kv:deploy/aws#api_key", ref.String())
+
+	ref, err = ParseReference("keyring:api_key")
+	assert.NoError(err)
+	assert.Equal(ProviderKeyring, ref.Provider)
+
+	for _, invalid := range []string{
+		"", "keyring", "keyring:", "s3:bucket/key",
+		"keyring:/etc/passwd", "keyring:../other/api_key", "keyring:a/../../b",
+		"http_kv:../other#api_key", "http_kv:/secret/data/other#api_key", "http_kv:#api_key",
+		"keyring:a//b", "keyring:a\\..\\b",
+	} {
+		_, err = ParseReference(invalid)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestHTTPKVProvider(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/evergreen/projects/project/deploy":
+			assert.NoError(json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data": map[string]interface{}{"api_key": "v2-key", "port": 22},
+				},
+			}))
+		case "/v1/kv/project/deploy":
+			assert.NoError(json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"value": "v1-value"},
+			}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewHTTPKVProvider(&evergreen.HTTPKVSecretsConfig{URL: server.URL + "/", Token: "token"})
+
+	value, err := provider.GetSecret(ctx, "project/deploy#api_key")
+	assert.NoError(err)
+	assert.Equal("v2-key", value)
+
+	_, err = provider.GetSecret(ctx, "project/deploy#missing")
+	assert.Error(err)
+	_, err = provider.GetSecret(ctx, "project/deploy#port")
+	assert.Error(err)
+	_, err = provider.GetSecret(ctx, "other/deploy#api_key")
+	assert.Error(err)
+	_, err = provider.GetSecret(ctx, "#api_key")
+	assert.Error(err)
+	_, err = provider.GetSecret(ctx, "project/../other/deploy#api_key")
+	assert.Error(err)
+
+	provider = NewHTTPKVProvider(&evergreen.HTTPKVSecretsConfig{URL: server.URL, Token: "token", PathPrefix: "/kv/"})
+	value, err = provider.GetSecret(ctx, "project/deploy")
+	assert.NoError(err)
+	assert.Equal("v1-value", value)
+
+	provider = NewHTTPKVProvider(&evergreen.HTTPKVSecretsConfig{URL: server.URL, Token: "wrong", PathPrefix: "kv"})
+	_, err = provider.GetSecret(ctx, "project/deploy")
+	assert.Error(err)
+}
+
+func TestKeyringProvider(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "evergreen-keyring")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keyring.json")
+
+	_, err = NewKeyringProvider(&evergreen.KeyringSecretsConfig{Path: path, Key: "not-hex"})
+	assert.Error(err)
+	_, err = NewKeyringProvider(&evergreen.KeyringSecretsConfig{Key: testKeyringKey})
+	assert.Error(err)
+
+	require.NoError(SetKeyringSecret(path, testKeyringKey, "project/api_key", "hunter2"))
+	require.NoError(SetKeyringSecret(path, testKeyringKey, "project/other", "other"))
+
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	assert.NotContains(string(raw), "hunter2")
+
+	provider, err := NewKeyringProvider(&evergreen.KeyringSecretsConfig{Path: path, Key: testKeyringKey})
+	require.NoError(err)
+
+	value, err := provider.GetSecret(ctx, "project/api_key")
+	assert.NoError(err)
+	assert.Equal("hunter2", value)
+
+	_, err = provider.GetSecret(ctx, "project/missing")
+	assert.Error(err)
+
+	// a different key must not be able to decrypt the values
+	wrongKey := "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"
+	provider, err = NewKeyringProvider(&evergreen.KeyringSecretsConfig{Path: path, Key: wrongKey})
+	require.NoError(err)
+	_, err = provider.GetSecret(ctx, "project/api_key")
+	assert.Error(err)
+}
+
+func TestResolve(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "evergreen-keyring")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keyring.json")
+	require.NoError(SetKeyringSecret(path, testKeyringKey, "project/name", "value"))
+	require.NoError(SetKeyringSecret(path, testKeyringKey, "other/name", "other"))
+
+	providers, err := LoadSecretProviders(evergreen.SecretsConfig{
+		Keyring: &evergreen.KeyringSecretsConfig{Path: path, Key: testKeyringKey},
+	})
+	require.NoError(err)
+	assert.Len(providers, 1)
+
+	value, err := Resolve(ctx, providers, "project", "keyring:name")
+	assert.NoError(err)
+	assert.Equal("value", value)
+
+	// references can't reach another project's secrets
+	_, err = Resolve(ctx, providers, "project", "keyring:../other/name")
+	assert.Error(err)
+	_, err = Resolve(ctx, providers, "", "keyring:other/name")
+	assert.Error(err)
+	_, err = Resolve(ctx, providers, "..", "keyring:other/name")
+	assert.Error(err)
+
+	_, err = Resolve(ctx, providers, "project", "http_kv:secret#value")
+	assert.Error(err)
+	_, err = Resolve(ctx, providers, "project", "bogus")
+	assert.Error(err)
+
+	_, err = LoadSecretProviders(evergreen.SecretsConfig{
+		Keyring: &evergreen.KeyringSecretsConfig{Path: path, Key: "abcd"},
+	})
+	assert.Error(err)
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/evergreen-ci/evergreen/model/artifact"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/secrets"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/evergreen/validator"
 	"github.com/evergreen-ci/gimlet"
@@ -155,6 +157,22 @@ func (as *APIServer) checkProject(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// requireProjectPermission checks that the user has the permission on the
+// project, and writes an error response if they do not. It returns true if
+// the request may proceed.
+func (as *APIServer) requireProjectPermission(w http.ResponseWriter, r *http.Request, u gimlet.User, projectRef *model.ProjectRef, permission role.Permission) bool {
+	ok, err := auth.HasProjectPermission(as.Settings.SuperUsers, u, projectRef, permission)
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return false
+	}
+	if !ok {
+		as.LoggedError(w, r, http.StatusForbidden, errors.Errorf("user does not have the '%s' permission on this project", permission))
+		return false
+	}
+	return true
+}
+
 func (as *APIServer) checkHost(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		h, code, err := model.ValidateHost(gimlet.GetVars(r)["hostId"], r)
@@ -267,7 +285,23 @@ func (as *APIServer) FetchProjectVars(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gimlet.WriteJSON(w, projectVars)
+	if len(projectVars.SecretRefs) > 0 {
+		providers, err := secrets.LoadSecretProviders(as.GetSettings().Secrets)
+		if err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if err = projectVars.ResolveSecretRefs(r.Context(), providers); err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	// the agent only gets the resolved values, never the references
+	gimlet.WriteJSON(w, apimodels.ExpansionVars{
+		Vars:        projectVars.Vars,
+		PrivateVars: projectVars.PrivateVars,
+	})
 }
 
 // AttachFiles updates file mappings for a task or build
@@ -327,6 +361,7 @@ func (as *APIServer) AppendTaskLog(w http.ResponseWriter, r *http.Request) {
 		as.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	model.PublishTaskLog(taskLog)
 
 	gimlet.WriteJSON(w, "Logs added")
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/units"
 	"github.com/evergreen-ci/evergreen/util"
@@ -66,6 +67,10 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if data.Finalize && !as.requireProjectPermission(w, r, dbUser, pref, role.SubmitPatches) {
+		return
+	}
+
 	intent, err := patch.NewCliIntent(dbUser.Id, data.Project, data.Githash, r.FormValue("module"), data.Patch, data.Description, data.Finalize, variants, data.Tasks, data.Alias)
 	if err != nil {
 		as.LoggedError(w, r, http.StatusBadRequest, err)
@@ -287,6 +292,15 @@ func (as *APIServer) existingPatchRequest(w http.ResponseWriter, r *http.Request
 			http.Error(w, "patch is already finalized", http.StatusBadRequest)
 			return
 		}
+		var projectRef *model.ProjectRef
+		projectRef, err = model.FindOneProjectRef(p.Project)
+		if err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if !as.requireProjectPermission(w, r, dbUser, projectRef, role.SubmitPatches) {
+			return
+		}
 		var patchedProject *model.Project
 		patchedProject, err = validator.GetPatchedProject(ctx, p, githubOauthToken)
 		if err != nil {
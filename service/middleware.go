@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/plugin"
 	"github.com/evergreen-ci/evergreen/util"
@@ -102,14 +103,24 @@ func (uis *UIServer) GetSettings() evergreen.Settings {
 }
 
 // requireAdmin takes in a request handler and returns a wrapped version which verifies that requests are
-// authenticated and that the user is either a super user or is part of the project context's project's admins.
+// authenticated and that the user is either a super user or has a role on the project context's project
+// that allows editing it, such as being one of the project's admins.
 func (uis *UIServer) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		// get the project context
 		projCtx := MustHaveProjectContext(r)
 		if dbUser := gimlet.GetUser(ctx); dbUser != nil {
-			if uis.isSuperUser(dbUser) || isAdmin(dbUser, projCtx.ProjectRef) {
+			if uis.isSuperUser(dbUser) {
+				next(w, r)
+				return
+			}
+			ok, err := auth.HasProjectPermission(uis.Settings.SuperUsers, dbUser, projCtx.ProjectRef, role.EditProject)
+			if err != nil {
+				uis.LoggedError(w, r, http.StatusInternalServerError, err)
+				return
+			}
+			if ok {
 				next(w, r)
 				return
 			}
@@ -119,6 +130,33 @@ func (uis *UIServer) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// requireProjectPermission returns a middleware that verifies that requests are authenticated and
+// that the user is either a super user or has a role on the project context's project that allows
+// the permission. Since the routes it protects are actions rather than pages, other requests are
+// refused instead of being redirected to the login page.
+func (uis *UIServer) requireProjectPermission(permission role.Permission) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			projCtx := MustHaveProjectContext(r)
+			dbUser := gimlet.GetUser(r.Context())
+			if dbUser == nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			ok, err := auth.HasProjectPermission(uis.Settings.SuperUsers, dbUser, projCtx.ProjectRef, permission)
+			if err != nil {
+				uis.LoggedError(w, r, http.StatusInternalServerError, err)
+				return
+			}
+			if !ok {
+				http.Error(w, fmt.Sprintf("user '%s' does not have the '%s' permission on this project", dbUser.Username(), permission), http.StatusForbidden)
+				return
+			}
+			next(w, r)
+		}
+	}
+}
+
 // requireUser takes a request handler and returns a wrapped version which verifies that requests
 // request are authenticated before proceeding. For a request which is not authenticated, it will
 // execute the onFail handler. If onFail is nil, a simple "unauthorized" error will be sent.
@@ -187,10 +225,11 @@ func (uis *UIServer) setCORSHeaders(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// isAdmin returns false if the user is nil or if its id is not
-// located in ProjectRef's Admins field.
-func isAdmin(u gimlet.User, project *model.ProjectRef) bool {
-	return util.StringSliceContains(project.Admins, u.Username())
+// isAdmin returns true if the user is located in ProjectRef's Admins field,
+// or has a role on the project, either by default or in the given role
+// assignments, that allows editing it.
+func isAdmin(u gimlet.User, project *model.ProjectRef, assignments []role.Assignment) bool {
+	return role.AnyHas(auth.ProjectRoles(u, project, assignments), role.EditProject)
 }
 
 // RedirectToLogin forces a redirect to the login page. The redirect param is set on the query
@@ -248,10 +287,17 @@ func (pc *projectContext) populateProjectRefs(includePrivate, isSuperUser bool,
 	if err != nil {
 		return err
 	}
+	assignments := []role.Assignment{}
+	if includePrivate && !isSuperUser {
+		assignments, err = role.FindAllForUser(user.Username(), user.Roles())
+		if err != nil {
+			return err
+		}
+	}
 	pc.AllProjects = make([]UIProjectFields, 0, len(allProjs))
 	// User is not logged in, so only include public projects.
 	for _, p := range allProjs {
-		if includePrivate && (isSuperUser || isAdmin(user, &p)) {
+		if includePrivate && (isSuperUser || isAdmin(user, &p, assignments)) {
 			pc.IsAdmin = true
 		}
 
@@ -11,8 +11,10 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/alerts"
+	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/user"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/units"
@@ -42,10 +44,14 @@ func (uis *UIServer) filterAuthorizedProjects(u *user.DBUser) ([]model.ProjectRe
 	if err != nil {
 		return nil, err
 	}
+	assignments, err := role.FindAllForUser(u.Username(), u.Roles())
+	if err != nil {
+		return nil, err
+	}
 	authorizedProjects := []model.ProjectRef{}
 	// only returns projects for which the user is authorized to see.
 	for _, project := range allProjects {
-		if uis.isSuperUser(u) || isAdmin(u, &project) {
+		if uis.isSuperUser(u) || isAdmin(u, &project, assignments) {
 			authorizedProjects = append(authorizedProjects, project)
 		}
 	}
@@ -183,25 +189,37 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	before, err := model.GetProjectSettings(id)
+	if err != nil {
+		uis.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
 	responseRef := struct {
-		Identifier         string               `json:"id"`
-		DisplayName        string               `json:"display_name"`
-		RemotePath         string               `json:"remote_path"`
-		BatchTime          int                  `json:"batch_time"`
-		DeactivatePrevious bool                 `json:"deactivate_previous"`
-		Branch             string               `json:"branch_name"`
-		ProjVarsMap        map[string]string    `json:"project_vars"`
-		ProjectAliases     []model.ProjectAlias `json:"project_aliases"`
-		DeleteAliases      []string             `json:"delete_aliases"`
-		PrivateVars        map[string]bool      `json:"private_vars"`
-		Enabled            bool                 `json:"enabled"`
-		Private            bool                 `json:"private"`
-		Owner              string               `json:"owner_name"`
-		Repo               string               `json:"repo_name"`
-		Admins             []string             `json:"admins"`
-		TracksPushEvents   bool                 `json:"tracks_push_events"`
-		PRTestingEnabled   bool                 `json:"pr_testing_enabled"`
-		PatchingDisabled   bool                 `json:"patching_disabled"`
+		Identifier         string                          `json:"id"`
+		DisplayName        string                          `json:"display_name"`
+		RemotePath         string                          `json:"remote_path"`
+		BatchTime          int                             `json:"batch_time"`
+		DeactivatePrevious bool                            `json:"deactivate_previous"`
+		Branch             string                          `json:"branch_name"`
+		ProjVarsMap        map[string]string               `json:"project_vars"`
+		ProjectAliases     []model.ProjectAlias            `json:"project_aliases"`
+		DeleteAliases      []string                        `json:"delete_aliases"`
+		PrivateVars        map[string]bool                 `json:"private_vars"`
+		SecretRefs         map[string]string               `json:"secret_refs"`
+		Enabled            bool                            `json:"enabled"`
+		Private            bool                            `json:"private"`
+		Owner              string                          `json:"owner_name"`
+		Repo               string                          `json:"repo_name"`
+		Admins             []string                        `json:"admins"`
+		DefaultRole        string                          `json:"default_role"`
+		TracksPushEvents   bool                            `json:"tracks_push_events"`
+		PRTestingEnabled   bool                            `json:"pr_testing_enabled"`
+		PatchingDisabled   bool                            `json:"patching_disabled"`
+		HourlyBudget       float64                         `json:"hourly_budget"`
+		CommitQueue        model.CommitQueueParams         `json:"commit_queue"`
+		PeriodicBuilds     []model.PeriodicBuildDefinition `json:"periodic_builds"`
+		Triggers           []model.TriggerDefinition       `json:"triggers"`
 		AlertConfig        map[string][]struct {
 			Provider string                 `json:"provider"`
 			Settings map[string]interface{} `json:"settings"`
@@ -223,6 +241,27 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if responseRef.HourlyBudget < 0 {
+		http.Error(w, "hourly budget cannot be negative", http.StatusBadRequest)
+		return
+	}
+
+	if responseRef.DefaultRole != "" && !role.IsValid(responseRef.DefaultRole) {
+		http.Error(w, fmt.Sprintf("invalid default role '%s'", responseRef.DefaultRole), http.StatusBadRequest)
+		return
+	}
+
+	if responseRef.CommitQueue.Enabled {
+		if !responseRef.PRTestingEnabled {
+			http.Error(w, "the commit queue requires GitHub PR testing", http.StatusBadRequest)
+			return
+		}
+		if !util.StringSliceContains(model.ValidMergeMethods, responseRef.CommitQueue.MergeMethod) {
+			http.Error(w, fmt.Sprintf("invalid commit queue merge method '%s'", responseRef.CommitQueue.MergeMethod), http.StatusBadRequest)
+			return
+		}
+	}
+
 	errs := []string{}
 	for i, pd := range responseRef.ProjectAliases {
 		if strings.TrimSpace(pd.Alias) == "" {
@@ -242,6 +281,9 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 			errs = append(errs, fmt.Sprintf("task regex #%d is invalid", i+1))
 		}
 	}
+	if err = (&model.ProjectVars{SecretRefs: responseRef.SecretRefs}).ValidateSecretRefs(); err != nil {
+		errs = append(errs, err.Error())
+	}
 	if len(errs) > 0 {
 		errMsg := ""
 		for _, err := range errs {
@@ -275,12 +317,60 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	projectRef.Private = responseRef.Private
 	projectRef.Owner = responseRef.Owner
 	projectRef.DeactivatePrevious = responseRef.DeactivatePrevious
+	periodicBuilds := model.ProjectRef{PeriodicBuilds: responseRef.PeriodicBuilds}
+	if err = periodicBuilds.ValidatePeriodicBuilds(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	triggers := model.ProjectRef{Identifier: id, Triggers: responseRef.Triggers}
+	if err = triggers.ValidateTriggers(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	existingUpstreams := map[string]bool{}
+	for _, def := range projectRef.Triggers {
+		existingUpstreams[def.Project] = true
+	}
+	for _, def := range responseRef.Triggers {
+		var upstream *model.ProjectRef
+		upstream, err = model.FindOneProjectRef(def.Project)
+		if err != nil {
+			uis.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if upstream == nil {
+			http.Error(w, fmt.Sprintf("upstream project '%s' of trigger '%s' not found", def.Project, def.ID), http.StatusBadRequest)
+			return
+		}
+		// only admins of a private project may trigger other projects'
+		// versions, and share its artifacts with them
+		if !upstream.Private || existingUpstreams[upstream.Identifier] {
+			continue
+		}
+		var ok bool
+		ok, err = auth.HasProjectPermission(uis.Settings.SuperUsers, dbUser, upstream, role.EditProject)
+		if err != nil {
+			uis.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if !ok {
+			http.Error(w, fmt.Sprintf("only admins of private project '%s' can add triggers on it", upstream.Identifier), http.StatusForbidden)
+			return
+		}
+	}
+
 	projectRef.Repo = responseRef.Repo
 	projectRef.Admins = responseRef.Admins
+	projectRef.DefaultRole = responseRef.DefaultRole
 	projectRef.Identifier = id
 	projectRef.TracksPushEvents = responseRef.TracksPushEvents
 	projectRef.PRTestingEnabled = responseRef.PRTestingEnabled
 	projectRef.PatchingDisabled = responseRef.PatchingDisabled
+	projectRef.HourlyBudget = responseRef.HourlyBudget
+	projectRef.CommitQueue = responseRef.CommitQueue
+	projectRef.PeriodicBuilds = responseRef.PeriodicBuilds
+	projectRef.Triggers = responseRef.Triggers
 	projectRef.NotifyOnBuildFailure = responseRef.NotifyOnBuildFailure
 
 	projectRef.Alerts = map[string][]model.AlertConfig{}
@@ -371,6 +461,14 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 			catcher.Add(err)
 		}
 		subscription := subscriptionIface.(event.Subscription)
+		// project subscriptions are for mainline versions unless they
+		// explicitly select another kind of system version
+		requester := evergreen.RepotrackerVersionRequester
+		for _, selector := range subscription.Selectors {
+			if selector.Type == "requester" && util.StringSliceContains(evergreen.SystemVersionRequesters, selector.Data) {
+				requester = selector.Data
+			}
+		}
 		subscription.Selectors = []event.Selector{
 			{
 				Type: "project",
@@ -378,7 +476,7 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 			},
 			{
 				Type: "requester",
-				Data: evergreen.RepotrackerVersionRequester,
+				Data: requester,
 			},
 		}
 		subscription.OwnerType = event.OwnerTypeProject
@@ -410,6 +508,15 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	}
 	projectVars.Vars = responseRef.ProjVarsMap
 	projectVars.PrivateVars = responseRef.PrivateVars
+	// The secret references are only replaced if the submission includes
+	// them, since not every client edits them.
+	if responseRef.SecretRefs != nil {
+		projectVars.SecretRefs = responseRef.SecretRefs
+	}
+	// Variables backed by a secret store keep only their reference.
+	for k := range projectVars.SecretRefs {
+		delete(projectVars.Vars, k)
+	}
 
 	_, err = projectVars.Upsert()
 	if err != nil {
@@ -430,6 +537,17 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	after, err := model.GetProjectSettings(id)
+	if err != nil {
+		uis.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	grip.Error(message.WrapError(model.LogProjectModified(id, dbUser.Username(), before, after), message.Fields{
+		"message": "error logging project modification",
+		"project": id,
+		"user":    dbUser.Username(),
+	}))
+
 	allProjects, err := uis.filterAuthorizedProjects(dbUser)
 	if err != nil {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
@@ -481,6 +599,11 @@ func (uis *UIServer) addProject(w http.ResponseWriter, r *http.Request) {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	grip.Error(message.WrapError(model.LogProjectAdded(id, dbUser.Username()), message.Fields{
+		"message": "error logging project creation",
+		"project": id,
+		"user":    dbUser.Username(),
+	}))
 
 	allProjects, err := uis.filterAuthorizedProjects(dbUser)
 
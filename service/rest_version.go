@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
@@ -271,6 +273,16 @@ func (restapi restAPI) modifyVersionInfo(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	ok, err := auth.HasProjectPermission(restapi.GetSettings().SuperUsers, user, projCtx.ProjectRef, role.TaskControl)
+	if err != nil {
+		restapi.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if !ok {
+		gimlet.WriteJSONResponse(w, http.StatusForbidden, responseError{Message: "not authorized to modify this version"})
+		return
+	}
+
 	input := struct {
 		Activated *bool `json:"activated"`
 	}{}
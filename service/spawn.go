@@ -10,8 +10,10 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
@@ -113,6 +115,33 @@ func (uis *UIServer) requestNewHost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// spawning a host to debug a task requires permission on its project
+	if putParams.Task != "" {
+		t, err := task.FindOneId(putParams.Task)
+		if err != nil {
+			uis.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "Error finding task"))
+			return
+		}
+		if t == nil {
+			http.Error(w, fmt.Sprintf("task '%s' not found", putParams.Task), http.StatusNotFound)
+			return
+		}
+		projectRef, err := model.FindOneProjectRef(t.Project)
+		if err != nil {
+			uis.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "Error finding project"))
+			return
+		}
+		ok, err := auth.HasProjectPermission(uis.Settings.SuperUsers, authedUser, projectRef, role.SpawnHosts)
+		if err != nil {
+			uis.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if !ok {
+			http.Error(w, fmt.Sprintf("user '%s' may not spawn hosts for tasks in project '%s'", authedUser.Username(), t.Project), http.StatusForbidden)
+			return
+		}
+	}
+
 	// save the supplied public key if needed
 	if putParams.SaveKey {
 		if err := authedUser.AddPublicKey(putParams.KeyName, putParams.PublicKey); err != nil {
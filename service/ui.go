@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/role"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/plugin"
 	"github.com/evergreen-ci/evergreen/thirdparty"
@@ -186,6 +187,8 @@ func (uis *UIServer) GetServiceApp() *gimlet.APIApp {
 	needsContext := gimlet.WrapperMiddleware(uis.loadCtx)
 	needsSuperUser := gimlet.WrapperMiddleware(uis.requireSuperUser)
 	needsAdmin := gimlet.WrapperMiddleware(uis.requireAdmin)
+	needsTaskControl := gimlet.WrapperMiddleware(uis.requireProjectPermission(role.TaskControl))
+	needsSubmitPatches := gimlet.WrapperMiddleware(uis.requireProjectPermission(role.SubmitPatches))
 	allowsCORS := gimlet.WrapperMiddleware(uis.setCORSHeaders)
 
 	app := gimlet.NewApp()
@@ -231,7 +234,7 @@ func (uis *UIServer) GetServiceApp() *gimlet.APIApp {
 	// Task page (and related routes)
 	app.AddRoute("/task/{task_id}").Wrap(needsContext).Handler(uis.taskPage).Get()
 	app.AddRoute("/task/{task_id}/{execution}").Wrap(needsContext).Handler(uis.taskPage).Get()
-	app.AddRoute("/tasks/{task_id}").Wrap(needsLogin, needsContext).Handler(uis.taskModify).Put()
+	app.AddRoute("/tasks/{task_id}").Wrap(needsLogin, needsContext, needsTaskControl).Handler(uis.taskModify).Put()
 	app.AddRoute("/json/task_log/{task_id}").Wrap(needsContext).Handler(uis.taskLog).Get()
 	app.AddRoute("/json/task_log/{task_id}/{execution}").Wrap(needsContext).Handler(uis.taskLog).Get()
 	app.AddRoute("/task_log_raw/{task_id}/{execution}").Wrap(needsContext, allowsCORS).Handler(uis.taskLogRaw).Get()
@@ -250,12 +253,12 @@ func (uis *UIServer) GetServiceApp() *gimlet.APIApp {
 
 	// Build page
 	app.AddRoute("/build/{build_id}").Wrap(needsContext).Handler(uis.buildPage).Get()
-	app.AddRoute("/builds/{build_id}").Wrap(needsLogin, needsContext).Handler(uis.modifyBuild).Put()
+	app.AddRoute("/builds/{build_id}").Wrap(needsLogin, needsContext, needsTaskControl).Handler(uis.modifyBuild).Put()
 	app.AddRoute("/json/build_history/{build_id}").Wrap(needsContext).Handler(uis.buildHistory).Get()
 
 	// Version page
 	app.AddRoute("/version/{version_id}").Wrap(needsContext).Handler(uis.versionPage).Get()
-	app.AddRoute("/version/{version_id}").Wrap(needsLogin, needsContext).Handler(uis.modifyVersion).Put()
+	app.AddRoute("/version/{version_id}").Wrap(needsLogin, needsContext, needsTaskControl).Handler(uis.modifyVersion).Put()
 	app.AddRoute("/json/version_history/{version_id}").Wrap(needsContext).Handler(uis.versionHistory).Get()
 	app.AddRoute("/version/{project_id}/{revision}").Wrap(needsContext).Handler(uis.versionFind).Get()
 
@@ -301,7 +304,7 @@ func (uis *UIServer) GetServiceApp() *gimlet.APIApp {
 
 	// Patch pages
 	app.AddRoute("/patch/{patch_id}").Wrap(needsLogin, needsContext).Handler(uis.patchPage).Get()
-	app.AddRoute("/patch/{patch_id}").Wrap(needsLogin, needsContext).Handler(uis.schedulePatch).Post()
+	app.AddRoute("/patch/{patch_id}").Wrap(needsLogin, needsContext, needsSubmitPatches).Handler(uis.schedulePatch).Post()
 	app.AddRoute("/diff/{patch_id}/").Wrap(needsLogin, needsContext).Handler(uis.diffPage).Get()
 	app.AddRoute("/filediff/{patch_id}/").Wrap(needsLogin, needsContext).Handler(uis.fileDiffPage).Get()
 	app.AddRoute("/rawdiff/{patch_id}/").Wrap(needsLogin, needsContext).Handler(uis.rawDiffPage).Get()
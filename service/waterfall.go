@@ -13,14 +13,16 @@ import (
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/pkg/errors"
 )
 
 const (
-	waterfallPerPageLimit  = 5
-	waterfallBVFilterParam = "bv_filter"
-	waterfallSkipParam     = "skip"
+	waterfallPerPageLimit   = 5
+	waterfallBVFilterParam  = "bv_filter"
+	waterfallSkipParam      = "skip"
+	waterfallRequesterParam = "requester"
 )
 
 // Pull the skip value out of the http request
@@ -33,6 +35,18 @@ func skipValue(r *http.Request) (int, error) {
 	return strconv.Atoi(toSkipStr)
 }
 
+// waterfallRequesters returns the requesters of the versions to show on the
+// waterfall. By default, versions from every system requester are shown.
+func waterfallRequesters(requester string) ([]string, error) {
+	if requester == "" {
+		return evergreen.SystemVersionRequesters, nil
+	}
+	if !util.StringSliceContains(evergreen.SystemVersionRequesters, requester) {
+		return nil, errors.Errorf("'%s' is not a valid waterfall requester", requester)
+	}
+	return []string{requester}, nil
+}
+
 // uiStatus determines task status label.
 func uiStatus(task waterfallTask) string {
 	switch task.Status {
@@ -119,6 +133,7 @@ type waterfallVersion struct {
 	// metadata about the enclosed versions.  if this version does not consist
 	// of multiple rolled-up versions, these will each only have length 1
 	Ids                 []string    `json:"ids"`
+	Requesters          []string    `json:"requesters"`
 	Messages            []string    `json:"messages"`
 	Authors             []string    `json:"authors"`
 	CreateTimes         []time.Time `json:"create_times"`
@@ -204,7 +219,7 @@ func variantHasActiveTasks(b build.Build, bvDisplayName string, variantQuery str
 // The skip value indicates how many versions back in time should be skipped
 // before starting to fetch versions, the project indicates which project the
 // returned versions should be a part of.
-func getVersionsAndVariants(skip, numVersionElements int, project *model.Project, variantQuery string) (versionVariantData, error) {
+func getVersionsAndVariants(skip, numVersionElements int, project *model.Project, variantQuery string, requesters []string) (versionVariantData, error) {
 	// the final array of versions to return
 	finalVersions := []waterfallVersion{}
 
@@ -226,7 +241,7 @@ func getVersionsAndVariants(skip, numVersionElements int, project *model.Project
 
 		// fetch the versions and associated builds
 		versionsFromDB, buildsByVersion, err :=
-			model.FetchVersionsAndAssociatedBuilds(project, skip, numVersionElements)
+			model.FetchVersionsAndAssociatedBuilds(project, skip, numVersionElements, requesters)
 
 		if err != nil {
 			return versionVariantData{}, errors.Wrap(err,
@@ -306,6 +321,8 @@ func getVersionsAndVariants(skip, numVersionElements int, project *model.Project
 				// add the version metadata into the last rolled-up version
 				lastRolledUpVersion.Ids = append(lastRolledUpVersion.Ids,
 					versionFromDB.Id)
+				lastRolledUpVersion.Requesters = append(lastRolledUpVersion.Requesters,
+					versionFromDB.Requester)
 				lastRolledUpVersion.Authors = append(lastRolledUpVersion.Authors,
 					versionFromDB.Author)
 				lastRolledUpVersion.Errors = append(
@@ -340,6 +357,7 @@ func getVersionsAndVariants(skip, numVersionElements int, project *model.Project
 			// version for it
 			activeVersion := waterfallVersion{
 				Ids:                 []string{versionFromDB.Id},
+				Requesters:          []string{versionFromDB.Requester},
 				Messages:            []string{versionFromDB.Message},
 				Authors:             []string{versionFromDB.Author},
 				CreateTimes:         []time.Time{versionFromDB.CreateTime},
@@ -462,7 +480,7 @@ func anyActiveTasks(builds []build.Build) bool {
 // Calculates how many actual versions would appear on the previous page, given
 // the starting skip for the current page as well as the number of version
 // elements per page (including elements containing rolled-up versions).
-func countOnPreviousPage(skip int, numVersionElements int, project *model.Project, variantQuery string) (int, error) {
+func countOnPreviousPage(skip int, numVersionElements int, project *model.Project, variantQuery string, requesters []string) (int, error) {
 	buildVariantMappings := project.GetVariantMappings()
 
 	// if there is no previous page
@@ -492,7 +510,7 @@ func countOnPreviousPage(skip int, numVersionElements int, project *model.Projec
 
 		// fetch the versions and builds
 		versionsFromDB, buildsByVersion, err :=
-			model.FetchVersionsAndAssociatedBuilds(project, stepBack, toFetch)
+			model.FetchVersionsAndAssociatedBuilds(project, stepBack, toFetch, requesters)
 
 		if err != nil {
 			return 0, errors.Wrap(err, "error fetching versions and builds")
@@ -578,7 +596,7 @@ func countOnPreviousPage(skip int, numVersionElements int, project *model.Projec
 	}
 }
 
-func waterfallDataAdaptor(vvData versionVariantData, project *model.Project, skip int, variantQuery string) (waterfallData, error) {
+func waterfallDataAdaptor(vvData versionVariantData, project *model.Project, skip int, variantQuery string, requesters []string) (waterfallData, error) {
 	var err error
 	finalData := waterfallData{}
 	var wfv waterfallVersions = vvData.Versions
@@ -594,13 +612,13 @@ func waterfallDataAdaptor(vvData versionVariantData, project *model.Project, ski
 	finalData.Rows = rows
 
 	// compute the total number of versions that exist
-	finalData.TotalVersions, err = version.Count(version.ByProjectId(project.Identifier))
+	finalData.TotalVersions, err = version.Count(version.ByProjectIdAndRequesters(project.Identifier, requesters))
 	if err != nil {
 		return waterfallData{}, err
 	}
 
 	// compute the number of versions on the previous page
-	finalData.PreviousPageCount, err = countOnPreviousPage(skip, waterfallPerPageLimit, project, variantQuery)
+	finalData.PreviousPageCount, err = countOnPreviousPage(skip, waterfallPerPageLimit, project, variantQuery, requesters)
 	if err != nil {
 		return waterfallData{}, err
 	}
@@ -631,9 +649,15 @@ func (uis *UIServer) waterfallPage(w http.ResponseWriter, r *http.Request) {
 
 	variantQuery := strings.TrimSpace(r.URL.Query().Get(waterfallBVFilterParam))
 
+	requesters, err := waterfallRequesters(r.URL.Query().Get(waterfallRequesterParam))
+	if err != nil {
+		uis.LoggedError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
 	// first, get all of the versions and variants we will need
 	vvData, err := getVersionsAndVariants(
-		skip, waterfallPerPageLimit, project, variantQuery,
+		skip, waterfallPerPageLimit, project, variantQuery, requesters,
 	)
 
 	if err != nil {
@@ -642,7 +666,7 @@ func (uis *UIServer) waterfallPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	finalData, err := waterfallDataAdaptor(
-		vvData, project, skip, variantQuery,
+		vvData, project, skip, variantQuery, requesters,
 	)
 	if err != nil {
 		uis.LoggedError(w, r, http.StatusInternalServerError, err)
@@ -689,7 +713,13 @@ func (restapi restAPI) getWaterfallData(w http.ResponseWriter, r *http.Request)
 
 	variantQuery := strings.TrimSpace(query.Get(waterfallBVFilterParam))
 
-	vvData, err := getVersionsAndVariants(skip, limit, project, variantQuery)
+	requesters, err := waterfallRequesters(query.Get(waterfallRequesterParam))
+	if err != nil {
+		gimlet.WriteJSONResponse(w, http.StatusBadRequest, responseError{Message: err.Error()})
+		return
+	}
+
+	vvData, err := getVersionsAndVariants(skip, limit, project, variantQuery, requesters)
 
 	if err != nil {
 		gimlet.WriteJSONResponse(
@@ -699,7 +729,7 @@ func (restapi restAPI) getWaterfallData(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	finalData, err := waterfallDataAdaptor(vvData, project, skip, variantQuery)
+	finalData, err := waterfallDataAdaptor(vvData, project, skip, variantQuery, requesters)
 
 	if err != nil {
 		gimlet.WriteJSONResponse(
@@ -116,6 +116,11 @@ func MockConfig() *evergreen.Settings {
 				From:       "from",
 				AdminEmail: []string{"email"},
 			},
+			Webhook: evergreen.WebhookConfig{
+				MaxAttempts:          3,
+				MinRetryDelaySeconds: 10,
+				MaxRetryDelaySeconds: 100,
+			},
 		},
 		Plugins:   map[string]map[string]interface{}{"k4": map[string]interface{}{"k5": "v5"}},
 		PprofPort: "port",
@@ -133,6 +138,10 @@ func MockConfig() *evergreen.Settings {
 				PrivateKeyID: "gce_key_id",
 				TokenURI:     "gce_token",
 			},
+			Kubernetes: evergreen.KubernetesConfig{
+				APIServer: "https://kubernetes.example.com",
+				Token:     "kubernetes_token",
+			},
 			OpenStack: evergreen.OpenStackConfig{
 				IdentityEndpoint: "endpoint",
 				Username:         "username",
@@ -156,23 +165,32 @@ func MockConfig() *evergreen.Settings {
 		Scheduler: evergreen.SchedulerConfig{
 			TaskFinder: "legacy",
 		},
+		Secrets: evergreen.SecretsConfig{
+			HTTPKV: &evergreen.HTTPKVSecretsConfig{
+				URL:         "https://vault.example.com",
+				Token:       "vault_token",
+				TokenHeader: "X-Vault-Token",
+			},
+		},
 		ServiceFlags: evergreen.ServiceFlags{
-			TaskDispatchDisabled:         true,
-			HostinitDisabled:             true,
-			MonitorDisabled:              true,
-			AlertsDisabled:               true,
-			TaskrunnerDisabled:           true,
-			RepotrackerDisabled:          true,
-			SchedulerDisabled:            true,
-			GithubPRTestingDisabled:      true,
-			RepotrackerPushEventDisabled: true,
-			CLIUpdatesDisabled:           true,
-			EventProcessingDisabled:      true,
-			JIRANotificationsDisabled:    true,
-			SlackNotificationsDisabled:   true,
-			EmailNotificationsDisabled:   true,
-			WebhookNotificationsDisabled: true,
-			GithubStatusAPIDisabled:      true,
+			TaskDispatchDisabled:            true,
+			HostinitDisabled:                true,
+			MonitorDisabled:                 true,
+			AlertsDisabled:                  true,
+			TaskrunnerDisabled:              true,
+			RepotrackerDisabled:             true,
+			SchedulerDisabled:               true,
+			GithubPRTestingDisabled:         true,
+			RepotrackerPushEventDisabled:    true,
+			CLIUpdatesDisabled:              true,
+			EventProcessingDisabled:         true,
+			JIRANotificationsDisabled:       true,
+			SlackNotificationsDisabled:      true,
+			TeamsNotificationsDisabled:      true,
+			MattermostNotificationsDisabled: true,
+			EmailNotificationsDisabled:      true,
+			WebhookNotificationsDisabled:    true,
+			GithubStatusAPIDisabled:         true,
 		},
 		Slack: evergreen.SlackConfig{
 			Options: &send.SlackOptions{
@@ -340,6 +340,58 @@ func GetBranchEvent(ctx context.Context, oauthToken, repoOwner, repo, branch str
 	return branchEvent, nil
 }
 
+// GetGithubPullRequest gets a pull request via an API call to GitHub
+func GetGithubPullRequest(ctx context.Context, oauthToken, repoOwner, repo string, prNumber int) (*github.PullRequest, error) {
+	httpClient, err := getGithubClient(oauthToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	pr, resp, err := client.PullRequests.Get(ctx, repoOwner, repo, prNumber)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error querying pull request '%s/%s' #%d", repoOwner, repo, prNumber)
+	}
+	if pr == nil {
+		return nil, errors.Errorf("pull request '%s/%s' #%d not found", repoOwner, repo, prNumber)
+	}
+
+	return pr, nil
+}
+
+// MergePullRequest merges a pull request via an API call to GitHub. The merge
+// only succeeds if the head of the pull request is still sha.
+func MergePullRequest(ctx context.Context, oauthToken, repoOwner, repo string, prNumber int, sha, mergeMethod, commitMessage string) error {
+	httpClient, err := getGithubClient(oauthToken)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	res, resp, err := client.PullRequests.Merge(ctx, repoOwner, repo, prNumber, commitMessage,
+		&github.PullRequestOptions{SHA: sha, MergeMethod: mergeMethod})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error merging pull request '%s/%s' #%d", repoOwner, repo, prNumber)
+	}
+	if res == nil || res.Merged == nil || !*res.Merged {
+		msg := ""
+		if res != nil && res.Message != nil {
+			msg = *res.Message
+		}
+		return errors.Errorf("pull request '%s/%s' #%d was not merged: %s", repoOwner, repo, prNumber, msg)
+	}
+
+	return nil
+}
+
 // githubRequest performs the specified http request. If the oauth token field is empty it will not use oauth
 func githubRequest(ctx context.Context, method string, url string, oauthToken string, data interface{}) (*http.Response, error) {
 	req, err := http.NewRequest(method, url, nil)
@@ -2,13 +2,17 @@ package trigger
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/version"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
@@ -302,6 +306,20 @@ func (t *buildTriggers) buildAttachments(data *commonTemplateData) []message.Sla
 	return attachments
 }
 
+func (t *buildTriggers) digestItem() *notification.DigestItem {
+	return &notification.DigestItem{
+		Object:       objectBuild,
+		ID:           t.build.Id,
+		DisplayName:  t.build.DisplayName,
+		Project:      t.build.Project,
+		Version:      t.build.Version,
+		Revision:     t.build.Revision,
+		BuildVariant: t.build.BuildVariant,
+		Status:       t.data.Status,
+		URL:          buildLink(&t.uiConfig, t.build.Id),
+	}
+}
+
 func (t *buildTriggers) generate(sub *event.Subscription, pastTenseOverride string) (*notification.Notification, error) {
 	data, err := t.makeData(sub, pastTenseOverride)
 	if err != nil {
@@ -323,3 +341,67 @@ func taskFormatFromCache(t *build.TaskCache) string {
 
 	return fmt.Sprintf("took %s, the task failed %s", t.TimeTaken, detailStatusToHumanSpeak(t.StatusDetails.Status))
 }
+
+func (t *buildTriggers) upstreamProject() string {
+	if !firesProjectTriggers(t.build.Requester, t.data.Status) {
+		return ""
+	}
+	return t.build.Project
+}
+
+func (t *buildTriggers) upstreamFor(def model.TriggerDefinition) (*version.UpstreamVersion, error) {
+	if def.Level != model.ProjectTriggerLevelBuild {
+		return nil, nil
+	}
+	if def.Variant != "" {
+		variantRegex, err := regexp.Compile(def.Variant)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid variant regex '%s'", def.Variant)
+		}
+		if !variantRegex.MatchString(t.build.BuildVariant) {
+			return nil, nil
+		}
+	}
+
+	status := t.data.Status
+	query := artifact.ByBuildId(t.build.Id)
+	if def.Task != "" {
+		taskRegex, err := regexp.Compile(def.Task)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid task regex '%s'", def.Task)
+		}
+		// the matching tasks which ran decide the status of the build
+		taskIds := []string{}
+		status = evergreen.BuildSucceeded
+		for _, task := range t.build.Tasks {
+			if !task.Activated || !taskRegex.MatchString(task.DisplayName) {
+				continue
+			}
+			taskIds = append(taskIds, task.Id)
+			if task.Status != evergreen.TaskSucceeded {
+				status = evergreen.BuildFailed
+			}
+		}
+		if len(taskIds) == 0 {
+			return nil, nil
+		}
+		query = artifact.ByTaskIds(taskIds)
+	}
+	if def.Status != "" && def.Status != status {
+		return nil, nil
+	}
+
+	artifacts, err := upstreamArtifacts(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding artifacts of build '%s'", t.build.Id)
+	}
+	return &version.UpstreamVersion{
+		Project:      t.build.Project,
+		VersionId:    t.build.Version,
+		Revision:     t.build.Revision,
+		BuildId:      t.build.Id,
+		BuildVariant: t.build.BuildVariant,
+		Status:       status,
+		Artifacts:    artifacts,
+	}, nil
+}
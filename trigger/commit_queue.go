@@ -0,0 +1,154 @@
+package trigger
+
+import (
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	registry.registerEventHandler(event.ResourceTypeCommitQueue, event.CommitQueueStateChange, makeCommitQueueTriggers)
+}
+
+const (
+	objectCommitQueue = "commit_queue"
+)
+
+type commitQueueTriggers struct {
+	event    *event.EventLogEntry
+	data     *event.CommitQueueEventData
+	uiConfig evergreen.UIConfig
+
+	base
+}
+
+func makeCommitQueueTriggers() eventHandler {
+	t := &commitQueueTriggers{}
+	t.base.triggers = map[string]trigger{
+		triggerOutcome: t.commitQueueOutcome,
+		triggerFailure: t.commitQueueFailure,
+		triggerSuccess: t.commitQueueSuccess,
+	}
+	return t
+}
+
+func (t *commitQueueTriggers) Fetch(e *event.EventLogEntry) error {
+	if err := t.uiConfig.Get(); err != nil {
+		return errors.Wrap(err, "Failed to fetch ui config")
+	}
+
+	var ok bool
+	t.data, ok = e.Data.(*event.CommitQueueEventData)
+	if !ok {
+		return errors.Errorf("commit queue '%s' contains unexpected data with type '%T'", e.ResourceId, e.Data)
+	}
+	t.event = e
+
+	return nil
+}
+
+func (t *commitQueueTriggers) Selectors() []event.Selector {
+	return []event.Selector{
+		{
+			Type: selectorID,
+			Data: t.event.ResourceId,
+		},
+		{
+			Type: selectorObject,
+			Data: objectCommitQueue,
+		},
+		{
+			Type: selectorProject,
+			Data: t.event.ResourceId,
+		},
+		{
+			Type: selectorOwner,
+			Data: t.data.Author,
+		},
+		{
+			Type: selectorStatus,
+			Data: t.data.Status,
+		},
+	}
+}
+
+func (t *commitQueueTriggers) commitQueueOutcome(sub *event.Subscription) (*notification.Notification, error) {
+	return t.generate(sub)
+}
+
+func (t *commitQueueTriggers) commitQueueFailure(sub *event.Subscription) (*notification.Notification, error) {
+	if t.data.Status != event.CommitQueueEvicted {
+		return nil, nil
+	}
+
+	return t.generate(sub)
+}
+
+func (t *commitQueueTriggers) commitQueueSuccess(sub *event.Subscription) (*notification.Notification, error) {
+	if t.data.Status != event.CommitQueueMerged {
+		return nil, nil
+	}
+
+	return t.generate(sub)
+}
+
+func (t *commitQueueTriggers) makeData(sub *event.Subscription) (*commonTemplateData, error) {
+	api := restModel.APICommitQueueItem{}
+	err := api.BuildFromService(commitqueue.CommitQueueItem{
+		PRNumber: t.data.PRNumber,
+		Author:   t.data.Author,
+		Version:  t.data.PatchID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error building json model")
+	}
+
+	pullURL := fmt.Sprintf("https://github.com/%s/%s/pull/%d", t.data.Owner, t.data.Repo, t.data.PRNumber)
+	data := commonTemplateData{
+		ID:              t.event.ResourceId,
+		DisplayName:     fmt.Sprintf("%s/%s#%d", t.data.Owner, t.data.Repo, t.data.PRNumber),
+		Description:     t.data.Message,
+		Object:          objectCommitQueue,
+		Project:         t.event.ResourceId,
+		URL:             pullURL,
+		PastTenseStatus: t.data.Status,
+		apiModel:        &api,
+	}
+
+	slackColor := evergreenSuccessColor
+	if t.data.Status == event.CommitQueueEvicted {
+		slackColor = evergreenFailColor
+		// link to the failed patch so that the author can see why
+		if t.data.PatchID != "" {
+			data.URL = versionLink(&t.uiConfig, t.data.PatchID)
+		}
+	}
+	data.slack = append(data.slack, message.SlackAttachment{
+		Title:     "Github Pull Request",
+		TitleLink: pullURL,
+		Text:      t.data.Message,
+		Color:     slackColor,
+	})
+
+	return &data, nil
+}
+
+func (t *commitQueueTriggers) generate(sub *event.Subscription) (*notification.Notification, error) {
+	data, err := t.makeData(sub)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to collect commit queue data")
+	}
+
+	payload, err := makeCommonPayload(sub, t.Selectors(), data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build notification")
+	}
+
+	return notification.New(t.event, sub.Trigger, &sub.Subscriber, payload)
+}
@@ -0,0 +1,155 @@
+package trigger
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestCommitQueueTriggers(t *testing.T) {
+	suite.Run(t, &commitQueueSuite{})
+}
+
+type commitQueueSuite struct {
+	event event.EventLogEntry
+	data  *event.CommitQueueEventData
+	subs  []event.Subscription
+
+	t *commitQueueTriggers
+
+	suite.Suite
+}
+
+func (s *commitQueueSuite) SetupSuite() {
+	s.Require().Implements((*eventHandler)(nil), &commitQueueTriggers{})
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *commitQueueSuite) SetupTest() {
+	s.NoError(db.ClearCollections(event.AllLogCollection, event.SubscriptionsCollection))
+
+	s.data = &event.CommitQueueEventData{
+		Status:   event.CommitQueueMerged,
+		PRNumber: 448,
+		Owner:    "evergreen-ci",
+		Repo:     "evergreen",
+		Author:   "someone",
+		PatchID:  "5aeb4514f27e4f9984646d97",
+	}
+	s.event = event.EventLogEntry{
+		ResourceType: event.ResourceTypeCommitQueue,
+		EventType:    event.CommitQueueStateChange,
+		ResourceId:   "mci",
+		Data:         s.data,
+	}
+
+	s.subs = []event.Subscription{
+		event.NewCommitQueueSubscriptionByOwner("someone", event.Subscriber{
+			Type: event.EvergreenWebhookSubscriberType,
+			Target: &event.WebhookSubscriber{
+				URL:    "http://example.com/2",
+				Secret: []byte("secret"),
+			},
+		}),
+		{
+			ID:      bson.NewObjectId().Hex(),
+			Type:    event.ResourceTypeCommitQueue,
+			Trigger: "failure",
+			Selectors: []event.Selector{
+				{
+					Type: "project",
+					Data: "mci",
+				},
+			},
+			Subscriber: event.Subscriber{
+				Type: event.EvergreenWebhookSubscriberType,
+				Target: &event.WebhookSubscriber{
+					URL:    "http://example.com/2",
+					Secret: []byte("secret"),
+				},
+			},
+			Owner: "someone",
+		},
+	}
+
+	for i := range s.subs {
+		s.NoError(s.subs[i].Upsert())
+	}
+
+	ui := &evergreen.UIConfig{
+		Url: "https://evergreen.mongodb.com",
+	}
+	s.NoError(ui.Set())
+
+	s.t = makeCommitQueueTriggers().(*commitQueueTriggers)
+	s.t.event = &s.event
+	s.t.data = s.data
+	s.t.uiConfig = *ui
+}
+
+func (s *commitQueueSuite) TestFetch() {
+	t, ok := makeCommitQueueTriggers().(*commitQueueTriggers)
+	s.Require().True(ok)
+	s.NoError(t.Fetch(&s.event))
+	s.Equal(&s.event, t.event)
+	s.NotNil(t.data)
+	s.NotZero(t.uiConfig)
+	s.NotEmpty(t.triggers)
+}
+
+func (s *commitQueueSuite) TestAllTriggers() {
+	n, err := NotificationsFromEvent(&s.event)
+	s.NoError(err)
+	s.Len(n, 1)
+
+	s.data.Status = event.CommitQueueEvicted
+	n, err = NotificationsFromEvent(&s.event)
+	s.NoError(err)
+	s.Len(n, 2)
+
+	s.data.Author = "someone-else"
+	n, err = NotificationsFromEvent(&s.event)
+	s.NoError(err)
+	s.Len(n, 1)
+}
+
+func (s *commitQueueSuite) TestCommitQueueFailure() {
+	n, err := s.t.commitQueueFailure(&s.subs[1])
+	s.NoError(err)
+	s.Nil(n)
+
+	s.data.Status = event.CommitQueueEvicted
+	n, err = s.t.commitQueueFailure(&s.subs[1])
+	s.NoError(err)
+	s.NotNil(n)
+}
+
+func (s *commitQueueSuite) TestCommitQueueSuccess() {
+	n, err := s.t.commitQueueSuccess(&s.subs[0])
+	s.NoError(err)
+	s.NotNil(n)
+
+	s.data.Status = event.CommitQueueEvicted
+	n, err = s.t.commitQueueSuccess(&s.subs[0])
+	s.NoError(err)
+	s.Nil(n)
+}
+
+func (s *commitQueueSuite) TestMakeData() {
+	data, err := s.t.makeData(&s.subs[0])
+	s.NoError(err)
+	s.Require().NotNil(data)
+	s.Equal("https://github.com/evergreen-ci/evergreen/pull/448", data.URL)
+	s.Equal("evergreen-ci/evergreen#448", data.DisplayName)
+
+	s.data.Status = event.CommitQueueEvicted
+	data, err = s.t.makeData(&s.subs[0])
+	s.NoError(err)
+	s.Require().NotNil(data)
+	s.Equal("https://evergreen.mongodb.com/version/5aeb4514f27e4f9984646d97/", data.URL)
+}
@@ -0,0 +1,166 @@
+package trigger
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// digestEmailTemplate is the template under alerts/templates that
+	// renders the body of digest emails
+	digestEmailTemplate = "email/digest.html"
+
+	digestSlackTemplate = "Evergreen digest: %d notifications, %d of them failures, across %d versions and variants"
+)
+
+// digestGroup is the items of a digest that belong to the same version and
+// variant.
+type digestGroup struct {
+	Project      string
+	Version      string
+	Revision     string
+	BuildVariant string
+	Failures     int
+	Items        []notification.DigestItem
+}
+
+// Title describes the version and variant of the group.
+func (g digestGroup) Title() string {
+	revision := g.Revision
+	if len(revision) > 7 {
+		revision = revision[:7]
+	}
+	if revision == "" {
+		revision = g.Version
+	}
+	title := fmt.Sprintf("%s @ %s", g.Project, revision)
+	if g.BuildVariant != "" {
+		title = fmt.Sprintf("%s on %s", title, g.BuildVariant)
+	}
+	return title
+}
+
+type digestTemplateData struct {
+	Count    int
+	Failures int
+	Groups   []digestGroup
+}
+
+func isFailedDigestItem(item *notification.DigestItem) bool {
+	return isFailedTaskStatus(item.Status) || item.Status == evergreen.BuildFailed || item.Status == evergreen.VersionFailed
+}
+
+// makeDigestData groups digest items by version and variant, in the order
+// that each group first appears.
+func makeDigestData(items []notification.DigestItem) *digestTemplateData {
+	data := &digestTemplateData{
+		Count: len(items),
+	}
+	groupIndexes := map[string]int{}
+	for _, item := range items {
+		key := strings.Join([]string{item.Project, item.Version, item.BuildVariant}, "/")
+		idx, ok := groupIndexes[key]
+		if !ok {
+			idx = len(data.Groups)
+			groupIndexes[key] = idx
+			data.Groups = append(data.Groups, digestGroup{
+				Project:      item.Project,
+				Version:      item.Version,
+				Revision:     item.Revision,
+				BuildVariant: item.BuildVariant,
+			})
+		}
+		if isFailedDigestItem(&item) {
+			data.Groups[idx].Failures++
+			data.Failures++
+		}
+		data.Groups[idx].Items = append(data.Groups[idx].Items, item)
+	}
+
+	return data
+}
+
+// MakeDigestPayload builds the payload for a subscriber of a single
+// notification that summarises the given digest items. Email bodies are
+// rendered with the digest template of the renderer.
+func MakeDigestPayload(subscriber *event.Subscriber, items []notification.DigestItem, render gimlet.Renderer) (interface{}, error) {
+	if len(items) == 0 {
+		return nil, errors.New("cannot make a digest without any notifications")
+	}
+	data := makeDigestData(items)
+
+	switch subscriber.Type {
+	case event.EmailSubscriberType:
+		return digestEmail(data, render)
+
+	case event.SlackSubscriberType:
+		return digestSlack(data), nil
+
+	case event.TeamsSubscriberType:
+		return teams(digestSlack(data), ""), nil
+
+	case event.MattermostSubscriberType:
+		return mattermost(digestSlack(data)), nil
+	}
+
+	return nil, errors.Errorf("digests are not supported for '%s' subscribers", subscriber.Type)
+}
+
+func digestEmail(data *digestTemplateData, render gimlet.Renderer) (*message.Email, error) {
+	if render == nil {
+		return nil, errors.New("cannot render digest email without a renderer")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := render.Render(buf, data, "content", digestEmailTemplate); err != nil {
+		return nil, errors.Wrap(err, "failed to execute digest email template")
+	}
+
+	return &message.Email{
+		Subject:           fmt.Sprintf("Evergreen: digest of %d notifications (%d failures)", data.Count, data.Failures),
+		Body:              buf.String(),
+		PlainTextContents: false,
+		Headers: map[string][]string{
+			evergreenHeaderPrefix + "digest": {"true"},
+		},
+	}, nil
+}
+
+func digestSlack(data *digestTemplateData) *notification.SlackPayload {
+	payload := &notification.SlackPayload{
+		Body: fmt.Sprintf(digestSlackTemplate, data.Count, data.Failures, len(data.Groups)),
+	}
+
+	for i := range data.Groups {
+		if i == slackAttachmentsLimit {
+			payload.Attachments = append(payload.Attachments, message.SlackAttachment{
+				Text: fmt.Sprintf("and %d more versions and variants", len(data.Groups)-slackAttachmentsLimit),
+			})
+			break
+		}
+
+		lines := make([]string, 0, len(data.Groups[i].Items))
+		for _, item := range data.Groups[i].Items {
+			lines = append(lines, fmt.Sprintf("%s <%s|%s> %s", item.Object, item.URL, item.DisplayName, item.Status))
+		}
+		color := evergreenSuccessColor
+		if data.Groups[i].Failures > 0 {
+			color = evergreenFailColor
+		}
+		payload.Attachments = append(payload.Attachments, message.SlackAttachment{
+			Title: data.Groups[i].Title(),
+			Text:  strings.Join(lines, "\n"),
+			Color: color,
+		})
+	}
+
+	return payload
+}
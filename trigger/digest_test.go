@@ -0,0 +1,128 @@
+package trigger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestDigestItems() []notification.DigestItem {
+	return []notification.DigestItem{
+		{
+			Object:       objectTask,
+			ID:           "t1",
+			DisplayName:  "compile",
+			Project:      "mci",
+			Version:      "v1",
+			Revision:     "abcdef0123456789",
+			BuildVariant: "ubuntu",
+			Status:       evergreen.TaskFailed,
+			URL:          "https://evergreen.example.com/task/t1",
+		},
+		{
+			Object:       objectTask,
+			ID:           "t2",
+			DisplayName:  "lint",
+			Project:      "mci",
+			Version:      "v1",
+			Revision:     "abcdef0123456789",
+			BuildVariant: "windows",
+			Status:       evergreen.TaskSucceeded,
+			URL:          "https://evergreen.example.com/task/t2",
+		},
+		{
+			Object:       objectTask,
+			ID:           "t3",
+			DisplayName:  "test",
+			Project:      "mci",
+			Version:      "v1",
+			Revision:     "abcdef0123456789",
+			BuildVariant: "ubuntu",
+			Status:       evergreen.TaskSystemFailed,
+			URL:          "https://evergreen.example.com/task/t3",
+		},
+		{
+			Object:      objectVersion,
+			ID:          "v2",
+			DisplayName: "v2",
+			Project:     "mci",
+			Version:     "v2",
+			Status:      evergreen.VersionFailed,
+			URL:         "https://evergreen.example.com/version/v2",
+		},
+	}
+}
+
+func TestMakeDigestData(t *testing.T) {
+	assert := assert.New(t)
+
+	data := makeDigestData(makeTestDigestItems())
+	assert.Equal(4, data.Count)
+	assert.Equal(3, data.Failures)
+	require.Len(t, data.Groups, 3)
+
+	assert.Equal("mci @ abcdef0 on ubuntu", data.Groups[0].Title())
+	assert.Equal(2, data.Groups[0].Failures)
+	require.Len(t, data.Groups[0].Items, 2)
+	assert.Equal("t1", data.Groups[0].Items[0].ID)
+	assert.Equal("t3", data.Groups[0].Items[1].ID)
+
+	assert.Equal("mci @ abcdef0 on windows", data.Groups[1].Title())
+	assert.Equal(0, data.Groups[1].Failures)
+
+	assert.Equal("mci @ v2", data.Groups[2].Title())
+	assert.Equal(1, data.Groups[2].Failures)
+}
+
+func TestMakeDigestPayload(t *testing.T) {
+	assert := assert.New(t)
+	items := makeTestDigestItems()
+
+	payload, err := MakeDigestPayload(&event.Subscriber{Type: event.SlackSubscriberType}, items, nil)
+	assert.NoError(err)
+	slackPayload, ok := payload.(*notification.SlackPayload)
+	require.True(t, ok)
+	assert.Contains(slackPayload.Body, "4 notifications")
+	require.Len(t, slackPayload.Attachments, 3)
+	assert.Equal(evergreenFailColor, slackPayload.Attachments[0].Color)
+	assert.Contains(slackPayload.Attachments[0].Text, "<https://evergreen.example.com/task/t3|test>")
+	assert.Equal(evergreenSuccessColor, slackPayload.Attachments[1].Color)
+
+	payload, err = MakeDigestPayload(&event.Subscriber{Type: event.TeamsSubscriberType}, items, nil)
+	assert.NoError(err)
+	teamsPayload, ok := payload.(*notification.TeamsPayload)
+	require.True(t, ok)
+	assert.Len(teamsPayload.Sections, 3)
+
+	payload, err = MakeDigestPayload(&event.Subscriber{Type: event.MattermostSubscriberType}, items, nil)
+	assert.NoError(err)
+	mattermostPayload, ok := payload.(*notification.MattermostPayload)
+	require.True(t, ok)
+	assert.Contains(mattermostPayload.Attachments[0].Text, "[test](https://evergreen.example.com/task/t3)")
+
+	render := gimlet.NewHTMLRenderer(gimlet.RendererOptions{
+		Directory:    filepath.Join(evergreen.FindEvergreenHome(), "alerts", "templates"),
+		DisableCache: true,
+	})
+	payload, err = MakeDigestPayload(&event.Subscriber{Type: event.EmailSubscriberType}, items, render)
+	assert.NoError(err)
+	email, ok := payload.(*message.Email)
+	require.True(t, ok)
+	assert.Equal("Evergreen: digest of 4 notifications (3 failures)", email.Subject)
+	assert.Contains(email.Body, "mci @ abcdef0 on ubuntu")
+	assert.Contains(email.Body, `href="https://evergreen.example.com/task/t1"`)
+
+	_, err = MakeDigestPayload(&event.Subscriber{Type: event.EmailSubscriberType}, items, nil)
+	assert.Error(err)
+	_, err = MakeDigestPayload(&event.Subscriber{Type: event.JIRACommentSubscriberType}, items, nil)
+	assert.Error(err)
+	_, err = MakeDigestPayload(&event.Subscriber{Type: event.SlackSubscriberType}, nil, nil)
+	assert.Error(err)
+}
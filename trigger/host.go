@@ -123,6 +123,18 @@ func (t *hostTriggers) generate(sub *event.Subscription, subjectTempl, bodyTempl
 		payload, err = hostExpirationEmailPayload(t.templateData, subjectTempl, bodyTempl, sub.Selectors)
 	case event.SlackSubscriberType:
 		payload, err = hostExpirationSlackPayload(t.templateData, bodyTempl, sub.Selectors)
+	case event.TeamsSubscriberType:
+		var slackPayload *notification.SlackPayload
+		slackPayload, err = hostExpirationSlackPayload(t.templateData, bodyTempl, sub.Selectors)
+		if err == nil {
+			payload = teams(slackPayload, t.templateData.URL)
+		}
+	case event.MattermostSubscriberType:
+		var slackPayload *notification.SlackPayload
+		slackPayload, err = hostExpirationSlackPayload(t.templateData, bodyTempl, sub.Selectors)
+		if err == nil {
+			payload = mattermost(slackPayload)
+		}
 	default:
 		return nil, nil
 	}
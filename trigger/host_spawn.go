@@ -114,6 +114,12 @@ func (t *spawnHostTriggers) makePayload(sub *event.Subscription) interface{} {
 	case event.SlackSubscriberType:
 		return t.slack()
 
+	case event.TeamsSubscriberType:
+		return teams(t.slack(), spawnHostURL(t.uiConfig.Url))
+
+	case event.MattermostSubscriberType:
+		return mattermost(t.slack())
+
 	case event.EmailSubscriberType:
 		return t.email()
 
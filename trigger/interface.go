@@ -28,6 +28,13 @@ type eventHandler interface {
 	ValidateTrigger(string) bool
 }
 
+// digestEventHandler is implemented by the event handlers whose
+// notifications can be collected into digests.
+type digestEventHandler interface {
+	// digestItem summarises the fetched event for a digest
+	digestItem() *notification.DigestItem
+}
+
 type trigger func(*event.Subscription) (*notification.Notification, error)
 
 type base struct {
@@ -0,0 +1,194 @@
+package trigger
+
+import (
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// templateEventHandler is implemented by the event handlers whose
+// notifications can be customised by the message template of a
+// subscription.
+type templateEventHandler interface {
+	// messageTemplateData returns the data model of the fetched event that
+	// message templates are rendered with
+	messageTemplateData() *event.MessageTemplateData
+}
+
+// applyMessageTemplate replaces the text of a notification with the message
+// template of its subscription.
+func applyMessageTemplate(h eventHandler, sub *event.Subscription, n *notification.Notification) error {
+	th, ok := h.(templateEventHandler)
+	if !ok {
+		return errors.Errorf("subscription '%s' has a message template, but its events can't be templated", sub.ID)
+	}
+
+	data := th.messageTemplateData()
+	data.Trigger = sub.Trigger
+	subject, body, err := sub.Template.Render(data)
+	if err != nil {
+		return errors.Wrapf(err, "can't render message template of subscription '%s'", sub.ID)
+	}
+
+	switch payload := n.Payload.(type) {
+	case *message.Email:
+		if subject != "" {
+			payload.Subject = subject
+		}
+		payload.Body = body
+		payload.PlainTextContents = true
+
+	case *notification.SlackPayload:
+		payload.Body = body
+
+	case *notification.TeamsPayload:
+		payload.Summary = slackToPlainText(body)
+		payload.Text = body
+
+	case *notification.MattermostPayload:
+		payload.Text = body
+
+	case *string:
+		*payload = body
+
+	case *message.JiraIssue:
+		if subject != "" {
+			payload.Summary, _ = truncateString(subject, 254)
+		}
+		payload.Description = body
+
+	default:
+		return errors.Errorf("message templates are not supported for '%s' subscribers", sub.Subscriber.Type)
+	}
+
+	return nil
+}
+
+func (t *taskTriggers) messageTemplateData() *event.MessageTemplateData {
+	failedTests := []string{}
+	for _, test := range t.task.LocalTestResults {
+		if test.Status == evergreen.TestFailedStatus {
+			failedTests = append(failedTests, test.TestFile)
+		}
+	}
+
+	return &event.MessageTemplateData{
+		Object:      objectTask,
+		ID:          t.task.Id,
+		DisplayName: t.task.DisplayName,
+		URL:         taskLink(&t.uiConfig, t.task.Id, t.task.Execution),
+		Project:     t.task.Project,
+		Status:      t.data.Status,
+		Task: &event.MessageTemplateTask{
+			ID:           t.task.Id,
+			DisplayName:  t.task.DisplayName,
+			BuildVariant: t.task.BuildVariant,
+			Status:       t.task.Status,
+			Execution:    t.task.Execution,
+			Version:      t.task.Version,
+			Revision:     t.task.Revision,
+			Requester:    t.task.Requester,
+			Details:      t.task.Details.Description,
+			TimedOut:     t.task.Details.TimedOut,
+			TimeTaken:    t.task.TimeTaken,
+			FailedTests:  failedTests,
+		},
+	}
+}
+
+func (t *buildTriggers) messageTemplateData() *event.MessageTemplateData {
+	failedTasks := []string{}
+	for _, task := range t.build.Tasks {
+		if isFailedTaskStatus(task.Status) {
+			failedTasks = append(failedTasks, task.DisplayName)
+		}
+	}
+
+	return &event.MessageTemplateData{
+		Object:      objectBuild,
+		ID:          t.build.Id,
+		DisplayName: t.build.DisplayName,
+		URL:         buildLink(&t.uiConfig, t.build.Id),
+		Project:     t.build.Project,
+		Status:      t.data.Status,
+		Build: &event.MessageTemplateBuild{
+			ID:           t.build.Id,
+			DisplayName:  t.build.DisplayName,
+			BuildVariant: t.build.BuildVariant,
+			Status:       t.build.Status,
+			Version:      t.build.Version,
+			Revision:     t.build.Revision,
+			Requester:    t.build.Requester,
+			TimeTaken:    t.build.TimeTaken,
+			FailedTasks:  failedTasks,
+		},
+	}
+}
+
+func (t *versionTriggers) messageTemplateData() *event.MessageTemplateData {
+	return &event.MessageTemplateData{
+		Object:      objectVersion,
+		ID:          t.version.Id,
+		DisplayName: t.version.Id,
+		URL:         versionLink(&t.uiConfig, t.version.Id),
+		Project:     t.version.Identifier,
+		Status:      t.data.Status,
+		Version: &event.MessageTemplateVersion{
+			ID:        t.version.Id,
+			Revision:  t.version.Revision,
+			Author:    t.version.Author,
+			Message:   t.version.Message,
+			Status:    t.version.Status,
+			Requester: t.version.Requester,
+		},
+	}
+}
+
+func (t *patchTriggers) messageTemplateData() *event.MessageTemplateData {
+	data := &event.MessageTemplateData{
+		Object:      objectPatch,
+		ID:          t.patch.Id.Hex(),
+		DisplayName: t.patch.Id.Hex(),
+		URL:         fmt.Sprintf("%s/version/%s", t.uiConfig.Url, t.patch.Version),
+		Project:     t.patch.Project,
+		Status:      t.data.Status,
+		Patch: &event.MessageTemplatePatch{
+			ID:          t.patch.Id.Hex(),
+			Number:      t.patch.PatchNumber,
+			Author:      t.patch.Author,
+			Description: t.patch.Description,
+			Status:      t.patch.Status,
+			Version:     t.patch.Version,
+		},
+	}
+	if t.patch.IsGithubPRPatch() {
+		data.Patch.PullRequestURL = fmt.Sprintf("https://github.com/%s/%s/pull/%d", t.patch.GithubPatchData.BaseOwner,
+			t.patch.GithubPatchData.BaseRepo, t.patch.GithubPatchData.PRNumber)
+	}
+
+	return data
+}
+
+func (t *hostBase) messageTemplateData() *event.MessageTemplateData {
+	return &event.MessageTemplateData{
+		Object:      objectHost,
+		ID:          t.host.Id,
+		DisplayName: t.host.Id,
+		URL:         spawnHostURL(t.uiConfig.Url),
+		Project:     t.host.Project,
+		Status:      t.host.Status,
+		Host: &event.MessageTemplateHost{
+			ID:             t.host.Id,
+			Distro:         t.host.Distro.Id,
+			Provider:       t.host.Provider,
+			Status:         t.host.Status,
+			Owner:          t.host.StartedBy,
+			Hostname:       t.host.Host,
+			ExpirationTime: t.host.ExpirationTime,
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package trigger
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMessageTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	h := &buildTriggers{
+		data: &event.BuildEventData{Status: evergreen.BuildFailed},
+		build: &build.Build{
+			Id:           "b1",
+			DisplayName:  "Ubuntu",
+			BuildVariant: "ubuntu",
+			Project:      "mci",
+			Status:       evergreen.BuildFailed,
+			Tasks: []build.TaskCache{
+				{DisplayName: "compile", Status: evergreen.TaskFailed},
+				{DisplayName: "lint", Status: evergreen.TaskSucceeded},
+			},
+		},
+		uiConfig: evergreen.UIConfig{Url: "https://evergreen.example.com"},
+	}
+	sub := &event.Subscription{
+		ID:      "sub",
+		Type:    event.ResourceTypeBuild,
+		Trigger: "outcome",
+		Template: &event.MessageTemplate{
+			Subject: "{{ .Project }} {{ .Object }} {{ .Status }}",
+			Body:    "{{ .Trigger }}: {{ join .Build.FailedTasks \",\" }} failed on {{ .Build.BuildVariant }}",
+		},
+	}
+	const subject = "mci build failed"
+	const body = "outcome: compile failed on ubuntu"
+
+	email := &message.Email{Subject: "old", Body: "<p>old</p>"}
+	assert.NoError(applyMessageTemplate(h, sub, &notification.Notification{Payload: email}))
+	assert.Equal(subject, email.Subject)
+	assert.Equal(body, email.Body)
+	assert.True(email.PlainTextContents)
+
+	slack := &notification.SlackPayload{Body: "old"}
+	assert.NoError(applyMessageTemplate(h, sub, &notification.Notification{Payload: slack}))
+	assert.Equal(body, slack.Body)
+
+	mattermost := &notification.MattermostPayload{Text: "old"}
+	assert.NoError(applyMessageTemplate(h, sub, &notification.Notification{Payload: mattermost}))
+	assert.Equal(body, mattermost.Text)
+
+	comment := "old"
+	assert.NoError(applyMessageTemplate(h, sub, &notification.Notification{Payload: &comment}))
+	assert.Equal(body, comment)
+
+	issue := &message.JiraIssue{Summary: "old", Description: "old"}
+	assert.NoError(applyMessageTemplate(h, sub, &notification.Notification{Payload: issue}))
+	assert.Equal(subject, issue.Summary)
+	assert.Equal(body, issue.Description)
+
+	// webhooks keep their fixed format
+	assert.Error(applyMessageTemplate(h, sub, &notification.Notification{Payload: &util.EvergreenWebhook{}}))
+
+	sub.Template.Body = "{{ .Task.ID }}"
+	assert.Error(applyMessageTemplate(h, sub, &notification.Notification{Payload: slack}))
+}
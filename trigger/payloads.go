@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"regexp"
+	"strings"
 	ttemplate "text/template"
 
 	"github.com/evergreen-ci/evergreen"
@@ -198,6 +200,78 @@ func slack(t *commonTemplateData) (*notification.SlackPayload, error) {
 	}, nil
 }
 
+// slackLinkRegexp matches links in Slack's <url|text> format
+var slackLinkRegexp = regexp.MustCompile(`<([^<>|]+)\|([^<>]+)>`)
+
+// slackToMarkdown rewrites the links in a Slack message as markdown links.
+func slackToMarkdown(s string) string {
+	return slackLinkRegexp.ReplaceAllString(s, "[$2]($1)")
+}
+
+// slackToPlainText replaces the links in a Slack message with their text.
+func slackToPlainText(s string) string {
+	return slackLinkRegexp.ReplaceAllString(s, "$2")
+}
+
+// mattermost converts a Slack payload to a Mattermost payload. Mattermost
+// accepts Slack attachments, but formats text as markdown.
+func mattermost(payload *notification.SlackPayload) *notification.MattermostPayload {
+	attachments := make([]message.SlackAttachment, 0, len(payload.Attachments))
+	for _, attachment := range payload.Attachments {
+		attachment.Text = slackToMarkdown(attachment.Text)
+		fields := make([]*message.SlackAttachmentField, 0, len(attachment.Fields))
+		for _, field := range attachment.Fields {
+			if field == nil {
+				continue
+			}
+			fields = append(fields, &message.SlackAttachmentField{
+				Title: field.Title,
+				Value: slackToMarkdown(field.Value),
+				Short: field.Short,
+			})
+		}
+		attachment.Fields = fields
+		attachments = append(attachments, attachment)
+	}
+
+	return &notification.MattermostPayload{
+		Text:        slackToMarkdown(payload.Body),
+		Attachments: attachments,
+	}
+}
+
+// teams converts a Slack payload to a Teams message card, with a section
+// for each attachment and a button linking to url.
+func teams(payload *notification.SlackPayload, url string) *notification.TeamsPayload {
+	card := notification.NewTeamsPayload(slackToPlainText(payload.Body), slackToMarkdown(payload.Body), url)
+	for _, attachment := range payload.Attachments {
+		if len(card.ThemeColor) == 0 {
+			card.ThemeColor = strings.TrimPrefix(attachment.Color, "#")
+		}
+
+		section := notification.TeamsSection{
+			ActivityTitle: attachment.Title,
+			Text:          slackToMarkdown(attachment.Text),
+			Markdown:      true,
+		}
+		if len(attachment.TitleLink) != 0 {
+			section.ActivityTitle = fmt.Sprintf("[%s](%s)", attachment.Title, attachment.TitleLink)
+		}
+		for _, field := range attachment.Fields {
+			if field == nil {
+				continue
+			}
+			section.Facts = append(section.Facts, notification.TeamsFact{
+				Name:  field.Title,
+				Value: slackToMarkdown(field.Value),
+			})
+		}
+		card.Sections = append(card.Sections, section)
+	}
+
+	return card
+}
+
 // truncateString splits a string into two parts, with the following behavior:
 // If the entire string is <= capacity, it's returned unchanged.
 // Otherwise, the string is split at the (capacity-3)'th byte. The first string
@@ -260,6 +334,20 @@ func makeCommonPayload(sub *event.Subscription, selectors []event.Selector,
 
 	case event.SlackSubscriberType:
 		return slack(data)
+
+	case event.TeamsSubscriberType:
+		payload, err := slack(data)
+		if err != nil {
+			return nil, err
+		}
+		return teams(payload, data.URL), nil
+
+	case event.MattermostSubscriberType:
+		payload, err := slack(data)
+		if err != nil {
+			return nil, err
+		}
+		return mattermost(payload), nil
 	}
 
 	return nil, errors.Errorf("unknown type: '%s'", sub.Subscriber.Type)
@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip/message"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -89,6 +92,60 @@ func (s *payloadSuite) TestSlack() {
 	s.Empty(m.Attachments)
 }
 
+func (s *payloadSuite) TestTeams() {
+	s.t.slack = []message.SlackAttachment{
+		{
+			Title:     "Build",
+			TitleLink: "https://example.com/build/1",
+			Color:     evergreenFailColor,
+			Text:      "see <https://example.com/task/1|the task>",
+			Fields: []*message.SlackAttachmentField{
+				{Title: "Task", Value: "<https://example.com/task/1|compile>"},
+			},
+		},
+	}
+	m, err := makeCommonPayload(&event.Subscription{
+		Trigger:    "outcome",
+		Subscriber: event.NewTeamsSubscriber("https://outlook.office.com/webhook/abc"),
+	}, nil, &s.t)
+	s.NoError(err)
+	card, ok := m.(*notification.TeamsPayload)
+	s.Require().True(ok)
+
+	s.Equal("MessageCard", card.Type)
+	s.Equal("The patch display-1234 in 'test' has failed!", card.Summary)
+	s.Equal("The patch [display-1234](https://example.com/patch/1234) in 'test' has failed!", card.Text)
+	s.Equal("ce3c3e", card.ThemeColor)
+	s.Require().Len(card.Sections, 1)
+	s.Equal("[Build](https://example.com/build/1)", card.Sections[0].ActivityTitle)
+	s.Equal("see [the task](https://example.com/task/1)", card.Sections[0].Text)
+	s.Equal([]notification.TeamsFact{{Name: "Task", Value: "[compile](https://example.com/task/1)"}}, card.Sections[0].Facts)
+	s.Require().Len(card.PotentialAction, 1)
+	s.Equal(s.url, card.PotentialAction[0].Targets[0].URI)
+}
+
+func (s *payloadSuite) TestMattermost() {
+	s.t.slack = []message.SlackAttachment{
+		{
+			Title:  "Build",
+			Fields: []*message.SlackAttachmentField{{Title: "Task", Value: "<https://example.com/task/1|compile>"}},
+		},
+	}
+	m, err := makeCommonPayload(&event.Subscription{
+		Trigger:    "outcome",
+		Subscriber: event.NewMattermostSubscriber("https://mattermost.example.com/hooks/abc"),
+	}, nil, &s.t)
+	s.NoError(err)
+	payload, ok := m.(*notification.MattermostPayload)
+	s.Require().True(ok)
+
+	s.Equal("The patch [display-1234](https://example.com/patch/1234) in 'test' has failed!", payload.Text)
+	s.Require().Len(payload.Attachments, 1)
+	s.Equal("[compile](https://example.com/task/1)", payload.Attachments[0].Fields[0].Value)
+	// the template data is not modified
+	s.Equal("<https://example.com/task/1|compile>", s.t.slack[0].Fields[0].Value)
+}
+
 func TestTruncateString(t *testing.T) {
 	assert := assert.New(t)
 
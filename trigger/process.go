@@ -1,6 +1,8 @@
 package trigger
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/mongodb/grip"
@@ -66,9 +68,38 @@ func NotificationsFromEvent(e *event.EventLogEntry) ([]notification.Notification
 		if n == nil {
 			continue
 		}
+		if subscriptions[i].Template != nil {
+			if err = applyMessageTemplate(h, &subscriptions[i], n); err != nil {
+				catcher.Add(err)
+				grip.Error(message.WrapError(err, msg))
+				continue
+			}
+		}
+		if subscriptions[i].Digest != nil {
+			if err = attachDigestItem(h, &subscriptions[i], n); err != nil {
+				catcher.Add(err)
+				grip.Error(message.WrapError(err, msg))
+				continue
+			}
+		}
 
 		notifications = append(notifications, *n)
 	}
 
 	return notifications, catcher.Resolve()
 }
+
+// attachDigestItem marks a notification to be sent in the digest of its
+// subscription, rather than on its own.
+func attachDigestItem(h eventHandler, sub *event.Subscription, n *notification.Notification) error {
+	dh, ok := h.(digestEventHandler)
+	if !ok {
+		return errors.Errorf("subscription '%s' has a digest, but its events can't be collected into digests", sub.ID)
+	}
+
+	n.Digest = dh.digestItem()
+	n.Digest.SubscriptionID = sub.ID
+	n.Digest.CreatedAt = time.Now().Truncate(time.Millisecond)
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package trigger
+
+import (
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// DownstreamVersionCreator creates, or schedules the creation of, the version
+// of a downstream project for a project trigger that an upstream version, or
+// build, fired.
+type DownstreamVersionCreator func(*model.ProjectRef, model.TriggerDefinition, *version.UpstreamVersion) error
+
+// EvalProjectTriggers finds the project triggers that an event fires, and
+// creates their versions with the given function. Only the completion of
+// mainline versions, and of their builds, fires project triggers. It returns
+// the number of triggers whose versions were created.
+//
+// It is possible for this function to return a count and errors at the same
+// time, if some of the triggers failed.
+func EvalProjectTriggers(e *event.EventLogEntry, create DownstreamVersionCreator) (int, error) {
+	h := registry.eventHandler(e.ResourceType, e.EventType)
+	if h == nil {
+		return 0, errors.Errorf("unknown event ResourceType '%s' or EventType '%s'", e.ResourceType, e.EventType)
+	}
+	th, ok := h.(projectTriggerEventHandler)
+	if !ok {
+		return 0, nil
+	}
+
+	if err := h.Fetch(e); err != nil {
+		return 0, errors.Wrapf(err, "error fetching data for event: %s (%s, %s)", e.ID, e.ResourceType, e.EventType)
+	}
+	project := th.upstreamProject()
+	if project == "" {
+		return 0, nil
+	}
+
+	refs, err := model.FindDownstreamProjectRefs(project)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error finding projects downstream of '%s'", project)
+	}
+
+	fired := 0
+	catcher := grip.NewSimpleCatcher()
+	for i := range refs {
+		for _, def := range refs[i].Triggers {
+			if def.Project != project {
+				continue
+			}
+			upstream, err := th.upstreamFor(def)
+			if err != nil {
+				catcher.Add(errors.Wrapf(err, "error evaluating trigger '%s' of project '%s'", def.ID, refs[i].Identifier))
+				continue
+			}
+			if upstream == nil {
+				continue
+			}
+
+			err = create(&refs[i], def, upstream)
+			grip.Error(message.WrapError(err, message.Fields{
+				"source":           "events-processing",
+				"message":          "error creating downstream version",
+				"event_id":         e.ID,
+				"project":          refs[i].Identifier,
+				"trigger":          def.ID,
+				"upstream_project": project,
+				"upstream_version": upstream.VersionId,
+			}))
+			if err != nil {
+				catcher.Add(errors.Wrapf(err, "error creating version for trigger '%s' of project '%s'", def.ID, refs[i].Identifier))
+				continue
+			}
+			fired++
+		}
+	}
+
+	return fired, catcher.Resolve()
+}
+
+// projectTriggerEventHandler is implemented by the event handlers whose
+// events can fire the project triggers of downstream projects.
+type projectTriggerEventHandler interface {
+	// upstreamProject returns the project of the fetched event, or an
+	// empty string if the event can't fire project triggers
+	upstreamProject() string
+
+	// upstreamFor returns the upstream version that the fetched event
+	// describes, or nil if the event doesn't fire the trigger
+	upstreamFor(model.TriggerDefinition) (*version.UpstreamVersion, error)
+}
+
+// firesProjectTriggers returns whether a version or build with the given
+// requester and status can fire project triggers.
+func firesProjectTriggers(requester, status string) bool {
+	if requester != evergreen.RepotrackerVersionRequester {
+		return false
+	}
+	return status == evergreen.VersionSucceeded || status == evergreen.VersionFailed
+}
+
+// upstreamArtifacts returns the files uploaded by the tasks matching the
+// artifact query.
+func upstreamArtifacts(query db.Q) ([]version.UpstreamArtifact, error) {
+	entries, err := artifact.FindAll(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding artifacts")
+	}
+
+	files := []version.UpstreamArtifact{}
+	links := map[string]bool{}
+	for _, entry := range entries {
+		for _, file := range entry.Files {
+			if links[file.Link] {
+				continue
+			}
+			links[file.Link] = true
+			files = append(files, version.UpstreamArtifact{
+				Task: entry.TaskDisplayName,
+				Name: file.Name,
+				Link: file.Link,
+			})
+		}
+	}
+	return files, nil
+}
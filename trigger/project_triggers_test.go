@@ -0,0 +1,186 @@
+package trigger
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestProjectTriggers(t *testing.T) {
+	suite.Run(t, &projectTriggersSuite{})
+}
+
+type projectTriggersSuite struct {
+	created []triggeredVersion
+
+	suite.Suite
+}
+
+type triggeredVersion struct {
+	project  string
+	trigger  string
+	upstream version.UpstreamVersion
+}
+
+func (s *projectTriggersSuite) SetupSuite() {
+	s.Require().Implements((*projectTriggerEventHandler)(nil), &versionTriggers{})
+	s.Require().Implements((*projectTriggerEventHandler)(nil), &buildTriggers{})
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *projectTriggersSuite) SetupTest() {
+	s.NoError(db.ClearCollections(model.ProjectRefCollection, version.Collection, build.Collection, artifact.Collection))
+	s.created = nil
+
+	s.NoError((&version.Version{
+		Id:         "lib_abc",
+		Identifier: "lib",
+		Revision:   "abc",
+		Requester:  evergreen.RepotrackerVersionRequester,
+		BuildIds:   []string{"lib_linux_abc"},
+	}).Insert())
+	s.NoError((&build.Build{
+		Id:           "lib_linux_abc",
+		Version:      "lib_abc",
+		Project:      "lib",
+		Revision:     "abc",
+		BuildVariant: "linux",
+		Requester:    evergreen.RepotrackerVersionRequester,
+		Tasks: []build.TaskCache{
+			{Id: "lib_linux_package_abc", DisplayName: "package", Status: evergreen.TaskSucceeded, Activated: true},
+			{Id: "lib_linux_test_abc", DisplayName: "test", Status: evergreen.TaskFailed, Activated: true},
+			{Id: "lib_linux_lint_abc", DisplayName: "lint", Status: evergreen.TaskUndispatched},
+		},
+	}).Insert())
+	s.NoError(artifact.Entry{
+		TaskId:          "lib_linux_package_abc",
+		TaskDisplayName: "package",
+		BuildId:         "lib_linux_abc",
+		Files:           []artifact.File{{Name: "tarball", Link: "https://example.com/lib.tgz"}},
+	}.Upsert())
+
+	s.NoError((&model.ProjectRef{
+		Identifier: "integration",
+		Enabled:    true,
+		Triggers: []model.TriggerDefinition{
+			{ID: "lib-green", Project: "lib", Level: model.ProjectTriggerLevelVersion, Status: evergreen.VersionSucceeded, Alias: "smoke"},
+			{ID: "lib-done", Project: "lib", Level: model.ProjectTriggerLevelVersion, Alias: "smoke"},
+			{ID: "lib-package", Project: "lib", Level: model.ProjectTriggerLevelBuild, Variant: "^linux$", Task: "^package$", Status: evergreen.BuildSucceeded, Alias: "smoke"},
+			{ID: "lib-tests", Project: "lib", Level: model.ProjectTriggerLevelBuild, Task: "^(test|lint)$", Status: evergreen.BuildSucceeded, Alias: "smoke"},
+			{ID: "lib-windows", Project: "lib", Level: model.ProjectTriggerLevelBuild, Variant: "^windows$", Alias: "smoke"},
+			{ID: "other", Project: "other", Level: model.ProjectTriggerLevelVersion, Alias: "smoke"},
+		},
+	}).Insert())
+	s.NoError((&model.ProjectRef{
+		Identifier: "disabled",
+		Triggers: []model.TriggerDefinition{
+			{ID: "lib-done", Project: "lib", Level: model.ProjectTriggerLevelVersion, Alias: "smoke"},
+		},
+	}).Insert())
+}
+
+func (s *projectTriggersSuite) create(ref *model.ProjectRef, def model.TriggerDefinition, upstream *version.UpstreamVersion) error {
+	s.created = append(s.created, triggeredVersion{project: ref.Identifier, trigger: def.ID, upstream: *upstream})
+	return nil
+}
+
+func (s *projectTriggersSuite) TestVersionTriggers() {
+	e := &event.EventLogEntry{
+		ResourceType: event.ResourceTypeVersion,
+		EventType:    event.VersionStateChange,
+		ResourceId:   "lib_abc",
+		Data:         &event.VersionEventData{Status: evergreen.VersionFailed},
+	}
+	fired, err := EvalProjectTriggers(e, s.create)
+	s.NoError(err)
+	s.Require().Equal(1, fired)
+	s.Require().Len(s.created, 1)
+	s.Equal("integration", s.created[0].project)
+	s.Equal("lib-done", s.created[0].trigger)
+	s.Equal("lib_abc", s.created[0].upstream.VersionId)
+	s.Equal("abc", s.created[0].upstream.Revision)
+	s.Equal(evergreen.VersionFailed, s.created[0].upstream.Status)
+	s.Empty(s.created[0].upstream.BuildId)
+	s.Require().Len(s.created[0].upstream.Artifacts, 1)
+	s.Equal("https://example.com/lib.tgz", s.created[0].upstream.Artifacts[0].Link)
+
+	s.created = nil
+	e.Data = &event.VersionEventData{Status: evergreen.VersionSucceeded}
+	fired, err = EvalProjectTriggers(e, s.create)
+	s.NoError(err)
+	s.Equal(2, fired)
+	s.Require().Len(s.created, 2)
+	s.Equal("lib-green", s.created[0].trigger)
+	s.Equal("lib-done", s.created[1].trigger)
+
+	// unfinished versions don't fire triggers
+	s.created = nil
+	e.Data = &event.VersionEventData{Status: evergreen.VersionStarted}
+	fired, err = EvalProjectTriggers(e, s.create)
+	s.NoError(err)
+	s.Zero(fired)
+	s.Empty(s.created)
+}
+
+func (s *projectTriggersSuite) TestBuildTriggers() {
+	e := &event.EventLogEntry{
+		ResourceType: event.ResourceTypeBuild,
+		EventType:    event.BuildStateChange,
+		ResourceId:   "lib_linux_abc",
+		Data:         &event.BuildEventData{Status: evergreen.BuildFailed},
+	}
+	fired, err := EvalProjectTriggers(e, s.create)
+	s.NoError(err)
+	s.Require().Equal(1, fired)
+	s.Require().Len(s.created, 1)
+	s.Equal("lib-package", s.created[0].trigger)
+
+	upstream := s.created[0].upstream
+	s.Equal("lib", upstream.Project)
+	s.Equal("lib_abc", upstream.VersionId)
+	s.Equal("lib_linux_abc", upstream.BuildId)
+	s.Equal("linux", upstream.BuildVariant)
+	s.Equal(evergreen.BuildSucceeded, upstream.Status)
+	s.Require().Len(upstream.Artifacts, 1)
+	s.Equal("package", upstream.Artifacts[0].Task)
+	s.Equal("tarball", upstream.Artifacts[0].Name)
+}
+
+func (s *projectTriggersSuite) TestPatchesDontFireTriggers() {
+	s.NoError(version.UpdateOne(
+		bson.M{version.IdKey: "lib_abc"},
+		bson.M{"$set": bson.M{version.RequesterKey: evergreen.PatchVersionRequester}},
+	))
+	e := &event.EventLogEntry{
+		ResourceType: event.ResourceTypeVersion,
+		EventType:    event.VersionStateChange,
+		ResourceId:   "lib_abc",
+		Data:         &event.VersionEventData{Status: evergreen.VersionSucceeded},
+	}
+	fired, err := EvalProjectTriggers(e, s.create)
+	s.NoError(err)
+	s.Zero(fired)
+	s.Empty(s.created)
+}
+
+func (s *projectTriggersSuite) TestOtherEventsDontFireTriggers() {
+	e := &event.EventLogEntry{
+		ResourceType: event.ResourceTypeCommitQueue,
+		EventType:    event.CommitQueueStateChange,
+		ResourceId:   "lib",
+		Data:         &event.CommitQueueEventData{Status: evergreen.VersionSucceeded},
+	}
+	fired, err := EvalProjectTriggers(e, s.create)
+	s.NoError(err)
+	s.Zero(fired)
+	s.Empty(s.created)
+}
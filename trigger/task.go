@@ -240,6 +240,20 @@ func (t *taskTriggers) makeData(sub *event.Subscription, pastTenseOverride strin
 	return &data, nil
 }
 
+func (t *taskTriggers) digestItem() *notification.DigestItem {
+	return &notification.DigestItem{
+		Object:       objectTask,
+		ID:           t.task.Id,
+		DisplayName:  t.task.DisplayName,
+		Project:      t.task.Project,
+		Version:      t.task.Version,
+		Revision:     t.task.Revision,
+		BuildVariant: t.task.BuildVariant,
+		Status:       t.data.Status,
+		URL:          taskLink(&t.uiConfig, t.task.Id, t.task.Execution),
+	}
+}
+
 func (t *taskTriggers) generate(sub *event.Subscription, pastTenseOverride string) (*notification.Notification, error) {
 	var payload interface{}
 	if sub.Subscriber.Type == event.JIRAIssueSubscriberType {
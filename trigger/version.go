@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -108,6 +110,19 @@ func (t *versionTriggers) makeData(sub *event.Subscription, pastTenseOverride st
 	return &data, nil
 }
 
+func (t *versionTriggers) digestItem() *notification.DigestItem {
+	return &notification.DigestItem{
+		Object:      objectVersion,
+		ID:          t.version.Id,
+		DisplayName: t.version.Id,
+		Project:     t.version.Identifier,
+		Version:     t.version.Id,
+		Revision:    t.version.Revision,
+		Status:      t.data.Status,
+		URL:         versionLink(&t.uiConfig, t.version.Id),
+	}
+}
+
 func (t *versionTriggers) generate(sub *event.Subscription, pastTenseOverride string) (*notification.Notification, error) {
 	data, err := t.makeData(sub, pastTenseOverride)
 	if err != nil {
@@ -241,3 +256,31 @@ func MakeVersionSelectors(v version.Version) []event.Selector {
 	}
 	return selectors
 }
+
+func (t *versionTriggers) upstreamProject() string {
+	if !firesProjectTriggers(t.version.Requester, t.data.Status) {
+		return ""
+	}
+	return t.version.Identifier
+}
+
+func (t *versionTriggers) upstreamFor(def model.TriggerDefinition) (*version.UpstreamVersion, error) {
+	if def.Level != model.ProjectTriggerLevelVersion {
+		return nil, nil
+	}
+	if def.Status != "" && def.Status != t.data.Status {
+		return nil, nil
+	}
+
+	artifacts, err := upstreamArtifacts(artifact.ByBuildIds(t.version.BuildIds))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding artifacts of version '%s'", t.version.Id)
+	}
+	return &version.UpstreamVersion{
+		Project:   t.version.Identifier,
+		VersionId: t.version.Id,
+		Revision:  t.version.Revision,
+		Status:    t.data.Status,
+		Artifacts: artifacts,
+	}, nil
+}
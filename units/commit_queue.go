@@ -0,0 +1,331 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/github"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	commitQueueJobName = "commit-queue"
+
+	// commitQueueMaxInFlight is the number of pull requests at the front of
+	// a commit queue that are tested at once. Each is tested on top of the
+	// pull requests ahead of it, on the assumption that they will merge.
+	commitQueueMaxInFlight = 3
+
+	// commitQueueLockTimeout is how long a job may hold the lock on a
+	// commit queue before another job may take it over.
+	commitQueueLockTimeout = 10 * time.Minute
+)
+
+func init() {
+	registry.AddJobType(commitQueueJobName, func() amboy.Job {
+		return makeCommitQueueJob()
+	})
+}
+
+type commitQueueJob struct {
+	ProjectID string `bson:"project_id" json:"project_id" yaml:"project_id"`
+	job.Base  `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	env evergreen.Environment
+}
+
+func makeCommitQueueJob() *commitQueueJob {
+	j := &commitQueueJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    commitQueueJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewCommitQueueJob creates a job that advances the commit queue of a
+// project: it merges the pull request at the head of the queue if its patch
+// passed, evicts it if its patch failed, and starts patches for the pull
+// requests behind it.
+func NewCommitQueueJob(projectID string, id string) amboy.Job {
+	j := makeCommitQueueJob()
+	j.ProjectID = projectID
+
+	j.SetID(fmt.Sprintf("%s.%s.%s", commitQueueJobName, projectID, id))
+	j.SetPriority(-1)
+	return j
+}
+
+func (j *commitQueueJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving service flags"))
+		return
+	}
+	if flags.CommitQueueDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     j.ID(),
+			"project": j.ProjectID,
+			"message": "commit queue is disabled",
+			"mode":    "degraded",
+		})
+		return
+	}
+
+	projectRef, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "error finding project '%s'", j.ProjectID))
+		return
+	}
+	if projectRef == nil {
+		j.AddError(errors.Errorf("project '%s' not found", j.ProjectID))
+		return
+	}
+	if !projectRef.Enabled || !projectRef.CommitQueue.Enabled {
+		return
+	}
+
+	// the jobs for different minutes can overlap, and must not both merge
+	// or start patches for the same pull requests
+	locked, err := commitqueue.Lock(j.ProjectID, j.ID(), commitQueueLockTimeout)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if !locked {
+		grip.Debug(message.Fields{
+			"job":     j.ID(),
+			"project": j.ProjectID,
+			"message": "commit queue is being processed by another job",
+		})
+		return
+	}
+	defer func() {
+		j.AddError(commitqueue.Unlock(j.ProjectID, j.ID()))
+	}()
+
+	cq, err := commitqueue.FindOneId(j.ProjectID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if cq == nil || len(cq.Queue) == 0 {
+		return
+	}
+
+	githubOauthToken, err := j.env.Settings().GetGithubOauthToken()
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	if err = j.processHead(ctx, cq, projectRef, githubOauthToken); err != nil {
+		j.AddError(err)
+		return
+	}
+	j.AddError(j.startPatches(ctx, cq, projectRef, githubOauthToken))
+}
+
+// processHead merges or evicts the pull request at the head of the queue
+// once its patch has finished.
+func (j *commitQueueJob) processHead(ctx context.Context, cq *commitqueue.CommitQueue, projectRef *model.ProjectRef, githubOauthToken string) error {
+	head, ok := cq.Next()
+	if !ok || head.Version == "" {
+		return nil
+	}
+	if !bson.IsObjectIdHex(head.Version) {
+		return j.evict(cq, projectRef, head, fmt.Sprintf("invalid patch id '%s'", head.Version))
+	}
+
+	p, err := patch.FindOne(patch.ById(bson.ObjectIdHex(head.Version)))
+	if err != nil {
+		return errors.Wrapf(err, "error finding patch '%s'", head.Version)
+	}
+	if p == nil {
+		// the patch was removed, so test the pull request again
+		return errors.WithStack(cq.SetVersion(head.PRNumber, "", head.HeadHash, head.GithubAuthor))
+	}
+
+	switch p.Status {
+	case evergreen.PatchSucceeded:
+		err = thirdparty.MergePullRequest(ctx, githubOauthToken, projectRef.Owner, projectRef.Repo,
+			head.PRNumber, head.HeadHash, projectRef.CommitQueue.MergeMethod, "")
+		if err != nil {
+			return j.evict(cq, projectRef, head, fmt.Sprintf("merge failed: %s", err.Error()))
+		}
+		if _, err = cq.Remove(head.PRNumber); err != nil {
+			return errors.WithStack(err)
+		}
+
+		event.LogCommitQueueStateChangeEvent(projectRef.Identifier, event.CommitQueueEventData{
+			Status:   event.CommitQueueMerged,
+			PRNumber: head.PRNumber,
+			Owner:    projectRef.Owner,
+			Repo:     projectRef.Repo,
+			Author:   head.Author,
+			PatchID:  head.Version,
+		})
+		grip.Info(message.Fields{
+			"job_type":  j.Type().Name,
+			"job":       j.ID(),
+			"project":   projectRef.Identifier,
+			"pr_number": head.PRNumber,
+			"head_hash": head.HeadHash,
+			"patch_id":  head.Version,
+			"message":   "merged pull request",
+		})
+
+	case evergreen.PatchFailed:
+		return j.evict(cq, projectRef, head, fmt.Sprintf("patch '%s' failed", head.Version))
+	}
+
+	return nil
+}
+
+// startPatches creates patches for the pull requests at the front of the
+// queue that are not being tested yet.
+func (j *commitQueueJob) startPatches(ctx context.Context, cq *commitqueue.CommitQueue, projectRef *model.ProjectRef, githubOauthToken string) error {
+	catcher := grip.NewBasicCatcher()
+	for i := 0; i < len(cq.Queue) && i < commitQueueMaxInFlight; i++ {
+		item := cq.Queue[i]
+		if item.Version != "" {
+			continue
+		}
+
+		pr, err := thirdparty.GetGithubPullRequest(ctx, githubOauthToken, projectRef.Owner, projectRef.Repo, item.PRNumber)
+		if err != nil {
+			catcher.Add(err)
+			break
+		}
+		if reason := unmergeableReason(pr, projectRef.Branch); reason != "" {
+			// the pull requests behind move up one position
+			catcher.Add(j.evict(cq, projectRef, item, reason))
+			i--
+			continue
+		}
+
+		prNumbers := make([]int, 0, i+1)
+		for _, ahead := range cq.Queue[:i+1] {
+			prNumbers = append(prNumbers, ahead.PRNumber)
+		}
+		patchID := bson.NewObjectId()
+		intent, err := patch.NewCommitQueueIntent(patchID, projectRef.Identifier, projectRef.Owner,
+			projectRef.Repo, projectRef.Branch, item.Author, prNumbers)
+		if err != nil {
+			catcher.Add(j.evict(cq, projectRef, item, err.Error()))
+			i--
+			continue
+		}
+		if err = intent.Insert(); err != nil {
+			catcher.Add(errors.Wrap(err, "error inserting commit queue patch intent"))
+			break
+		}
+		if err = cq.SetVersion(item.PRNumber, patchID.Hex(), *pr.Head.SHA, pr.User.GetLogin()); err != nil {
+			catcher.Add(err)
+			break
+		}
+
+		processor := NewPatchIntentProcessor(patchID, intent)
+		processor.Run(ctx)
+		if err = processor.Error(); err != nil {
+			catcher.Add(j.evict(cq, projectRef, cq.Queue[i], fmt.Sprintf("could not create patch: %s", err.Error())))
+			i--
+		}
+	}
+
+	return catcher.Resolve()
+}
+
+// evict removes a pull request from the queue and notifies its author. The
+// patches of the pull requests behind it are aborted, since they included
+// its changes.
+func (j *commitQueueJob) evict(cq *commitqueue.CommitQueue, projectRef *model.ProjectRef, item commitqueue.CommitQueueItem, reason string) error {
+	idx := cq.FindItem(item.PRNumber)
+	if idx < 0 {
+		return nil
+	}
+
+	catcher := grip.NewBasicCatcher()
+	if _, err := cq.Remove(item.PRNumber); err != nil {
+		return errors.WithStack(err)
+	}
+
+	cleared, err := cq.ClearVersions(idx)
+	catcher.Add(err)
+	for _, patchID := range cleared {
+		if !bson.IsObjectIdHex(patchID) {
+			continue
+		}
+		p, err := patch.FindOne(patch.ById(bson.ObjectIdHex(patchID)))
+		if err != nil {
+			catcher.Add(err)
+			continue
+		}
+		if p != nil {
+			catcher.Add(model.CancelPatch(p, commitQueueJobName))
+		}
+	}
+
+	event.LogCommitQueueStateChangeEvent(projectRef.Identifier, event.CommitQueueEventData{
+		Status:   event.CommitQueueEvicted,
+		PRNumber: item.PRNumber,
+		Owner:    projectRef.Owner,
+		Repo:     projectRef.Repo,
+		Author:   item.Author,
+		PatchID:  item.Version,
+		Message:  reason,
+	})
+	grip.Info(message.Fields{
+		"job_type":        j.Type().Name,
+		"job":             j.ID(),
+		"project":         projectRef.Identifier,
+		"pr_number":       item.PRNumber,
+		"patch_id":        item.Version,
+		"reason":          reason,
+		"patches_aborted": cleared,
+		"message":         "evicted pull request",
+	})
+
+	return catcher.Resolve()
+}
+
+func unmergeableReason(pr *github.PullRequest, branch string) string {
+	if pr.GetState() != "open" {
+		return "pull request is not open"
+	}
+	if pr.Base == nil || pr.Base.GetRef() != branch {
+		return fmt.Sprintf("pull request is not against branch '%s'", branch)
+	}
+	if pr.Head == nil || pr.Head.SHA == nil || *pr.Head.SHA == "" {
+		return "pull request has no head commit"
+	}
+	if pr.Mergeable != nil && !*pr.Mergeable {
+		return "pull request has merge conflicts"
+	}
+	return ""
+}
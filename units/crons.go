@@ -6,8 +6,10 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/notification"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/amboy"
@@ -212,6 +214,68 @@ func PopulateEventAlertProcessing(parts int) amboy.QueueOperation {
 	}
 }
 
+func PopulateEventDigestJobs(parts int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.EventProcessingDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "alerts disabled",
+				"impact":  "not sending notification digests",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		subscriptionIDs, err := notification.FindPendingDigestSubscriptions()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfHour(parts).Format(tsFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, id := range subscriptionIDs {
+			catcher.Add(queue.Put(NewEventDigestJob(id, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+func PopulateWebhookRetryJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.WebhookNotificationsDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "webhook notifications disabled",
+				"impact":  "not retrying failed webhooks",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		notifications, err := notification.FindRetryableNotifications(time.Now())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		catcher := grip.NewBasicCatcher()
+		for i := range notifications {
+			catcher.Add(queue.Put(newEventNotificationRetryJob(&notifications[i])))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
 func PopulateTaskMonitoring() amboy.QueueOperation {
 	return func(queue amboy.Queue) error {
 		flags, err := evergreen.GetServiceFlags()
@@ -683,3 +747,130 @@ func PopulatePeriodicNotificationJobs(parts int) amboy.QueueOperation {
 		return catcher.Resolve()
 	}
 }
+
+func PopulateFlakyTestDetectionJobs(part int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if flags.FlakyTestDetectionDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "flaky test detection is disabled",
+				"impact":  "flaky test scores are not updated",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		projects, err := model.FindAllTrackedProjectRefs()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfHour(part).Format(tsFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, proj := range projects {
+			if !proj.Enabled {
+				continue
+			}
+
+			catcher.Add(queue.Put(NewFlakyTestDetectionJob(proj.Identifier, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+func PopulateCommitQueueJobs(part int) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if flags.CommitQueueDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "commit queue is disabled",
+				"impact":  "pull requests are not merged",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		queues, err := commitqueue.FindAll()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfMinute(part).Format(tsFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, cq := range queues {
+			catcher.Add(queue.Put(NewCommitQueueJob(cq.ProjectID, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulatePeriodicBuildJobs enqueues a job for every periodic build
+// definition that is scheduled for the current minute.
+func PopulatePeriodicBuildJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if flags.RepotrackerDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "repotracker is disabled",
+				"impact":  "periodic builds disabled",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		projects, err := model.FindPeriodicProjectRefs()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		now := time.Now().Truncate(time.Minute)
+		catcher := grip.NewBasicCatcher()
+		for _, proj := range projects {
+			for _, def := range proj.PeriodicBuilds {
+				schedule, err := util.ParseCron(def.Cron)
+				if err != nil {
+					catcher.Add(errors.Wrapf(err, "invalid periodic build '%s' for project '%s'", def.ID, proj.Identifier))
+					continue
+				}
+
+				for _, scheduled := range scheduledPeriodicBuilds(schedule, now) {
+					j := NewPeriodicBuildJob(proj.Identifier, def.ID, scheduled)
+					if _, ok := queue.Get(j.ID()); ok {
+						continue
+					}
+					catcher.Add(queue.Put(j))
+				}
+			}
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// periodicBuildLookback is how far back the periodic build cron looks for
+// scheduled builds, so that a late or skipped tick doesn't lose them. Jobs
+// for the same scheduled time have the same ID, so each build runs once.
+const periodicBuildLookback = 10 * time.Minute
+
+// scheduledPeriodicBuilds returns the times the schedule fired within the
+// lookback window that ends at now.
+func scheduledPeriodicBuilds(schedule util.CronSchedule, now time.Time) []time.Time {
+	times := []time.Time{}
+	for t := schedule.Next(now.Add(-periodicBuildLookback)); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		times = append(times, t)
+	}
+	return times
+}
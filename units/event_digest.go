@@ -0,0 +1,179 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/trigger"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventDigestJobName = "event-digest"
+)
+
+func init() {
+	registry.AddJobType(eventDigestJobName, func() amboy.Job { return makeEventDigestJob() })
+}
+
+type eventDigestJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env      evergreen.Environment
+	q        amboy.Queue
+	flags    *evergreen.ServiceFlags
+	render   gimlet.Renderer
+
+	SubscriptionID string `bson:"subscription_id" json:"subscription_id" yaml:"subscription_id"`
+}
+
+func makeEventDigestJob() *eventDigestJob {
+	j := &eventDigestJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    eventDigestJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+// NewEventDigestJob sends one notification that summarises the notifications
+// waiting in the digest of a subscription, once the digest is due.
+func NewEventDigestJob(subscriptionID, ts string) amboy.Job {
+	j := makeEventDigestJob()
+	j.SubscriptionID = subscriptionID
+
+	j.SetID(fmt.Sprintf("%s:%s:%s", eventDigestJobName, subscriptionID, ts))
+	return j
+}
+
+func (j *eventDigestJob) setup() error {
+	if len(j.SubscriptionID) == 0 {
+		return errors.New("subscription ID is not valid")
+	}
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	if j.q == nil {
+		j.q = j.env.RemoteQueue()
+	}
+	if j.q == nil || !j.q.Started() {
+		return errors.New("evergreen environment not setup correctly")
+	}
+	if j.flags == nil {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.Wrap(err, "error retrieving service flags")
+		}
+		j.flags = flags
+	}
+	if j.render == nil {
+		cacheTemplates := false
+		if settings := j.env.Settings(); settings != nil {
+			cacheTemplates = settings.Ui.CacheTemplates
+		}
+		j.render = gimlet.NewHTMLRenderer(gimlet.RendererOptions{
+			Directory:    filepath.Join(evergreen.FindEvergreenHome(), "alerts", "templates"),
+			DisableCache: !cacheTemplates,
+		})
+	}
+
+	return nil
+}
+
+func (j *eventDigestJob) Run(_ context.Context) {
+	defer j.MarkComplete()
+
+	if err := j.setup(); err != nil {
+		j.AddError(err)
+		return
+	}
+
+	pending, err := notification.FindPendingDigest(j.SubscriptionID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	sub, err := event.FindSubscriptionByID(j.SubscriptionID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	// if the subscription was removed, or no longer has a digest, the
+	// pending notifications are sent right away
+	subscriber := pending[0].Subscriber
+	if sub != nil {
+		subscriber = sub.Subscriber
+		if sub.Digest != nil && !sub.Digest.IsDue(len(pending), pending[0].Digest.CreatedAt, time.Now()) {
+			return
+		}
+	}
+
+	items := make([]notification.DigestItem, 0, len(pending))
+	ids := make([]string, 0, len(pending))
+	for _, n := range pending {
+		items = append(items, *n.Digest)
+		ids = append(ids, n.ID)
+	}
+
+	payload, err := trigger.MakeDigestPayload(&subscriber, items, j.render)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "can't make digest for subscription '%s'", j.SubscriptionID))
+		return
+	}
+
+	// the ID of the newest notification in the digest makes the digest
+	// notification unique, so a retried job can't send the digest twice. If
+	// the digest already exists, an earlier job saved it but failed before
+	// marking its notifications, so this job finishes sending it.
+	digest := notification.Notification{
+		ID:         fmt.Sprintf("digest-%s", pending[len(pending)-1].ID),
+		Subscriber: subscriber,
+		Payload:    payload,
+	}
+	if err = notification.InsertMany(digest); err != nil && !db.IsDuplicateKey(err) {
+		j.AddError(errors.Wrapf(err, "can't save digest for subscription '%s'", j.SubscriptionID))
+		return
+	}
+	if err = notification.MarkDigested(digest.ID, ids); err != nil {
+		j.AddError(err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"job_id":          j.ID(),
+		"job":             eventDigestJobName,
+		"source":          "events-processing",
+		"message":         "sending digest",
+		"subscription_id": j.SubscriptionID,
+		"notification_id": digest.ID,
+		"notifications":   len(pending),
+	})
+
+	if notificationIsEnabled(j.flags, &digest) {
+		j.AddError(j.q.Put(newEventNotificationJob(digest.ID)))
+	} else {
+		j.AddError(digest.MarkError(errors.New("sender disabled")))
+	}
+}
@@ -0,0 +1,164 @@
+package units
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type eventDigestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	cancel func()
+
+	env *mock.Environment
+	sub event.Subscription
+}
+
+func TestEventDigestJob(t *testing.T) {
+	suite.Run(t, &eventDigestSuite{})
+}
+
+func (s *eventDigestSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+}
+
+func (s *eventDigestSuite) TearDownSuite() {
+	s.cancel()
+}
+
+func (s *eventDigestSuite) SetupTest() {
+	s.env = &mock.Environment{}
+	s.Require().NoError(s.env.Configure(s.ctx, filepath.Join(evergreen.FindEvergreenHome(), testutil.TestDir, testutil.TestSettings), nil))
+	s.Require().NoError(s.env.Remote.Start(s.ctx))
+	s.NoError(db.ClearCollections(notification.Collection, event.SubscriptionsCollection, evergreen.ConfigCollection))
+
+	channel := "#evergreen"
+	s.sub = event.Subscription{
+		ID:      "sub",
+		Type:    event.ResourceTypeTask,
+		Trigger: "build-break",
+		Selectors: []event.Selector{
+			{Type: "project", Data: "mci"},
+		},
+		Subscriber: event.Subscriber{
+			Type:   event.SlackSubscriberType,
+			Target: &channel,
+		},
+		OwnerType: event.OwnerTypeProject,
+		Owner:     "mci",
+		Digest: &event.DigestOptions{
+			Frequency: event.DigestCount,
+			Count:     2,
+		},
+	}
+	s.Require().NoError(s.sub.Upsert())
+}
+
+func (s *eventDigestSuite) insertPending(id, variant string) {
+	s.Require().NoError(notification.InsertMany(notification.Notification{
+		ID:         id,
+		Subscriber: s.sub.Subscriber,
+		Payload:    &notification.SlackPayload{Body: id},
+		Digest: &notification.DigestItem{
+			SubscriptionID: s.sub.ID,
+			Object:         "task",
+			ID:             id,
+			DisplayName:    id,
+			Project:        "mci",
+			Version:        "v1",
+			BuildVariant:   variant,
+			Status:         evergreen.TaskFailed,
+			URL:            "https://evergreen.example.com/task/" + id,
+			CreatedAt:      time.Now().Truncate(time.Millisecond),
+		},
+	}))
+}
+
+func (s *eventDigestSuite) runJob() {
+	j := NewEventDigestJob(s.sub.ID, "ts").(*eventDigestJob)
+	j.env = s.env
+	j.q = s.env.Remote
+	j.flags = &evergreen.ServiceFlags{}
+	j.Run(s.ctx)
+	s.NoError(j.Error())
+}
+
+func (s *eventDigestSuite) TestDigestWaitsUntilDue() {
+	s.insertPending("n1", "ubuntu")
+	s.runJob()
+
+	ids, err := notification.FindPendingDigestSubscriptions()
+	s.NoError(err)
+	s.Equal([]string{s.sub.ID}, ids)
+	digest, err := notification.Find("digest-n1")
+	s.NoError(err)
+	s.Nil(digest)
+}
+
+func (s *eventDigestSuite) TestDigestSendsOneNotification() {
+	s.insertPending("n1", "ubuntu")
+	s.insertPending("n2", "windows")
+	s.runJob()
+
+	pending, err := notification.FindPendingDigest(s.sub.ID)
+	s.NoError(err)
+	s.Empty(pending)
+
+	digest, err := notification.Find("digest-n2")
+	s.NoError(err)
+	s.Require().NotNil(digest)
+	s.Nil(digest.Digest)
+	payload, ok := digest.Payload.(*notification.SlackPayload)
+	s.Require().True(ok)
+	s.Contains(payload.Body, "2 notifications")
+	s.Len(payload.Attachments, 2)
+
+	n1, err := notification.Find("n1")
+	s.NoError(err)
+	s.Require().NotNil(n1)
+	s.False(n1.SentAt.IsZero())
+	s.Equal(digest.ID, n1.Digest.SentIn)
+}
+
+func (s *eventDigestSuite) TestDigestIsSentWhenSavedByAnEarlierJob() {
+	s.insertPending("n1", "ubuntu")
+	s.insertPending("n2", "windows")
+	// an earlier job saved the digest, but failed to mark the
+	// notifications in it
+	s.Require().NoError(notification.InsertMany(notification.Notification{
+		ID:         "digest-n2",
+		Subscriber: s.sub.Subscriber,
+		Payload:    &notification.SlackPayload{Body: "2 notifications"},
+	}))
+	s.runJob()
+
+	pending, err := notification.FindPendingDigest(s.sub.ID)
+	s.NoError(err)
+	s.Empty(pending)
+	n2, err := notification.Find("n2")
+	s.NoError(err)
+	s.Require().NotNil(n2)
+	s.Equal("digest-n2", n2.Digest.SentIn)
+}
+
+func (s *eventDigestSuite) TestDigestIsSentWhenSubscriptionIsRemoved() {
+	s.insertPending("n1", "ubuntu")
+	s.NoError(event.RemoveSubscription(s.sub.ID))
+	s.runJob()
+
+	digest, err := notification.Find("digest-n1")
+	s.NoError(err)
+	s.NotNil(digest)
+}
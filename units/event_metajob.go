@@ -44,6 +44,12 @@ func notificationIsEnabled(flags *evergreen.ServiceFlags, n *notification.Notifi
 	case event.SlackSubscriberType:
 		return !flags.SlackNotificationsDisabled
 
+	case event.TeamsSubscriberType:
+		return !flags.TeamsNotificationsDisabled
+
+	case event.MattermostSubscriberType:
+		return !flags.MattermostNotificationsDisabled
+
 	default:
 		grip.Alert(message.Fields{
 			"message": "notificationIsEnabled saw unknown subscriber type",
@@ -85,7 +91,7 @@ func NewEventMetaJob(q amboy.Queue, ts string) amboy.Job {
 	return j
 }
 
-func tryProcessOneEvent(e *event.EventLogEntry) (n []notification.Notification, err error) {
+func tryProcessOneEvent(e *event.EventLogEntry, q amboy.Queue) (n []notification.Notification, err error) {
 	if e == nil {
 		return nil, errors.New("nil event")
 	}
@@ -121,6 +127,27 @@ func tryProcessOneEvent(e *event.EventLogEntry) (n []notification.Notification,
 		"event_type": e.ResourceType,
 	}))
 
+	// downstream versions are created by their own jobs, so they can be
+	// retried without holding up notifications. Failing to queue them
+	// doesn't fail the event, since it would otherwise never be marked
+	// processed.
+	fired, triggerErr := trigger.EvalProjectTriggers(e, enqueueProjectTriggerJobs(q))
+	grip.InfoWhen(fired > 0, message.Fields{
+		"job":        eventMetaJobName,
+		"source":     "events-processing",
+		"message":    "queued downstream versions",
+		"event_id":   e.ID,
+		"event_type": e.ResourceType,
+		"triggers":   fired,
+	})
+	grip.Error(message.WrapError(triggerErr, message.Fields{
+		"job":        eventMetaJobName,
+		"source":     "events-processing",
+		"message":    "errors processing project triggers for event",
+		"event_id":   e.ID,
+		"event_type": e.ResourceType,
+	}))
+
 	return n, err
 }
 
@@ -134,7 +161,7 @@ func (j *eventMetaJob) dispatchLoop(ctx context.Context) error {
 	notifications := []notification.Notification{}
 
 	for i := range j.events {
-		msgs, err := tryProcessOneEvent(&j.events[i])
+		msgs, err := tryProcessOneEvent(&j.events[i], j.q)
 		catcher.Add(err)
 		if err != nil {
 			continue
@@ -168,6 +195,11 @@ func (j *eventMetaJob) dispatchLoop(ctx context.Context) error {
 func (j *eventMetaJob) dispatch(notifications []notification.Notification) error {
 	catcher := grip.NewSimpleCatcher()
 	for i := range notifications {
+		// notifications for subscriptions with a digest are sent later, in
+		// the event-digest job of their subscription
+		if notifications[i].Digest != nil {
+			continue
+		}
 		if notificationIsEnabled(j.flags, &notifications[i]) {
 			catcher.Add(j.q.Put(newEventNotificationJob(notifications[i].ID)))
 		} else {
@@ -3,10 +3,12 @@ package units
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/dependency"
 	"github.com/mongodb/amboy/job"
@@ -57,6 +59,17 @@ func newEventNotificationJob(id string) amboy.Job {
 	return j
 }
 
+// newEventNotificationRetryJob sends a failed webhook notification again.
+// The ID is unique to the scheduled retry, so the retry is only sent once,
+// however many times it's queued.
+func newEventNotificationRetryJob(n *notification.Notification) amboy.Job {
+	j := makeEventNotificationJob()
+	j.NotificationID = n.ID
+
+	j.SetID(fmt.Sprintf("%s:%s:retry-%d:%d", eventNotificationJobName, n.ID, n.Attempts, n.NextAttempt.UnixNano()))
+	return j
+}
+
 func (j *eventNotificationJob) setup() error {
 	if len(j.NotificationID) == 0 {
 		return errors.New("notification ID is not valid")
@@ -99,6 +112,12 @@ func (j *eventNotificationJob) Run(_ context.Context) {
 		j.AddError(n.MarkError(err))
 		return
 	}
+	if !n.NextAttempt.IsZero() {
+		if err = n.ClearRetry(); err != nil {
+			j.AddError(err)
+			return
+		}
+	}
 
 	err = j.send(n)
 	grip.Error(message.WrapError(err, message.Fields{
@@ -140,7 +159,11 @@ func (j *eventNotificationJob) send(n *notification.Notification) error {
 		return errors.Wrap(err, "error setting level in sender")
 	}
 
-	err = sender.SetErrorHandler(getSendErrorHandler(n))
+	webhookConf := evergreen.WebhookConfig{}
+	if settings := j.env.Settings(); settings != nil {
+		webhookConf = settings.Notify.Webhook
+	}
+	err = sender.SetErrorHandler(getSendErrorHandler(n, webhookConf))
 	grip.Error(message.WrapError(err, message.Fields{
 		"message":           "failed to set error handler",
 		"notification_id":   n.ID,
@@ -159,6 +182,12 @@ func (j *eventNotificationJob) checkDegradedMode(n *notification.Notification) e
 	case event.SlackSubscriberType:
 		return checkFlag(j.flags.SlackNotificationsDisabled)
 
+	case event.TeamsSubscriberType:
+		return checkFlag(j.flags.TeamsNotificationsDisabled)
+
+	case event.MattermostSubscriberType:
+		return checkFlag(j.flags.MattermostNotificationsDisabled)
+
 	case event.JIRAIssueSubscriberType:
 		return checkFlag(j.flags.JIRANotificationsDisabled)
 
@@ -188,7 +217,7 @@ func checkFlag(flag bool) error {
 	return nil
 }
 
-func getSendErrorHandler(n *notification.Notification) send.ErrorHandler {
+func getSendErrorHandler(n *notification.Notification, webhookConf evergreen.WebhookConfig) send.ErrorHandler {
 	return func(err error, c message.Composer) {
 		if err == nil || c == nil {
 			return
@@ -201,7 +230,11 @@ func getSendErrorHandler(n *notification.Notification) send.ErrorHandler {
 			"composer":          c.String(),
 		}))
 
-		err = n.MarkError(err)
+		if n.Subscriber.Type == event.EvergreenWebhookSubscriberType {
+			err = handleWebhookFailure(n, err, webhookConf, time.Now())
+		} else {
+			err = n.MarkError(err)
+		}
 		grip.Error(message.WrapError(err, message.Fields{
 			"job":               eventNotificationJobName,
 			"notification_id":   n.ID,
@@ -212,3 +245,28 @@ func getSendErrorHandler(n *notification.Notification) send.ErrorHandler {
 		}))
 	}
 }
+
+// handleWebhookFailure schedules a failed webhook notification to be sent
+// again with exponential backoff, or moves it to the dead-letter queue once
+// it has run out of attempts. Failures because the circuit breaker of the
+// endpoint is open don't use up an attempt.
+func handleWebhookFailure(n *notification.Notification, sendErr error, conf evergreen.WebhookConfig, now time.Time) error {
+	conf = conf.Defaulted()
+	if circuitErr, ok := sendErr.(*util.WebhookCircuitOpenError); ok {
+		return n.MarkRetry(sendErr, n.Attempts, circuitErr.Until)
+	}
+
+	attempts := n.Attempts + 1
+	if attempts >= conf.MaxAttempts {
+		grip.Warning(message.WrapError(sendErr, message.Fields{
+			"job":             eventNotificationJobName,
+			"source":          "events-processing",
+			"message":         "webhook notification ran out of attempts, moving to dead-letter queue",
+			"notification_id": n.ID,
+			"attempts":        attempts,
+		}))
+		return n.MoveToDeadLetter(sendErr, attempts)
+	}
+
+	return n.MarkRetry(sendErr, attempts, now.Add(conf.RetryDelay(attempts)))
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -49,7 +50,7 @@ func (s *eventNotificationSuite) SetupTest() {
 	s.env = &mock.Environment{}
 	s.NoError(s.env.Configure(s.ctx, filepath.Join(evergreen.FindEvergreenHome(), testutil.TestDir, testutil.TestSettings), nil))
 
-	s.NoError(db.ClearCollections(notification.Collection, evergreen.ConfigCollection))
+	s.NoError(db.ClearCollections(notification.Collection, notification.DeadLetterCollection, evergreen.ConfigCollection))
 
 	s.notifications = []notification.Notification{
 		{
@@ -269,3 +270,53 @@ func (s *eventNotificationSuite) TestSendFailureResultsInNoMessages() {
 		s.NotZero(s.notificationHasError(s.webhook.ID, "^composer is not loggable$"))
 	}
 }
+
+func (s *eventNotificationSuite) TestWebhookFailureRetriesWithBackoff() {
+	conf := evergreen.WebhookConfig{
+		MaxAttempts:          3,
+		MinRetryDelaySeconds: 10,
+		MaxRetryDelaySeconds: 100,
+	}
+	now := time.Now().Truncate(time.Millisecond)
+	sendErr := errors.New("evergreen-webhook response status was 500 Internal Server Error")
+
+	s.NoError(handleWebhookFailure(s.webhook, sendErr, conf, now))
+	s.Equal(1, s.webhook.Attempts)
+	s.Equal(now.Add(10*time.Second), s.webhook.NextAttempt)
+
+	// an open circuit doesn't use up an attempt
+	until := now.Add(time.Hour)
+	s.NoError(handleWebhookFailure(s.webhook, &util.WebhookCircuitOpenError{Endpoint: "http://127.0.0.1:12345", Until: until}, conf, now))
+	s.Equal(1, s.webhook.Attempts)
+	s.Equal(until, s.webhook.NextAttempt)
+
+	s.NoError(handleWebhookFailure(s.webhook, sendErr, conf, now))
+	s.Equal(2, s.webhook.Attempts)
+	s.Equal(now.Add(20*time.Second), s.webhook.NextAttempt)
+
+	retryable, err := notification.FindRetryableNotifications(now.Add(time.Minute))
+	s.NoError(err)
+	s.Require().Len(retryable, 1)
+	s.Equal(s.webhook.ID, retryable[0].ID)
+	job := newEventNotificationRetryJob(&retryable[0])
+	s.NotEqual(newEventNotificationJob(s.webhook.ID).ID(), job.ID())
+	s.Equal(job.ID(), newEventNotificationRetryJob(&retryable[0]).ID())
+
+	// sending the retry clears the schedule
+	job.(*eventNotificationJob).env = s.env
+	job.Run(s.ctx)
+	s.NoError(job.Error())
+	s.notificationHasError(s.webhook.ID, "")
+	retryable, err = notification.FindRetryableNotifications(now.Add(time.Minute))
+	s.NoError(err)
+	s.Empty(retryable)
+
+	// the last attempt moves the notification to the dead-letter queue
+	s.NoError(handleWebhookFailure(s.webhook, sendErr, conf, now))
+	letter, err := notification.FindDeadLetter(s.webhook.ID)
+	s.NoError(err)
+	s.Require().NotNil(letter)
+	s.Equal(3, letter.Attempts)
+	s.Equal(sendErr.Error(), letter.Error)
+	s.notificationHasError(s.webhook.ID, "500 Internal Server Error")
+}
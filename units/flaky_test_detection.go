@@ -0,0 +1,78 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+const flakyTestDetectionJobName = "flaky-test-detection"
+
+func init() {
+	registry.AddJobType(flakyTestDetectionJobName, func() amboy.Job {
+		return makeFlakyTestDetectionJob()
+	})
+}
+
+type flakyTestDetectionJob struct {
+	Project  string `bson:"project" json:"project" yaml:"project"`
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+}
+
+func makeFlakyTestDetectionJob() *flakyTestDetectionJob {
+	j := &flakyTestDetectionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    flakyTestDetectionJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewFlakyTestDetectionJob creates a job that recomputes the flaky tests of a
+// project from the test results of its recent mainline tasks.
+func NewFlakyTestDetectionJob(project string, id string) amboy.Job {
+	j := makeFlakyTestDetectionJob()
+	j.Project = project
+
+	j.SetID(fmt.Sprintf("%s.%s.%s", flakyTestDetectionJobName, project, id))
+	j.SetPriority(-1)
+	return j
+}
+
+func (j *flakyTestDetectionJob) Run(_ context.Context) {
+	defer j.MarkComplete()
+
+	startAt := time.Now()
+	since := startAt.Add(-flakytest.DetectionWindow)
+	tests, err := flakytest.Detect(j.Project, since)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	if err = flakytest.ReplaceForProject(j.Project, tests); err != nil {
+		j.AddError(err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"job_type":    j.Type().Name,
+		"job":         j.ID(),
+		"project":     j.Project,
+		"flaky_tests": len(tests),
+		"window":      since,
+		"duration":    time.Since(startAt).String(),
+	})
+}
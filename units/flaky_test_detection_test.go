@@ -0,0 +1,76 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/mongodb/amboy/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlakyTestDetectionJobFactory(t *testing.T) {
+	assert := assert.New(t)
+
+	factory, err := registry.GetJobFactory(flakyTestDetectionJobName)
+	assert.NoError(err)
+	assert.NotNil(factory)
+
+	j, ok := factory().(*flakyTestDetectionJob)
+	assert.True(ok)
+	assert.NotNil(j)
+
+	jOne := NewFlakyTestDetectionJob("foo", "id")
+	jTwo := NewFlakyTestDetectionJob("foo", "id")
+	jThree := NewFlakyTestDetectionJob("bar", "id")
+	assert.Equal(jOne.ID(), jTwo.ID())
+	assert.NotEqual(jThree.ID(), jOne.ID())
+}
+
+func TestFlakyTestDetectionJob(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	require.NoError(db.ClearCollections(flakytest.Collection, task.Collection, testresult.Collection))
+	defer func() {
+		assert.NoError(db.ClearCollections(flakytest.Collection, task.Collection, testresult.Collection))
+	}()
+
+	stale := flakytest.FlakyTest{ID: "stale", Project: "proj", Score: 1}
+	require.NoError(flakytest.ReplaceForProject("proj", []flakytest.FlakyTest{stale}))
+
+	t1 := task.Task{
+		Id:                  "t1",
+		Project:             "proj",
+		BuildVariant:        "bv",
+		DisplayName:         "unit",
+		Revision:            "r1",
+		RevisionOrderNumber: 1,
+		Execution:           1,
+		Status:              evergreen.TaskSucceeded,
+		FinishTime:          time.Now().Add(-time.Minute),
+		Requester:           evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(t1.Insert())
+	require.NoError(testresult.InsertMany([]testresult.TestResult{
+		{TaskID: "t1", Execution: 0, TestFile: "flaky", Status: evergreen.TestFailedStatus},
+		{TaskID: "t1", Execution: 1, TestFile: "flaky", Status: evergreen.TestSucceededStatus},
+	}))
+
+	j := NewFlakyTestDetectionJob("proj", "id")
+	j.Run(context.Background())
+	assert.NoError(j.Error())
+	assert.True(j.Status().Completed)
+
+	tests, err := flakytest.Find(flakytest.ByProject("proj", "", 0))
+	require.NoError(err)
+	require.Len(tests, 1)
+	assert.Equal("flaky", tests[0].TestFile)
+	assert.Equal(1, tests[0].Restarts)
+	assert.InDelta(1.0, tests[0].Score, 0.0001)
+}
@@ -3,10 +3,13 @@ package units
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/dependency"
@@ -136,9 +139,80 @@ func (j *idleHostJob) Run(ctx context.Context) {
 
 	// if we haven't heard from the host or it's been idle for longer than the cutoff, we should terminate
 	if communicationTime >= idleTimeCutoff || idleTime >= idleTimeCutoff {
+		// only hosts that are still communicating count toward the
+		// distro's minimum hosts and warm standby
+		if communicationTime < idleTimeCutoff {
+			keep, err := j.isKeptForPoolTarget()
+			if err != nil {
+				j.AddError(errors.Wrapf(err, "problem checking pool target of distro %s", j.host.Distro.Id))
+				return
+			}
+			if keep {
+				grip.Info(message.Fields{
+					"op":      j.Type().Name,
+					"id":      j.ID(),
+					"message": "not terminating idle host, distro needs it for its minimum hosts or warm standby",
+					"host":    j.host.Id,
+					"distro":  j.host.Distro.Id,
+					"idle":    idleTime.String(),
+				})
+				return
+			}
+		}
+
 		j.Terminated = true
 		tjob := NewHostTerminationJob(j.env, *j.host)
 		tjob.Run(ctx)
 		j.AddError(tjob.Error())
 	}
 }
+
+// isKeptForPoolTarget returns true if the host is one of the hosts its distro
+// keeps running for its minimum hosts or warm standby. The oldest hosts that
+// are still communicating are kept, so that concurrent jobs for the distro's
+// other idle hosts agree on which hosts to keep.
+func (j *idleHostJob) isKeptForPoolTarget() (bool, error) {
+	d, err := distro.FindOne(distro.ById(j.host.Distro.Id))
+	if err != nil {
+		if !db.ResultsNotFound(err) {
+			return false, errors.WithStack(err)
+		}
+		d = j.host.Distro
+	}
+
+	target := d.HostPoolTarget(time.Now())
+	if target.MinHosts == 0 && target.WarmStandby == 0 {
+		return false, nil
+	}
+
+	hosts, err := host.Find(host.ByDistroId(d.Id))
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	sort.Slice(hosts, func(i, k int) bool {
+		if hosts[i].CreationTime.Equal(hosts[k].CreationTime) {
+			return hosts[i].Id < hosts[k].Id
+		}
+		return hosts[i].CreationTime.Before(hosts[k].CreationTime)
+	})
+
+	kept, idle := 0, 0
+	for _, h := range hosts {
+		if kept >= target.MinHosts && idle >= target.WarmStandby {
+			break
+		}
+		if h.Id != j.host.Id && h.GetElapsedCommunicationTime() >= idleTimeCutoff {
+			continue
+		}
+		isIdle := h.RunningTask == ""
+		if h.Id == j.host.Id {
+			return kept < target.MinHosts || (isIdle && idle < target.WarmStandby), nil
+		}
+		kept++
+		if isIdle {
+			idle++
+		}
+	}
+
+	return false, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
 	modelUtil "github.com/evergreen-ci/evergreen/model/testutil"
 	"github.com/evergreen-ci/evergreen/testutil"
@@ -57,7 +58,7 @@ func TestFlaggingIdleHosts(t *testing.T) {
 	Convey("When flagging idle hosts to be terminated", t, func() {
 
 		// reset the db
-		testutil.HandleTestingErr(db.ClearCollections(host.Collection),
+		testutil.HandleTestingErr(db.ClearCollections(host.Collection, distro.Collection),
 			t, "error clearing hosts collection")
 		testutil.HandleTestingErr(modelUtil.AddTestIndexes(host.Collection,
 			true, true, host.RunningTaskKey), t, "error adding host index")
@@ -173,5 +174,39 @@ func TestFlaggingIdleHosts(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(len(idle), ShouldEqual, 0)
 		})
+		Convey("hosts that have stopped communicating should be flagged even"+
+			" if their distro needs more hosts", func() {
+			now := time.Now()
+			d := distro.Distro{Id: "pool", Provider: evergreen.ProviderNameMock, MinHosts: 1}
+			h6 := host.Host{
+				Id:                    "h6",
+				Distro:                d,
+				Provider:              evergreen.ProviderNameMock,
+				CreationTime:          now.Add(-30 * time.Minute),
+				LastCommunicationTime: now.Add(-20 * time.Minute),
+				Status:                evergreen.HostRunning,
+				StartedBy:             evergreen.User,
+			}
+			So(h6.Insert(), ShouldBeNil)
+			h7 := host.Host{
+				Id:                    "h7",
+				Distro:                d,
+				Provider:              evergreen.ProviderNameMock,
+				CreationTime:          now.Add(-25 * time.Minute),
+				LastTask:              "t1",
+				LastTaskCompletedTime: now.Add(-20 * time.Minute),
+				LastCommunicationTime: now,
+				Status:                evergreen.HostRunning,
+				StartedBy:             evergreen.User,
+				Provisioned:           true,
+			}
+			So(h7.Insert(), ShouldBeNil)
+
+			// the idle host that still communicates is kept for the
+			// distro's minimum hosts instead of the unresponsive one
+			idle, err := flagIdleHosts(ctx, env)
+			So(err, ShouldBeNil)
+			So(idle, ShouldResemble, []string{"h6"})
+		})
 	})
 }
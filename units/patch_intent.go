@@ -153,10 +153,19 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 		canFinalize, err = j.buildGithubPatchDoc(ctx, patchDoc, githubOauthToken)
 		catcher.Add(err)
 
+	case patch.CommitQueueIntentType:
+		catcher.Add(j.buildCommitQueuePatchDoc(ctx, patchDoc, githubOauthToken))
+
 	default:
 		return errors.Errorf("Intent type '%s' is unknown", j.IntentType)
 	}
-	if len := len(patchDoc.Patches); len != 1 {
+	if j.IntentType == patch.CommitQueueIntentType {
+		// commit queue patches stack the diffs of the pull requests ahead
+		// in the queue
+		if len(patchDoc.Patches) == 0 {
+			catcher.Add(errors.New("patch document should have at least 1 patch, found 0"))
+		}
+	} else if len := len(patchDoc.Patches); len != 1 {
 		catcher.Add(errors.Errorf("patch document should have 1 patch, found %d", len))
 	}
 
@@ -232,7 +241,20 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 	}
 	patchDoc.PatchedConfig = string(projectYamlBytes)
 
-	project.BuildProjectTVPairs(patchDoc, j.intent.GetAlias())
+	alias := j.intent.GetAlias()
+	if j.IntentType == patch.CommitQueueIntentType {
+		// projects without a commit queue alias run their pull request
+		// tasks before merging
+		var aliases []model.ProjectAlias
+		aliases, err = model.FindAliasInProject(patchDoc.Project, alias)
+		if err != nil {
+			return errors.Wrap(err, "problem finding commit queue alias")
+		}
+		if len(aliases) == 0 {
+			alias = patch.GithubAlias
+		}
+	}
+	project.BuildProjectTVPairs(patchDoc, alias)
 
 	if j.intent.ShouldFinalizePatch() && len(patchDoc.Tasks) == 0 &&
 		len(patchDoc.BuildVariants) == 0 {
@@ -420,6 +442,67 @@ func (j *patchIntentProcessor) buildGithubPatchDoc(ctx context.Context, patchDoc
 	return isMember, nil
 }
 
+func (j *patchIntentProcessor) buildCommitQueuePatchDoc(ctx context.Context, patchDoc *patch.Patch, githubOauthToken string) error {
+	defer j.intent.SetProcessed()
+
+	projectRef, err := model.FindOneProjectRef(patchDoc.Project)
+	if err != nil {
+		return errors.Wrapf(err, "Could not find project ref '%s'", patchDoc.Project)
+	}
+	if projectRef == nil {
+		return errors.Errorf("Could not find project ref '%s'", patchDoc.Project)
+	}
+	if !projectRef.CommitQueue.Enabled {
+		return errors.Errorf("commit queue is disabled for project '%s'", projectRef.Identifier)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// test against the current tip of the branch
+	branch, err := thirdparty.GetBranchEvent(ctx, githubOauthToken, projectRef.Owner, projectRef.Repo, projectRef.Branch)
+	if err != nil {
+		return errors.Wrapf(err, "could not find the head of branch '%s' for project '%s'",
+			projectRef.Branch, projectRef.Identifier)
+	}
+	if branch.Commit == nil || branch.Commit.SHA == nil {
+		return errors.Errorf("branch '%s' for project '%s' has no head", projectRef.Branch, projectRef.Identifier)
+	}
+	patchDoc.Githash = *branch.Commit.SHA
+
+	prNumbers, err := patch.CommitQueuePRNumbers(j.intent)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, prNumber := range prNumbers {
+		// only the pull requests in the patch are needed to build the diff
+		prPatch := patch.GithubPatch{
+			PRNumber:  prNumber,
+			BaseOwner: projectRef.Owner,
+			BaseRepo:  projectRef.Repo,
+		}
+		patchContent, summaries, err := thirdparty.GetGithubPullRequestDiff(ctx, githubOauthToken, &prPatch)
+		if err != nil {
+			return errors.Wrapf(err, "could not fetch the diff of pull request #%d", prNumber)
+		}
+
+		patchFileID := fmt.Sprintf("%s_%d", j.PatchID.Hex(), prNumber)
+		if err = db.WriteGridFile(patch.GridFSPrefix, patchFileID, strings.NewReader(patchContent)); err != nil {
+			return errors.Wrap(err, "failed to write patch file to db")
+		}
+		patchDoc.Patches = append(patchDoc.Patches, patch.ModulePatch{
+			ModuleName: "",
+			Githash:    patchDoc.Githash,
+			PatchSet: patch.PatchSet{
+				PatchFileId: patchFileID,
+				Summary:     summaries,
+			},
+		})
+	}
+
+	return nil
+}
+
 func findEvergreenUserForPR(githubUID int) (*user.DBUser, error) {
 	// try and find a user by github uid
 	u, err := user.FindByGithubUID(githubUID)
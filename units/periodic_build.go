@@ -0,0 +1,93 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/repotracker"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	periodicBuildJobName = "periodic-build"
+)
+
+func init() {
+	registry.AddJobType(periodicBuildJobName, func() amboy.Job { return makePeriodicBuildJob() })
+}
+
+type periodicBuildJob struct {
+	ProjectID    string    `bson:"project_id" json:"project_id" yaml:"project_id"`
+	DefinitionID string    `bson:"definition_id" json:"definition_id" yaml:"definition_id"`
+	Scheduled    time.Time `bson:"scheduled" json:"scheduled" yaml:"scheduled"`
+	job.Base     `bson:"job_base" json:"job_base" yaml:"job_base"`
+}
+
+func makePeriodicBuildJob() *periodicBuildJob {
+	j := &periodicBuildJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    periodicBuildJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewPeriodicBuildJob creates a job to create the version of a project's
+// periodic build definition that was scheduled for the given time.
+func NewPeriodicBuildJob(projectID, definitionID string, scheduled time.Time) amboy.Job {
+	j := makePeriodicBuildJob()
+	j.ProjectID = projectID
+	j.DefinitionID = definitionID
+	j.Scheduled = scheduled
+	j.SetID(fmt.Sprintf("%s:%s:%s:%s", periodicBuildJobName, projectID, definitionID, scheduled.Format(tsFormat)))
+	return j
+}
+
+func (j *periodicBuildJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	ref, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if ref == nil {
+		j.AddError(errors.Errorf("can't find project ref for project '%s'", j.ProjectID))
+		return
+	}
+
+	var def *model.PeriodicBuildDefinition
+	for i := range ref.PeriodicBuilds {
+		if ref.PeriodicBuilds[i].ID == j.DefinitionID {
+			def = &ref.PeriodicBuilds[i]
+			break
+		}
+	}
+	if def == nil {
+		j.AddError(errors.Errorf("project '%s' has no periodic build '%s'", j.ProjectID, j.DefinitionID))
+		return
+	}
+
+	if _, err = repotracker.CreatePeriodicVersion(ref, *def, j.Scheduled); err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"job":            periodicBuildJobName,
+			"job_id":         j.ID(),
+			"project":        j.ProjectID,
+			"periodic_build": j.DefinitionID,
+			"scheduled":      j.Scheduled,
+		}))
+		j.AddError(err)
+	}
+}
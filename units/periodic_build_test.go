@@ -0,0 +1,122 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/repotracker"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriodicBuildJob(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	testConfig := testutil.TestConfig()
+	db.SetGlobalSessionProvider(testConfig.SessionFactory())
+	require.NoError(db.ClearCollections(model.ProjectRefCollection, model.RepositoriesCollection,
+		version.Collection, build.Collection, task.Collection))
+
+	scheduled := time.Date(2018, time.August, 15, 2, 0, 0, 0, time.UTC)
+	j := NewPeriodicBuildJob("proj", "nightly", scheduled).(*periodicBuildJob)
+	assert.Equal("periodic-build:proj:nightly:2018-08-15.02-00-00", j.ID())
+	j.Run(context.Background())
+	require.Error(j.Error())
+	assert.Contains(j.Error().Error(), "can't find project ref for project")
+
+	ref := &model.ProjectRef{
+		Identifier: "proj",
+		Enabled:    true,
+		PeriodicBuilds: []model.PeriodicBuildDefinition{
+			{ID: "nightly", Cron: "0 2 * * *", Variant: "linux", Task: "^test$", Message: "nightly tests"},
+		},
+	}
+	require.NoError(ref.Insert())
+
+	j = NewPeriodicBuildJob("proj", "hourly", scheduled).(*periodicBuildJob)
+	j.Run(context.Background())
+	require.Error(j.Error())
+	assert.Contains(j.Error().Error(), "has no periodic build 'hourly'")
+
+	base := &version.Version{
+		Id:                  "proj_abc",
+		Identifier:          "proj",
+		Revision:            "abc",
+		Requester:           evergreen.RepotrackerVersionRequester,
+		RevisionOrderNumber: 1,
+		Config: `
+buildvariants:
+- name: linux
+  run_on: [distro]
+  tasks:
+  - name: compile
+  - name: test
+  - name: lint
+tasks:
+- name: compile
+- name: test
+  depends_on:
+  - name: compile
+- name: lint
+`,
+	}
+	require.NoError(base.Insert())
+
+	j = NewPeriodicBuildJob("proj", "nightly", scheduled).(*periodicBuildJob)
+	j.Run(context.Background())
+	require.NoError(j.Error())
+
+	v, err := version.FindOne(version.ById(repotracker.PeriodicVersionId(ref, ref.PeriodicBuilds[0], scheduled)))
+	require.NoError(err)
+	require.NotNil(v)
+	assert.Equal(evergreen.PeriodicBuildRequester, v.Requester)
+	assert.Equal("abc", v.Revision)
+	assert.Equal("nightly tests", v.Message)
+	require.Len(v.BuildVariants, 1)
+	assert.True(v.BuildVariants[0].Activated)
+
+	tasks, err := task.Find(task.ByVersion(v.Id))
+	require.NoError(err)
+	require.Len(tasks, 2)
+	for _, t := range tasks {
+		assert.Contains([]string{"compile", "test"}, t.DisplayName)
+		assert.Equal(evergreen.PeriodicBuildRequester, t.Requester)
+		assert.True(t.Activated)
+	}
+
+	// running the same scheduled build again does not create another version
+	j = NewPeriodicBuildJob("proj", "nightly", scheduled).(*periodicBuildJob)
+	j.Run(context.Background())
+	require.NoError(j.Error())
+	count, err := version.Count(version.ByMostRecentForRequester("proj", evergreen.PeriodicBuildRequester))
+	require.NoError(err)
+	assert.Equal(1, count)
+}
+
+func TestScheduledPeriodicBuilds(t *testing.T) {
+	assert := assert.New(t)
+
+	nightly, err := util.ParseCron("0 2 * * *")
+	require.NoError(t, err)
+	scheduled := time.Date(2018, time.August, 15, 2, 0, 0, 0, time.UTC)
+
+	assert.Equal([]time.Time{scheduled}, scheduledPeriodicBuilds(nightly, scheduled))
+	// a delayed tick still finds the build
+	assert.Equal([]time.Time{scheduled}, scheduledPeriodicBuilds(nightly, scheduled.Add(7*time.Minute)))
+	assert.Empty(scheduledPeriodicBuilds(nightly, scheduled.Add(-time.Minute)))
+	assert.Empty(scheduledPeriodicBuilds(nightly, scheduled.Add(periodicBuildLookback)))
+
+	frequent, err := util.ParseCron("*/5 * * * *")
+	require.NoError(t, err)
+	assert.Equal([]time.Time{scheduled, scheduled.Add(5 * time.Minute)},
+		scheduledPeriodicBuilds(frequent, scheduled.Add(7*time.Minute)))
+}
@@ -0,0 +1,109 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/repotracker"
+	"github.com/evergreen-ci/evergreen/trigger"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	projectTriggerJobName = "project-trigger"
+)
+
+func init() {
+	registry.AddJobType(projectTriggerJobName, func() amboy.Job { return makeProjectTriggerJob() })
+}
+
+type projectTriggerJob struct {
+	ProjectID string                  `bson:"project_id" json:"project_id" yaml:"project_id"`
+	TriggerID string                  `bson:"trigger_id" json:"trigger_id" yaml:"trigger_id"`
+	Upstream  version.UpstreamVersion `bson:"upstream" json:"upstream" yaml:"upstream"`
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+}
+
+func makeProjectTriggerJob() *projectTriggerJob {
+	j := &projectTriggerJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    projectTriggerJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewProjectTriggerJob creates a job to create the version of a project
+// trigger that an upstream version, or build, fired. The job has the ID of
+// the version it creates, so a trigger fired again for the same upstream
+// version doesn't create another job.
+func NewProjectTriggerJob(ref *model.ProjectRef, def model.TriggerDefinition, upstream *version.UpstreamVersion) amboy.Job {
+	j := makeProjectTriggerJob()
+	j.ProjectID = ref.Identifier
+	j.TriggerID = def.ID
+	j.Upstream = *upstream
+	j.SetID(fmt.Sprintf("%s:%s", projectTriggerJobName, repotracker.TriggeredVersionId(ref, def, upstream)))
+	return j
+}
+
+// enqueueProjectTriggerJobs returns a function that adds a job to the queue to
+// create the version of each project trigger that fires.
+func enqueueProjectTriggerJobs(q amboy.Queue) trigger.DownstreamVersionCreator {
+	return func(ref *model.ProjectRef, def model.TriggerDefinition, upstream *version.UpstreamVersion) error {
+		j := NewProjectTriggerJob(ref, def, upstream)
+		if _, ok := q.Get(j.ID()); ok {
+			return nil
+		}
+		return q.Put(j)
+	}
+}
+
+func (j *projectTriggerJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	ref, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if ref == nil {
+		j.AddError(errors.Errorf("can't find project ref for project '%s'", j.ProjectID))
+		return
+	}
+
+	var def *model.TriggerDefinition
+	for i := range ref.Triggers {
+		if ref.Triggers[i].ID == j.TriggerID {
+			def = &ref.Triggers[i]
+			break
+		}
+	}
+	if def == nil {
+		j.AddError(errors.Errorf("project '%s' has no trigger '%s'", j.ProjectID, j.TriggerID))
+		return
+	}
+
+	if _, err = repotracker.CreateTriggeredVersion(ref, *def, &j.Upstream); err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"job":              projectTriggerJobName,
+			"job_id":           j.ID(),
+			"project":          j.ProjectID,
+			"trigger":          j.TriggerID,
+			"upstream_project": j.Upstream.Project,
+			"upstream_version": j.Upstream.VersionId,
+		}))
+		j.AddError(err)
+	}
+}
@@ -0,0 +1,92 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/amboy/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectTriggerJob(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	testConfig := testutil.TestConfig()
+	db.SetGlobalSessionProvider(testConfig.SessionFactory())
+	require.NoError(db.ClearCollections(model.ProjectRefCollection, model.ProjectAliasCollection,
+		model.RepositoriesCollection, version.Collection, build.Collection, task.Collection))
+
+	ref := &model.ProjectRef{
+		Identifier: "integration",
+		Enabled:    true,
+		Triggers: []model.TriggerDefinition{
+			{ID: "lib-green", Project: "lib", Level: model.ProjectTriggerLevelVersion, Status: evergreen.VersionSucceeded, Alias: "smoke"},
+		},
+	}
+	upstream := &version.UpstreamVersion{
+		Project:   "lib",
+		VersionId: "lib_abc",
+		Revision:  "abc",
+		Status:    evergreen.VersionSucceeded,
+	}
+
+	j := NewProjectTriggerJob(ref, ref.Triggers[0], upstream).(*projectTriggerJob)
+	assert.Equal("project-trigger:integration_lib_green_lib_abc", j.ID())
+	j.Run(context.Background())
+	require.Error(j.Error())
+	assert.Contains(j.Error().Error(), "can't find project ref for project")
+
+	require.NoError(ref.Insert())
+	alias := &model.ProjectAlias{ProjectID: "integration", Alias: "smoke", Variant: "^linux$", Task: "^smoke$"}
+	require.NoError(alias.Upsert())
+	require.NoError((&version.Version{
+		Id:         "lib_abc",
+		Identifier: "lib",
+		Revision:   "abc",
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}).Insert())
+	require.NoError((&version.Version{
+		Id:                  "integration_def",
+		Identifier:          "integration",
+		Revision:            "def",
+		Requester:           evergreen.RepotrackerVersionRequester,
+		RevisionOrderNumber: 1,
+		Config: `
+buildvariants:
+- name: linux
+  run_on: [distro]
+  tasks:
+  - name: smoke
+tasks:
+- name: smoke
+`,
+	}).Insert())
+
+	j = NewProjectTriggerJob(ref, ref.Triggers[0], upstream).(*projectTriggerJob)
+	j.Run(context.Background())
+	require.NoError(j.Error())
+	v, err := version.FindOne(version.ByMostRecentForRequester("integration", evergreen.TriggerRequester))
+	require.NoError(err)
+	require.NotNil(v)
+	require.NotNil(v.Upstream)
+	assert.Equal("lib-green", v.Upstream.TriggerID)
+
+	// a trigger fired again for the same upstream version is only queued
+	// once
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := queue.NewLocalUnordered(1)
+	require.NoError(q.Start(ctx))
+	enqueue := enqueueProjectTriggerJobs(q)
+	require.NoError(enqueue(ref, ref.Triggers[0], upstream))
+	require.NoError(enqueue(ref, ref.Triggers[0], upstream))
+	assert.Equal(1, q.Stats().Total)
+}
@@ -0,0 +1,115 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/pkg/errors"
+)
+
+// ChatWebhook is a JSON message for the incoming webhook of a chat service,
+// such as Microsoft Teams or Mattermost.
+type ChatWebhook struct {
+	URL  string `bson:"url"`
+	Body []byte `bson:"body"`
+}
+
+type chatWebhookMessage struct {
+	raw ChatWebhook
+
+	message.Base
+}
+
+func NewChatWebhookMessage(url string, body []byte) message.Composer {
+	return &chatWebhookMessage{
+		raw: ChatWebhook{
+			URL:  url,
+			Body: body,
+		},
+	}
+}
+
+func (w *chatWebhookMessage) Loggable() bool {
+	if len(w.raw.URL) == 0 || len(w.raw.Body) == 0 {
+		return false
+	}
+
+	u, err := url.Parse(w.raw.URL)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+func (w *chatWebhookMessage) Raw() interface{} {
+	return &w.raw
+}
+
+func (w *chatWebhookMessage) String() string {
+	return string(w.raw.Body)
+}
+
+type chatWebhookLogger struct {
+	client *http.Client
+	*send.Base
+}
+
+// NewChatWebhookLogger returns a sender that posts chat webhook messages to
+// the URL of each message.
+func NewChatWebhookLogger(name string) (send.Sender, error) {
+	s := &chatWebhookLogger{
+		Base: send.NewBase(name),
+	}
+
+	return s, nil
+}
+
+func (w *chatWebhookLogger) Send(m message.Composer) {
+	if w.Level().ShouldLog(m) {
+		if err := w.send(m); err != nil {
+			w.ErrorHandler(err, m)
+		}
+	}
+}
+
+func (w *chatWebhookLogger) send(m message.Composer) error {
+	raw, ok := m.Raw().(*ChatWebhook)
+	if !ok {
+		return errors.Errorf("%s sender received unexpected composer", w.Name())
+	}
+
+	req, err := http.NewRequest(http.MethodPost, raw.URL, bytes.NewReader(raw.Body))
+	if err != nil {
+		return errors.Wrapf(err, "%s failed to create http request", w.Name())
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx, cancel := context.WithTimeout(req.Context(), evergreenWebhookTimeout)
+	defer cancel()
+
+	req = req.WithContext(ctx)
+
+	client := w.client
+	if client == nil {
+		client = GetHTTPClient()
+		defer PutHTTPClient(client)
+	}
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "%s failed to send webhook data", w.Name())
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("%s response status was %d %s", w.Name(), resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	return nil
+}
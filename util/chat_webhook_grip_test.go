@@ -0,0 +1,57 @@
+package util
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatWebhookComposer(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(NewChatWebhookMessage("", []byte("{}")).Loggable())
+	assert.False(NewChatWebhookMessage("https://example.com", nil).Loggable())
+	assert.False(NewChatWebhookMessage("example.com/hook", []byte("{}")).Loggable())
+
+	m := NewChatWebhookMessage("https://example.com/hook", []byte(`{"text": "hi"}`))
+	assert.True(m.Loggable())
+	assert.Equal(`{"text": "hi"}`, m.String())
+	raw, ok := m.Raw().(*ChatWebhook)
+	require.True(t, ok)
+	assert.Equal("https://example.com/hook", raw.URL)
+}
+
+func TestChatWebhookSender(t *testing.T) {
+	var body []byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var err error
+		body, err = ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender, err := NewChatWebhookLogger("teams")
+	require.NoError(t, err)
+	errs := []error{}
+	require.NoError(t, sender.SetErrorHandler(func(err error, _ message.Composer) {
+		errs = append(errs, err)
+	}))
+
+	sender.Send(NewChatWebhookMessage(server.URL, []byte(`{"text": "hi"}`)))
+	assert.Empty(t, errs)
+	assert.Equal(t, `{"text": "hi"}`, string(body))
+
+	status = http.StatusBadRequest
+	sender.Send(NewChatWebhookMessage(server.URL, []byte(`{"text": "bye"}`)))
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "400")
+}
@@ -0,0 +1,179 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CronSchedule is a parsed standard five field cron expression
+// ("minute hour day-of-month month day-of-week"). Schedules are evaluated
+// in UTC.
+type CronSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// a day matches if either day field matches when both are restricted,
+	// following the behavior of cron(8)
+	dayOfMonthStar bool
+	dayOfWeekStar  bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	cronMinute     = cronField{name: "minute", min: 0, max: 59}
+	cronHour       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12}
+	cronDayOfWeek  = cronField{name: "day of week", min: 0, max: 7}
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// cronSearchLimit bounds the search for the next activation, so that
+// schedules which can never match (e.g. "0 0 30 2 *") terminate.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// ParseCron parses a five field cron expression. Each field may be '*', a
+// number, a range ("1-5"), a list ("1,3,5") and may carry a step ("*/15",
+// "0-30/10"). The descriptors @yearly, @monthly, @weekly, @daily and
+// @hourly are also accepted.
+func ParseCron(expr string) (CronSchedule, error) {
+	schedule := CronSchedule{}
+	expr = strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return schedule, errors.Errorf("cron expression '%s' must have 5 fields, found %d", expr, len(fields))
+	}
+
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], cronMinute); err != nil {
+		return schedule, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], cronHour); err != nil {
+		return schedule, err
+	}
+	if schedule.dayOfMonth, err = parseCronField(fields[2], cronDayOfMonth); err != nil {
+		return schedule, err
+	}
+	if schedule.month, err = parseCronField(fields[3], cronMonth); err != nil {
+		return schedule, err
+	}
+	if schedule.dayOfWeek, err = parseCronField(fields[4], cronDayOfWeek); err != nil {
+		return schedule, err
+	}
+	// both 0 and 7 are Sunday
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	schedule.dayOfMonthStar = strings.HasPrefix(fields[2], "*")
+	schedule.dayOfWeekStar = strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeAndStep := strings.SplitN(part, "/", 2)
+		step := 1
+		if len(rangeAndStep) == 2 {
+			var err error
+			step, err = strconv.Atoi(rangeAndStep[1])
+			if err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step '%s' in %s field", rangeAndStep[1], bounds.name)
+			}
+		}
+
+		var start, end int
+		var err error
+		switch {
+		case rangeAndStep[0] == "*":
+			start, end = bounds.min, bounds.max
+		case strings.Contains(rangeAndStep[0], "-"):
+			limits := strings.SplitN(rangeAndStep[0], "-", 2)
+			if start, err = strconv.Atoi(limits[0]); err != nil {
+				return 0, errors.Errorf("invalid value '%s' in %s field", limits[0], bounds.name)
+			}
+			if end, err = strconv.Atoi(limits[1]); err != nil {
+				return 0, errors.Errorf("invalid value '%s' in %s field", limits[1], bounds.name)
+			}
+		default:
+			if start, err = strconv.Atoi(rangeAndStep[0]); err != nil {
+				return 0, errors.Errorf("invalid value '%s' in %s field", rangeAndStep[0], bounds.name)
+			}
+			end = start
+			if len(rangeAndStep) == 2 {
+				end = bounds.max
+			}
+		}
+
+		if start < bounds.min || end > bounds.max || start > end {
+			return 0, errors.Errorf("'%s' is out of range for %s field (%d-%d)", part, bounds.name, bounds.min, bounds.max)
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first time strictly after the given time which matches
+// the schedule, or the zero time if the schedule never matches.
+func (s CronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dow := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"*/15 * * * *",
+		"0 9-17 * * 1-5",
+		"0,30 0 1,15 * *",
+		"0 0 * * 7",
+		"@daily",
+		"@Weekly",
+	} {
+		_, err := ParseCron(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@never",
+	} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	start := time.Date(2018, time.August, 15, 10, 7, 30, 0, time.UTC) // a Wednesday
+
+	for expr, expected := range map[string]time.Time{
+		"* * * * *":      time.Date(2018, time.August, 15, 10, 8, 0, 0, time.UTC),
+		"*/15 * * * *":   time.Date(2018, time.August, 15, 10, 15, 0, 0, time.UTC),
+		"@hourly":        time.Date(2018, time.August, 15, 11, 0, 0, 0, time.UTC),
+		"0 2 * * *":      time.Date(2018, time.August, 16, 2, 0, 0, 0, time.UTC),
+		"30 9 * * 1-5":   time.Date(2018, time.August, 16, 9, 30, 0, 0, time.UTC),
+		"0 0 * * 0":      time.Date(2018, time.August, 19, 0, 0, 0, 0, time.UTC),
+		"0 0 * * 7":      time.Date(2018, time.August, 19, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * *":      time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 1 1 *":      time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":     time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 25 * 1":     time.Date(2018, time.August, 20, 0, 0, 0, 0, time.UTC),
+		"0 12 31 * 4":    time.Date(2018, time.August, 16, 12, 0, 0, 0, time.UTC),
+		"7 10 15 8 *":    time.Date(2019, time.August, 15, 10, 7, 0, 0, time.UTC),
+		"0 0 30 2 *":     time.Time{},
+		"0,45 10 15 8 *": time.Date(2018, time.August, 15, 10, 45, 0, 0, time.UTC),
+	} {
+		schedule, err := ParseCron(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, schedule.Next(start), expr)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -26,3 +28,36 @@ func CalculateHMACHash(secret []byte, body []byte) (string, error) {
 
 	return "sha256=" + hex.EncodeToString(mac.Sum(nil)), nil
 }
+
+// CalculateTimestampedHMACHash calculates a sha256 HMAC hash of the timestamp,
+// in seconds since the epoch, and the body, joined by a ".". Signing the
+// timestamp along with the body lets receivers reject replayed requests.
+// The string result will be prefixed with "sha256=", followed by the HMAC hash.
+func CalculateTimestampedHMACHash(secret []byte, timestamp int64, body []byte) (string, error) {
+	signed := append([]byte(strconv.FormatInt(timestamp, 10)+"."), body...)
+	return CalculateHMACHash(secret, signed)
+}
+
+// ValidateTimestampedHMACHash checks a signature made by
+// CalculateTimestampedHMACHash, and that its timestamp is no further than
+// the tolerance from now.
+func ValidateTimestampedHMACHash(secret []byte, body []byte, timestamp, signature string, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid signature timestamp '%s'", timestamp)
+	}
+	age := now.Sub(time.Unix(ts, 0))
+	if age > tolerance || age < -tolerance {
+		return errors.Errorf("signature timestamp %d is outside the tolerance of %s", ts, tolerance)
+	}
+
+	expected, err := CalculateTimestampedHMACHash(secret, ts, body)
+	if err != nil {
+		return errors.Wrap(err, "can't calculate signature")
+	}
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("signature does not match")
+	}
+
+	return nil
+}
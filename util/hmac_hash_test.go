@@ -2,6 +2,7 @@ package util
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,3 +16,26 @@ func TestCalculateHMACHash(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal("sha256=d9d154a6958468d66ee12eec0fe7f9bc1c3dd6e1aa65851c66dd8be33f6ab1ae", text)
 }
+
+func TestTimestampedHMACHash(t *testing.T) {
+	assert := assert.New(t)
+	body := []byte("Four score and seven bits ago")
+	secret := []byte("i have the best beard")
+	now := time.Unix(1546300800, 0)
+
+	text, err := CalculateTimestampedHMACHash(secret, now.Unix(), body)
+	assert.NoError(err)
+	untimed, err := CalculateHMACHash(secret, body)
+	assert.NoError(err)
+	assert.NotEqual(untimed, text)
+
+	assert.NoError(ValidateTimestampedHMACHash(secret, body, "1546300800", text, time.Minute, now))
+	assert.NoError(ValidateTimestampedHMACHash(secret, body, "1546300800", text, time.Minute, now.Add(59*time.Second)))
+
+	// replayed too late, or with a different timestamp
+	assert.Error(ValidateTimestampedHMACHash(secret, body, "1546300800", text, time.Minute, now.Add(2*time.Minute)))
+	assert.Error(ValidateTimestampedHMACHash(secret, body, "1546300830", text, time.Minute, now))
+	assert.Error(ValidateTimestampedHMACHash(secret, body, "soon", text, time.Minute, now))
+	assert.Error(ValidateTimestampedHMACHash([]byte("another beard"), body, "1546300800", text, time.Minute, now))
+	assert.Error(ValidateTimestampedHMACHash(secret, []byte("forged"), "1546300800", text, time.Minute, now))
+}
@@ -3,8 +3,11 @@ package util
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/mongodb/grip"
@@ -17,6 +20,12 @@ const (
 	evergreenWebhookTimeout       = 10 * time.Second
 	evergreenNotificationIDHeader = "X-Evergreen-Notification-ID"
 	evergreenHMACHeader           = "X-Evergreen-Signature"
+
+	// evergreenTimestampedHMACHeader is an HMAC of the value of
+	// evergreenTimestampHeader and the body, which receivers can use to
+	// reject replayed requests
+	evergreenTimestampHeader       = "X-Evergreen-Signature-Timestamp"
+	evergreenTimestampedHMACHeader = "X-Evergreen-Timestamped-Signature"
 )
 
 type EvergreenWebhook struct {
@@ -87,14 +96,110 @@ func (w *evergreenWebhookMessage) String() string {
 	return string(w.raw.Body)
 }
 
+// EvergreenWebhookOptions configures the circuit breaker of the
+// evergreen-webhook sender. After CircuitBreakerThreshold consecutive failures
+// to send to an endpoint, messages for that endpoint fail without being sent
+// until CircuitBreakerCooldown has passed. A zero threshold disables circuit
+// breaking.
+type EvergreenWebhookOptions struct {
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+}
+
+// WebhookCircuitOpenError is the error for messages that weren't sent,
+// because their endpoint has failed too many times in a row.
+type WebhookCircuitOpenError struct {
+	Endpoint string
+	Until    time.Time
+}
+
+func (e *WebhookCircuitOpenError) Error() string {
+	return fmt.Sprintf("evergreen-webhook circuit for '%s' is open until %s", e.Endpoint, e.Until.Format(time.RFC3339))
+}
+
+type webhookEndpointState struct {
+	failures  int
+	openUntil time.Time
+}
+
+type webhookCircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	endpoints map[string]*webhookEndpointState
+}
+
+// webhookEndpoint returns the scheme and host of a webhook URL, which
+// identify the endpoint that circuit breaking applies to.
+func webhookEndpoint(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+func (b *webhookCircuitBreaker) check(endpoint string, now time.Time) error {
+	if b == nil || b.threshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.endpoints[endpoint]
+	if ok && now.Before(state.openUntil) {
+		return &WebhookCircuitOpenError{Endpoint: endpoint, Until: state.openUntil}
+	}
+	return nil
+}
+
+// record tracks the outcome of sending to the endpoint. Once the cooldown
+// has passed, a single failure opens the circuit again, until a message is
+// sent successfully.
+func (b *webhookCircuitBreaker) record(endpoint string, failed bool, now time.Time) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		delete(b.endpoints, endpoint)
+		return
+	}
+	state, ok := b.endpoints[endpoint]
+	if !ok {
+		state = &webhookEndpointState{}
+		b.endpoints[endpoint] = state
+	}
+	state.failures++
+	if state.failures >= b.threshold {
+		state.openUntil = now.Add(b.cooldown)
+	}
+}
+
 type evergreenWebhookLogger struct {
-	client *http.Client
+	client  *http.Client
+	breaker *webhookCircuitBreaker
 	*send.Base
 }
 
 func NewEvergreenWebhookLogger() (send.Sender, error) {
+	return NewEvergreenWebhookLoggerWithOptions(EvergreenWebhookOptions{})
+}
+
+func NewEvergreenWebhookLoggerWithOptions(opts EvergreenWebhookOptions) (send.Sender, error) {
+	if opts.CircuitBreakerThreshold < 0 || opts.CircuitBreakerCooldown < 0 {
+		return nil, errors.New("evergreen-webhook circuit breaker options cannot be negative")
+	}
 	s := &evergreenWebhookLogger{
 		Base: send.NewBase("evergreen"),
+		breaker: &webhookCircuitBreaker{
+			threshold: opts.CircuitBreakerThreshold,
+			cooldown:  opts.CircuitBreakerCooldown,
+			endpoints: map[string]*webhookEndpointState{},
+		},
 	}
 
 	return s, nil
@@ -114,6 +219,19 @@ func (w *evergreenWebhookLogger) send(m message.Composer) error {
 		return errors.New("evergreen-webhook sender received unexpected composer")
 	}
 
+	endpoint := webhookEndpoint(raw.URL)
+	now := time.Now()
+	if err := w.breaker.check(endpoint, now); err != nil {
+		return err
+	}
+
+	err := w.post(raw, now)
+	w.breaker.record(endpoint, err != nil, now)
+
+	return err
+}
+
+func (w *evergreenWebhookLogger) post(raw *EvergreenWebhook, now time.Time) error {
 	reader := bytes.NewReader(raw.Body)
 	req, err := http.NewRequest(http.MethodPost, raw.URL, reader)
 	if err != nil {
@@ -124,6 +242,11 @@ func (w *evergreenWebhookLogger) send(m message.Composer) error {
 	if err != nil {
 		return errors.Wrap(err, "evergreen-webhook failed to calculate hash")
 	}
+	timestamp := now.Unix()
+	timestampedHash, err := CalculateTimestampedHMACHash(raw.Secret, timestamp, raw.Body)
+	if err != nil {
+		return errors.Wrap(err, "evergreen-webhook failed to calculate timestamped hash")
+	}
 
 	for k := range raw.Headers {
 		for i := range raw.Headers[k] {
@@ -133,6 +256,10 @@ func (w *evergreenWebhookLogger) send(m message.Composer) error {
 
 	req.Header.Del(evergreenHMACHeader)
 	req.Header.Add(evergreenHMACHeader, hash)
+	req.Header.Del(evergreenTimestampHeader)
+	req.Header.Add(evergreenTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Del(evergreenTimestampedHMACHeader)
+	req.Header.Add(evergreenTimestampedHMACHeader, timestampedHash)
 	req.Header.Del(evergreenNotificationIDHeader)
 	req.Header.Add(evergreenNotificationIDHeader, raw.NotificationID)
 
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
@@ -78,7 +79,7 @@ func TestEvergreenWebhookSender(t *testing.T) {
 	s.Send(m)
 	assert.Equal("https://example.com", transport.lastUrl)
 
-	assert.Len(transport.header, 3)
+	assert.Len(transport.header, 5)
 	assert.Len(transport.header["Test"], 2)
 	assert.Contains(transport.header["Test"], "test1")
 	assert.Contains(transport.header["Test"], "test2")
@@ -120,6 +121,55 @@ func TestEvergreenWebhookSenderWithBadSecret(t *testing.T) {
 	assert.Equal("https://example.com", transport.lastUrl)
 }
 
+func TestEvergreenWebhookSenderCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+
+	sender, err := NewEvergreenWebhookLoggerWithOptions(EvergreenWebhookOptions{
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  time.Hour,
+	})
+	assert.NoError(err)
+	s, ok := sender.(*evergreenWebhookLogger)
+	assert.True(ok)
+	transport := mockWebhookTransport{
+		secret: []byte("hi"),
+	}
+	s.client = &http.Client{
+		Transport: &transport,
+	}
+	errs := make(chan error, 1)
+	assert.NoError(s.SetErrorHandler(func(err error, _ message.Composer) {
+		errs <- err
+	}))
+
+	forged := NewWebhookMessage("evergreen", "https://example.com/hook", []byte("bye"), []byte("something forged"), nil)
+	for i := 0; i < 2; i++ {
+		s.Send(forged)
+		assert.EqualError(<-errs, "evergreen-webhook response status was 400 Bad Request")
+	}
+
+	// the circuit for the endpoint is open, so nothing is sent
+	transport.lastUrl = ""
+	s.Send(NewWebhookMessage("evergreen", "https://example.com/other", []byte("hi"), []byte("something important"), nil))
+	err = <-errs
+	assert.IsType(&WebhookCircuitOpenError{}, err)
+	assert.Equal("", transport.lastUrl)
+
+	// other endpoints are unaffected
+	s.Send(NewWebhookMessage("evergreen", "https://example.org/hook", []byte("hi"), []byte("something important"), nil))
+	assert.Equal("https://example.org/hook", transport.lastUrl)
+	assert.Len(errs, 0)
+
+	// once the cooldown is over, a success closes the circuit
+	s.breaker.endpoints["https://example.com"].openUntil = time.Now().Add(-time.Second)
+	s.Send(NewWebhookMessage("evergreen", "https://example.com/hook", []byte("hi"), []byte("something important"), nil))
+	assert.Len(errs, 0)
+	assert.NotContains(s.breaker.endpoints, "https://example.com")
+
+	_, err = NewEvergreenWebhookLoggerWithOptions(EvergreenWebhookOptions{CircuitBreakerThreshold: -1})
+	assert.Error(err)
+}
+
 type mockWebhookTransport struct {
 	lastUrl string
 	secret  []byte
@@ -171,6 +221,12 @@ func (t *mockWebhookTransport) RoundTrip(req *http.Request) (*http.Response, err
 		resp.Body = ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf("expected signature: %s, got %s", sig, hash)))
 		return resp, nil
 	}
+	if err = ValidateTimestampedHMACHash(t.secret, body, req.Header.Get(evergreenTimestampHeader),
+		req.Header.Get(evergreenTimestampedHMACHeader), time.Minute, time.Now()); err != nil {
+		resp.StatusCode = http.StatusBadRequest
+		resp.Body = ioutil.NopCloser(bytes.NewBufferString(err.Error()))
+		return resp, nil
+	}
 	resp.StatusCode = http.StatusNoContent
 	grip.Info(message.Fields{
 		"message":   fmt.Sprintf("received %s", mid),
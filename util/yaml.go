@@ -1,9 +1,13 @@
 package util
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -39,3 +43,59 @@ func ReadFromYAMLFile(fn string, data interface{}) error {
 
 	return errors.Wrap(yaml.Unmarshal(bytes, data), "problem reading yaml")
 }
+
+// IsYAMLFile returns true if the file name has a YAML extension.
+func IsYAMLFile(fn string) bool {
+	ext := strings.ToLower(filepath.Ext(fn))
+	return ext == ".yml" || ext == ".yaml"
+}
+
+// YAMLToJSON converts a YAML document to JSON. Mappings must have string,
+// number or boolean keys, which become JSON object keys.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "problem parsing yaml")
+	}
+	doc, err := yamlValueToJSON(doc)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	out, err := json.Marshal(doc)
+	return out, errors.Wrap(err, "problem marshalling json")
+}
+
+// yamlValueToJSON replaces the maps in a value unmarshalled from YAML, whose
+// keys are interfaces, with maps keyed by strings that can be marshalled to
+// JSON.
+func yamlValueToJSON(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			switch key.(type) {
+			case string, int, int64, uint64, float64, bool:
+			default:
+				return nil, errors.Errorf("yaml key '%v' of type %T cannot be converted to json", key, key)
+			}
+			converted, err := yamlValueToJSON(val)
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(key)] = converted
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			converted, err := yamlValueToJSON(val)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = converted
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
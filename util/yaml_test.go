@@ -0,0 +1,47 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	out, err := YAMLToJSON([]byte(`
+tasks:
+  - name: compile
+    priority: 10
+    commands:
+      - command: shell.exec
+        params:
+          script: make
+buildvariants:
+  - name: linux
+    expansions:
+      1: one
+      verbose: true
+`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"tasks": [{"name": "compile", "priority": 10, "commands": [{"command": "shell.exec", "params": {"script": "make"}}]}],
+		"buildvariants": [{"name": "linux", "expansions": {"1": "one", "verbose": true}}]
+	}`, string(out))
+
+	out, err = YAMLToJSON([]byte(`{"tasks": [{"name": "compile"}]}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tasks": [{"name": "compile"}]}`, string(out))
+
+	_, err = YAMLToJSON([]byte("tasks: [\n"))
+	assert.Error(t, err)
+
+	_, err = YAMLToJSON([]byte("? [a, b]\n: c\n"))
+	assert.Error(t, err)
+}
+
+func TestIsYAMLFile(t *testing.T) {
+	assert.True(t, IsYAMLFile("generated.yml"))
+	assert.True(t, IsYAMLFile("dir/generated.YAML"))
+	assert.False(t, IsYAMLFile("generated.json"))
+	assert.False(t, IsYAMLFile("yml"))
+}
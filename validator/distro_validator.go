@@ -21,6 +21,8 @@ var distroSyntaxValidators = []distroValidator{
 	ensureValidExpansions,
 	ensureStaticHostsAreNotSpawnable,
 	ensureValidContainerPool,
+	ensureValidHourlyBudget,
+	ensureValidHostPoolTarget,
 }
 
 // CheckDistro checks if the distro configuration syntax is valid. Returns
@@ -190,3 +192,60 @@ func ensureValidContainerPool(ctx context.Context, d *distro.Distro, s *evergree
 	}
 	return nil
 }
+
+// ensureValidHourlyBudget checks that a distro's hourly budget is not negative.
+func ensureValidHourlyBudget(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if d.HourlyBudget < 0 {
+		return []ValidationError{
+			{
+				Message: fmt.Sprintf("distro '%s' cannot be negative", distro.HourlyBudgetKey),
+				Level:   Error,
+			},
+		}
+	}
+	return nil
+}
+
+// ensureValidHostPoolTarget checks that a distro's minimum hosts, warm
+// standby and host schedules are consistent with its pool size.
+func ensureValidHostPoolTarget(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	errs := []ValidationError{}
+	checkTarget := func(desc string, minHosts, warmStandby int) {
+		if minHosts < 0 {
+			errs = append(errs, ValidationError{Error, fmt.Sprintf("%s '%s' cannot be negative", desc, distro.MinHostsKey)})
+		}
+		if warmStandby < 0 {
+			errs = append(errs, ValidationError{Error, fmt.Sprintf("%s '%s' cannot be negative", desc, distro.WarmStandbyKey)})
+		}
+		if d.PoolSize > 0 && minHosts > d.PoolSize {
+			errs = append(errs, ValidationError{Error, fmt.Sprintf("%s '%s' cannot be greater than '%s'", desc, distro.MinHostsKey, distro.PoolSizeKey)})
+		}
+		if d.PoolSize > 0 && warmStandby > d.PoolSize {
+			errs = append(errs, ValidationError{Error, fmt.Sprintf("%s '%s' cannot be greater than '%s'", desc, distro.WarmStandbyKey, distro.PoolSizeKey)})
+		}
+	}
+
+	checkTarget("distro", d.MinHosts, d.WarmStandby)
+	for i, schedule := range d.HostSchedules {
+		desc := fmt.Sprintf("host schedule %d", i)
+		checkTarget(desc, schedule.MinHosts, schedule.WarmStandby)
+		if schedule.StartHour < 0 || schedule.StartHour > 23 {
+			errs = append(errs, ValidationError{Error, fmt.Sprintf("%s start hour must be between 0 and 23", desc)})
+		}
+		if schedule.EndHour <= schedule.StartHour || schedule.EndHour > 24 {
+			errs = append(errs, ValidationError{Error, fmt.Sprintf("%s end hour must be after the start hour, and at most 24", desc)})
+		}
+		for _, day := range schedule.Days {
+			if !distro.IsValidHostScheduleDay(day) {
+				errs = append(errs, ValidationError{Error, fmt.Sprintf("%s day '%s' is not a day of the week, 'weekdays' or 'weekends'", desc, day)})
+			}
+		}
+	}
+
+	targetSet := d.MinHosts > 0 || d.WarmStandby > 0 || len(d.HostSchedules) > 0
+	if targetSet && d.Provider != "" && !d.IsEphemeral() {
+		errs = append(errs, ValidationError{Warning, fmt.Sprintf("distro '%s' only applies to distros that spawn hosts", distro.MinHostsKey)})
+	}
+
+	return errs
+}
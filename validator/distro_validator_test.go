@@ -322,3 +322,48 @@ func TestEnsureValidContainerPool(t *testing.T) {
 	err = ensureValidContainerPool(ctx, d4, conf)
 	assert.Nil(err)
 }
+
+func TestEnsureValidHourlyBudget(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.Nil(ensureValidHourlyBudget(ctx, &distro.Distro{Id: "d"}, conf))
+	assert.Nil(ensureValidHourlyBudget(ctx, &distro.Distro{Id: "d", HourlyBudget: 12.5}, conf))
+	assert.NotNil(ensureValidHourlyBudget(ctx, &distro.Distro{Id: "d", HourlyBudget: -1}, conf))
+}
+
+func TestEnsureValidHostPoolTarget(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := &distro.Distro{Id: "d", Provider: evergreen.ProviderNameEc2OnDemand, PoolSize: 30}
+	assert.Empty(ensureValidHostPoolTarget(ctx, d, conf))
+
+	d.MinHosts = 5
+	d.WarmStandby = 2
+	d.HostSchedules = []distro.HostSchedule{
+		{Days: []string{"weekdays"}, StartHour: 9, EndHour: 18, MinHosts: 20},
+		{Days: []string{"saturday"}, StartHour: 0, EndHour: 24, WarmStandby: 1},
+	}
+	assert.Empty(ensureValidHostPoolTarget(ctx, d, conf))
+
+	d.MinHosts = -1
+	d.WarmStandby = 31
+	assert.Len(ensureValidHostPoolTarget(ctx, d, conf), 2)
+
+	d.MinHosts = 5
+	d.WarmStandby = 2
+	d.HostSchedules = []distro.HostSchedule{
+		{Days: []string{"weekday"}, StartHour: 18, EndHour: 9, MinHosts: 40},
+	}
+	assert.Len(ensureValidHostPoolTarget(ctx, d, conf), 3)
+
+	d.HostSchedules = nil
+	d.Provider = evergreen.ProviderNameStatic
+	errs := ensureValidHostPoolTarget(ctx, d, conf)
+	if assert.Len(errs, 1) {
+		assert.Equal(Warning, errs[0].Level)
+	}
+}
@@ -55,6 +55,7 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 	}
 
 	project := &model.Project{}
+	fetch := patchIncludeFetcher(p, githubOauthToken, projectRef)
 
 	// if the patched config exists, use that as the project file bytes.
 	if p.PatchedConfig != "" {
@@ -63,7 +64,7 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 
 	// apply remote configuration patch if needed
 	if !p.IsGithubPRPatch() && p.ConfigChanged(projectRef.RemotePath) && p.PatchedConfig == "" {
-		project, err = model.MakePatchedConfig(ctx, p, projectRef.RemotePath, string(projectFileBytes))
+		project, err = model.MakePatchedConfig(ctx, p, projectRef.RemotePath, string(projectFileBytes), fetch)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Could not patch remote configuration file")
 		}
@@ -82,10 +83,33 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 			return nil, errors.New(message)
 		}
 	} else {
-		// configuration is not patched
+		// configuration is not patched, though files it includes may be
+		if !p.IsGithubPRPatch() && p.PatchedConfig == "" {
+			fetch = model.NewPatchedIncludeFetcher(p, fetch)
+		}
+		if projectFileBytes, err = model.ResolveIncludes(ctx, projectFileBytes, fetch); err != nil {
+			return nil, errors.Wrap(err, "Could not resolve project includes")
+		}
 		if err = model.LoadProjectInto(projectFileBytes, projectRef.Identifier, project); err != nil {
 			return nil, errors.WithStack(err)
 		}
 	}
 	return project, nil
 }
+
+// patchIncludeFetcher returns an IncludeFetcher that reads the files included
+// by the patch's project configuration from GitHub. A pull request's files
+// are read at its head commit, from the repository it was opened from, so
+// that included files it changes are used; other patches' files are read at
+// their base revision.
+func patchIncludeFetcher(p *patch.Patch, githubOauthToken string, projectRef *model.ProjectRef) model.IncludeFetcher {
+	if !p.IsGithubPRPatch() {
+		return model.NewGithubIncludeFetcher(githubOauthToken, projectRef.Owner, projectRef.Repo, p.Githash)
+	}
+
+	owner, repo := p.GithubPatchData.HeadOwner, p.GithubPatchData.HeadRepo
+	if owner == "" || repo == "" {
+		owner, repo = projectRef.Owner, projectRef.Repo
+	}
+	return model.NewGithubIncludeFetcher(githubOauthToken, owner, repo, p.GithubPatchData.HeadHash)
+}
@@ -58,6 +58,7 @@ var projectSyntaxValidators = []projectValidator{
 	validateTaskGroups,
 	validateGenerateTasks,
 	validateCreateHosts,
+	validateRetryPolicies,
 }
 
 // Functions used to validate the semantics of a project configuration file.
@@ -897,6 +898,37 @@ func validateCreateHosts(p *model.Project) []ValidationError {
 	return errs
 }
 
+// validateRetryPolicies ensures that the retry policies of tasks and build
+// variant tasks are well formed.
+func validateRetryPolicies(p *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	for _, t := range p.Tasks {
+		if t.Retry == nil {
+			continue
+		}
+		if err := t.Retry.Validate(); err != nil {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("task '%s' has an invalid retry policy: %s", t.Name, err.Error()),
+				Level:   Error,
+			})
+		}
+	}
+	for _, bv := range p.BuildVariants {
+		for _, t := range bv.Tasks {
+			if t.Retry == nil {
+				continue
+			}
+			if err := t.Retry.Validate(); err != nil {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("task '%s' in buildvariant '%s' has an invalid retry policy: %s", t.Name, bv.Name, err.Error()),
+					Level:   Error,
+				})
+			}
+		}
+	}
+	return errs
+}
+
 func validateTimesCalledPerTask(p *model.Project, ts map[string]int, commandName string, times int) (errs []ValidationError) {
 	for _, bv := range p.BuildVariants {
 		for _, t := range bv.Tasks {
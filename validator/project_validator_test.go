@@ -1917,3 +1917,58 @@ func TestValidateCreateHosts(t *testing.T) {
 	errs = validateCreateHosts(&p)
 	assert.Len(errs, 1)
 }
+
+func TestValidateRetryPolicies(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	// passing case
+	yml := `
+  tasks:
+  - name: t_1
+    retry:
+      max_attempts: 3
+      on: [system-failure, timeout]
+      backoff_secs: 30
+    commands:
+    - command: shell.exec
+  buildvariants:
+  - name: "bv"
+    tasks:
+    - name: t_1
+      retry:
+        max_attempts: 2
+        on: [test-failure]
+  `
+	var p model.Project
+	err := model.LoadProjectInto([]byte(yml), "id", &p)
+	require.NoError(err)
+	assert.Len(validateRetryPolicies(&p), 0)
+
+	// invalid policies on both the task and the variant task
+	yml = `
+  tasks:
+  - name: t_1
+    retry:
+      max_attempts: 10
+      on: [system-failure]
+    commands:
+    - command: shell.exec
+  buildvariants:
+  - name: "bv"
+    tasks:
+    - name: t_1
+      retry:
+        max_attempts: 2
+        on: [disk-full]
+        backoff_secs: -1
+  `
+	p = model.Project{}
+	err = model.LoadProjectInto([]byte(yml), "id", &p)
+	require.NoError(err)
+	errs := validateRetryPolicies(&p)
+	require.Len(errs, 2)
+	assert.Contains(errs[0].Message, "max_attempts")
+	assert.Contains(errs[1].Message, "disk-full")
+	assert.Contains(errs[1].Message, "backoff_secs")
+}